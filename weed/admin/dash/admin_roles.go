@@ -0,0 +1,123 @@
+package dash
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// AdminRole is the capability of a logged in user, or of a worker
+type AdminRole string
+
+const (
+	// RoleViewer can only look at the cluster
+	RoleViewer AdminRole = "viewer"
+	// RoleOperator can also run maintenance tasks, like scans, vacuum and retention purges
+	RoleOperator AdminRole = "operator"
+	// RoleAdmin can also manage users, policies, buckets, files, topics and the configuration
+	RoleAdmin AdminRole = "admin"
+)
+
+var roleRanks = map[AdminRole]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// ParseAdminRole returns the role of a name, accepting the readonly and readwrite roles of the OIDC default role mapping
+func ParseAdminRole(name string) (AdminRole, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "viewer", "readonly":
+		return RoleViewer, true
+	case "operator", "readwrite":
+		return RoleOperator, true
+	case "admin":
+		return RoleAdmin, true
+	}
+	return "", false
+}
+
+// HighestAdminRole returns the most capable role of the names, false if none is a role
+func HighestAdminRole(names []string) (highest AdminRole, found bool) {
+	for _, name := range names {
+		if role, ok := ParseAdminRole(name); ok && (!found || role.Allows(highest)) {
+			highest, found = role, true
+		}
+	}
+	return
+}
+
+// Allows tells if the role has the capabilities of the required role
+func (r AdminRole) Allows(required AdminRole) bool {
+	return roleRanks[r] >= roleRanks[required] && roleRanks[r] > 0
+}
+
+// operatorRoutes are the changing routes an operator can call
+var operatorRoutes = []string{
+	"POST /api/maintenance/scan",
+	"POST /api/maintenance/tasks/:id/cancel",
+	"POST /api/volumes/:id/:server/vacuum",
+	"POST /api/mq/retention/purge",
+}
+
+// adminOnlyRoutes are the routes reading secrets or data, which need an admin even to read
+var adminOnlyRoutes = []string{
+	"/object-store/users",
+	"/api/users",
+	"/api/files/download",
+	"/api/files/view",
+}
+
+// RequiredRole returns the role needed for a route. Reading needs a viewer, except for the users
+// with their access keys and the file contents. Changes need an admin, except for the maintenance operations.
+func RequiredRole(method, route string) AdminRole {
+	if slices.Contains(adminOnlyRoutes, route) || strings.HasPrefix(route, "/api/users/") {
+		return RoleAdmin
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return RoleViewer
+	}
+	if slices.Contains(operatorRoutes, method+" "+route) {
+		return RoleOperator
+	}
+	return RoleAdmin
+}
+
+// RequireRole checks the role of the logged in user against the route, after RequireAuth or RequireAuthAPI
+func RequireRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := ParseAdminRole(sessionRole(c))
+		required := RequiredRole(c.Request.Method, c.FullPath())
+		if !role.Allows(required) {
+			glog.V(1).Infof("admin user %v with role %q denied %s %s", c.GetString("username"), role, c.Request.Method, c.FullPath())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Permission denied",
+				"message": "This requires the " + string(required) + " role",
+			})
+			c.Abort()
+			return
+		}
+		c.Set("role", string(role))
+		c.Next()
+	}
+}
+
+// sessionRole returns the role of the logged in user, empty for sessions without a role, see sessionUser
+func sessionRole(c *gin.Context) string {
+	role, _ := sessions.Default(c).Get("role").(string)
+	return role
+}
+
+// setSession logs the user in with the role
+func setSession(c *gin.Context, username string, role AdminRole) {
+	session := sessions.Default(c)
+	session.Set("authenticated", true)
+	session.Set("username", username)
+	session.Set("role", string(role))
+	session.Save()
+}
@@ -0,0 +1,99 @@
+package dash
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredRole(t *testing.T) {
+	assert.Equal(t, RoleViewer, RequiredRole(http.MethodGet, "/cluster/volumes"))
+	assert.Equal(t, RoleViewer, RequiredRole(http.MethodGet, "/api/s3/buckets"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodGet, "/object-store/users"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodGet, "/api/users/:username"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodGet, "/api/files/download"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodGet, "/api/files/view"))
+	assert.Equal(t, RoleViewer, RequiredRole(http.MethodGet, "/api/files/properties"))
+	assert.Equal(t, RoleOperator, RequiredRole(http.MethodPost, "/api/maintenance/scan"))
+	assert.Equal(t, RoleOperator, RequiredRole(http.MethodPost, "/api/volumes/:id/:server/vacuum"))
+	assert.Equal(t, RoleOperator, RequiredRole(http.MethodPost, "/api/mq/retention/purge"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodPut, "/api/maintenance/config"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodDelete, "/api/collections/:name"))
+	assert.Equal(t, RoleAdmin, RequiredRole(http.MethodPost, "/api/users"))
+}
+
+func TestAdminRoleAllows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleOperator))
+	assert.True(t, RoleOperator.Allows(RoleOperator))
+	assert.False(t, RoleOperator.Allows(RoleAdmin))
+	assert.False(t, RoleViewer.Allows(RoleOperator))
+	assert.False(t, AdminRole("").Allows(RoleViewer))
+	assert.False(t, AdminRole("root").Allows(RoleViewer))
+
+	role, found := HighestAdminRole([]string{"readonly", "operator", "unknown"})
+	assert.True(t, found)
+	assert.Equal(t, RoleOperator, role)
+	_, found = HighestAdminRole([]string{"", "arn:aws:iam::role/S3Admin"})
+	assert.False(t, found)
+}
+
+func TestDirectoryRole(t *testing.T) {
+	// without role groups, no directory user can login
+	_, found := directoryRole(nil, []string{"staff"})
+	assert.False(t, found)
+
+	roleGroups := map[AdminRole][]string{
+		RoleAdmin:    {"storage-admins"},
+		RoleOperator: {"storage-ops"},
+		RoleViewer:   {"staff"},
+	}
+	role, found := directoryRole(roleGroups, []string{"staff", "storage-ops"})
+	assert.True(t, found)
+	assert.Equal(t, RoleOperator, role)
+	_, found = directoryRole(roleGroups, []string{"sales"})
+	assert.False(t, found)
+}
+
+func TestRequireRoleLegacySession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(sessions.Sessions("admin-session", cookie.NewStore([]byte("secret"))))
+	r.GET("/login/legacy", func(c *gin.Context) {
+		// a session created before the roles
+		session := sessions.Default(c)
+		session.Set("authenticated", true)
+		session.Set("username", "admin")
+		session.Save()
+	})
+	r.GET("/login/viewer", func(c *gin.Context) {
+		setSession(c, "viewer", RoleViewer)
+	})
+	r.GET("/login/admin", func(c *gin.Context) {
+		setSession(c, "admin", RoleAdmin)
+	})
+	r.GET("/api/files/view", RequireAuthAPI(), RequireRole(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for login, expected := range map[string]int{
+		// the session without a role is cleared, so the user logs in again
+		"/login/legacy": http.StatusUnauthorized,
+		"/login/viewer": http.StatusForbidden,
+		"/login/admin":  http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, login, nil))
+		req := httptest.NewRequest(http.MethodGet, "/api/files/view", nil)
+		for _, cookie := range w.Result().Cookies() {
+			req.AddCookie(cookie)
+		}
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, expected, w.Code, login)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
@@ -57,6 +58,13 @@ type AdminServer struct {
 
 	// Worker gRPC server
 	workerGrpcServer *WorkerGrpcServer
+
+	// Directory for the login of directory users, with the groups of each role if any
+	directory           providers.IdentityProvider
+	directoryRoleGroups map[AdminRole][]string
+
+	// OIDC login, if configured
+	oidcLogin *OIDCLogin
 }
 
 // Type definitions moved to types.go
@@ -1776,6 +1784,12 @@ func (s *AdminServer) UpdateTopicRetention(namespace, name string, enabled bool,
 			RetentionSeconds: retentionSeconds,
 			Enabled:          true,
 		}
+		// Preserve size limits and cleanup policy that are not edited here
+		if currentConfig.Retention != nil {
+			configRequest.Retention.RetentionBytes = currentConfig.Retention.RetentionBytes
+			configRequest.Retention.CleanupPolicy = currentConfig.Retention.CleanupPolicy
+			configRequest.Retention.TombstoneRetentionSeconds = currentConfig.Retention.TombstoneRetentionSeconds
+		}
 	} else {
 		// Set retention to disabled
 		configRequest.Retention = &mq_pb.TopicRetention{
@@ -2,11 +2,26 @@ package dash
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 )
 
+// SetDirectory lets the users of a directory, like LDAP or Active Directory, login.
+// The groups of a user give its role. If no groups are set, all directory users login as admins.
+func (s *AdminServer) SetDirectory(directory providers.IdentityProvider, adminGroups, operatorGroups, viewerGroups []string) {
+	s.directory = directory
+	s.directoryRoleGroups = map[AdminRole][]string{
+		RoleAdmin:    adminGroups,
+		RoleOperator: operatorGroups,
+		RoleViewer:   viewerGroups,
+	}
+}
+
 // ShowLogin displays the login page
 func (s *AdminServer) ShowLogin(c *gin.Context) {
 	// If authentication is not required, redirect to admin
@@ -29,12 +44,12 @@ func (s *AdminServer) HandleLogin(username, password string) gin.HandlerFunc {
 		loginUsername := c.PostForm("username")
 		loginPassword := c.PostForm("password")
 
-		if loginUsername == username && loginPassword == password {
-			session := sessions.Default(c)
-			session.Set("authenticated", true)
-			session.Set("username", loginUsername)
-			session.Save()
-
+		role, authenticated := RoleAdmin, password != "" && loginUsername == username && loginPassword == password
+		if !authenticated {
+			role, authenticated = s.authenticateDirectoryUser(c, loginUsername, loginPassword)
+		}
+		if authenticated {
+			setSession(c, loginUsername, role)
 			c.Redirect(http.StatusSeeOther, "/admin")
 			return
 		}
@@ -44,6 +59,38 @@ func (s *AdminServer) HandleLogin(username, password string) gin.HandlerFunc {
 	}
 }
 
+// authenticateDirectoryUser verifies a user with the directory, if any, and returns its role
+func (s *AdminServer) authenticateDirectoryUser(c *gin.Context, username, password string) (AdminRole, bool) {
+	if s.directory == nil || username == "" || password == "" || strings.Contains(username, ":") {
+		return "", false
+	}
+
+	identity, err := s.directory.Authenticate(c.Request.Context(), username+":"+password)
+	if err != nil {
+		glog.V(1).Infof("directory %s rejected admin login of %s: %v", s.directory.Name(), username, err)
+		return "", false
+	}
+
+	role, found := directoryRole(s.directoryRoleGroups, identity.Groups)
+	if !found {
+		glog.V(1).Infof("directory user %s is not in the groups of any role %v", username, s.directoryRoleGroups)
+	}
+	return role, found
+}
+
+// directoryRole returns the most capable role whose groups contain one of the user's groups.
+// Users outside all role groups, including every user when no groups are set, cannot login.
+func directoryRole(roleGroups map[AdminRole][]string, userGroups []string) (AdminRole, bool) {
+	for _, role := range []AdminRole{RoleAdmin, RoleOperator, RoleViewer} {
+		for _, group := range userGroups {
+			if slices.Contains(roleGroups[role], group) {
+				return role, true
+			}
+		}
+	}
+	return "", false
+}
+
 // HandleLogout handles user logout
 func (s *AdminServer) HandleLogout(c *gin.Context) {
 	session := sessions.Default(c)
@@ -383,3 +383,13 @@ func (s *AdminServer) GetCollectionDetails(collectionName string, page int, page
 		SortOrder:      sortOrder,
 	}, nil
 }
+
+// DeleteCollection deletes a collection with all of its volumes
+func (s *AdminServer) DeleteCollection(name string) error {
+	return s.WithMasterClient(func(client master_pb.SeaweedClient) error {
+		_, err := client.CollectionDelete(context.Background(), &master_pb.CollectionDeleteRequest{
+			Name: name,
+		})
+		return err
+	})
+}
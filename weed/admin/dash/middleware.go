@@ -1,20 +1,20 @@
 package dash
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/seaweedfs/seaweedfs/weed/audit"
 )
 
 // RequireAuth checks if user is authenticated
 func RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		session := sessions.Default(c)
-		authenticated := session.Get("authenticated")
-		username := session.Get("username")
+		username, authenticated := sessionUser(c)
 
-		if authenticated != true || username == nil {
+		if !authenticated {
 			c.Redirect(http.StatusTemporaryRedirect, "/login")
 			c.Abort()
 			return
@@ -30,11 +30,9 @@ func RequireAuth() gin.HandlerFunc {
 // Returns JSON error instead of redirecting to login page
 func RequireAuthAPI() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		session := sessions.Default(c)
-		authenticated := session.Get("authenticated")
-		username := session.Get("username")
+		username, authenticated := sessionUser(c)
 
-		if authenticated != true || username == nil {
+		if !authenticated {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "Authentication required",
 				"message": "Please log in to access this endpoint",
@@ -48,3 +46,46 @@ func RequireAuthAPI() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// sessionUser returns the logged in user of the session. Sessions from before the roles have no role,
+// so they are cleared, and the user has to login again to get one.
+func sessionUser(c *gin.Context) (username any, authenticated bool) {
+	session := sessions.Default(c)
+	username = session.Get("username")
+	if session.Get("authenticated") != true || username == nil {
+		return nil, false
+	}
+	if _, hasRole := session.Get("role").(string); !hasRole {
+		session.Clear()
+		session.Save()
+		return nil, false
+	}
+	return username, true
+}
+
+// AuditRequests records the requests changing the cluster in the audit log, after they are handled.
+// The action is the route, e.g. "DELETE /api/s3/buckets/:bucket", and the target the requested path.
+func AuditRequests(s *AdminServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.FullPath() == "/login" {
+			// redirected on both success and failure, and only changes the session
+			return
+		}
+		actor := ""
+		if username, exists := c.Get("username"); exists {
+			actor = fmt.Sprint(username)
+		}
+		var err error
+		if status := c.Writer.Status(); status >= http.StatusBadRequest {
+			err = fmt.Errorf("%d %s", status, http.StatusText(status))
+		}
+		record := audit.NewRecord(audit.ComponentAdmin, actor, c.ClientIP(), c.Request.Method+" "+c.FullPath(), c.Request.URL.RequestURI(), err)
+		audit.Log(s.WithFilerClient, record)
+	}
+}
@@ -0,0 +1,180 @@
+package dash
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/oidc"
+)
+
+// OIDCLoginConfig configures the single sign-on of the admin UI, read from a JSON file.
+// The roleMapping rules must map the claims of the users to the viewer, operator and admin roles.
+type OIDCLoginConfig struct {
+	oidc.OIDCConfig
+
+	// Name is shown on the login button, "SSO" by default
+	Name string `json:"name,omitempty"`
+
+	// RedirectURL is the /login/oidc/callback URL of the admin server, as registered at the issuer
+	RedirectURL string `json:"redirectUrl"`
+}
+
+// OIDCLogin lets users login through an OIDC issuer with the authorization code flow
+type OIDCLogin struct {
+	name        string
+	redirectURL string
+	provider    *oidc.OIDCProvider
+	endpoints   *oidc.Endpoints
+}
+
+// NewOIDCLoginFromFile creates the OIDC login configured by a JSON file of OIDCLoginConfig
+func NewOIDCLoginFromFile(configFile string) (*OIDCLogin, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("read oidc config %s: %w", configFile, err)
+	}
+	config := &OIDCLoginConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse oidc config %s: %w", configFile, err)
+	}
+	if config.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc config %s: redirectUrl is required", configFile)
+	}
+	if config.RoleMapping == nil {
+		return nil, fmt.Errorf("oidc config %s: roleMapping to the viewer, operator and admin roles is required", configFile)
+	}
+	if config.Name == "" {
+		config.Name = "SSO"
+	}
+
+	provider := oidc.NewOIDCProvider("admin-oidc")
+	if err := provider.Initialize(&config.OIDCConfig); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	endpoints, err := provider.DiscoverEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCLogin{
+		name:        config.Name,
+		redirectURL: config.RedirectURL,
+		provider:    provider,
+		endpoints:   endpoints,
+	}, nil
+}
+
+// SetOIDCLogin lets users login with OIDC
+func (s *AdminServer) SetOIDCLogin(login *OIDCLogin) {
+	s.oidcLogin = login
+}
+
+// OIDCLoginName is the name of the OIDC login, empty if there is none
+func (s *AdminServer) OIDCLoginName() string {
+	if s.oidcLogin == nil {
+		return ""
+	}
+	return s.oidcLogin.name
+}
+
+// HandleOIDCLogin sends the user to the issuer to login
+func (s *AdminServer) HandleOIDCLogin(c *gin.Context) {
+	if s.oidcLogin == nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+	state, nonce := randomToken(), randomToken()
+	session := sessions.Default(c)
+	session.Set("oidc_state", state)
+	session.Set("oidc_nonce", nonce)
+	session.Save()
+
+	c.Redirect(http.StatusSeeOther, s.oidcLogin.provider.AuthCodeURL(s.oidcLogin.endpoints, s.oidcLogin.redirectURL, state, nonce))
+}
+
+// HandleOIDCCallback logs the user in with the code the issuer sent it back with
+func (s *AdminServer) HandleOIDCCallback(c *gin.Context) {
+	if s.oidcLogin == nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+	session := sessions.Default(c)
+	state, _ := session.Get("oidc_state").(string)
+	nonce, _ := session.Get("oidc_nonce").(string)
+	session.Delete("oidc_state")
+	session.Delete("oidc_nonce")
+	session.Save()
+
+	if errorCode := c.Query("error"); errorCode != "" {
+		redirectLoginError(c, "Login failed: "+errorCode)
+		return
+	}
+	if state == "" || c.Query("state") != state {
+		redirectLoginError(c, "Login expired, please try again")
+		return
+	}
+
+	ctx := c.Request.Context()
+	idToken, err := s.oidcLogin.provider.ExchangeCode(ctx, s.oidcLogin.endpoints, s.oidcLogin.redirectURL, c.Query("code"))
+	if err != nil {
+		glog.Warningf("oidc login: %v", err)
+		redirectLoginError(c, "Login failed")
+		return
+	}
+	claims, err := s.oidcLogin.provider.ValidateToken(ctx, idToken)
+	if err != nil || claims.Claims["nonce"] != nonce {
+		glog.Warningf("oidc login with invalid id token: %v", err)
+		redirectLoginError(c, "Login failed")
+		return
+	}
+	username, role, err := s.oidcLogin.authenticate(ctx, idToken)
+	if err != nil {
+		glog.V(1).Infof("oidc login: %v", err)
+		redirectLoginError(c, "Your account has no role in this admin server")
+		return
+	}
+
+	setSession(c, username, role)
+	c.Redirect(http.StatusSeeOther, "/admin")
+}
+
+// authenticate validates an ID token, and returns the user and its role mapped from the claims
+func (l *OIDCLogin) authenticate(ctx context.Context, token string) (string, AdminRole, error) {
+	identity, err := l.provider.Authenticate(ctx, token)
+	if err != nil {
+		return "", "", err
+	}
+	username := identity.Email
+	if username == "" {
+		username = identity.UserID
+	}
+	role, found := HighestAdminRole(strings.Split(identity.Attributes["roles"], ","))
+	if !found {
+		return username, "", fmt.Errorf("user %s has no admin role in %q", username, identity.Attributes["roles"])
+	}
+	return username, role, nil
+}
+
+func redirectLoginError(c *gin.Context, message string) {
+	c.Redirect(http.StatusSeeOther, "/login?error="+url.QueryEscape(message))
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		glog.Fatalf("generate random token: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,49 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeWorker checks that a worker has the operator role to run maintenance tasks, if jwt.admin_signing.key is set.
+// Without the key, any worker can connect, and runAdmin warns about it when the admin interface requires login.
+// A worker authenticates with a jwt signed with that key, or with an ID token of the OIDC login.
+func (s *WorkerGrpcServer) authorizeWorker(ctx context.Context) error {
+	if len(s.signingKey) == 0 {
+		return nil
+	}
+	token := security.GetGrpcJwt(ctx)
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "worker token is required")
+	}
+	subject, role, err := s.workerRole(ctx, token)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid worker token: %v", err)
+	}
+	if !role.Allows(RoleOperator) {
+		glog.V(1).Infof("worker %s with role %q denied", subject, role)
+		return status.Errorf(codes.PermissionDenied, "%s with role %q can not run maintenance tasks", subject, role)
+	}
+	return nil
+}
+
+func (s *WorkerGrpcServer) workerRole(ctx context.Context, token security.EncodedJwt) (string, AdminRole, error) {
+	claims := &security.SeaweedAdminClaims{}
+	jwtToken, err := security.DecodeJwt(s.signingKey, token, claims)
+	if err == nil && jwtToken.Valid {
+		role, _ := ParseAdminRole(claims.Role)
+		return claims.Subject, role, nil
+	}
+	if login := s.adminServer.oidcLogin; login != nil {
+		return login.authenticate(ctx, string(token))
+	}
+	if err == nil {
+		err = fmt.Errorf("token is not valid")
+	}
+	return "", "", err
+}
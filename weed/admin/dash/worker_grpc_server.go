@@ -21,6 +21,7 @@ import (
 type WorkerGrpcServer struct {
 	worker_pb.UnimplementedWorkerServiceServer
 	adminServer *AdminServer
+	signingKey  security.SigningKey
 
 	// Worker connection management
 	connections map[string]*WorkerConnection
@@ -62,6 +63,7 @@ type WorkerConnection struct {
 func NewWorkerGrpcServer(adminServer *AdminServer) *WorkerGrpcServer {
 	return &WorkerGrpcServer{
 		adminServer:        adminServer,
+		signingKey:         security.SigningKey(util.GetViper().GetString("jwt.admin_signing.key")),
 		connections:        make(map[string]*WorkerConnection),
 		pendingLogRequests: make(map[string]*LogRequestContext),
 		stopChan:           make(chan struct{}),
@@ -140,6 +142,10 @@ func (s *WorkerGrpcServer) Stop() error {
 func (s *WorkerGrpcServer) WorkerStream(stream worker_pb.WorkerService_WorkerStreamServer) error {
 	ctx := stream.Context()
 
+	if err := s.authorizeWorker(ctx); err != nil {
+		return err
+	}
+
 	// get client address
 	address := findClientAddress(ctx)
 
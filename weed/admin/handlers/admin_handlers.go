@@ -48,15 +48,20 @@ func (h *AdminHandlers) SetupRoutes(r *gin.Engine, authRequired bool, username,
 	// Health check (no auth required)
 	r.GET("/health", h.HealthCheck)
 
+	// Record the changes in the audit log
+	r.Use(dash.AuditRequests(h.adminServer))
+
 	if authRequired {
 		// Authentication routes (no auth required)
 		r.GET("/login", h.authHandlers.ShowLogin)
 		r.POST("/login", h.authHandlers.HandleLogin(username, password))
+		r.GET("/login/oidc", h.authHandlers.HandleOIDCLogin)
+		r.GET("/login/oidc/callback", h.authHandlers.HandleOIDCCallback)
 		r.GET("/logout", h.authHandlers.HandleLogout)
 
-		// Protected routes group
+		// Protected routes group, each route requires the role of dash.RequiredRole
 		protected := r.Group("/")
-		protected.Use(dash.RequireAuth())
+		protected.Use(dash.RequireAuth(), dash.RequireRole())
 
 		// Main admin interface routes
 		protected.GET("/", h.ShowDashboard)
@@ -98,7 +103,7 @@ func (h *AdminHandlers) SetupRoutes(r *gin.Engine, authRequired bool, username,
 
 		// API routes for AJAX calls
 		api := r.Group("/api")
-		api.Use(dash.RequireAuthAPI()) // Use API-specific auth middleware
+		api.Use(dash.RequireAuthAPI(), dash.RequireRole()) // Use API-specific auth middleware
 		{
 			api.GET("/cluster/topology", h.clusterHandlers.GetClusterTopology)
 			api.GET("/cluster/masters", h.clusterHandlers.GetMasters)
@@ -159,6 +164,12 @@ func (h *AdminHandlers) SetupRoutes(r *gin.Engine, authRequired bool, username,
 				volumeApi.POST("/:id/:server/vacuum", h.clusterHandlers.VacuumVolume)
 			}
 
+			// Collection management API routes
+			collectionApi := api.Group("/collections")
+			{
+				collectionApi.DELETE("/:name", h.clusterHandlers.DeleteCollection)
+			}
+
 			// Maintenance API routes
 			maintenanceApi := api.Group("/maintenance")
 			{
@@ -285,6 +296,12 @@ func (h *AdminHandlers) SetupRoutes(r *gin.Engine, authRequired bool, username,
 				volumeApi.POST("/:id/:server/vacuum", h.clusterHandlers.VacuumVolume)
 			}
 
+			// Collection management API routes
+			collectionApi := api.Group("/collections")
+			{
+				collectionApi.DELETE("/:name", h.clusterHandlers.DeleteCollection)
+			}
+
 			// Maintenance API routes
 			maintenanceApi := api.Group("/maintenance")
 			{
@@ -26,7 +26,7 @@ func (a *AuthHandlers) ShowLogin(c *gin.Context) {
 
 	// Render login template
 	c.Header("Content-Type", "text/html")
-	loginComponent := layout.LoginForm(c, "SeaweedFS Admin", errorMessage)
+	loginComponent := layout.LoginForm(c, "SeaweedFS Admin", errorMessage, a.adminServer.OIDCLoginName())
 	err := loginComponent.Render(c.Request.Context(), c.Writer)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render login template: " + err.Error()})
@@ -39,6 +39,16 @@ func (a *AuthHandlers) HandleLogin(username, password string) gin.HandlerFunc {
 	return a.adminServer.HandleLogin(username, password)
 }
 
+// HandleOIDCLogin sends the user to the OIDC issuer to login
+func (a *AuthHandlers) HandleOIDCLogin(c *gin.Context) {
+	a.adminServer.HandleOIDCLogin(c)
+}
+
+// HandleOIDCCallback logs in the user coming back from the OIDC issuer
+func (a *AuthHandlers) HandleOIDCCallback(c *gin.Context) {
+	a.adminServer.HandleOIDCCallback(c)
+}
+
 // HandleLogout handles user logout
 func (a *AuthHandlers) HandleLogout(c *gin.Context) {
 	a.adminServer.HandleLogout(c)
@@ -428,3 +428,24 @@ func (h *ClusterHandlers) VacuumVolume(c *gin.Context) {
 		"server":    server,
 	})
 }
+
+// DeleteCollection deletes a collection with all of its volumes
+func (h *ClusterHandlers) DeleteCollection(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Collection name is required"})
+		return
+	}
+
+	if err := h.adminServer.DeleteCollection(name); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete collection: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Collection deleted successfully",
+		"collection": name,
+	})
+}
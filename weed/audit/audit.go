@@ -0,0 +1,103 @@
+// Package audit keeps a tamper-evident log of administrative operations.
+//
+// Each filer chains the records it receives: every record carries the hash of the
+// previous record of the same filer, so a changed, removed or reordered record breaks the chain.
+// The records are appended as JSON lines to one file per filer and day under Directory.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// Directory keeps the audit log files, as <Directory>/<yyyy-mm-dd>/<filer>.log
+	Directory = "/etc/seaweedfs/audit"
+
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	ComponentShell = "shell"
+	ComponentAdmin = "admin"
+	ComponentIam   = "iam"
+)
+
+// NewRecord creates a record of an operation, with the result derived from its error
+func NewRecord(component, actor, sourceIp, action, target string, err error) *filer_pb.AuditRecord {
+	record := &filer_pb.AuditRecord{
+		Actor:     actor,
+		SourceIp:  sourceIp,
+		Component: component,
+		Action:    action,
+		Target:    target,
+		Result:    ResultSuccess,
+	}
+	if err != nil {
+		record.Result = ResultFailure
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// LogFile returns the file keeping the records of a filer on the day of the time
+func LogFile(host string, t time.Time) string {
+	return fmt.Sprintf("%s/%s/%s.log", Directory, t.UTC().Format(time.DateOnly), host)
+}
+
+// ComputeHash returns the hex encoded sha256 of the record fields, starting with the previous hash.
+// Each field is length prefixed, so that moving characters between fields changes the hash.
+func ComputeHash(record *filer_pb.AuditRecord) string {
+	h := sha256.New()
+	for _, field := range []string{
+		record.PrevHash,
+		strconv.FormatInt(record.TsNs, 10),
+		record.Host,
+		strconv.FormatUint(record.Sequence, 10),
+		record.Actor,
+		record.SourceIp,
+		record.Component,
+		record.Action,
+		record.Target,
+		record.Result,
+		record.Error,
+	} {
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(field)))
+		h.Write(size[:])
+		h.Write([]byte(field))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyChain checks the records of one filer, in sequence order.
+// The first record may continue an earlier chain, unless it is the first record of the filer.
+func VerifyChain(records []*filer_pb.AuditRecord) error {
+	for i, record := range records {
+		if hash := ComputeHash(record); hash != record.Hash {
+			return fmt.Errorf("%s record %d: hash %s does not match its content", record.Host, record.Sequence, record.Hash)
+		}
+		if i == 0 {
+			if record.Sequence == 1 && record.PrevHash != "" {
+				return fmt.Errorf("%s record 1: unexpected previous hash %s", record.Host, record.PrevHash)
+			}
+			continue
+		}
+		prev := records[i-1]
+		if record.Host != prev.Host {
+			return fmt.Errorf("record %d: filer %s in the chain of %s", record.Sequence, record.Host, prev.Host)
+		}
+		if record.Sequence != prev.Sequence+1 {
+			return fmt.Errorf("%s record %d: follows record %d", record.Host, record.Sequence, prev.Sequence)
+		}
+		if record.PrevHash != prev.Hash {
+			return fmt.Errorf("%s record %d: previous hash %s does not match record %d", record.Host, record.Sequence, record.PrevHash, prev.Sequence)
+		}
+	}
+	return nil
+}
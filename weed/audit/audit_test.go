@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newTestChain(n int) (records []*filer_pb.AuditRecord) {
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		record := NewRecord(ComponentShell, "alice", "10.0.0.1", "volume.delete", fmt.Sprintf("-volumeId=%d", i), nil)
+		record.TsNs = int64(i) * int64(time.Second)
+		record.Host = "filer1:8888"
+		record.Sequence = uint64(i)
+		record.PrevHash = prevHash
+		record.Hash = ComputeHash(record)
+		prevHash = record.Hash
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNewRecord(t *testing.T) {
+	record := NewRecord(ComponentIam, "admin", "", "DeleteAccessKey", "UserName=bob", nil)
+	assert.Equal(t, ResultSuccess, record.Result)
+	assert.Equal(t, "", record.Error)
+
+	record = NewRecord(ComponentIam, "admin", "", "DeleteAccessKey", "UserName=bob", errors.New("403 Forbidden"))
+	assert.Equal(t, ResultFailure, record.Result)
+	assert.Equal(t, "403 Forbidden", record.Error)
+}
+
+func TestComputeHash(t *testing.T) {
+	a := &filer_pb.AuditRecord{Actor: "ab", Action: "c"}
+	b := &filer_pb.AuditRecord{Actor: "a", Action: "bc"}
+	assert.Equal(t, ComputeHash(a), ComputeHash(a))
+	assert.NotEqual(t, ComputeHash(a), ComputeHash(b))
+
+	c := &filer_pb.AuditRecord{Actor: "ab", Action: "c", PrevHash: "00"}
+	assert.NotEqual(t, ComputeHash(a), ComputeHash(c))
+}
+
+func TestVerifyChain(t *testing.T) {
+	assert.Nil(t, VerifyChain(newTestChain(5)))
+	assert.Nil(t, VerifyChain(newTestChain(5)[2:]), "a chain may start in the middle")
+	assert.Nil(t, VerifyChain(nil))
+
+	changed := newTestChain(5)
+	changed[2].Target = "-volumeId=42"
+	assert.NotNil(t, VerifyChain(changed))
+
+	rehashed := newTestChain(5)
+	rehashed[2].Target = "-volumeId=42"
+	rehashed[2].Hash = ComputeHash(rehashed[2])
+	assert.NotNil(t, VerifyChain(rehashed), "the next record keeps the original hash")
+
+	removed := newTestChain(5)
+	removed = append(removed[:2], removed[3:]...)
+	assert.NotNil(t, VerifyChain(removed))
+
+	reordered := newTestChain(5)
+	reordered[1], reordered[2] = reordered[2], reordered[1]
+	assert.NotNil(t, VerifyChain(reordered))
+
+	forged := newTestChain(1)
+	forged[0].PrevHash = "00"
+	forged[0].Hash = ComputeHash(forged[0])
+	assert.NotNil(t, VerifyChain(forged))
+}
+
+func TestParseLogAndFilter(t *testing.T) {
+	chain := newTestChain(3)
+	chain[1].Action = "collection.delete"
+	chain[1].Hash = ComputeHash(chain[1])
+	chain[2].Result = ResultFailure
+
+	var data []byte
+	for _, record := range chain {
+		line, err := protojson.Marshal(record)
+		assert.Nil(t, err)
+		data = append(append(data, line...), '\n')
+	}
+	records, err := ParseLog(data)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, chain[2].Hash, records[2].Hash)
+
+	_, err = ParseLog([]byte("{\"actor\":\"alice\"}\nnot json\n"))
+	assert.NotNil(t, err)
+
+	assert.True(t, (&Filter{}).Match(records[0]))
+	assert.True(t, (&Filter{Action: "collection.delete"}).Match(records[1]))
+	assert.False(t, (&Filter{Action: "collection.delete"}).Match(records[0]))
+	assert.True(t, (&Filter{Target: "Id=3", Result: ResultFailure}).Match(records[2]))
+	assert.False(t, (&Filter{Actor: "bob"}).Match(records[0]))
+	assert.False(t, (&Filter{Since: time.Unix(2, 0)}).Match(records[0]))
+	assert.True(t, (&Filter{Since: time.Unix(2, 0)}).Match(records[1]))
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// Append sends the record to the filer, which chains and stores it
+func Append(ctx context.Context, client filer_pb.SeaweedFilerClient, record *filer_pb.AuditRecord) (*filer_pb.AuditRecord, error) {
+	resp, err := client.AppendAuditRecord(ctx, &filer_pb.AppendAuditRecordRequest{
+		Record: record,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("append audit record: %w", err)
+	}
+	return resp.Record, nil
+}
+
+// Log appends the record with a filer client, and only logs a failure,
+// since the audited operation has already happened.
+func Log(withFilerClient func(fn func(filer_pb.SeaweedFilerClient) error) error, record *filer_pb.AuditRecord) {
+	err := withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := Append(context.Background(), client, record)
+		return err
+	})
+	if err != nil {
+		glog.Errorf("audit %s %s %s by %q: %v", record.Component, record.Action, record.Target, record.Actor, err)
+	}
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Filter selects audit records; empty fields match everything
+type Filter struct {
+	Since     time.Time
+	Host      string
+	Actor     string
+	Component string
+	Action    string
+	Target    string // substring of the target
+	Result    string
+}
+
+func (f *Filter) Match(record *filer_pb.AuditRecord) bool {
+	if !f.Since.IsZero() && record.TsNs < f.Since.UnixNano() {
+		return false
+	}
+	return matchField(f.Host, record.Host) &&
+		matchField(f.Actor, record.Actor) &&
+		matchField(f.Component, record.Component) &&
+		matchField(f.Action, record.Action) &&
+		matchField(f.Result, record.Result) &&
+		(f.Target == "" || strings.Contains(record.Target, f.Target))
+}
+
+func matchField(expected, actual string) bool {
+	return expected == "" || expected == actual
+}
+
+// ParseLog parses the json lines of an audit log file
+func ParseLog(data []byte) (records []*filer_pb.AuditRecord, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		record := &filer_pb.AuditRecord{}
+		if err := protojson.Unmarshal(line, record); err != nil {
+			return records, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
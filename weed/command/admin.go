@@ -22,6 +22,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/admin"
 	"github.com/seaweedfs/seaweedfs/weed/admin/dash"
 	"github.com/seaweedfs/seaweedfs/weed/admin/handlers"
+	"github.com/seaweedfs/seaweedfs/weed/iam/ldap"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -38,6 +39,12 @@ type AdminOptions struct {
 	adminUser     *string
 	adminPassword *string
 	dataDir       *string
+
+	ldapConfigFile     *string
+	ldapAdminGroups    *string
+	ldapOperatorGroups *string
+	ldapViewerGroups   *string
+	oidcConfigFile     *string
 }
 
 func init() {
@@ -49,6 +56,11 @@ func init() {
 
 	a.adminUser = cmdAdmin.Flag.String("adminUser", "admin", "admin interface username")
 	a.adminPassword = cmdAdmin.Flag.String("adminPassword", "", "admin interface password (if empty, auth is disabled)")
+	a.ldapConfigFile = cmdAdmin.Flag.String("ldapConfigFile", "", "path to JSON file of the LDAP or Active Directory server for the login of directory users")
+	a.ldapAdminGroups = cmdAdmin.Flag.String("ldapAdminGroups", "", "comma-separated directory groups logging in as admin (directory users outside all role groups cannot login)")
+	a.ldapOperatorGroups = cmdAdmin.Flag.String("ldapOperatorGroups", "", "comma-separated directory groups logging in as operator, running maintenance tasks")
+	a.ldapViewerGroups = cmdAdmin.Flag.String("ldapViewerGroups", "", "comma-separated directory groups logging in as read-only viewer")
+	a.oidcConfigFile = cmdAdmin.Flag.String("oidcConfigFile", "", "path to JSON file of the OIDC issuer for single sign-on, with the roleMapping of the users to viewer, operator and admin")
 }
 
 var cmdAdmin = &Command{
@@ -82,7 +94,17 @@ var cmdAdmin = &Command{
   Authentication:
     - If adminPassword is not set, the admin interface runs without authentication
     - If adminPassword is set, users must login with adminUser/adminPassword
+    - If ldapConfigFile is set, users can also login with their LDAP or Active Directory
+      credentials, with the role of their ldapAdminGroups, ldapOperatorGroups or ldapViewerGroups;
+      directory users in none of these groups cannot login
+    - If oidcConfigFile is set, users can also login through an OIDC issuer, with the role
+      given by the roleMapping rules on their claims
+    - Roles: a viewer can look at everything except the object store users and the file contents,
+      an operator can also run maintenance tasks, and an admin can also change users, buckets,
+      files and configuration
     - Sessions are secured with auto-generated session keys
+    - With any login, set jwt.admin_signing.key in security.toml, so the workers need the
+      operator role, too; without it, the workers connect without authentication
 
   Security Configuration:
     - The admin server reads TLS configuration from security.toml
@@ -101,6 +123,8 @@ var cmdAdmin = &Command{
   Worker Communication:
     - Workers connect via gRPC on HTTP port + 10000
     - Workers use [grpc.admin] configuration from security.toml
+    - If [jwt.admin_signing] key is set, workers need a jwt signed with it, or an OIDC ID token,
+      with the operator role
     - TLS is automatically used if certificates are configured
     - Workers fall back to insecure connections if TLS is unavailable
 
@@ -137,9 +161,13 @@ func runAdmin(cmd *Command, args []string) bool {
 	}
 
 	// Security warnings
-	if *a.adminPassword == "" {
+	if *a.adminPassword == "" && *a.ldapConfigFile == "" && *a.oidcConfigFile == "" {
 		fmt.Println("WARNING: Admin interface is running without authentication!")
 		fmt.Println("         Set -adminPassword for production use")
+	} else if util.GetViper().GetString("jwt.admin_signing.key") == "" {
+		// without the key, any worker can connect and the roles only apply to the web interface
+		fmt.Println("WARNING: jwt.admin_signing.key is not set in security.toml, so workers connect without authentication!")
+		fmt.Println("         Set it to require the operator role from the workers, too")
 	}
 
 	fmt.Printf("Starting SeaweedFS Admin Interface on port %d\n", *a.port)
@@ -153,7 +181,14 @@ func runAdmin(cmd *Command, args []string) bool {
 	}
 	if *a.adminPassword != "" {
 		fmt.Printf("Authentication: Enabled (user: %s)\n", *a.adminUser)
-	} else {
+	}
+	if *a.ldapConfigFile != "" {
+		fmt.Printf("Authentication: Enabled (LDAP: %s)\n", *a.ldapConfigFile)
+	}
+	if *a.oidcConfigFile != "" {
+		fmt.Printf("Authentication: Enabled (OIDC: %s)\n", *a.oidcConfigFile)
+	}
+	if *a.adminPassword == "" && *a.ldapConfigFile == "" && *a.oidcConfigFile == "" {
 		fmt.Printf("Authentication: Disabled\n")
 	}
 
@@ -253,9 +288,33 @@ func startAdminServer(ctx context.Context, options AdminOptions) error {
 		}
 	}()
 
+	// Let directory users login
+	if *options.ldapConfigFile != "" {
+		directory, err := ldap.NewLDAPProviderFromFile("ldap", *options.ldapConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to initialize LDAP provider: %w", err)
+		}
+		defer directory.Close()
+		if *options.ldapAdminGroups == "" && *options.ldapOperatorGroups == "" && *options.ldapViewerGroups == "" {
+			log.Printf("Warning: no ldapAdminGroups, ldapOperatorGroups or ldapViewerGroups are set, so no directory user can login")
+		}
+		adminServer.SetDirectory(directory, util.StringSplit(*options.ldapAdminGroups, ","),
+			util.StringSplit(*options.ldapOperatorGroups, ","), util.StringSplit(*options.ldapViewerGroups, ","))
+	}
+
+	// Let users login through an OIDC issuer
+	if *options.oidcConfigFile != "" {
+		oidcLogin, err := dash.NewOIDCLoginFromFile(*options.oidcConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to initialize OIDC login: %w", err)
+		}
+		adminServer.SetOIDCLogin(oidcLogin)
+	}
+
 	// Create handlers and setup routes
 	adminHandlers := handlers.NewAdminHandlers(adminServer)
-	adminHandlers.SetupRoutes(r, *options.adminPassword != "", *options.adminUser, *options.adminPassword)
+	authRequired := *options.adminPassword != "" || *options.ldapConfigFile != "" || *options.oidcConfigFile != ""
+	adminHandlers.SetupRoutes(r, authRequired, *options.adminUser, *options.adminPassword)
 
 	// Server configuration
 	addr := fmt.Sprintf(":%d", *options.port)
@@ -0,0 +1,511 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+var (
+	clusterBackup ClusterBackupOptions
+)
+
+type ClusterBackupOptions struct {
+	master         *string
+	filer          *string
+	target         *string
+	s3Endpoint     *string
+	s3Region       *string
+	full           *bool
+	grpcDialOption grpc.DialOption
+}
+
+func init() {
+	cmdClusterBackup.Run = runClusterBackup // break init cycle
+	clusterBackup.master = cmdClusterBackup.Flag.String("master", "localhost:9333", "the master server")
+	clusterBackup.filer = cmdClusterBackup.Flag.String("filer", "localhost:8888", "the filer server")
+	clusterBackup.target = cmdClusterBackup.Flag.String("target", "", "a local folder, or s3://bucket/prefix, to keep the backups")
+	clusterBackup.s3Endpoint = cmdClusterBackup.Flag.String("s3.endpoint", "", "the endpoint of an S3 compatible target, empty for AWS S3")
+	clusterBackup.s3Region = cmdClusterBackup.Flag.String("s3.region", "us-east-1", "the region of the S3 target")
+	clusterBackup.full = cmdClusterBackup.Flag.Bool("full", false, "copy all metadata and volumes, instead of the changes since the last backup")
+}
+
+var cmdClusterBackup = &Command{
+	UsageLine: "cluster.backup -master=localhost:9333 -filer=localhost:8888 -target=/path/to/backups",
+	Short:     "incrementally backup the filer metadata and all volumes at one consistent point in time",
+	Long: `Incrementally backup the filer metadata and all volumes at one consistent point in time.
+
+	Each run adds one backup to the target. The first backup copies a metadata snapshot and all volumes.
+	Later backups copy only the metadata events and the volume data appended since the previous backup.
+
+	weed cluster.backup -master=localhost:9333 -filer=localhost:8888 -target=/backups/cluster1
+	weed cluster.backup -target=s3://bucket/cluster1 -s3.endpoint=http://localhost:8333
+
+	Each backup records a cut: a metadata event time, and the .dat offset of each volume taken after it.
+	All chunks referenced by the metadata up to the cut are in the volume data up to the offsets,
+	so a restore never has metadata pointing to missing chunks.
+	Use "weed cluster.restore" to verify a backup and restore it into an empty cluster.
+
+	The S3 credentials are read from the environment or the shared AWS config, the same as the aws cli.
+	Erasure coded volumes are not backed up. Decode them with "ec.decode" first.
+
+	The target has one folder per backup:
+
+	000001/manifest.json       the cut, and the copied offsets of each volume, written last
+	000001/meta.snapshot       the metadata snapshot in the "fs.meta.save" format, only in full backups
+	000001/meta.events         the metadata events up to the cut, since the previous backup or the snapshot
+	000001/volumes/<id>.dat    the .dat bytes of volume <id>, since the previous backup unless marked full
+
+`,
+}
+
+const (
+	clusterBackupManifestFile = "manifest.json"
+	clusterBackupSnapshotFile = "meta.snapshot"
+	clusterBackupEventsFile   = "meta.events"
+)
+
+// clusterBackupManifest describes one backup, see the cluster.backup help
+type clusterBackupManifest struct {
+	Sequence         int                    `json:"sequence"`
+	Master           string                 `json:"master"`
+	Filer            string                 `json:"filer"`
+	StartedAt        time.Time              `json:"startedAt"`
+	CompletedAt      time.Time              `json:"completedAt"`
+	MetaSnapshot     bool                   `json:"metaSnapshot"`
+	MetaSinceNs      int64                  `json:"metaSinceNs"`
+	MetaCutNs        int64                  `json:"metaCutNs"`
+	MetaEventCount   int64                  `json:"metaEventCount"`
+	Volumes          []*clusterBackupVolume `json:"volumes"`
+	SkippedEcVolumes []uint32               `json:"skippedEcVolumes,omitempty"`
+}
+
+type clusterBackupVolume struct {
+	Id              uint32 `json:"id"`
+	Collection      string `json:"collection"`
+	Replication     string `json:"replication"`
+	Ttl             string `json:"ttl"`
+	DiskType        string `json:"diskType"`
+	Version         uint32 `json:"version"`
+	Server          string `json:"server"`
+	CompactRevision uint32 `json:"compactRevision"`
+	// a full copy starts from the super block, otherwise it continues the copy of the previous backup
+	Full        bool   `json:"full"`
+	StartOffset uint64 `json:"startOffset"`
+	StopOffset  uint64 `json:"stopOffset"`
+}
+
+func (v *clusterBackupVolume) hasData() bool {
+	return v.Full || v.StopOffset > v.StartOffset
+}
+
+func clusterBackupFile(sequence int, name string) string {
+	return fmt.Sprintf("%06d/%s", sequence, name)
+}
+
+func clusterBackupVolumeFile(sequence int, volumeId uint32) string {
+	return clusterBackupFile(sequence, fmt.Sprintf("volumes/%d.dat", volumeId))
+}
+
+// loadClusterBackupManifests lists the completed backups, the oldest first
+func loadClusterBackupManifests(target clusterBackupTarget) (manifests []*clusterBackupManifest, err error) {
+	names, err := target.List()
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", target, err)
+	}
+	for _, name := range names {
+		dir, file := path.Split(name)
+		if file != clusterBackupManifestFile {
+			continue
+		}
+		if _, parseErr := strconv.Atoi(strings.TrimSuffix(dir, "/")); parseErr != nil {
+			continue
+		}
+		manifest, err := readClusterBackupManifest(target, name)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+func readClusterBackupManifest(target clusterBackupTarget, name string) (*clusterBackupManifest, error) {
+	reader, err := target.Read(name)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	defer reader.Close()
+	manifest := &clusterBackupManifest{}
+	if err = json.NewDecoder(reader).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	return manifest, nil
+}
+
+func runClusterBackup(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+	clusterBackup.grpcDialOption = security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	if *clusterBackup.target == "" {
+		return false
+	}
+	target, err := newClusterBackupTarget(*clusterBackup.target, *clusterBackup.s3Endpoint, *clusterBackup.s3Region)
+	if err != nil {
+		glog.Errorf("backup target %s: %v", *clusterBackup.target, err)
+		return true
+	}
+
+	manifest, err := clusterBackup.backup(target)
+	if err != nil {
+		glog.Errorf("cluster backup to %s: %v", target, err)
+		return true
+	}
+	fmt.Printf("backup %d is complete in %s, consistent at %v\n", manifest.Sequence, target, time.Unix(0, manifest.MetaCutNs).UTC())
+	return true
+}
+
+func (option *ClusterBackupOptions) backup(target clusterBackupTarget) (*clusterBackupManifest, error) {
+
+	manifests, err := loadClusterBackupManifests(target)
+	if err != nil {
+		return nil, err
+	}
+	var previous *clusterBackupManifest
+	if len(manifests) > 0 && !*option.full {
+		previous = manifests[len(manifests)-1]
+	}
+	manifest := &clusterBackupManifest{
+		Sequence:  1,
+		Master:    *option.master,
+		Filer:     *option.filer,
+		StartedAt: time.Now().UTC(),
+	}
+	if len(manifests) > 0 {
+		manifest.Sequence = manifests[len(manifests)-1].Sequence + 1
+	}
+	if exists, err := target.Exists(clusterBackupFile(manifest.Sequence, clusterBackupManifestFile)); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("backup %d already exists in %s", manifest.Sequence, target)
+	}
+
+	// 1. the metadata snapshot, which can be changing while copied,
+	// made consistent by the metadata events since it started
+	if previous == nil {
+		manifest.MetaSnapshot = true
+		if manifest.MetaSinceNs, err = option.filerTimeNs(); err != nil {
+			return nil, err
+		}
+		count, err := option.writeMetaSnapshot(target, clusterBackupFile(manifest.Sequence, clusterBackupSnapshotFile))
+		if err != nil {
+			return nil, fmt.Errorf("metadata snapshot: %w", err)
+		}
+		fmt.Printf("copied %d metadata entries\n", count)
+	} else {
+		manifest.MetaSinceNs = previous.MetaCutNs
+	}
+
+	// 2. the cut
+	if manifest.MetaCutNs, err = option.filerTimeNs(); err != nil {
+		return nil, err
+	}
+
+	// 3. the volume offsets, taken after the cut, so they cover all chunks written before it
+	if err = option.collectVolumes(manifest, previous); err != nil {
+		return nil, err
+	}
+
+	// 4. the metadata events up to the cut
+	if manifest.MetaEventCount, err = option.writeMetaEvents(target, clusterBackupFile(manifest.Sequence, clusterBackupEventsFile), manifest.MetaSinceNs, manifest.MetaCutNs); err != nil {
+		return nil, fmt.Errorf("metadata events: %w", err)
+	}
+	fmt.Printf("copied %d metadata events\n", manifest.MetaEventCount)
+
+	// 5. the volume data up to the offsets
+	for _, volume := range manifest.Volumes {
+		if !volume.hasData() {
+			continue
+		}
+		size, err := option.writeVolume(target, clusterBackupVolumeFile(manifest.Sequence, volume.Id), volume)
+		if err != nil {
+			return nil, fmt.Errorf("volume %d on %s: %w", volume.Id, volume.Server, err)
+		}
+		fmt.Printf("copied volume %d from %s, %d bytes\n", volume.Id, volume.Server, size)
+	}
+	for _, vid := range manifest.SkippedEcVolumes {
+		fmt.Printf("skipped erasure coded volume %d\n", vid)
+	}
+
+	// 6. the manifest completes the backup
+	manifest.CompletedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = target.Write(clusterBackupFile(manifest.Sequence, clusterBackupManifestFile), strings.NewReader(string(data))); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (option *ClusterBackupOptions) filerTimeNs() (tsNs int64, err error) {
+	err = pb.WithFilerClient(false, 0, pb.ServerAddress(*option.filer), option.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		resp, pingErr := client.Ping(context.Background(), &filer_pb.PingRequest{})
+		if pingErr != nil {
+			return pingErr
+		}
+		tsNs = resp.StartTimeNs
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ping filer %s: %w", *option.filer, err)
+	}
+	return
+}
+
+// writeClusterBackupRecords writes length prefixed records as produced by fn, the same as "fs.meta.save"
+func writeClusterBackupRecords(target clusterBackupTarget, name string, fn func(write func(message proto.Message) error) error) error {
+	pr, pw := io.Pipe()
+	go func() {
+		sizeBuf := make([]byte, 4)
+		pw.CloseWithError(fn(func(message proto.Message) error {
+			data, err := proto.Marshal(message)
+			if err != nil {
+				return err
+			}
+			util.Uint32toBytes(sizeBuf, uint32(len(data)))
+			if _, err = pw.Write(sizeBuf); err != nil {
+				return err
+			}
+			_, err = pw.Write(data)
+			return err
+		}))
+	}()
+	_, err := target.Write(name, pr)
+	pr.CloseWithError(err)
+	return err
+}
+
+// readClusterBackupRecords reads the records written by writeClusterBackupRecords
+func readClusterBackupRecords(reader io.Reader, newMessage func() proto.Message, fn func(message proto.Message) error) error {
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(reader, sizeBuf); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		data := make([]byte, util.BytesToUint32(sizeBuf))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return err
+		}
+		message := newMessage()
+		if err := proto.Unmarshal(data, message); err != nil {
+			return err
+		}
+		if err := fn(message); err != nil {
+			return err
+		}
+	}
+}
+
+func (option *ClusterBackupOptions) writeMetaSnapshot(target clusterBackupTarget, name string) (count int64, err error) {
+	err = writeClusterBackupRecords(target, name, func(write func(message proto.Message) error) error {
+		return pb.WithFilerClient(true, 0, pb.ServerAddress(*option.filer), option.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			return filer_pb.StreamBfs(client, "/", 0, func(parentPath util.FullPath, entry *filer_pb.Entry) error {
+				if strings.HasPrefix(string(parentPath.Child(entry.Name)), filer.SystemLogDir) {
+					return nil
+				}
+				count++
+				return write(&filer_pb.FullEntry{Dir: string(parentPath), Entry: entry})
+			})
+		})
+	})
+	return
+}
+
+func (option *ClusterBackupOptions) writeMetaEvents(target clusterBackupTarget, name string, sinceNs, cutNs int64) (count int64, err error) {
+	err = writeClusterBackupRecords(target, name, func(write func(message proto.Message) error) error {
+		var writeErr error
+		followErr := pb.FollowMetadata(pb.ServerAddress(*option.filer), option.grpcDialOption, &pb.MetadataFollowOption{
+			ClientName:     "cluster_backup",
+			ClientId:       util.RandomInt32(),
+			PathPrefix:     "/",
+			StartTsNs:      sinceNs,
+			StopTsNs:       cutNs,
+			EventErrorType: pb.DontLogError,
+		}, func(resp *filer_pb.SubscribeMetadataResponse) error {
+			if writeErr != nil || resp.TsNs > cutNs || filer_pb.IsEmpty(resp) || strings.HasPrefix(resp.Directory, filer.SystemLogDir) {
+				return nil
+			}
+			count++
+			writeErr = write(resp)
+			return writeErr
+		})
+		if writeErr != nil {
+			return writeErr
+		}
+		return followErr
+	})
+	return
+}
+
+// collectVolumes picks one replica of each volume, and the range of its .dat to copy
+func (option *ClusterBackupOptions) collectVolumes(manifest, previous *clusterBackupManifest) error {
+	previousVolumes := make(map[uint32]*clusterBackupVolume)
+	if previous != nil {
+		for _, v := range previous.Volumes {
+			previousVolumes[v.Id] = v
+		}
+	}
+
+	replicas := make(map[uint32][]pb.ServerAddress)
+	diskTypes := make(map[uint32]string)
+	var volumeIds []uint32
+	ecVolumes := make(map[uint32]bool)
+	err := pb.WithMasterClient(false, pb.ServerAddress(*option.master), option.grpcDialOption, false, func(client master_pb.SeaweedClient) error {
+		resp, err := client.VolumeList(context.Background(), &master_pb.VolumeListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, dc := range resp.TopologyInfo.GetDataCenterInfos() {
+			for _, rack := range dc.RackInfos {
+				for _, dn := range rack.DataNodeInfos {
+					for _, disk := range dn.DiskInfos {
+						for _, v := range disk.VolumeInfos {
+							if _, found := replicas[v.Id]; !found {
+								volumeIds = append(volumeIds, v.Id)
+							}
+							replicas[v.Id] = append(replicas[v.Id], pb.NewServerAddressFromDataNode(dn))
+							diskTypes[v.Id] = v.DiskType
+						}
+						for _, ec := range disk.EcShardInfos {
+							ecVolumes[ec.Id] = true
+						}
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("list volumes from %s: %w", *option.master, err)
+	}
+	for vid := range ecVolumes {
+		manifest.SkippedEcVolumes = append(manifest.SkippedEcVolumes, vid)
+	}
+
+	for _, vid := range volumeIds {
+		server := replicas[vid][0]
+		prev := previousVolumes[vid]
+		for _, replica := range replicas[vid] {
+			// keep copying from the same replica, since the replicas have different offsets
+			if prev != nil && string(replica) == prev.Server {
+				server = replica
+			}
+		}
+		var status *volume_server_pb.VolumeSyncStatusResponse
+		err = pb.WithVolumeServerClient(false, server, option.grpcDialOption, func(client volume_server_pb.VolumeServerClient) (statusErr error) {
+			status, statusErr = client.VolumeSyncStatus(context.Background(), &volume_server_pb.VolumeSyncStatusRequest{VolumeId: vid})
+			return
+		})
+		if err != nil {
+			return fmt.Errorf("volume %d status on %s: %w", vid, server, err)
+		}
+		volume := &clusterBackupVolume{
+			Id:              vid,
+			Collection:      status.Collection,
+			Replication:     status.Replication,
+			Ttl:             status.Ttl,
+			DiskType:        diskTypes[vid],
+			Version:         status.Version,
+			Server:          string(server),
+			CompactRevision: status.CompactRevision,
+			Full:            true,
+			StopOffset:      status.TailOffset,
+		}
+		if prev != nil && prev.Server == volume.Server && prev.CompactRevision == volume.CompactRevision && prev.StopOffset <= volume.StopOffset {
+			volume.Full = false
+			volume.StartOffset = prev.StopOffset
+		}
+		manifest.Volumes = append(manifest.Volumes, volume)
+	}
+	return nil
+}
+
+func (option *ClusterBackupOptions) writeVolume(target clusterBackupTarget, name string, volume *clusterBackupVolume) (int64, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(pb.WithVolumeServerClient(true, pb.ServerAddress(volume.Server), option.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			var receive func() ([]byte, error)
+			if volume.Full {
+				stream, err := client.CopyFile(context.Background(), &volume_server_pb.CopyFileRequest{
+					VolumeId:           volume.Id,
+					Ext:                ".dat",
+					CompactionRevision: volume.CompactRevision,
+					StopOffset:         volume.StopOffset,
+					Collection:         volume.Collection,
+				})
+				if err != nil {
+					return err
+				}
+				receive = func() ([]byte, error) {
+					resp, err := stream.Recv()
+					return resp.GetFileContent(), err
+				}
+			} else {
+				stream, err := client.VolumeIncrementalCopy(context.Background(), &volume_server_pb.VolumeIncrementalCopyRequest{
+					VolumeId:    volume.Id,
+					SinceOffset: volume.StartOffset,
+					StopOffset:  volume.StopOffset,
+				})
+				if err != nil {
+					return err
+				}
+				receive = func() ([]byte, error) {
+					resp, err := stream.Recv()
+					return resp.GetFileContent(), err
+				}
+			}
+			for {
+				data, err := receive()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if _, err = pw.Write(data); err != nil {
+					return err
+				}
+			}
+		}))
+	}()
+	size, err := target.Write(name, pr)
+	pr.CloseWithError(err)
+	if err == nil && uint64(size) != volume.StopOffset-volume.StartOffset {
+		// the volume is compacted or changed in the middle of copying
+		err = fmt.Errorf("copied %d bytes, expecting %d", size, volume.StopOffset-volume.StartOffset)
+	}
+	return size, err
+}
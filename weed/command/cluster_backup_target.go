@@ -0,0 +1,188 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// clusterBackupTarget keeps the files of cluster backups, in a local folder or an S3 bucket
+type clusterBackupTarget interface {
+	Write(name string, reader io.Reader) (size int64, err error)
+	Read(name string) (io.ReadCloser, error)
+	Exists(name string) (bool, error)
+	List() ([]string, error)
+	String() string
+}
+
+func newClusterBackupTarget(location, s3Endpoint, s3Region string) (clusterBackupTarget, error) {
+	if strings.HasPrefix(location, "s3://") {
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, "s3://"), "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("missing bucket in %s", location)
+		}
+		config := &aws.Config{
+			Region:           aws.String(s3Region),
+			S3ForcePathStyle: aws.Bool(s3Endpoint != ""),
+		}
+		if s3Endpoint != "" {
+			config.Endpoint = aws.String(s3Endpoint)
+		}
+		// the credentials come from the environment or the shared AWS config, as with the aws cli
+		sess, err := session.NewSession(config)
+		if err != nil {
+			return nil, fmt.Errorf("create aws session: %w", err)
+		}
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		return &s3ClusterBackupTarget{
+			conn:     s3.New(sess),
+			uploader: s3manager.NewUploader(sess),
+			bucket:   bucket,
+			prefix:   prefix,
+		}, nil
+	}
+	if err := os.MkdirAll(location, 0755); err != nil {
+		return nil, err
+	}
+	return &localClusterBackupTarget{dir: location}, nil
+}
+
+type localClusterBackupTarget struct {
+	dir string
+}
+
+func (t *localClusterBackupTarget) Write(name string, reader io.Reader) (int64, error) {
+	path := filepath.Join(t.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	// a file is only visible after it is complete
+	tmp := path + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(dst, reader)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	return size, os.Rename(tmp, path)
+}
+
+func (t *localClusterBackupTarget) Read(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(t.dir, filepath.FromSlash(name)))
+}
+
+func (t *localClusterBackupTarget) Exists(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(t.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (t *localClusterBackupTarget) List() (names []string, err error) {
+	err = filepath.Walk(t.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+		name, err := filepath.Rel(t.dir, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+		return nil
+	})
+	sort.Strings(names)
+	return
+}
+
+func (t *localClusterBackupTarget) String() string {
+	return t.dir
+}
+
+type s3ClusterBackupTarget struct {
+	conn     *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func (t *s3ClusterBackupTarget) Write(name string, reader io.Reader) (int64, error) {
+	counter := &countingReader{reader: reader}
+	_, err := t.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.prefix + name),
+		Body:   counter,
+	})
+	return counter.count, err
+}
+
+func (t *s3ClusterBackupTarget) Read(name string) (io.ReadCloser, error) {
+	resp, err := t.conn.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.prefix + name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (t *s3ClusterBackupTarget) Exists(name string) (bool, error) {
+	_, err := t.conn.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.prefix + name),
+	})
+	if awsErr, ok := err.(awserr.RequestFailure); ok && awsErr.StatusCode() == 404 {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (t *s3ClusterBackupTarget) List() (names []string, err error) {
+	err = t.conn.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(t.bucket),
+		Prefix: aws.String(t.prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(object.Key), t.prefix))
+		}
+		return true
+	})
+	sort.Strings(names)
+	return
+}
+
+func (t *s3ClusterBackupTarget) String() string {
+	return fmt.Sprintf("s3://%s/%s", t.bucket, t.prefix)
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.count += int64(n)
+	return
+}
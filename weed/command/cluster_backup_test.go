@@ -0,0 +1,98 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestClusterBackupRecords(t *testing.T) {
+	target, err := newClusterBackupTarget(t.TempDir(), "", "")
+	assert.NoError(t, err)
+
+	err = writeClusterBackupRecords(target, clusterBackupFile(1, clusterBackupSnapshotFile), func(write func(message proto.Message) error) error {
+		for _, name := range []string{"a", "b", "c"} {
+			if err := write(&filer_pb.FullEntry{Dir: "/dir", Entry: &filer_pb.Entry{Name: name}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	var names []string
+	err = readClusterBackupFile(target, "000001/meta.snapshot", func() proto.Message {
+		return &filer_pb.FullEntry{}
+	}, func(message proto.Message) error {
+		names = append(names, message.(*filer_pb.FullEntry).Entry.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	files, err := target.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"000001/meta.snapshot"}, files)
+}
+
+func TestClusterBackupMetadata(t *testing.T) {
+	m := newClusterBackupMetadata()
+	m.insert("/", &filer_pb.Entry{Name: "a", IsDirectory: true})
+	m.insert("/a", &filer_pb.Entry{Name: "b", IsDirectory: true})
+	m.insert("/a/b", &filer_pb.Entry{Name: "f.txt"})
+	m.insert("/", &filer_pb.Entry{Name: "ab.txt"})
+
+	// rename a directory
+	m.apply(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/",
+		EventNotification: &filer_pb.EventNotification{
+			OldEntry:      &filer_pb.Entry{Name: "a", IsDirectory: true},
+			NewEntry:      &filer_pb.Entry{Name: "x", IsDirectory: true},
+			NewParentPath: "/",
+		},
+	})
+	assert.Contains(t, m.entries, util.FullPath("/x/b/f.txt"))
+	assert.Equal(t, "/x/b", m.entries["/x/b/f.txt"].Dir)
+	assert.NotContains(t, m.entries, util.FullPath("/a/b"))
+	assert.Contains(t, m.entries, util.FullPath("/ab.txt"))
+
+	// delete a directory
+	m.apply(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/x",
+		EventNotification: &filer_pb.EventNotification{
+			OldEntry: &filer_pb.Entry{Name: "b", IsDirectory: true},
+		},
+	})
+	assert.Equal(t, 2, len(m.entries))
+	assert.Contains(t, m.entries, util.FullPath("/x"))
+}
+
+func TestSelectClusterBackups(t *testing.T) {
+	manifests := []*clusterBackupManifest{{Sequence: 1}, {Sequence: 2}, {Sequence: 3}}
+	selected, err := selectClusterBackups(manifests, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(selected))
+	selected, err = selectClusterBackups(manifests, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, selected[len(selected)-1].Sequence)
+	_, err = selectClusterBackups(manifests, 4)
+	assert.Error(t, err)
+}
+
+func TestClusterRestoreVolumeCut(t *testing.T) {
+	v := &clusterRestoreVolume{needles: make(map[types.NeedleId]*clusterRestoreNeedle), cutNs: 100}
+	v.VisitNeedle(&needle.Needle{Id: 1, Size: 10, AppendAtNs: 10}, 8, nil, nil)
+	v.VisitNeedle(&needle.Needle{Id: 2, Size: 10, AppendAtNs: 20}, 48, nil, nil)
+	v.VisitNeedle(&needle.Needle{Id: 1, Size: 0, AppendAtNs: 90}, 88, nil, nil)
+	// deleted after the cut, still referenced by the metadata of the backup
+	v.VisitNeedle(&needle.Needle{Id: 2, Size: 0, AppendAtNs: 110}, 120, nil, nil)
+
+	assert.NotContains(t, v.needles, types.NeedleId(1))
+	assert.Contains(t, v.needles, types.NeedleId(2))
+}
@@ -0,0 +1,595 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/storage"
+	"github.com/seaweedfs/seaweedfs/weed/storage/backend"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+var (
+	clusterRestore ClusterRestoreOptions
+)
+
+type ClusterRestoreOptions struct {
+	master         *string
+	filer          *string
+	source         *string
+	s3Endpoint     *string
+	s3Region       *string
+	sequence       *int
+	tempDir        *string
+	verifyOnly     *bool
+	grpcDialOption grpc.DialOption
+}
+
+func init() {
+	cmdClusterRestore.Run = runClusterRestore // break init cycle
+	clusterRestore.master = cmdClusterRestore.Flag.String("master", "localhost:9333", "the master server of the empty cluster")
+	clusterRestore.filer = cmdClusterRestore.Flag.String("filer", "localhost:8888", "the filer server of the empty cluster")
+	clusterRestore.source = cmdClusterRestore.Flag.String("source", "", "the local folder, or s3://bucket/prefix, of the backups")
+	clusterRestore.s3Endpoint = cmdClusterRestore.Flag.String("s3.endpoint", "", "the endpoint of an S3 compatible source, empty for AWS S3")
+	clusterRestore.s3Region = cmdClusterRestore.Flag.String("s3.region", "us-east-1", "the region of the S3 source")
+	clusterRestore.sequence = cmdClusterRestore.Flag.Int("backup", 0, "the backup number to restore, 0 for the latest")
+	clusterRestore.tempDir = cmdClusterRestore.Flag.String("tempDir", os.TempDir(), "a local folder to assemble the volumes, needing the space of all volumes")
+	clusterRestore.verifyOnly = cmdClusterRestore.Flag.Bool("verifyOnly", false, "only check the backup is complete and consistent, without restoring")
+}
+
+var cmdClusterRestore = &Command{
+	UsageLine: "cluster.restore -master=localhost:9333 -filer=localhost:8888 -source=/path/to/backups",
+	Short:     "verify a cluster.backup and restore it into an empty cluster",
+	Long: `Verify a backup made by "weed cluster.backup", and restore it into an empty cluster.
+
+	weed cluster.restore -source=/backups/cluster1 -verifyOnly
+	weed cluster.restore -master=localhost:9333 -filer=localhost:8888 -source=/backups/cluster1
+	weed cluster.restore -source=s3://bucket/cluster1 -s3.endpoint=http://localhost:8333 -backup=12
+
+	The volumes are assembled from the backups in -tempDir, and the metadata from the snapshot and events.
+	Before anything is restored, every chunk referenced by the metadata is checked in the volumes,
+	and the restore stops if any is missing.
+
+	The volumes keep their ids, and are spread over the volume servers with free slots.
+	Only one copy of each volume is restored. Run "volume.fix.replication" afterwards to add the replicas.
+
+`,
+}
+
+func runClusterRestore(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+	clusterRestore.grpcDialOption = security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	if *clusterRestore.source == "" {
+		return false
+	}
+	source, err := newClusterBackupTarget(*clusterRestore.source, *clusterRestore.s3Endpoint, *clusterRestore.s3Region)
+	if err != nil {
+		glog.Errorf("backup source %s: %v", *clusterRestore.source, err)
+		return true
+	}
+	if err = clusterRestore.restore(source); err != nil {
+		glog.Errorf("cluster restore from %s: %v", source, err)
+	}
+	return true
+}
+
+func (option *ClusterRestoreOptions) restore(source clusterBackupTarget) error {
+
+	manifests, err := loadClusterBackupManifests(source)
+	if err != nil {
+		return err
+	}
+	manifests, err = selectClusterBackups(manifests, *option.sequence)
+	if err != nil {
+		return err
+	}
+	last := manifests[len(manifests)-1]
+	fmt.Printf("restoring backup %d, consistent at %v\n", last.Sequence, last.CompletedAt)
+
+	metadata, err := loadClusterBackupMetadata(source, manifests)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("loaded %d metadata entries\n", len(metadata.entries))
+
+	tempDir, err := os.MkdirTemp(*option.tempDir, "cluster_restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	volumes := make(map[uint32]*clusterRestoreVolume)
+	defer func() {
+		for _, v := range volumes {
+			v.close()
+		}
+	}()
+	for _, volume := range last.Volumes {
+		v, err := assembleClusterBackupVolume(source, manifests, volume, last.MetaCutNs, tempDir)
+		if err != nil {
+			return fmt.Errorf("volume %d: %w", volume.Id, err)
+		}
+		volumes[volume.Id] = v
+		fmt.Printf("assembled volume %d, %d files\n", volume.Id, len(v.needles))
+	}
+
+	missing, err := verifyClusterBackup(metadata, volumes, func(message string) {
+		fmt.Println(message)
+	})
+	if err != nil {
+		return err
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d chunks are missing from the volumes", missing)
+	}
+	fmt.Printf("verified all chunks of backup %d\n", last.Sequence)
+	if *option.verifyOnly {
+		return nil
+	}
+
+	servers, err := option.allocateServers(last.Volumes)
+	if err != nil {
+		return err
+	}
+	for _, volume := range last.Volumes {
+		if err = option.restoreVolume(servers[volume.Id], volume, volumes[volume.Id]); err != nil {
+			return fmt.Errorf("restore volume %d to %s: %w", volume.Id, servers[volume.Id], err)
+		}
+		fmt.Printf("restored volume %d to %s\n", volume.Id, servers[volume.Id])
+	}
+
+	if err = option.restoreMetadata(metadata); err != nil {
+		return err
+	}
+	fmt.Printf("restored %d metadata entries to %s\n", len(metadata.entries), *option.filer)
+	return nil
+}
+
+// selectClusterBackups returns the backups needed to restore the backup of the sequence, which is the last one
+func selectClusterBackups(manifests []*clusterBackupManifest, sequence int) ([]*clusterBackupManifest, error) {
+	var selected []*clusterBackupManifest
+	for _, manifest := range manifests {
+		if sequence == 0 || manifest.Sequence <= sequence {
+			selected = append(selected, manifest)
+		}
+	}
+	if len(selected) == 0 || sequence != 0 && selected[len(selected)-1].Sequence != sequence {
+		return nil, fmt.Errorf("backup %d is not found", sequence)
+	}
+	return selected, nil
+}
+
+// clusterBackupMetadata replays the metadata snapshot and events, keyed by the full path
+type clusterBackupMetadata struct {
+	entries map[util.FullPath]*filer_pb.FullEntry
+}
+
+func newClusterBackupMetadata() *clusterBackupMetadata {
+	return &clusterBackupMetadata{entries: make(map[util.FullPath]*filer_pb.FullEntry)}
+}
+
+func (m *clusterBackupMetadata) insert(dir string, entry *filer_pb.Entry) {
+	m.entries[util.NewFullPath(dir, entry.Name)] = &filer_pb.FullEntry{Dir: dir, Entry: entry}
+}
+
+func (m *clusterBackupMetadata) remove(fullPath util.FullPath) {
+	existing, found := m.entries[fullPath]
+	if !found {
+		return
+	}
+	delete(m.entries, fullPath)
+	if existing.Entry.IsDirectory {
+		prefix := string(fullPath) + "/"
+		for p := range m.entries {
+			if strings.HasPrefix(string(p), prefix) {
+				delete(m.entries, p)
+			}
+		}
+	}
+}
+
+func (m *clusterBackupMetadata) move(oldPath, newPath util.FullPath) {
+	prefix := string(oldPath) + "/"
+	for p, fullEntry := range m.entries {
+		if strings.HasPrefix(string(p), prefix) {
+			delete(m.entries, p)
+			moved := util.FullPath(string(newPath) + strings.TrimPrefix(string(p), string(oldPath)))
+			dir, _ := moved.DirAndName()
+			m.entries[moved] = &filer_pb.FullEntry{Dir: dir, Entry: fullEntry.Entry}
+		}
+	}
+}
+
+func (m *clusterBackupMetadata) apply(resp *filer_pb.SubscribeMetadataResponse) {
+	message := resp.EventNotification
+	var oldPath util.FullPath
+	if message.OldEntry != nil {
+		oldPath = util.NewFullPath(resp.Directory, message.OldEntry.Name)
+	}
+	if message.NewEntry == nil {
+		m.remove(oldPath)
+		return
+	}
+	newPath := util.NewFullPath(message.NewParentPath, message.NewEntry.Name)
+	if message.OldEntry != nil && oldPath != newPath {
+		if message.OldEntry.IsDirectory {
+			m.move(oldPath, newPath)
+		}
+		delete(m.entries, oldPath)
+	}
+	m.insert(message.NewParentPath, message.NewEntry)
+}
+
+func loadClusterBackupMetadata(source clusterBackupTarget, manifests []*clusterBackupManifest) (*clusterBackupMetadata, error) {
+	start := -1
+	for i, manifest := range manifests {
+		if manifest.MetaSnapshot {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no metadata snapshot before backup %d", manifests[len(manifests)-1].Sequence)
+	}
+
+	metadata := newClusterBackupMetadata()
+	err := readClusterBackupFile(source, clusterBackupFile(manifests[start].Sequence, clusterBackupSnapshotFile), func() proto.Message {
+		return &filer_pb.FullEntry{}
+	}, func(message proto.Message) error {
+		fullEntry := message.(*filer_pb.FullEntry)
+		metadata.insert(fullEntry.Dir, fullEntry.Entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := start; i < len(manifests); i++ {
+		if i > start && manifests[i].MetaSinceNs != manifests[i-1].MetaCutNs {
+			return nil, fmt.Errorf("metadata events of backup %d do not continue backup %d", manifests[i].Sequence, manifests[i-1].Sequence)
+		}
+		err = readClusterBackupFile(source, clusterBackupFile(manifests[i].Sequence, clusterBackupEventsFile), func() proto.Message {
+			return &filer_pb.SubscribeMetadataResponse{}
+		}, func(message proto.Message) error {
+			metadata.apply(message.(*filer_pb.SubscribeMetadataResponse))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+func readClusterBackupFile(source clusterBackupTarget, name string, newMessage func() proto.Message, fn func(message proto.Message) error) error {
+	reader, err := source.Read(name)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	defer reader.Close()
+	if err = readClusterBackupRecords(reader, newMessage, fn); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	return nil
+}
+
+type clusterRestoreNeedle struct {
+	offset int64
+	size   types.Size
+	cookie types.Cookie
+}
+
+// clusterRestoreVolume is a volume assembled from the backups, with the needles live at the cut
+type clusterRestoreVolume struct {
+	version     needle.Version
+	dataBackend backend.BackendStorageFile
+	needles     map[types.NeedleId]*clusterRestoreNeedle
+	cutNs       uint64
+}
+
+func (v *clusterRestoreVolume) VisitSuperBlock(superBlock super_block.SuperBlock) error {
+	v.version = superBlock.Version
+	return nil
+}
+
+func (v *clusterRestoreVolume) ReadNeedleBody() bool {
+	// for the append time of the deletions
+	return true
+}
+
+func (v *clusterRestoreVolume) VisitNeedle(n *needle.Needle, offset int64, needleHeader, needleBody []byte) error {
+	if n.Size > 0 && n.Size.IsValid() {
+		v.needles[n.Id] = &clusterRestoreNeedle{offset: offset, size: n.Size, cookie: n.Cookie}
+		return nil
+	}
+	// the deletions after the cut are not seen by the metadata of the backup
+	if n.AppendAtNs == 0 || n.AppendAtNs <= v.cutNs {
+		delete(v.needles, n.Id)
+	}
+	return nil
+}
+
+func (v *clusterRestoreVolume) close() {
+	if v.dataBackend != nil {
+		v.dataBackend.Close()
+	}
+}
+
+// assembleClusterBackupVolume joins the .dat bytes of the volume from its last full copy up to the last backup
+func assembleClusterBackupVolume(source clusterBackupTarget, manifests []*clusterBackupManifest, volume *clusterBackupVolume, cutNs int64, dir string) (*clusterRestoreVolume, error) {
+	var segments []string
+	expectedOffset := volume.StopOffset
+	for i := len(manifests) - 1; i >= 0; i-- {
+		var found *clusterBackupVolume
+		for _, v := range manifests[i].Volumes {
+			if v.Id == volume.Id {
+				found = v
+			}
+		}
+		if found == nil || found.StopOffset != expectedOffset {
+			return nil, fmt.Errorf("missing the data before offset %d, in backup %d", expectedOffset, manifests[i].Sequence)
+		}
+		if found.hasData() {
+			segments = append([]string{clusterBackupVolumeFile(manifests[i].Sequence, volume.Id)}, segments...)
+		}
+		if found.Full {
+			break
+		}
+		expectedOffset = found.StartOffset
+	}
+
+	datFile := storage.VolumeFileName(dir, volume.Collection, int(volume.Id)) + ".dat"
+	dst, err := os.OpenFile(datFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		reader, err := source.Read(segment)
+		if err != nil {
+			dst.Close()
+			return nil, fmt.Errorf("read %s: %w", segment, err)
+		}
+		_, err = io.Copy(dst, reader)
+		reader.Close()
+		if err != nil {
+			dst.Close()
+			return nil, fmt.Errorf("read %s: %w", segment, err)
+		}
+	}
+	if size, _ := dst.Seek(0, io.SeekCurrent); uint64(size) != volume.StopOffset {
+		dst.Close()
+		return nil, fmt.Errorf("assembled %d bytes, expecting %d", size, volume.StopOffset)
+	}
+
+	v := &clusterRestoreVolume{
+		dataBackend: backend.NewDiskFile(dst),
+		needles:     make(map[types.NeedleId]*clusterRestoreNeedle),
+		cutNs:       uint64(cutNs),
+	}
+	if err = storage.ScanVolumeFile(dir, volume.Collection, needle.VolumeId(volume.Id), storage.NeedleMapInMemory, v); err != nil {
+		v.close()
+		return nil, err
+	}
+	return v, nil
+}
+
+// verifyClusterBackup counts the chunks referenced by the metadata but missing in the volumes
+func verifyClusterBackup(metadata *clusterBackupMetadata, volumes map[uint32]*clusterRestoreVolume, report func(message string)) (missing int, err error) {
+	var checkChunks func(fullPath util.FullPath, chunks []*filer_pb.FileChunk) error
+	checkChunks = func(fullPath util.FullPath, chunks []*filer_pb.FileChunk) error {
+		for _, chunk := range chunks {
+			fid, err := filer_pb.ToFileIdObject(chunk.GetFileIdString())
+			if err != nil {
+				return fmt.Errorf("%s chunk %s: %w", fullPath, chunk.GetFileIdString(), err)
+			}
+			v, found := volumes[fid.VolumeId]
+			var n *clusterRestoreNeedle
+			if found {
+				n = v.needles[types.NeedleId(fid.FileKey)]
+			}
+			if n == nil || uint32(n.cookie) != fid.Cookie {
+				missing++
+				report(fmt.Sprintf("missing chunk %s of %s", chunk.GetFileIdString(), fullPath))
+				continue
+			}
+			if !chunk.IsChunkManifest {
+				continue
+			}
+			manifest, err := v.readChunkManifest(n, chunk)
+			if err != nil {
+				return fmt.Errorf("%s chunk manifest %s: %w", fullPath, chunk.GetFileIdString(), err)
+			}
+			if err = checkChunks(fullPath, manifest.Chunks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for p, fullEntry := range metadata.entries {
+		if err = checkChunks(p, fullEntry.Entry.GetChunks()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (v *clusterRestoreVolume) readChunkManifest(n *clusterRestoreNeedle, chunk *filer_pb.FileChunk) (*filer_pb.FileChunkManifest, error) {
+	data := new(needle.Needle)
+	if err := data.ReadData(v.dataBackend, n.offset, n.size, v.version); err != nil {
+		return nil, err
+	}
+	content := data.Data
+	if data.IsCompressed() {
+		decompressed, err := util.DecompressData(content)
+		if err != nil {
+			return nil, err
+		}
+		content = decompressed
+	}
+	if len(chunk.CipherKey) > 0 {
+		decrypted, err := util.Decrypt(content, util.CipherKey(chunk.CipherKey))
+		if err != nil {
+			return nil, err
+		}
+		content = decrypted
+	}
+	if chunk.IsCompressed {
+		if decompressed, err := util.DecompressData(content); err == nil {
+			content = decompressed
+		}
+	}
+	manifest := &filer_pb.FileChunkManifest{}
+	if err := proto.Unmarshal(content, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// allocateServers spreads the volumes over the volume servers with free slots of the same disk type
+func (option *ClusterRestoreOptions) allocateServers(volumes []*clusterBackupVolume) (servers map[uint32]pb.ServerAddress, err error) {
+	freeSlots := make(map[string]map[pb.ServerAddress]int64)
+	var nodes []pb.ServerAddress
+	existing := make(map[uint32]bool)
+	err = pb.WithMasterClient(false, pb.ServerAddress(*option.master), option.grpcDialOption, false, func(client master_pb.SeaweedClient) error {
+		resp, err := client.VolumeList(context.Background(), &master_pb.VolumeListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, dc := range resp.TopologyInfo.GetDataCenterInfos() {
+			for _, rack := range dc.RackInfos {
+				for _, dn := range rack.DataNodeInfos {
+					address := pb.NewServerAddressFromDataNode(dn)
+					nodes = append(nodes, address)
+					for diskType, disk := range dn.DiskInfos {
+						if freeSlots[diskType] == nil {
+							freeSlots[diskType] = make(map[pb.ServerAddress]int64)
+						}
+						freeSlots[diskType][address] = disk.MaxVolumeCount - disk.VolumeCount
+						for _, v := range disk.VolumeInfos {
+							existing[v.Id] = true
+						}
+						for _, ec := range disk.EcShardInfos {
+							existing[ec.Id] = true
+						}
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list volumes from %s: %w", *option.master, err)
+	}
+
+	servers = make(map[uint32]pb.ServerAddress)
+	next := 0
+	for _, volume := range volumes {
+		if existing[volume.Id] {
+			return nil, fmt.Errorf("volume %d already exists, the cluster is not empty", volume.Id)
+		}
+		for i := 0; i < len(nodes) && servers[volume.Id] == ""; i++ {
+			node := nodes[(next+i)%len(nodes)]
+			if freeSlots[volume.DiskType][node] > 0 {
+				freeSlots[volume.DiskType][node]--
+				servers[volume.Id] = node
+				next = (next + i + 1) % len(nodes)
+			}
+		}
+		if servers[volume.Id] == "" {
+			return nil, fmt.Errorf("no free slot for volume %d on disk type %q", volume.Id, volume.DiskType)
+		}
+	}
+	return servers, nil
+}
+
+func (option *ClusterRestoreOptions) restoreVolume(server pb.ServerAddress, volume *clusterBackupVolume, v *clusterRestoreVolume) error {
+	needles := make([]types.NeedleId, 0, len(v.needles))
+	for id := range v.needles {
+		needles = append(needles, id)
+	}
+	sort.Slice(needles, func(i, j int) bool {
+		return v.needles[needles[i]].offset < v.needles[needles[j]].offset
+	})
+
+	return pb.WithVolumeServerClient(false, server, option.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		if _, err := client.AllocateVolume(context.Background(), &volume_server_pb.AllocateVolumeRequest{
+			VolumeId:    volume.Id,
+			Collection:  volume.Collection,
+			Replication: volume.Replication,
+			Ttl:         volume.Ttl,
+			DiskType:    volume.DiskType,
+			Version:     volume.Version,
+		}); err != nil {
+			return fmt.Errorf("allocate: %w", err)
+		}
+		for _, id := range needles {
+			n := v.needles[id]
+			blob, err := needle.ReadNeedleBlob(v.dataBackend, n.offset, n.size, v.version)
+			if err != nil {
+				return fmt.Errorf("read needle %s: %w", id, err)
+			}
+			if _, err = client.WriteNeedleBlob(context.Background(), &volume_server_pb.WriteNeedleBlobRequest{
+				VolumeId:   volume.Id,
+				NeedleId:   uint64(id),
+				Size:       int32(n.size),
+				NeedleBlob: blob,
+			}); err != nil {
+				return fmt.Errorf("write needle %s: %w", id, err)
+			}
+		}
+		status, err := client.ReadVolumeFileStatus(context.Background(), &volume_server_pb.ReadVolumeFileStatusRequest{VolumeId: volume.Id})
+		if err != nil {
+			return err
+		}
+		if status.FileCount != uint64(len(needles)) {
+			return fmt.Errorf("restored %d files, expecting %d", status.FileCount, len(needles))
+		}
+		return nil
+	})
+}
+
+func (option *ClusterRestoreOptions) restoreMetadata(metadata *clusterBackupMetadata) error {
+	paths := make([]string, 0, len(metadata.entries))
+	for p := range metadata.entries {
+		paths = append(paths, string(p))
+	}
+	// the parent directories first
+	sort.Strings(paths)
+
+	return pb.WithFilerClient(false, 0, pb.ServerAddress(*option.filer), option.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		for _, p := range paths {
+			fullEntry := metadata.entries[util.FullPath(p)]
+			if strings.HasPrefix(p, filer.SystemLogDir) {
+				continue
+			}
+			if err := filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+				Directory:                fullEntry.Dir,
+				Entry:                    fullEntry.Entry,
+				OExcl:                    !fullEntry.Entry.IsDirectory,
+				IsFromOtherCluster:       true,
+				SkipCheckParentDirectory: true,
+			}); err != nil {
+				return fmt.Errorf("restore %s: %w", p, err)
+			}
+		}
+		return nil
+	})
+}
@@ -14,6 +14,8 @@ var Commands = []*Command{
 	cmdUnautocomplete,
 	cmdBackup,
 	cmdBenchmark,
+	cmdClusterBackup,
+	cmdClusterRestore,
 	cmdCompact,
 	cmdDownload,
 	cmdExport,
@@ -26,6 +28,7 @@ var Commands = []*Command{
 	cmdFilerRemoteGateway,
 	cmdFilerRemoteSynchronize,
 	cmdFilerReplicate,
+	cmdFilerStoreMigrate,
 	cmdFilerSynchronize,
 	cmdFix,
 	cmdFuse,
@@ -45,6 +48,7 @@ var Commands = []*Command{
 	cmdVolume,
 	cmdWebDav,
 	cmdSftp,
+	cmdNfs,
 	cmdWorker,
 }
 
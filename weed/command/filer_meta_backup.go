@@ -105,8 +105,17 @@ func runFilerMetaBackup(cmd *Command, args []string) bool {
 }
 
 func (metaBackup *FilerMetaBackupOptions) initStore(v *viper.Viper) error {
+	store, err := initFilerStoreFromConfig(v)
+	if err != nil {
+		return err
+	}
+	metaBackup.store = filer.NewFilerStoreWrapper(store)
+	return nil
+}
+
+// initFilerStoreFromConfig initializes the filer store enabled in a filer.toml
+func initFilerStoreFromConfig(v *viper.Viper) (filer.FilerStore, error) {
 	// load configuration for default filer store
-	hasDefaultStoreConfigured := false
 	for _, store := range filer.Stores {
 		if v.GetBool(store.GetName() + ".enabled") {
 			store = reflect.New(reflect.ValueOf(store).Elem().Type()).Interface().(filer.FilerStore)
@@ -114,16 +123,10 @@ func (metaBackup *FilerMetaBackupOptions) initStore(v *viper.Viper) error {
 				glog.Fatalf("failed to initialize store for %s: %+v", store.GetName(), err)
 			}
 			glog.V(0).Infof("configured filer store to %s", store.GetName())
-			hasDefaultStoreConfigured = true
-			metaBackup.store = filer.NewFilerStoreWrapper(store)
-			break
+			return store, nil
 		}
 	}
-	if !hasDefaultStoreConfigured {
-		return fmt.Errorf("no filer store enabled in %s", v.ConfigFileUsed())
-	}
-
-	return nil
+	return nil, fmt.Errorf("no filer store enabled in %s", v.ConfigFileUsed())
 }
 
 func (metaBackup *FilerMetaBackupOptions) traverseMetadata() (err error) {
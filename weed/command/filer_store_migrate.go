@@ -0,0 +1,478 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+var (
+	storeMigrate FilerStoreMigrateOptions
+)
+
+type FilerStoreMigrateOptions struct {
+	grpcDialOption grpc.DialOption
+	filerAddress   *string
+	targetConfig   *string
+	restart        *bool
+	verifyOnly     *bool
+	idleTime       *time.Duration
+
+	store       filer.VirtualFilerStore
+	clientId    int32
+	clientEpoch int32
+
+	// the verification waits for the events to stop
+	storeLock      sync.Mutex
+	lastEventTime  time.Time
+	lastVerifyTime time.Time
+}
+
+func init() {
+	cmdFilerStoreMigrate.Run = runFilerStoreMigrate // break init cycle
+	storeMigrate.filerAddress = cmdFilerStoreMigrate.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	storeMigrate.targetConfig = cmdFilerStoreMigrate.Flag.String("config", "", "path to a filer.toml with the new filer store enabled")
+	storeMigrate.restart = cmdFilerStoreMigrate.Flag.Bool("restart", false, "copy everything again, instead of resuming from the last copied change")
+	storeMigrate.verifyOnly = cmdFilerStoreMigrate.Flag.Bool("verifyOnly", false, "only compare the filer with the new store, and exit")
+	storeMigrate.idleTime = cmdFilerStoreMigrate.Flag.Duration("verifyAfterIdle", 10*time.Second, "verify the new store when no change has come for this long")
+	storeMigrate.clientId = util.RandomInt32()
+}
+
+var cmdFilerStoreMigrate = &Command{
+	UsageLine: "filer.store.migrate -filer=localhost:8888 -config=/path/to/new_filer.toml",
+	Short:     "copy the filer store of a running filer to another filer store, and follow its changes",
+	Long: `Copy all entries and key value pairs of a running filer to another filer store, and follow the changes.
+
+	weed filer.store.migrate -filer=localhost:8888 -config=/path/to/new_filer.toml
+	weed filer.store.migrate -filer=localhost:8888 -config=/path/to/new_filer.toml -verifyOnly
+
+	The new filer store is the one enabled in the new_filer.toml, which can be generated by
+	"weed scaffold -config=filer". The filer keeps running on its current store while:
+
+	1. All entries are copied, then all key value pairs, such as hard links and sync offsets.
+	2. The changes made on the filer are followed, from the time the copy started.
+	3. Each time the changes stop coming for -verifyAfterIdle, the key value pairs are copied again,
+	   and each directory is compared by its entry count and checksum, on the filer and in the new store.
+
+	To switch over, once the new store is verified:
+
+	1. Stop the filer. This command receives the last changes before the filer stops.
+	2. Stop this command.
+	3. Start the filer with the new_filer.toml. The filer keeps its signature, which is also copied.
+
+	The filer store needs to list its key value pairs, supported by leveldb, leveldb2, and the sql stores.
+	Only one filer can be using the current store, since only the changes on the filer are followed.
+	The progress is kept in the new store, so this command can resume after being stopped.
+
+`,
+}
+
+var (
+	storeMigrateOffsetKey = []byte("filer.store.migrate")
+)
+
+func runFilerStoreMigrate(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+	storeMigrate.grpcDialOption = security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	if *storeMigrate.targetConfig == "" {
+		return false
+	}
+	v := viper.New()
+	v.SetConfigFile(*storeMigrate.targetConfig)
+	if err := v.ReadInConfig(); err != nil {
+		glog.Fatalf("Failed to load %s file: %v\nPlease use this command to generate the a %s.toml file\n"+
+			"    weed scaffold -config=%s -output=.\n\n\n",
+			*storeMigrate.targetConfig, err, "filer", "filer")
+	}
+	store, err := initFilerStoreFromConfig(v)
+	if err != nil {
+		glog.Errorf("init new filer store: %v", err)
+		return true
+	}
+	defer store.Shutdown()
+	storeMigrate.store = filer.NewFilerStoreWrapper(store)
+
+	if *storeMigrate.verifyOnly {
+		if err := storeMigrate.verify(); err != nil {
+			glog.Errorf("verify %s: %v", store.GetName(), err)
+		}
+		return true
+	}
+
+	_, err = storeMigrate.getOffset()
+	if *storeMigrate.restart || err != nil {
+		if err := storeMigrate.copyAll(); err != nil {
+			glog.Errorf("copy filer %s to %s: %v", *storeMigrate.filerAddress, store.GetName(), err)
+			return true
+		}
+	}
+
+	go storeMigrate.verifyWhenIdle()
+
+	for {
+		err := storeMigrate.followChanges()
+		if err != nil {
+			glog.Errorf("follow filer %s: %v", *storeMigrate.filerAddress, err)
+			time.Sleep(1747 * time.Millisecond)
+		}
+	}
+}
+
+func (option *FilerStoreMigrateOptions) copyAll() error {
+	var startTsNs int64
+	err := option.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.Ping(context.Background(), &filer_pb.PingRequest{})
+		if err != nil {
+			return err
+		}
+		startTsNs = resp.StartTimeNs
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+
+	entryCount, err := option.copyEntries("/")
+	if err != nil {
+		return err
+	}
+	kvCount, err := option.copyKvs()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("copied %d entries and %d key value pairs\n", entryCount, kvCount)
+
+	return option.setOffset(startTsNs)
+}
+
+func (option *FilerStoreMigrateOptions) copyEntries(dir util.FullPath) (count int64, err error) {
+	err = option.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.StreamBfs(client, dir, 0, func(parentPath util.FullPath, entry *filer_pb.Entry) error {
+			if err := option.store.InsertEntry(context.Background(), filer.FromPbEntry(string(parentPath), entry)); err != nil {
+				return fmt.Errorf("insert %s: %w", parentPath.Child(entry.Name), err)
+			}
+			count++
+			return nil
+		})
+	})
+	return
+}
+
+func (option *FilerStoreMigrateOptions) copyKvs() (count int64, err error) {
+	err = option.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		stream, err := client.KvList(context.Background(), &filer_pb.KvListRequest{})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(resp.Key, storeMigrateOffsetKey) {
+				continue
+			}
+			if err = option.store.KvPut(context.Background(), resp.Key, resp.Value); err != nil {
+				return fmt.Errorf("kv put %x: %w", resp.Key, err)
+			}
+			count++
+		}
+	})
+	if err != nil && strings.Contains(err.Error(), filer.ErrUnsupportedKvList.Error()) {
+		glog.Warningf("the filer store can not list its key value pairs, only hard links are copied with their entries")
+		return count, nil
+	}
+	if err != nil {
+		return count, fmt.Errorf("copy key value pairs: %w", err)
+	}
+	return
+}
+
+func (option *FilerStoreMigrateOptions) followChanges() error {
+
+	startTsNs, err := option.getOffset()
+	if err != nil {
+		return fmt.Errorf("read offset: %w", err)
+	}
+	glog.V(0).Infof("following filer %s from %v", *option.filerAddress, time.Unix(0, startTsNs))
+
+	processEventFn := pb.AddOffsetFunc(func(resp *filer_pb.SubscribeMetadataResponse) error {
+		option.storeLock.Lock()
+		defer option.storeLock.Unlock()
+		option.lastEventTime = time.Now()
+		return applyStoreMigrateEvent(option.store, resp)
+	}, 3*time.Second, func(counter int64, lastTsNs int64) error {
+		glog.V(0).Infof("filer store migration progressed to %v %0.2f/sec", time.Unix(0, lastTsNs), float64(counter)/float64(3))
+		return option.setOffset(lastTsNs)
+	})
+
+	option.clientEpoch++
+	return option.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.SubscribeLocalMetadata(ctx, &filer_pb.SubscribeMetadataRequest{
+			ClientName:  "store_migrate",
+			PathPrefix:  "/",
+			SinceNs:     startTsNs,
+			ClientId:    option.clientId,
+			ClientEpoch: option.clientEpoch,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe: %w", err)
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err = processEventFn(resp); err != nil {
+				return err
+			}
+			startTsNs = resp.TsNs
+		}
+	})
+}
+
+func applyStoreMigrateEvent(store filer.VirtualFilerStore, resp *filer_pb.SubscribeMetadataResponse) error {
+	ctx := context.Background()
+	message := resp.EventNotification
+	if filer_pb.IsEmpty(resp) {
+		return nil
+	}
+	if message.OldEntry != nil && (message.NewEntry == nil || filer_pb.IsRename(resp)) {
+		oldPath := util.FullPath(resp.Directory).Child(message.OldEntry.Name)
+		if message.OldEntry.IsDirectory {
+			if err := store.DeleteFolderChildren(ctx, oldPath); err != nil {
+				return fmt.Errorf("delete children of %s: %w", oldPath, err)
+			}
+		}
+		if err := store.DeleteOneEntry(ctx, filer.FromPbEntry(resp.Directory, message.OldEntry)); err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+			return fmt.Errorf("delete %s: %w", oldPath, err)
+		}
+	}
+	if message.NewEntry != nil {
+		entry := filer.FromPbEntry(message.NewParentPath, message.NewEntry)
+		if err := store.InsertEntry(ctx, entry); err != nil {
+			return fmt.Errorf("insert %s: %w", entry.FullPath, err)
+		}
+	}
+	return nil
+}
+
+// verifyWhenIdle verifies the new store whenever the changes stop for a while
+func (option *FilerStoreMigrateOptions) verifyWhenIdle() {
+	for range time.Tick(time.Second) {
+		option.storeLock.Lock()
+		changed := option.lastVerifyTime.IsZero() || option.lastEventTime.After(option.lastVerifyTime)
+		idle := changed && time.Since(option.lastEventTime) > *option.idleTime
+		if idle {
+			option.lastVerifyTime = time.Now()
+		}
+		option.storeLock.Unlock()
+		if !idle {
+			continue
+		}
+		if err := option.verify(); err != nil {
+			glog.Errorf("verify: %v", err)
+		}
+	}
+}
+
+// verify copies again what the changes do not carry, and compares each directory
+func (option *FilerStoreMigrateOptions) verify() error {
+	if !*option.verifyOnly {
+		// the metadata logs and the key value pairs are not in the changes
+		if err := option.copySystemLogs(); err != nil {
+			return err
+		}
+		if _, err := option.copyKvs(); err != nil {
+			return err
+		}
+	}
+
+	var directoryCount, entryCount int64
+	var mismatches []string
+	directories := []util.FullPath{"/"}
+	for len(directories) > 0 {
+		dir := directories[0]
+		directories = directories[1:]
+		source, err := option.sourceDirectory(dir)
+		if err != nil {
+			return err
+		}
+		for _, subDir := range source.subDirectories {
+			directories = append(directories, subDir)
+		}
+		target, err := option.targetDirectory(dir)
+		if err != nil {
+			return err
+		}
+		if source.count != target.count || !bytes.Equal(source.checksum, target.checksum) {
+			// the directory can be changing, so look again
+			time.Sleep(time.Second)
+			if source, err = option.sourceDirectory(dir); err == nil {
+				target, err = option.targetDirectory(dir)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if source.count != target.count || !bytes.Equal(source.checksum, target.checksum) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d entries checksum %x on the filer, %d entries checksum %x in the new store",
+				dir, source.count, source.checksum, target.count, target.checksum))
+		}
+		directoryCount++
+		entryCount += source.count
+	}
+
+	for _, mismatch := range mismatches {
+		fmt.Printf("mismatch %s\n", mismatch)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d of %d directories differ", len(mismatches), directoryCount)
+	}
+	fmt.Printf("%v verified %d directories and %d entries, the new store %s is ready to switch over\n",
+		time.Now().Format(time.RFC3339), directoryCount, entryCount, option.store.GetName())
+	return nil
+}
+
+// copySystemLogs copies the metadata logs, with the directories created for them
+func (option *FilerStoreMigrateOptions) copySystemLogs() error {
+	logDir := util.FullPath(filer.SystemLogDir)
+	for dir := logDir; dir != "/"; {
+		parent, _ := dir.DirAndName()
+		entry, err := filer_pb.GetEntry(context.Background(), option, dir)
+		if errors.Is(err, filer_pb.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("find %s: %w", dir, err)
+		}
+		if err = option.store.InsertEntry(context.Background(), filer.FromPbEntry(parent, entry)); err != nil {
+			return fmt.Errorf("insert %s: %w", dir, err)
+		}
+		dir = util.FullPath(parent)
+	}
+	_, err := option.copyEntries(logDir)
+	return err
+}
+
+type storeMigrateDirectory struct {
+	count          int64
+	checksum       []byte
+	subDirectories []util.FullPath
+}
+
+func newStoreMigrateDirectory(entries []*filer_pb.Entry) (*storeMigrateDirectory, error) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	d := &storeMigrateDirectory{count: int64(len(entries))}
+	h := md5.New()
+	for _, entry := range entries {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(data)
+	}
+	d.checksum = h.Sum(nil)
+	return d, nil
+}
+
+func (option *FilerStoreMigrateOptions) sourceDirectory(dir util.FullPath) (*storeMigrateDirectory, error) {
+	var entries []*filer_pb.Entry
+	err := filer_pb.ReadDirAllEntries(context.Background(), option, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list %s on the filer: %w", dir, err)
+	}
+	d, err := newStoreMigrateDirectory(entries)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDirectory {
+			d.subDirectories = append(d.subDirectories, dir.Child(entry.Name))
+		}
+	}
+	return d, nil
+}
+
+func (option *FilerStoreMigrateOptions) targetDirectory(dir util.FullPath) (*storeMigrateDirectory, error) {
+	var entries []*filer_pb.Entry
+	lastFileName := ""
+	for {
+		var count int
+		var err error
+		lastFileName, err = option.store.ListDirectoryEntries(context.Background(), dir, lastFileName, false, 1024, func(entry *filer.Entry) bool {
+			count++
+			entries = append(entries, entry.ToProtoEntry())
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s in the new store: %w", dir, err)
+		}
+		if count < 1024 {
+			break
+		}
+	}
+	return newStoreMigrateDirectory(entries)
+}
+
+func (option *FilerStoreMigrateOptions) getOffset() (tsNs int64, err error) {
+	value, err := option.store.KvGet(context.Background(), storeMigrateOffsetKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid offset %x", value)
+	}
+	return int64(util.BytesToUint64(value)), nil
+}
+
+func (option *FilerStoreMigrateOptions) setOffset(tsNs int64) error {
+	valueBuf := make([]byte, 8)
+	util.Uint64toBytes(valueBuf, uint64(tsNs))
+	return option.store.KvPut(context.Background(), storeMigrateOffsetKey, valueBuf)
+}
+
+var _ = filer_pb.FilerClient(&FilerStoreMigrateOptions{})
+
+func (option *FilerStoreMigrateOptions) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithFilerClient(streamingMode, option.clientId, pb.ServerAddress(*option.filerAddress), option.grpcDialOption, fn)
+}
+
+func (option *FilerStoreMigrateOptions) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (option *FilerStoreMigrateOptions) GetDataCenter() string {
+	return ""
+}
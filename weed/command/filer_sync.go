@@ -51,6 +51,7 @@ type SyncOptions struct {
 	concurrency     *int
 	aDoDeleteFiles  *bool
 	bDoDeleteFiles  *bool
+	conflict        *string
 	clientId        int32
 	clientEpoch     atomic.Int32
 }
@@ -96,6 +97,7 @@ func init() {
 	syncOptions.metricsHttpPort = cmdFilerSynchronize.Flag.Int("metricsPort", 0, "metrics listen port")
 	syncOptions.aDoDeleteFiles = cmdFilerSynchronize.Flag.Bool("a.doDeleteFiles", true, "delete and update files when synchronizing on filer A")
 	syncOptions.bDoDeleteFiles = cmdFilerSynchronize.Flag.Bool("b.doDeleteFiles", true, "delete and update files when synchronizing on filer B")
+	syncOptions.conflict = cmdFilerSynchronize.Flag.String("conflict", ConflictLastWriterWins, "how to resolve a file changed on both sides: lastWriterWins, keepBoth, preferA or preferB")
 	syncOptions.clientId = util.RandomInt32()
 }
 
@@ -114,6 +116,22 @@ var cmdFilerSynchronize = &Command{
 	If restarted, the synchronization will resume from the previous checkpoints, persisted every minute.
 	A fresh sync will start from the earliest metadata logs.
 
+	Conflicts:
+
+	A file changed on both sides before either change is replicated is a conflict. It is resolved
+	the same way on both sides, by -conflict:
+
+	* lastWriterWins: the change with the later modification time wins, the other one is discarded.
+	* keepBoth: the later change wins, the other one is kept next to it as <name>.conflict-<time>-<filer><.ext>
+	* preferA, preferB: the change on filer A, or filer B, wins.
+
+	A file changed on one side and deleted on the other side is kept. Each conflict is recorded
+	in /etc/seaweedfs/sync/conflicts/ on the side resolving it, see "filer.sync.conflicts" in weed shell.
+
+	By default, a conflict is found when the target file has changed since it was last replicated.
+	For a more precise check, configure vector clocks on both clusters, e.g.
+	"fs.configure -locationPrefix=/shared/ -syncVectorClock -apply" in weed shell.
+
 `,
 }
 
@@ -124,6 +142,11 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 
 	grace.SetupProfiling(*syncCpuProfile, *syncMemProfile)
 
+	if err := checkConflictStrategy(*syncOptions.conflict); err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
 	filerA := pb.ServerAddress(*syncOptions.filerA)
 	filerB := pb.ServerAddress(*syncOptions.filerB)
 
@@ -171,6 +194,8 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 				*syncOptions.bDebug,
 				*syncOptions.concurrency,
 				*syncOptions.bDoDeleteFiles,
+				*syncOptions.conflict,
+				*syncOptions.conflict == ConflictPreferA,
 				aFilerSignature,
 				bFilerSignature)
 			if err != nil {
@@ -209,6 +234,8 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 					*syncOptions.aDebug,
 					*syncOptions.concurrency,
 					*syncOptions.aDoDeleteFiles,
+					*syncOptions.conflict,
+					*syncOptions.conflict == ConflictPreferB,
 					bFilerSignature,
 					aFilerSignature)
 				if err != nil {
@@ -241,7 +268,7 @@ func initOffsetFromTsMs(grpcDialOption grpc.DialOption, targetFiler pb.ServerAdd
 }
 
 func doSubscribeFilerMetaChanges(clientId int32, clientEpoch int32, grpcDialOption grpc.DialOption, sourceFiler pb.ServerAddress, sourcePath string, sourceExcludePaths []string, sourceReadChunkFromFiler bool, targetFiler pb.ServerAddress, targetPath string,
-	replicationStr, collection string, ttlSec int, sinkWriteChunkByFiler bool, diskType string, debug bool, concurrency int, doDeleteFiles bool, sourceFilerSignature int32, targetFilerSignature int32) error {
+	replicationStr, collection string, ttlSec int, sinkWriteChunkByFiler bool, diskType string, debug bool, concurrency int, doDeleteFiles bool, conflictStrategy string, preferSource bool, sourceFilerSignature int32, targetFilerSignature int32) error {
 
 	// if first time, start from now
 	// if has previously synced, resume from that point of time
@@ -258,8 +285,22 @@ func doSubscribeFilerMetaChanges(clientId int32, clientEpoch int32, grpcDialOpti
 	filerSink := &filersink.FilerSink{}
 	filerSink.DoInitialize(targetFiler.ToHttpAddress(), targetFiler.ToGrpcAddress(), targetPath, replicationStr, collection, ttlSec, diskType, grpcDialOption, sinkWriteChunkByFiler)
 	filerSink.SetSourceFiler(filerSource)
+	filerSink.TrackReplicated()
 
 	persistEventFn := genProcessFunction(sourcePath, targetPath, sourceExcludePaths, nil, filerSink, doDeleteFiles, debug)
+	conflictResolver := &syncConflictResolver{
+		strategy:        conflictStrategy,
+		preferSource:    preferSource,
+		sourceFiler:     sourceFiler,
+		targetFiler:     targetFiler,
+		sourcePath:      sourcePath,
+		targetPath:      targetPath,
+		excludePaths:    sourceExcludePaths,
+		doDeleteFiles:   doDeleteFiles,
+		sourceSignature: sourceFilerSignature,
+		targetSignature: targetFilerSignature,
+		sink:            filerSink,
+	}
 
 	processEventFn := func(resp *filer_pb.SubscribeMetadataResponse) error {
 		message := resp.EventNotification
@@ -269,6 +310,9 @@ func doSubscribeFilerMetaChanges(clientId int32, clientEpoch int32, grpcDialOpti
 				return nil
 			}
 		}
+		if handled, err := conflictResolver.handle(resp); handled || err != nil {
+			return err
+		}
 		return persistEventFn(resp)
 	}
 
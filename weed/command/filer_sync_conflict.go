@@ -0,0 +1,327 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/replication/sink/filersink"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	ConflictLastWriterWins = "lastWriterWins"
+	ConflictKeepBoth       = "keepBoth"
+	ConflictPreferA        = "preferA"
+	ConflictPreferB        = "preferB"
+)
+
+type syncConflictCheck int
+
+const (
+	syncNoConflict syncConflictCheck = iota
+	syncStaleChange
+	syncConflict
+)
+
+// syncConflictResolver finds the changes replicated from the source filer that meet
+// a different change of the same file on the target filer, and resolves them by the strategy.
+// Both directions of an active-active sync resolve the same conflict the same way.
+type syncConflictResolver struct {
+	strategy        string
+	preferSource    bool
+	sourceFiler     pb.ServerAddress
+	targetFiler     pb.ServerAddress
+	sourcePath      string
+	targetPath      string
+	excludePaths    []string
+	doDeleteFiles   bool
+	sourceSignature int32
+	targetSignature int32
+	sink            *filersink.FilerSink
+}
+
+func checkConflictStrategy(strategy string) error {
+	switch strategy {
+	case ConflictLastWriterWins, ConflictKeepBoth, ConflictPreferA, ConflictPreferB:
+		return nil
+	}
+	return fmt.Errorf("unknown conflict strategy %s, expecting %s, %s, %s or %s", strategy, ConflictLastWriterWins, ConflictKeepBoth, ConflictPreferA, ConflictPreferB)
+}
+
+// handle resolves a conflicting change, and returns true if the change needs no further replication
+func (r *syncConflictResolver) handle(resp *filer_pb.SubscribeMetadataResponse) (handled bool, err error) {
+	message := resp.EventNotification
+	if message.OldEntry != nil && message.OldEntry.IsDirectory || message.NewEntry != nil && message.NewEntry.IsDirectory {
+		return false, nil
+	}
+	if isMultipartUploadDir(resp.Directory+"/") || !strings.HasPrefix(resp.Directory+"/", r.sourcePath) {
+		return false, nil
+	}
+	for _, excludePath := range r.excludePaths {
+		if strings.HasPrefix(resp.Directory+"/", excludePath) {
+			return false, nil
+		}
+	}
+
+	var sourceKey util.FullPath
+	switch {
+	case filer_pb.IsCreate(resp):
+		sourceKey = util.FullPath(message.NewParentPath).Child(message.NewEntry.Name)
+	case filer_pb.IsUpdate(resp):
+		sourceKey = util.FullPath(message.NewParentPath).Child(message.NewEntry.Name)
+	case filer_pb.IsDelete(resp) && r.doDeleteFiles:
+		sourceKey = util.FullPath(resp.Directory).Child(message.OldEntry.Name)
+	default:
+		// renames are replicated as they are
+		return false, nil
+	}
+	if !strings.HasPrefix(string(sourceKey), r.sourcePath) {
+		return false, nil
+	}
+	key := buildKey(r.sink, message, r.targetPath, sourceKey, r.sourcePath)
+
+	existing, err := r.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil || existing.IsDirectory {
+		return false, nil
+	}
+	replicatedTag, err := r.sink.ReplicatedTag(key)
+	if err != nil {
+		return false, fmt.Errorf("replicated tag of %s: %v", key, err)
+	}
+
+	switch checkSyncConflict(message.OldEntry, message.NewEntry, existing, replicatedTag) {
+	case syncStaleChange:
+		glog.V(1).Infof("skip %s change to %s older than %s", r.sourceFiler, sourceKey, key)
+		return true, nil
+	case syncConflict:
+		return true, r.resolve(resp, key, existing)
+	}
+	return false, nil
+}
+
+// checkSyncConflict compares the target entry with the source change from oldEntry to newEntry,
+// by their vector clocks if both have one, or else by whether the target has changed since it was replicated.
+// replicatedTag is the content tag last replicated to the target, empty if unknown.
+func checkSyncConflict(oldEntry, newEntry, existing *filer_pb.Entry, replicatedTag string) syncConflictCheck {
+	if newEntry != nil && filersink.ContentTag(newEntry) == filersink.ContentTag(existing) {
+		return syncNoConflict
+	}
+
+	incoming := newEntry
+	if incoming == nil {
+		incoming = oldEntry
+	}
+	incomingClock := filer.VectorClockOf(incoming.Extended)
+	existingClock := filer.VectorClockOf(existing.Extended)
+	if len(incomingClock) > 0 && len(existingClock) > 0 && !incomingClock.Equal(existingClock) {
+		if newEntry == nil {
+			// the target has changes the deleted file did not have
+			if !incomingClock.Descends(existingClock) {
+				return syncConflict
+			}
+			return syncNoConflict
+		}
+		if incomingClock.Descends(existingClock) {
+			return syncNoConflict
+		}
+		if existingClock.Descends(incomingClock) {
+			return syncStaleChange
+		}
+		return syncConflict
+	}
+
+	if replicatedTag != "" && newEntry != nil && filersink.ContentTag(newEntry) == replicatedTag {
+		// replayed after a restart, the target has changed since
+		return syncStaleChange
+	}
+	if oldEntry != nil && filersink.ContentTag(oldEntry) == filersink.ContentTag(existing) {
+		// the target still has the content the source has changed
+		return syncNoConflict
+	}
+	if replicatedTag != "" && filersink.ContentTag(existing) == replicatedTag {
+		// the target has not changed since it was replicated, the change is only late
+		return syncNoConflict
+	}
+	return syncConflict
+}
+
+// isSourceWinning is the same decision on both sides, given the same two entries
+func (r *syncConflictResolver) isSourceWinning(incoming, existing *filer_pb.Entry) bool {
+	switch r.strategy {
+	case ConflictPreferA, ConflictPreferB:
+		return r.preferSource
+	}
+	if incoming.Attributes.GetMtime() != existing.Attributes.GetMtime() {
+		return incoming.Attributes.GetMtime() > existing.Attributes.GetMtime()
+	}
+	return filersink.ContentTag(incoming) > filersink.ContentTag(existing)
+}
+
+func (r *syncConflictResolver) resolve(resp *filer_pb.SubscribeMetadataResponse, key string, existing *filer_pb.Entry) error {
+	message := resp.EventNotification
+	conflict := &filer_pb.SyncConflict{
+		TsNs:          time.Now().UnixNano(),
+		Path:          key,
+		SourceFiler:   string(r.sourceFiler),
+		TargetFiler:   string(r.targetFiler),
+		Strategy:      r.strategy,
+		SourceDeleted: message.NewEntry == nil,
+		TargetMtime:   existing.Attributes.GetMtime(),
+		TargetEtag:    filersink.ContentTag(existing),
+	}
+	dir, name := util.FullPath(key).DirAndName()
+
+	if message.NewEntry == nil {
+		// the deleting side can not tell, so both sides keep the changed file
+		conflict.SourceMtime = resp.TsNs / int64(time.Second)
+		conflict.Resolution = "target kept, the change wins over the deletion"
+		return r.record(conflict, message.Signatures)
+	}
+
+	incoming := proto.Clone(message.NewEntry).(*filer_pb.Entry)
+	conflict.SourceMtime = incoming.Attributes.GetMtime()
+	conflict.SourceEtag = filersink.ContentTag(incoming)
+	mergedClock := filer.VectorClockOf(incoming.Extended).Merge(filer.VectorClockOf(existing.Extended))
+
+	if r.isSourceWinning(incoming, existing) {
+		if r.strategy == ConflictKeepBoth {
+			conflict.ConflictPath = string(util.NewFullPath(dir, syncConflictName(name, existing, r.targetSignature)))
+			if err := r.rename(dir, name, conflict.ConflictPath, message.Signatures); err != nil {
+				return fmt.Errorf("keep %s as %s: %v", key, conflict.ConflictPath, err)
+			}
+			conflict.Resolution = "source wins, target kept as a copy"
+		} else {
+			conflict.Resolution = "source wins"
+		}
+		if len(mergedClock) > 0 {
+			incoming.Extended = withVectorClock(incoming.Extended, mergedClock)
+		}
+		if err := r.sink.ReplaceEntry(key, incoming, message.Signatures); err != nil {
+			return err
+		}
+		return r.record(conflict, message.Signatures)
+	}
+
+	if r.strategy == ConflictKeepBoth {
+		conflict.ConflictPath = string(util.NewFullPath(dir, syncConflictName(name, incoming, r.sourceSignature)))
+		if err := r.sink.CreateEntry(conflict.ConflictPath, incoming, message.Signatures); err != nil {
+			return fmt.Errorf("keep %s as %s: %v", key, conflict.ConflictPath, err)
+		}
+		conflict.Resolution = "target wins, source kept as a copy"
+	} else {
+		conflict.Resolution = "target wins"
+	}
+	if len(mergedClock) > 0 && !filer.VectorClockOf(existing.Extended).Equal(mergedClock) {
+		// the target has now seen the source change as well
+		existing.Extended = withVectorClock(existing.Extended, mergedClock)
+		if err := r.update(dir, existing, message.Signatures); err != nil {
+			return err
+		}
+	}
+	return r.record(conflict, message.Signatures)
+}
+
+// syncConflictName names the losing copy after its side and time, the same on both sides
+func syncConflictName(name string, loser *filer_pb.Entry, loserSignature int32) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if base == "" {
+		base, ext = name, ""
+	}
+	mtime := time.Unix(loser.Attributes.GetMtime(), 0).UTC().Format("20060102T150405")
+	return fmt.Sprintf("%s.conflict-%s-%08x%s", base, mtime, uint32(loserSignature), ext)
+}
+
+func withVectorClock(extended map[string][]byte, vc filer.VectorClock) map[string][]byte {
+	updated := make(map[string][]byte, len(extended)+1)
+	for k, v := range extended {
+		updated[k] = v
+	}
+	updated[filer.VectorClockKey] = vc.Bytes()
+	return updated
+}
+
+func (r *syncConflictResolver) lookup(key string) (entry *filer_pb.Entry, err error) {
+	dir, name := util.FullPath(key).DirAndName()
+	err = r.sink.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, lookupErr := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if lookupErr == filer_pb.ErrNotFound {
+			return nil
+		}
+		if lookupErr != nil {
+			return lookupErr
+		}
+		entry = resp.Entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: %v", key, err)
+	}
+	return entry, nil
+}
+
+func (r *syncConflictResolver) rename(dir, name, newPath string, signatures []int32) error {
+	newDir, newName := util.FullPath(newPath).DirAndName()
+	return r.sink.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: dir,
+			OldName:      name,
+			NewDirectory: newDir,
+			NewName:      newName,
+			Signatures:   signatures,
+		})
+		return err
+	})
+}
+
+func (r *syncConflictResolver) update(dir string, entry *filer_pb.Entry, signatures []int32) error {
+	return r.sink.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(context.Background(), client, &filer_pb.UpdateEntryRequest{
+			Directory:          dir,
+			Entry:              entry,
+			IsFromOtherCluster: true,
+			Signatures:         signatures,
+		})
+	})
+}
+
+// record keeps the conflict on the target filer, see filer.sync.conflicts
+func (r *syncConflictResolver) record(conflict *filer_pb.SyncConflict, signatures []int32) error {
+	glog.V(0).Infof("sync conflict %s from %s: %s", conflict.Path, conflict.SourceFiler, conflict.Resolution)
+	data, err := proto.Marshal(conflict)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return r.sink.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: filer.SyncConflictsDir,
+			Entry: &filer_pb.Entry{
+				Name: fmt.Sprintf("%019d-%08x", conflict.TsNs, uint32(util.RandomInt32())),
+				Attributes: &filer_pb.FuseAttributes{
+					Mtime:    now.Unix(),
+					Crtime:   now.Unix(),
+					FileMode: uint32(0644),
+					FileSize: uint64(len(data)),
+				},
+				Content: data,
+			},
+			Signatures: signatures,
+		})
+	})
+}
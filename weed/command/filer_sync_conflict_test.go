@@ -0,0 +1,85 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/replication/sink/filersink"
+	"github.com/stretchr/testify/assert"
+)
+
+func syncTestEntry(content string, mtime int64, extended map[string]string) *filer_pb.Entry {
+	entry := &filer_pb.Entry{
+		Name:       "a.txt",
+		Content:    []byte(content),
+		Attributes: &filer_pb.FuseAttributes{Mtime: mtime},
+		Extended:   make(map[string][]byte),
+	}
+	for k, v := range extended {
+		entry.Extended[k] = []byte(v)
+	}
+	return entry
+}
+
+func TestCheckSyncConflict(t *testing.T) {
+	base := syncTestEntry("v1", 1, nil)
+	changed := syncTestEntry("v2", 2, nil)
+
+	// the target still has the base content
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, changed, syncTestEntry("v1", 1, nil), ""))
+	// the target already has the change
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, changed, syncTestEntry("v2", 2, nil), ""))
+
+	// the target has a replicated content, only not the latest one
+	replica := syncTestEntry("v0", 1, nil)
+	assert.Equal(t, syncConflict, checkSyncConflict(base, changed, replica, "something else"), "a wrong tag is a local change")
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, changed, replica, filersink.ContentTag(replica)))
+
+	// the change was replicated before, and the target has changed since
+	assert.Equal(t, syncStaleChange, checkSyncConflict(base, changed, syncTestEntry("v3", 3, nil), filersink.ContentTag(changed)))
+
+	// the target has changed locally
+	assert.Equal(t, syncConflict, checkSyncConflict(base, changed, syncTestEntry("v3", 3, nil), ""))
+	assert.Equal(t, syncConflict, checkSyncConflict(nil, changed, syncTestEntry("v3", 3, nil), ""))
+	assert.Equal(t, syncConflict, checkSyncConflict(base, nil, syncTestEntry("v3", 3, nil), ""))
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, nil, syncTestEntry("v1", 1, nil), ""))
+}
+
+func TestCheckSyncConflictByVectorClock(t *testing.T) {
+	clock := func(vc string) map[string]string {
+		return map[string]string{filer.VectorClockKey: vc}
+	}
+	base := syncTestEntry("v1", 1, clock("1:1"))
+	changed := syncTestEntry("v2", 2, clock("1:2"))
+
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, changed, syncTestEntry("v1", 1, clock("1:1")), ""))
+	assert.Equal(t, syncStaleChange, checkSyncConflict(base, changed, syncTestEntry("v3", 3, clock("1:3")), ""))
+	assert.Equal(t, syncConflict, checkSyncConflict(base, changed, syncTestEntry("v3", 3, clock("1:1,2:1")), ""))
+	assert.Equal(t, syncNoConflict, checkSyncConflict(base, changed, syncTestEntry("v3", 3, clock("1:1")), ""), "the same clock is decided by content")
+
+	assert.Equal(t, syncConflict, checkSyncConflict(changed, nil, syncTestEntry("v3", 3, clock("1:2,2:1")), ""))
+	assert.Equal(t, syncNoConflict, checkSyncConflict(changed, nil, syncTestEntry("v1", 1, clock("1:1")), ""))
+}
+
+func TestSyncConflictWinner(t *testing.T) {
+	a := &syncConflictResolver{strategy: ConflictKeepBoth}
+	b := &syncConflictResolver{strategy: ConflictKeepBoth}
+	older, newer := syncTestEntry("x", 1, nil), syncTestEntry("y", 2, nil)
+	assert.True(t, a.isSourceWinning(newer, older))
+	assert.False(t, b.isSourceWinning(older, newer), "both sides keep the newer change")
+
+	sameTime1, sameTime2 := syncTestEntry("x", 1, nil), syncTestEntry("y", 1, nil)
+	assert.NotEqual(t, a.isSourceWinning(sameTime1, sameTime2), b.isSourceWinning(sameTime2, sameTime1))
+
+	preferA := &syncConflictResolver{strategy: ConflictPreferA, preferSource: true}
+	assert.True(t, preferA.isSourceWinning(older, newer))
+}
+
+func TestSyncConflictName(t *testing.T) {
+	loser := syncTestEntry("x", time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC).Unix(), nil)
+	assert.Equal(t, "report.conflict-20261018T140000-0000002a.doc", syncConflictName("report.doc", loser, 42))
+	assert.Equal(t, ".bashrc.conflict-20261018T140000-ffffffff", syncConflictName(".bashrc", loser, -1))
+	assert.Equal(t, "Makefile.conflict-20261018T140000-0000002a", syncConflictName("Makefile", loser, 42))
+}
@@ -200,6 +200,16 @@ func runFuse(cmd *Command, args []string) bool {
 			} else {
 				panic(fmt.Errorf("disableXAttr: %s", err))
 			}
+		case "posixLocks":
+			if parsed, err := strconv.ParseBool(parameter.value); err == nil {
+				mountOptions.posixLocks = &parsed
+			} else {
+				panic(fmt.Errorf("posixLocks: %s", err))
+			}
+		case "writebackCacheDir":
+			mountOptions.writebackCacheDir = &parameter.value
+		case "pin":
+			mountOptions.pin = &parameter.value
 		case "cpuprofile":
 			mountCpuProfile = &parameter.value
 		case "memprofile":
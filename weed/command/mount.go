@@ -33,6 +33,9 @@ type MountOptions struct {
 	debugPort          *int
 	localSocket        *string
 	disableXAttr       *bool
+	posixLocks         *bool
+	writebackCacheDir  *string
+	pin                *string
 	extraOptions       []string
 	fuseCommandPid     int
 
@@ -81,6 +84,9 @@ func init() {
 	mountOptions.debugPort = cmdMount.Flag.Int("debug.port", 6061, "http port for debugging")
 	mountOptions.localSocket = cmdMount.Flag.String("localSocket", "", "default to /tmp/seaweedfs-mount-<mount_dir_hash>.sock")
 	mountOptions.disableXAttr = cmdMount.Flag.Bool("disableXAttr", false, "disable xattr")
+	mountOptions.posixLocks = cmdMount.Flag.Bool("posixLocks", false, "share fcntl and flock locks with other mounts through the filer")
+	mountOptions.writebackCacheDir = cmdMount.Flag.String("writebackCacheDir", "", "keep written data and file changes in this directory until they reach the filer, surviving restarts and filer outages")
+	mountOptions.pin = cmdMount.Flag.String("pin", "", "comma-separated filer paths to keep a full local copy of, readable when the filer is offline")
 	mountOptions.fuseCommandPid = 0
 
 	// RDMA acceleration flags
@@ -111,6 +117,23 @@ var cmdMount = &Command{
 
   On OS X, it requires OSXFUSE (https://osxfuse.github.io/).
 
+  Write Back Cache:
+  With -writebackCacheDir, closed and fsynced files are kept in a local journal,
+  and uploaded in the background. Writes continue while the filer is unreachable,
+  and the journal is replayed on the next mount. If a file was also changed on the
+  filer in the meantime, the local version is saved next to it as
+  <name>.conflict-<host>-<time>.<ext>. A file with pending changes can only be
+  renamed or removed while the filer is reachable.
+    weed mount -filer=localhost:8888 -dir=/mnt/seaweedfs -writebackCacheDir=/var/cache/seaweedfs
+
+  Pinned Directories:
+  With -pin, all metadata and chunks under the pinned filer paths are copied to
+  <cacheDir>/pinned, never evicted, and refreshed every few minutes. When the filer
+  is offline, the pinned paths are still readable, and changes are rejected with
+  EROFS unless they can go to the write back cache. The mount also starts when the
+  filer is offline. Pins can be changed at runtime with "mount.pin" in "weed shell".
+    weed mount -filer=localhost:8888 -dir=/mnt/seaweedfs -pin=/projects/docs,/tools
+
   RDMA Acceleration:
   For ultra-fast reads, enable RDMA acceleration with an RDMA sidecar:
     weed mount -filer=localhost:8888 -dir=/mnt/seaweedfs \
@@ -74,6 +74,7 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
 	var cipher bool
 	var err error
+	pins := util.StringSplit(*option.pin, ",")
 	for i := 0; i < 10; i++ {
 		err = pb.WithOneOfGrpcFilerClients(false, filerAddresses, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
 			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
@@ -83,13 +84,21 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 			cipher = resp.Cipher
 			return nil
 		})
-		if err != nil {
-			glog.V(0).Infof("failed to talk to filer %v: %v", filerAddresses, err)
-			glog.V(0).Infof("wait for %d seconds ...", i+1)
-			time.Sleep(time.Duration(i+1) * time.Second)
+		if err == nil {
+			break
+		}
+		if len(pins) > 0 {
+			// the pinned paths can be served without the filer
+			break
 		}
+		glog.V(0).Infof("failed to talk to filer %v: %v", filerAddresses, err)
+		glog.V(0).Infof("wait for %d seconds ...", i+1)
+		time.Sleep(time.Duration(i+1) * time.Second)
 	}
-	if err != nil {
+	filerOffline := err != nil
+	if filerOffline && len(pins) > 0 {
+		glog.Warningf("failed to talk to filer %v: %v, mounting the pinned paths offline", filerAddresses, err)
+	} else if err != nil {
 		glog.Errorf("failed to talk to filer %v: %v", filerAddresses, err)
 		return true
 	}
@@ -181,7 +190,7 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		SingleThreaded:           false,
 		DisableXAttrs:            *option.disableXAttr,
 		Debug:                    *option.debug,
-		EnableLocks:              false,
+		EnableLocks:              *option.posixLocks,
 		ExplicitDataCacheControl: false,
 		DirectMount:              true,
 		DirectMountFlags:         0,
@@ -252,6 +261,9 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		Cipher:             cipher,
 		UidGidMapper:       uidGidMapper,
 		DisableXAttr:       *option.disableXAttr,
+		PosixLocks:         *option.posixLocks,
+		WritebackCacheDir:  util.ResolvePath(*option.writebackCacheDir),
+		Pins:               pins,
 		IsMacOs:            runtime.GOOS == "darwin",
 		// RDMA acceleration options
 		RdmaEnabled:       *option.rdmaEnabled,
@@ -265,7 +277,9 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 	// create mount root
 	mountRootPath := util.FullPath(mountRoot)
 	mountRootParent, mountDir := mountRootPath.DirAndName()
-	if err = filer_pb.Mkdir(context.Background(), seaweedFileSystem, mountRootParent, mountDir, nil); err != nil {
+	if filerOffline {
+		glog.V(0).Infof("skip creating dir %s on the offline filer %s", mountRoot, filerAddresses)
+	} else if err = filer_pb.Mkdir(context.Background(), seaweedFileSystem, mountRootParent, mountDir, nil); err != nil {
 		fmt.Printf("failed to create dir %s on filer %s: %v\n", mountRoot, filerAddresses, err)
 		return false
 	}
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/nfs"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/grace"
+	"github.com/seaweedfs/seaweedfs/weed/util/version"
+)
+
+var (
+	nfsStandaloneOptions NfsOptions
+)
+
+type NfsOptions struct {
+	filer            *string
+	filerRootPath    *string
+	exportsFile      *string
+	bindIp           *string
+	port             *int
+	collection       *string
+	replication      *string
+	disk             *string
+	dataCenter       *string
+	cacheDir         *string
+	chunkSizeLimitMB *int
+}
+
+func init() {
+	cmdNfs.Run = runNfs // break init cycle
+	nfsStandaloneOptions.filer = cmdNfs.Flag.String("filer", "localhost:8888", "filer server address")
+	nfsStandaloneOptions.filerRootPath = cmdNfs.Flag.String("filer.path", "/", "export this remote path to all clients, if there is no exports file")
+	nfsStandaloneOptions.exportsFile = cmdNfs.Flag.String("exports", "", "exports file in the /etc/exports format, listing the exported filer paths and the clients allowed to mount them")
+	nfsStandaloneOptions.bindIp = cmdNfs.Flag.String("ip.bind", "", "ip address to bind to. Default listen to all.")
+	nfsStandaloneOptions.port = cmdNfs.Flag.Int("port", 2049, "nfs server listen port, for both the NFS and MOUNT protocols")
+	nfsStandaloneOptions.collection = cmdNfs.Flag.String("collection", "", "collection to create the files")
+	nfsStandaloneOptions.replication = cmdNfs.Flag.String("replication", "", "replication to create the files")
+	nfsStandaloneOptions.disk = cmdNfs.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	nfsStandaloneOptions.dataCenter = cmdNfs.Flag.String("dataCenter", "", "prefer to write to volumes in this data center")
+	nfsStandaloneOptions.cacheDir = cmdNfs.Flag.String("cacheDir", os.TempDir(), "local directory to keep the file handles handed out to clients")
+	nfsStandaloneOptions.chunkSizeLimitMB = cmdNfs.Flag.Int("chunkSizeLimitMB", 8, "buffer uncommitted writes to a file up to this size before uploading them as one chunk")
+}
+
+var cmdNfs = &Command{
+	UsageLine: "nfs -port=2049 -filer=<ip:port> [-exports=/etc/seaweedfs/exports]",
+	Short:     "start an NFSv3 server that is backed by a filer",
+	Long: `start an NFSv3 server that is backed by a filer.
+
+	The NFS and MOUNT protocols are served on the same TCP port, without a portmapper.
+	Mount on Linux with:
+
+		mount -t nfs -o vers=3,tcp,port=2049,mountport=2049,nolock <nfs server>:/buckets/data /mnt/data
+
+	The exports file lists the exported filer paths and the clients allowed to mount them:
+
+		/buckets/data   10.0.0.0/8(rw) 192.168.1.5(ro,no_root_squash)
+		/archive        *(ro)
+
+	Without options, a client has read-only access and its root user is mapped to nobody.
+	Without an exports file, -filer.path is exported read-write to all clients.
+
+	File handles are kept in -cacheDir, so clients can continue after the server restarts.
+	Byte range locks are not supported, so mount with -o nolock.
+
+`,
+}
+
+func runNfs(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+
+	glog.V(0).Infof("Starting Seaweed NFS Server %s at %s:%d", version.Version(), *nfsStandaloneOptions.bindIp, *nfsStandaloneOptions.port)
+
+	return nfsStandaloneOptions.startNfsServer()
+
+}
+
+func (no *NfsOptions) startNfsServer() bool {
+
+	filerAddress := pb.ServerAddress(*no.filer)
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	exports, err := no.loadExports()
+	if err != nil {
+		glog.Fatalf("NFS exports: %v", err)
+	}
+
+	for {
+		err := pb.WithGrpcFilerClient(false, 0, filerAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			_, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerAddress, err)
+			}
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("wait to connect to filer %s grpc address %s", *no.filer, filerAddress.ToGrpcAddress())
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *no.filer, filerAddress.ToGrpcAddress())
+			break
+		}
+	}
+
+	handleDir := filepath.Join(util.ResolvePath(*no.cacheDir), fmt.Sprintf("nfs_handles_%d", util.HashStringToLong(fmt.Sprintf("%s:%d", *no.filer, *no.port))&0x7fffffff))
+	server, err := nfs.NewNfsServer(&nfs.NfsServerOption{
+		Filer:          filerAddress,
+		GrpcDialOption: grpcDialOption,
+		Exports:        exports,
+		HandleDir:      handleDir,
+		Collection:     *no.collection,
+		Replication:    *no.replication,
+		DiskType:       *no.disk,
+		DataCenter:     *no.dataCenter,
+		ChunkSizeLimit: int64(*no.chunkSizeLimitMB) * 1024 * 1024,
+	})
+	if err != nil {
+		glog.Fatalf("NFS Server startup error: %v", err)
+	}
+	grace.OnInterrupt(server.Shutdown)
+
+	listenAddress := fmt.Sprintf("%s:%d", *no.bindIp, *no.port)
+	listener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("NFS Server listener on %s error: %v", listenAddress, err)
+	}
+
+	glog.V(0).Infof("Start Seaweed NFS Server %s at %s", version.Version(), listenAddress)
+	if err = server.Serve(listener); err != nil {
+		glog.Fatalf("NFS Server Fail to serve: %v", err)
+	}
+
+	return true
+}
+
+func (no *NfsOptions) loadExports() ([]*nfs.Export, error) {
+	if *no.exportsFile == "" {
+		return []*nfs.Export{{
+			Path:  util.FullPath(*no.filerRootPath),
+			Rules: []*nfs.ExportRule{{ReadOnly: false, RootSquash: false}},
+		}}, nil
+	}
+	file, err := os.Open(util.ResolvePath(*no.exportsFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	exports, err := nfs.ParseExports(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", *no.exportsFile, err)
+	}
+	if len(exports) == 0 {
+		return nil, fmt.Errorf("%s exports nothing", *no.exportsFile)
+	}
+	return exports, nil
+}
@@ -127,6 +127,7 @@ func init() {
 	filerOptions.downloadMaxMBps = cmdServer.Flag.Int("filer.downloadMaxMBps", 0, "download max speed for each download request, in MB per second")
 	filerOptions.diskType = cmdServer.Flag.String("filer.disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	filerOptions.exposeDirectoryData = cmdServer.Flag.Bool("filer.exposeDirectoryData", true, "expose directory data via filer. If false, filer UI will be innaccessible.")
+	filerOptions.searchIndexDir = cmdServer.Flag.String("filer.searchIndexDir", "", "keep a search index of all entries in this directory, to serve fs.find and SearchEntries")
 
 	serverOptions.v.port = cmdServer.Flag.Int("volume.port", 8080, "volume server http listen port")
 	serverOptions.v.portGrpc = cmdServer.Flag.Int("volume.port.grpc", 0, "volume server grpc listen port")
@@ -177,6 +178,9 @@ func init() {
 	sftpOptions.clientAliveInterval = cmdServer.Flag.Duration("sftp.clientAliveInterval", 5*time.Second, "interval for sending keep-alive messages")
 	sftpOptions.clientAliveCountMax = cmdServer.Flag.Int("sftp.clientAliveCountMax", 3, "maximum number of missed keep-alive messages before disconnecting")
 	sftpOptions.userStoreFile = cmdServer.Flag.String("sftp.userStoreFile", "", "path to JSON file containing user credentials and permissions")
+	sftpOptions.authStore = cmdServer.Flag.String("sftp.auth.store", "", "credential store of the users instead of -sftp.userStoreFile, e.g. filer_etc or postgres, so the S3 identities and policies also apply to SFTP")
+	sftpOptions.trustedUserCAKeys = cmdServer.Flag.String("sftp.trustedUserCAKeys", "", "path to file of CA public keys in authorized_keys format, whose signed SSH user certificates are accepted")
+	sftpOptions.ldapConfigFile = cmdServer.Flag.String("sftp.ldapConfigFile", "", "path to JSON file of the LDAP or Active Directory server verifying passwords of users not in the user store")
 	sftpOptions.localSocket = cmdServer.Flag.String("sftp.localSocket", "", "default to /tmp/seaweedfs-sftp-<port>.sock")
 	iamOptions.port = cmdServer.Flag.Int("iam.port", 8111, "iam server http listen port")
 
@@ -190,6 +194,9 @@ func init() {
 	webdavOptions.cacheSizeMB = cmdServer.Flag.Int64("webdav.cacheCapacityMB", 0, "local cache capacity in MB")
 	webdavOptions.maxMB = cmdServer.Flag.Int("webdav.maxMB", 4, "split files larger than the limit")
 	webdavOptions.filerRootPath = cmdServer.Flag.String("webdav.filer.path", "/", "use this remote path from filer server")
+	webdavOptions.auth = cmdServer.Flag.String("webdav.auth", "", "[basic] require Basic authentication against the S3 identities, empty to allow anonymous access")
+	webdavOptions.authStore = cmdServer.Flag.String("webdav.auth.store", "", "credential store of the identities, defaults to credential.toml or filer_etc")
+	webdavOptions.userRootPath = cmdServer.Flag.String("webdav.userRoot", "", "give each user this root directory under webdav.filer.path, with {user} replaced by the user name")
 
 	mqBrokerOptions.port = cmdServer.Flag.Int("mq.broker.port", 17777, "message queue broker gRPC listen port")
 
@@ -36,6 +36,9 @@ type SftpOptions struct {
 	clientAliveInterval *time.Duration
 	clientAliveCountMax *int
 	userStoreFile       *string
+	authStore           *string
+	trustedUserCAKeys   *string
+	ldapConfigFile      *string
 	dataCenter          *string
 	metricsHttpPort     *int
 	metricsHttpIp       *string
@@ -70,6 +73,9 @@ func init() {
 	sftpOptionsStandalone.clientAliveInterval = cmdSftp.Flag.Duration("clientAliveInterval", 5*time.Second, "interval for sending keep-alive messages")
 	sftpOptionsStandalone.clientAliveCountMax = cmdSftp.Flag.Int("clientAliveCountMax", 3, "maximum number of missed keep-alive messages before disconnecting")
 	sftpOptionsStandalone.userStoreFile = cmdSftp.Flag.String("userStoreFile", "", "path to JSON file containing user credentials and permissions")
+	sftpOptionsStandalone.authStore = cmdSftp.Flag.String("auth.store", "", "credential store of the users instead of -userStoreFile, e.g. filer_etc or postgres, so the S3 identities and policies also apply to SFTP")
+	sftpOptionsStandalone.trustedUserCAKeys = cmdSftp.Flag.String("trustedUserCAKeys", "", "path to file of CA public keys in authorized_keys format, whose signed SSH user certificates are accepted")
+	sftpOptionsStandalone.ldapConfigFile = cmdSftp.Flag.String("ldapConfigFile", "", "path to JSON file of the LDAP or Active Directory server verifying passwords of users not in the user store")
 	sftpOptionsStandalone.dataCenter = cmdSftp.Flag.String("dataCenter", "", "prefer to read and write to volumes in this data center")
 	sftpOptionsStandalone.metricsHttpPort = cmdSftp.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
 	sftpOptionsStandalone.metricsHttpIp = cmdSftp.Flag.String("metricsIp", "", "metrics listen ip. If empty, default to same as -ip.bind option.")
@@ -101,6 +107,7 @@ func (sftpOpt *SftpOptions) startSftpServer() bool {
 	var metricsAddress string
 	var metricsIntervalSec int
 	var filerGroup string
+	var dirBuckets string
 
 	// Connect to the filer service and try to retrieve basic configuration.
 	for {
@@ -111,6 +118,7 @@ func (sftpOpt *SftpOptions) startSftpServer() bool {
 			}
 			metricsAddress, metricsIntervalSec = resp.MetricsAddress, int(resp.MetricsIntervalSec)
 			filerGroup = resp.FilerGroup
+			dirBuckets = resp.DirBuckets
 			glog.V(0).Infof("SFTP read filer configuration, using filer at: %s", filerAddress)
 			return nil
 		})
@@ -133,19 +141,23 @@ func (sftpOpt *SftpOptions) startSftpServer() bool {
 
 	// Create a new SFTP service instance with all options
 	service := sftpd.NewSFTPService(&sftpd.SFTPServiceOptions{
-		GrpcDialOption:      grpcDialOption,
-		DataCenter:          *sftpOpt.dataCenter,
-		FilerGroup:          filerGroup,
-		Filer:               filerAddress,
-		SshPrivateKey:       *sftpOpt.sshPrivateKey,
-		HostKeysFolder:      *sftpOpt.hostKeysFolder,
-		AuthMethods:         authMethods,
-		MaxAuthTries:        *sftpOpt.maxAuthTries,
-		BannerMessage:       *sftpOpt.bannerMessage,
-		LoginGraceTime:      *sftpOpt.loginGraceTime,
-		ClientAliveInterval: *sftpOpt.clientAliveInterval,
-		ClientAliveCountMax: *sftpOpt.clientAliveCountMax,
-		UserStoreFile:       *sftpOpt.userStoreFile,
+		GrpcDialOption:        grpcDialOption,
+		DataCenter:            *sftpOpt.dataCenter,
+		FilerGroup:            filerGroup,
+		DirBuckets:            dirBuckets,
+		Filer:                 filerAddress,
+		SshPrivateKey:         *sftpOpt.sshPrivateKey,
+		HostKeysFolder:        *sftpOpt.hostKeysFolder,
+		AuthMethods:           authMethods,
+		MaxAuthTries:          *sftpOpt.maxAuthTries,
+		BannerMessage:         *sftpOpt.bannerMessage,
+		LoginGraceTime:        *sftpOpt.loginGraceTime,
+		ClientAliveInterval:   *sftpOpt.clientAliveInterval,
+		ClientAliveCountMax:   *sftpOpt.clientAliveCountMax,
+		UserStoreFile:         *sftpOpt.userStoreFile,
+		CredentialStore:       *sftpOpt.authStore,
+		TrustedUserCAKeysFile: *sftpOpt.trustedUserCAKeys,
+		LdapConfigFile:        *sftpOpt.ldapConfigFile,
 	})
 
 	// Set up Unix socket if on non-Windows platforms
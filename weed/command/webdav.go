@@ -10,6 +10,9 @@ import (
 	"strconv"
 	"time"
 
+	"google.golang.org/grpc"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
@@ -35,6 +38,9 @@ type WebDavOption struct {
 	cacheDir       *string
 	cacheSizeMB    *int64
 	maxMB          *int
+	auth           *string
+	authStore      *string
+	userRootPath   *string
 }
 
 func init() {
@@ -51,6 +57,9 @@ func init() {
 	webDavStandaloneOptions.cacheSizeMB = cmdWebDav.Flag.Int64("cacheCapacityMB", 0, "local cache capacity in MB")
 	webDavStandaloneOptions.maxMB = cmdWebDav.Flag.Int("maxMB", 4, "split files larger than the limit")
 	webDavStandaloneOptions.filerRootPath = cmdWebDav.Flag.String("filer.path", "/", "use this remote path from filer server")
+	webDavStandaloneOptions.auth = cmdWebDav.Flag.String("auth", "", "[basic] require Basic authentication against the S3 identities, empty to allow anonymous access")
+	webDavStandaloneOptions.authStore = cmdWebDav.Flag.String("auth.store", "", "credential store of the identities, defaults to credential.toml or filer_etc")
+	webDavStandaloneOptions.userRootPath = cmdWebDav.Flag.String("userRoot", "", "give each user this root directory under filer.path, with {user} replaced by the user name, e.g. /home/{user}")
 }
 
 var cmdWebDav = &Command{
@@ -58,6 +67,14 @@ var cmdWebDav = &Command{
 	Short:     "start a webdav server that is backed by a filer",
 	Long: `start a webdav server that is backed by a filer.
 
+	Locks taken by WebDAV clients are kept in the filer, and shared by all webdav servers using the same filers.
+
+	With -auth=basic, users log in with an S3 identity name or access key, and the secret key as the password.
+	Identities with the Read or List action can read, and identities with the Write action can change files.
+	The Admin action allows both.
+
+	weed webdav -filer=localhost:8888 -auth=basic -userRoot=/home/{user}
+
 `,
 }
 
@@ -110,6 +127,27 @@ func (wo *WebDavOption) startWebDav() bool {
 		}
 	}
 
+	var authenticator weed_server.WebDavAuthenticator
+	switch *wo.auth {
+	case "":
+		if *wo.userRootPath != "" {
+			glog.Fatalf("-userRoot requires -auth")
+		}
+	case "basic":
+		credentialManager, err := credential.NewCredentialManagerWithDefaults(credential.CredentialStoreTypeName(*wo.authStore))
+		if err != nil {
+			glog.Fatalf("WebDav credential store: %v", err)
+		}
+		if filerClientSetter, ok := credentialManager.GetStore().(interface {
+			SetFilerClient(string, grpc.DialOption)
+		}); ok {
+			filerClientSetter.SetFilerClient(string(filerAddress), grpcDialOption)
+		}
+		authenticator = weed_server.NewCredentialWebDavAuthenticator(credentialManager)
+	default:
+		glog.Fatalf("unknown webdav auth %s", *wo.auth)
+	}
+
 	ws, webdavServer_err := weed_server.NewWebDavServer(&weed_server.WebDavOption{
 		Filer:          filerAddress,
 		FilerRootPath:  *wo.filerRootPath,
@@ -123,12 +161,14 @@ func (wo *WebDavOption) startWebDav() bool {
 		CacheDir:       util.ResolvePath(*wo.cacheDir),
 		CacheSizeMB:    *wo.cacheSizeMB,
 		MaxMB:          *wo.maxMB,
+		Authenticator:  authenticator,
+		UserRootPath:   *wo.userRootPath,
 	})
 	if webdavServer_err != nil {
 		glog.Fatalf("WebDav Server startup error: %v", webdavServer_err)
 	}
 
-	httpS := &http.Server{Handler: ws.Handler}
+	httpS := &http.Server{Handler: ws}
 
 	listenAddress := fmt.Sprintf("%s:%d", *wo.ipBind, *wo.port)
 	webDavListener, err := util.NewListener(listenAddress, time.Duration(10)*time.Second)
@@ -0,0 +1,70 @@
+package credential
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+)
+
+// IdentityActions resolves the effective actions of identities, so that S3 and the
+// other gateways grant the same permissions from one configuration.
+type IdentityActions struct {
+	policyActions  map[string][]string // managed policy name -> actions
+	memberPolicies map[string][]string // identity name -> policies attached to its groups
+}
+
+// NewIdentityActions prepares the managed policies and group memberships of the configuration
+func NewIdentityActions(config *iam_pb.S3ApiConfiguration) *IdentityActions {
+	a := &IdentityActions{
+		policyActions:  LoadManagedPolicyActions(config.GetPolicies()),
+		memberPolicies: make(map[string][]string),
+	}
+	for _, group := range config.GetGroups() {
+		for _, member := range group.Members {
+			a.memberPolicies[member] = append(a.memberPolicies[member], group.PolicyNames...)
+		}
+	}
+	return a
+}
+
+// Actions returns the identity's own actions, followed by the actions of the managed
+// policies attached to it directly or through its groups.
+func (a *IdentityActions) Actions(identity *iam_pb.Identity) []string {
+	actions := slices.Clone(identity.Actions)
+	for _, policyName := range append(slices.Clone(identity.PolicyNames), a.memberPolicies[identity.Name]...) {
+		for _, action := range a.policyActions[policyName] {
+			if !slices.Contains(actions, action) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// LoadManagedPolicyActions converts the default version of each managed policy into identity actions
+func LoadManagedPolicyActions(policies []*iam_pb.ManagedPolicy) map[string][]string {
+	policyActions := make(map[string][]string)
+	for _, policy := range policies {
+		for _, version := range policy.Versions {
+			if version.VersionId != policy.DefaultVersionId {
+				continue
+			}
+			var policyDocument policy_engine.PolicyDocument
+			if err := json.Unmarshal([]byte(version.Document), &policyDocument); err != nil {
+				glog.Warningf("managed policy %s: invalid document: %v", policy.Name, err)
+				break
+			}
+			actions, err := policy_engine.ConvertPolicyToLegacyActions(&policyDocument)
+			if err != nil {
+				glog.Warningf("managed policy %s is not applied: %v", policy.Name, err)
+				break
+			}
+			policyActions[policy.Name] = actions
+			break
+		}
+	}
+	return policyActions
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"google.golang.org/protobuf/proto"
 )
 
 func (store *MemoryStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3ApiConfiguration, error) {
@@ -25,6 +26,18 @@ func (store *MemoryStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3ApiC
 		identityCopy := store.deepCopyIdentity(user)
 		config.Identities = append(config.Identities, identityCopy)
 	}
+	for _, group := range store.groups {
+		config.Groups = append(config.Groups, proto.Clone(group).(*iam_pb.Group))
+	}
+	for _, role := range store.roles {
+		config.Roles = append(config.Roles, proto.Clone(role).(*iam_pb.Role))
+	}
+	for _, policy := range store.managed {
+		config.Policies = append(config.Policies, proto.Clone(policy).(*iam_pb.ManagedPolicy))
+	}
+	for _, tenant := range store.tenants {
+		config.Tenants = append(config.Tenants, proto.Clone(tenant).(*iam_pb.Tenant))
+	}
 
 	return config, nil
 }
@@ -53,6 +66,23 @@ func (store *MemoryStore) SaveConfiguration(ctx context.Context, config *iam_pb.
 		}
 	}
 
+	store.groups = make(map[string]*iam_pb.Group)
+	for _, group := range config.Groups {
+		store.groups[group.Name] = proto.Clone(group).(*iam_pb.Group)
+	}
+	store.roles = make(map[string]*iam_pb.Role)
+	for _, role := range config.Roles {
+		store.roles[role.Name] = proto.Clone(role).(*iam_pb.Role)
+	}
+	store.managed = make(map[string]*iam_pb.ManagedPolicy)
+	for _, policy := range config.Policies {
+		store.managed[policy.Name] = proto.Clone(policy).(*iam_pb.ManagedPolicy)
+	}
+	store.tenants = make(map[string]*iam_pb.Tenant)
+	for _, tenant := range config.Tenants {
+		store.tenants[tenant.Name] = proto.Clone(tenant).(*iam_pb.Tenant)
+	}
+
 	return nil
 }
 
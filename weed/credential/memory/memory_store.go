@@ -20,6 +20,10 @@ type MemoryStore struct {
 	users       map[string]*iam_pb.Identity             // username -> identity
 	accessKeys  map[string]string                       // access_key -> username
 	policies    map[string]policy_engine.PolicyDocument // policy_name -> policy_document
+	groups      map[string]*iam_pb.Group                // group_name -> group
+	roles       map[string]*iam_pb.Role                 // role_name -> role
+	managed     map[string]*iam_pb.ManagedPolicy        // policy_name -> versioned managed policy
+	tenants     map[string]*iam_pb.Tenant               // tenant_name -> tenant
 	initialized bool
 }
 
@@ -38,6 +42,10 @@ func (store *MemoryStore) Initialize(configuration util.Configuration, prefix st
 	store.users = make(map[string]*iam_pb.Identity)
 	store.accessKeys = make(map[string]string)
 	store.policies = make(map[string]policy_engine.PolicyDocument)
+	store.groups = make(map[string]*iam_pb.Group)
+	store.roles = make(map[string]*iam_pb.Role)
+	store.managed = make(map[string]*iam_pb.ManagedPolicy)
+	store.tenants = make(map[string]*iam_pb.Tenant)
 	store.initialized = true
 
 	return nil
@@ -50,6 +58,10 @@ func (store *MemoryStore) Shutdown() {
 	store.users = nil
 	store.accessKeys = nil
 	store.policies = nil
+	store.groups = nil
+	store.roles = nil
+	store.managed = nil
+	store.tenants = nil
 	store.initialized = false
 }
 
@@ -61,6 +73,10 @@ func (store *MemoryStore) Reset() {
 	if store.initialized {
 		store.users = make(map[string]*iam_pb.Identity)
 		store.accessKeys = make(map[string]string)
+		store.groups = make(map[string]*iam_pb.Group)
+		store.roles = make(map[string]*iam_pb.Role)
+		store.managed = make(map[string]*iam_pb.ManagedPolicy)
+		store.tenants = make(map[string]*iam_pb.Tenant)
 	}
 }
 
@@ -313,3 +313,62 @@ func TestMemoryStoreConfigurationSaveLoad(t *testing.T) {
 		t.Errorf("User2 credentials not correct: %+v", user2.Credentials)
 	}
 }
+
+func TestMemoryStoreGroupsRolesAndPolicies(t *testing.T) {
+	store := &MemoryStore{}
+	config := util.GetViper()
+	if err := store.Initialize(config, "credential."); err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+
+	ctx := context.Background()
+
+	originalConfig := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:        "user1",
+				PolicyNames: []string{"read-only"},
+				Tags:        map[string]string{"team": "storage"},
+			},
+		},
+		Groups: []*iam_pb.Group{
+			{Name: "team", Members: []string{"user1"}, PolicyNames: []string{"read-only"}},
+		},
+		Roles: []*iam_pb.Role{
+			{Name: "deployer", AssumeRolePolicyDocument: "{}"},
+		},
+		Policies: []*iam_pb.ManagedPolicy{
+			{Name: "read-only", DefaultVersionId: "v1", Versions: []*iam_pb.PolicyVersion{{VersionId: "v1", Document: "{}"}}},
+		},
+	}
+
+	if err := store.SaveConfiguration(ctx, originalConfig); err != nil {
+		t.Fatalf("Failed to save configuration: %v", err)
+	}
+
+	// mutating the saved configuration must not leak into the store
+	originalConfig.Groups[0].Members = nil
+
+	loadedConfig, err := store.LoadConfiguration(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if len(loadedConfig.Groups) != 1 || len(loadedConfig.Groups[0].Members) != 1 {
+		t.Errorf("Groups not correct: %+v", loadedConfig.Groups)
+	}
+	if len(loadedConfig.Roles) != 1 || loadedConfig.Roles[0].Name != "deployer" {
+		t.Errorf("Roles not correct: %+v", loadedConfig.Roles)
+	}
+	if len(loadedConfig.Policies) != 1 || loadedConfig.Policies[0].DefaultVersionId != "v1" {
+		t.Errorf("Policies not correct: %+v", loadedConfig.Policies)
+	}
+
+	user1, err := store.GetUser(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Failed to get user1: %v", err)
+	}
+	if len(user1.PolicyNames) != 1 || user1.Tags["team"] != "storage" {
+		t.Errorf("User1 policies or tags not correct: %+v", user1)
+	}
+}
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+func marshalIdentityAttributes(identity *iam_pb.Identity) (policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON []byte, err error) {
+	if len(identity.PolicyNames) > 0 {
+		if policyNamesJSON, err = json.Marshal(identity.PolicyNames); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	if len(identity.Tags) > 0 {
+		if tagsJSON, err = json.Marshal(identity.Tags); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	if identity.Sftp != nil {
+		if sftpJSON, err = json.Marshal(identity.Sftp); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	if len(identity.ClientCertificates) > 0 {
+		if clientCertificatesJSON, err = json.Marshal(identity.ClientCertificates); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	return policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, nil
+}
+
+func unmarshalIdentityAttributes(policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON []byte, identity *iam_pb.Identity) error {
+	if len(policyNamesJSON) > 0 {
+		if err := json.Unmarshal(policyNamesJSON, &identity.PolicyNames); err != nil {
+			return err
+		}
+	}
+	if len(tagsJSON) > 0 {
+		if err := json.Unmarshal(tagsJSON, &identity.Tags); err != nil {
+			return err
+		}
+	}
+	if len(sftpJSON) > 0 {
+		identity.Sftp = &iam_pb.SftpConfig{}
+		if err := json.Unmarshal(sftpJSON, identity.Sftp); err != nil {
+			return err
+		}
+	}
+	if len(clientCertificatesJSON) > 0 {
+		if err := json.Unmarshal(clientCertificatesJSON, &identity.ClientCertificates); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadIamEntities reads groups, roles, managed policies and tenants into the configuration
+func (store *PostgresStore) loadIamEntities(ctx context.Context, config *iam_pb.S3ApiConfiguration) error {
+	groupRows, err := store.db.QueryContext(ctx, "SELECT name, members, policy_names, create_date FROM iam_groups ORDER BY name")
+	if err != nil {
+		return fmt.Errorf("failed to query groups: %w", err)
+	}
+	defer groupRows.Close()
+	for groupRows.Next() {
+		group := &iam_pb.Group{}
+		var membersJSON, policyNamesJSON []byte
+		if err := groupRows.Scan(&group.Name, &membersJSON, &policyNamesJSON, &group.CreateDate); err != nil {
+			return fmt.Errorf("failed to scan group row: %w", err)
+		}
+		if len(membersJSON) > 0 {
+			if err := json.Unmarshal(membersJSON, &group.Members); err != nil {
+				return fmt.Errorf("failed to unmarshal members for group %s: %v", group.Name, err)
+			}
+		}
+		if len(policyNamesJSON) > 0 {
+			if err := json.Unmarshal(policyNamesJSON, &group.PolicyNames); err != nil {
+				return fmt.Errorf("failed to unmarshal policies for group %s: %v", group.Name, err)
+			}
+		}
+		config.Groups = append(config.Groups, group)
+	}
+
+	roleRows, err := store.db.QueryContext(ctx, "SELECT name, description, assume_role_policy_document, policy_names, tags, create_date FROM iam_roles ORDER BY name")
+	if err != nil {
+		return fmt.Errorf("failed to query roles: %w", err)
+	}
+	defer roleRows.Close()
+	for roleRows.Next() {
+		role := &iam_pb.Role{}
+		var description, document sql.NullString
+		var policyNamesJSON, tagsJSON []byte
+		if err := roleRows.Scan(&role.Name, &description, &document, &policyNamesJSON, &tagsJSON, &role.CreateDate); err != nil {
+			return fmt.Errorf("failed to scan role row: %w", err)
+		}
+		role.Description = description.String
+		role.AssumeRolePolicyDocument = document.String
+		if len(policyNamesJSON) > 0 {
+			if err := json.Unmarshal(policyNamesJSON, &role.PolicyNames); err != nil {
+				return fmt.Errorf("failed to unmarshal policies for role %s: %v", role.Name, err)
+			}
+		}
+		if len(tagsJSON) > 0 {
+			if err := json.Unmarshal(tagsJSON, &role.Tags); err != nil {
+				return fmt.Errorf("failed to unmarshal tags for role %s: %v", role.Name, err)
+			}
+		}
+		config.Roles = append(config.Roles, role)
+	}
+
+	policyRows, err := store.db.QueryContext(ctx, "SELECT name, description, default_version_id, create_date FROM managed_policies ORDER BY name")
+	if err != nil {
+		return fmt.Errorf("failed to query managed policies: %w", err)
+	}
+	defer policyRows.Close()
+	policies := make(map[string]*iam_pb.ManagedPolicy)
+	for policyRows.Next() {
+		policy := &iam_pb.ManagedPolicy{}
+		var description sql.NullString
+		if err := policyRows.Scan(&policy.Name, &description, &policy.DefaultVersionId, &policy.CreateDate); err != nil {
+			return fmt.Errorf("failed to scan managed policy row: %w", err)
+		}
+		policy.Description = description.String
+		policies[policy.Name] = policy
+		config.Policies = append(config.Policies, policy)
+	}
+
+	versionRows, err := store.db.QueryContext(ctx, "SELECT policy_name, version_id, document, create_date FROM managed_policy_versions ORDER BY policy_name, create_date, version_id")
+	if err != nil {
+		return fmt.Errorf("failed to query managed policy versions: %w", err)
+	}
+	defer versionRows.Close()
+	for versionRows.Next() {
+		var policyName string
+		version := &iam_pb.PolicyVersion{}
+		if err := versionRows.Scan(&policyName, &version.VersionId, &version.Document, &version.CreateDate); err != nil {
+			return fmt.Errorf("failed to scan managed policy version row: %w", err)
+		}
+		if policy, found := policies[policyName]; found {
+			policy.Versions = append(policy.Versions, version)
+		}
+	}
+
+	tenantRows, err := store.db.QueryContext(ctx, "SELECT name, max_buckets, quota_bytes, quota_objects, max_requests_per_second, create_date FROM iam_tenants ORDER BY name")
+	if err != nil {
+		return fmt.Errorf("failed to query tenants: %w", err)
+	}
+	defer tenantRows.Close()
+	for tenantRows.Next() {
+		tenant := &iam_pb.Tenant{}
+		var quotaBytes, quotaObjects int64
+		if err := tenantRows.Scan(&tenant.Name, &tenant.MaxBuckets, &quotaBytes, &quotaObjects, &tenant.MaxRequestsPerSecond, &tenant.CreateDate); err != nil {
+			return fmt.Errorf("failed to scan tenant row: %w", err)
+		}
+		tenant.QuotaBytes, tenant.QuotaObjects = uint64(quotaBytes), uint64(quotaObjects)
+		config.Tenants = append(config.Tenants, tenant)
+	}
+
+	return nil
+}
+
+// saveIamEntities replaces all groups, roles, managed policies and tenants within the transaction
+func saveIamEntities(ctx context.Context, tx *sql.Tx, config *iam_pb.S3ApiConfiguration) error {
+	for _, table := range []string{"iam_groups", "iam_roles", "managed_policies", "iam_tenants"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	for _, group := range config.Groups {
+		membersJSON, err := json.Marshal(group.Members)
+		if err != nil {
+			return fmt.Errorf("failed to marshal members for group %s: %v", group.Name, err)
+		}
+		policyNamesJSON, err := json.Marshal(group.PolicyNames)
+		if err != nil {
+			return fmt.Errorf("failed to marshal policies for group %s: %v", group.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO iam_groups (name, members, policy_names, create_date) VALUES ($1, $2, $3, $4)",
+			group.Name, membersJSON, policyNamesJSON, group.CreateDate); err != nil {
+			return fmt.Errorf("failed to insert group %s: %v", group.Name, err)
+		}
+	}
+
+	for _, role := range config.Roles {
+		policyNamesJSON, err := json.Marshal(role.PolicyNames)
+		if err != nil {
+			return fmt.Errorf("failed to marshal policies for role %s: %v", role.Name, err)
+		}
+		tagsJSON, err := json.Marshal(role.Tags)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tags for role %s: %v", role.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO iam_roles (name, description, assume_role_policy_document, policy_names, tags, create_date) VALUES ($1, $2, $3, $4, $5, $6)",
+			role.Name, role.Description, role.AssumeRolePolicyDocument, policyNamesJSON, tagsJSON, role.CreateDate); err != nil {
+			return fmt.Errorf("failed to insert role %s: %v", role.Name, err)
+		}
+	}
+
+	for _, policy := range config.Policies {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO managed_policies (name, description, default_version_id, create_date) VALUES ($1, $2, $3, $4)",
+			policy.Name, policy.Description, policy.DefaultVersionId, policy.CreateDate); err != nil {
+			return fmt.Errorf("failed to insert managed policy %s: %v", policy.Name, err)
+		}
+		for _, version := range policy.Versions {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO managed_policy_versions (policy_name, version_id, document, create_date) VALUES ($1, $2, $3, $4)",
+				policy.Name, version.VersionId, version.Document, version.CreateDate); err != nil {
+				return fmt.Errorf("failed to insert version %s of managed policy %s: %v", version.VersionId, policy.Name, err)
+			}
+		}
+	}
+
+	for _, tenant := range config.Tenants {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO iam_tenants (name, max_buckets, quota_bytes, quota_objects, max_requests_per_second, create_date) VALUES ($1, $2, $3, $4, $5, $6)",
+			tenant.Name, tenant.MaxBuckets, int64(tenant.QuotaBytes), int64(tenant.QuotaObjects), tenant.MaxRequestsPerSecond, tenant.CreateDate); err != nil {
+			return fmt.Errorf("failed to insert tenant %s: %v", tenant.Name, err)
+		}
+	}
+
+	return nil
+}
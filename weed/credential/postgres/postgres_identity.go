@@ -18,7 +18,7 @@ func (store *PostgresStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 	config := &iam_pb.S3ApiConfiguration{}
 
 	// Query all users
-	rows, err := store.db.QueryContext(ctx, "SELECT username, email, account_data, actions FROM users")
+	rows, err := store.db.QueryContext(ctx, "SELECT username, email, account_data, actions, policy_names, tags, sftp, client_certificates, COALESCE(tenant, '') FROM users")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
@@ -26,14 +26,16 @@ func (store *PostgresStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 
 	for rows.Next() {
 		var username, email string
-		var accountDataJSON, actionsJSON []byte
+		var accountDataJSON, actionsJSON, policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON []byte
 
-		if err := rows.Scan(&username, &email, &accountDataJSON, &actionsJSON); err != nil {
+		var tenant string
+		if err := rows.Scan(&username, &email, &accountDataJSON, &actionsJSON, &policyNamesJSON, &tagsJSON, &sftpJSON, &clientCertificatesJSON, &tenant); err != nil {
 			return nil, fmt.Errorf("failed to scan user row: %w", err)
 		}
 
 		identity := &iam_pb.Identity{
-			Name: username,
+			Name:   username,
+			Tenant: tenant,
 		}
 
 		// Parse account data
@@ -50,6 +52,10 @@ func (store *PostgresStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 			}
 		}
 
+		if err := unmarshalIdentityAttributes(policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, identity); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal policies for user %s: %v", username, err)
+		}
+
 		// Query credentials for this user
 		credRows, err := store.db.QueryContext(ctx, "SELECT access_key, secret_key FROM credentials WHERE username = $1", username)
 		if err != nil {
@@ -73,6 +79,10 @@ func (store *PostgresStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 		config.Identities = append(config.Identities, identity)
 	}
 
+	if err := store.loadIamEntities(ctx, config); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -116,10 +126,15 @@ func (store *PostgresStore) SaveConfiguration(ctx context.Context, config *iam_p
 			}
 		}
 
+		policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, err := marshalIdentityAttributes(identity)
+		if err != nil {
+			return fmt.Errorf("failed to marshal policies for user %s: %v", identity.Name, err)
+		}
+
 		// Insert user
-		_, err := tx.ExecContext(ctx,
-			"INSERT INTO users (username, email, account_data, actions) VALUES ($1, $2, $3, $4)",
-			identity.Name, "", accountDataJSON, actionsJSON)
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO users (username, email, account_data, actions, policy_names, tags, sftp, client_certificates, tenant) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+			identity.Name, "", accountDataJSON, actionsJSON, policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, identity.Tenant)
 		if err != nil {
 			return fmt.Errorf("failed to insert user %s: %v", identity.Name, err)
 		}
@@ -135,6 +150,10 @@ func (store *PostgresStore) SaveConfiguration(ctx context.Context, config *iam_p
 		}
 	}
 
+	if err := saveIamEntities(ctx, tx, config); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -178,10 +197,15 @@ func (store *PostgresStore) CreateUser(ctx context.Context, identity *iam_pb.Ide
 		}
 	}
 
+	policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, err := marshalIdentityAttributes(identity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policies: %w", err)
+	}
+
 	// Insert user
 	_, err = tx.ExecContext(ctx,
-		"INSERT INTO users (username, email, account_data, actions) VALUES ($1, $2, $3, $4)",
-		identity.Name, "", accountDataJSON, actionsJSON)
+		"INSERT INTO users (username, email, account_data, actions, policy_names, tags, sftp, client_certificates, tenant) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		identity.Name, "", accountDataJSON, actionsJSON, policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, identity.Tenant)
 	if err != nil {
 		return fmt.Errorf("failed to insert user: %w", err)
 	}
@@ -204,12 +228,12 @@ func (store *PostgresStore) GetUser(ctx context.Context, username string) (*iam_
 		return nil, fmt.Errorf("store not configured")
 	}
 
-	var email string
-	var accountDataJSON, actionsJSON []byte
+	var email, tenant string
+	var accountDataJSON, actionsJSON, policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON []byte
 
 	err := store.db.QueryRowContext(ctx,
-		"SELECT email, account_data, actions FROM users WHERE username = $1",
-		username).Scan(&email, &accountDataJSON, &actionsJSON)
+		"SELECT email, account_data, actions, policy_names, tags, sftp, client_certificates, COALESCE(tenant, '') FROM users WHERE username = $1",
+		username).Scan(&email, &accountDataJSON, &actionsJSON, &policyNamesJSON, &tagsJSON, &sftpJSON, &clientCertificatesJSON, &tenant)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, credential.ErrUserNotFound
@@ -218,7 +242,8 @@ func (store *PostgresStore) GetUser(ctx context.Context, username string) (*iam_
 	}
 
 	identity := &iam_pb.Identity{
-		Name: username,
+		Name:   username,
+		Tenant: tenant,
 	}
 
 	// Parse account data
@@ -235,6 +260,10 @@ func (store *PostgresStore) GetUser(ctx context.Context, username string) (*iam_
 		}
 	}
 
+	if err := unmarshalIdentityAttributes(policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, identity); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policies: %w", err)
+	}
+
 	// Query credentials
 	rows, err := store.db.QueryContext(ctx, "SELECT access_key, secret_key FROM credentials WHERE username = $1", username)
 	if err != nil {
@@ -297,10 +326,15 @@ func (store *PostgresStore) UpdateUser(ctx context.Context, username string, ide
 		}
 	}
 
+	policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, err := marshalIdentityAttributes(identity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policies: %w", err)
+	}
+
 	// Update user
 	_, err = tx.ExecContext(ctx,
-		"UPDATE users SET email = $2, account_data = $3, actions = $4, updated_at = CURRENT_TIMESTAMP WHERE username = $1",
-		username, "", accountDataJSON, actionsJSON)
+		"UPDATE users SET email = $2, account_data = $3, actions = $4, policy_names = $5, tags = $6, sftp = $7, client_certificates = $8, tenant = $9, updated_at = CURRENT_TIMESTAMP WHERE username = $1",
+		username, "", accountDataJSON, actionsJSON, policyNamesJSON, tagsJSON, sftpJSON, clientCertificatesJSON, identity.Tenant)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
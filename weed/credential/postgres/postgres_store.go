@@ -93,9 +93,19 @@ func (store *PostgresStore) createTables() error {
 			email VARCHAR(255),
 			account_data JSONB,
 			actions JSONB,
+			policy_names JSONB,
+			tags JSONB,
+			sftp JSONB,
+			client_certificates JSONB,
+			tenant VARCHAR(255),
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS policy_names JSONB;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS tags JSONB;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS sftp JSONB;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS client_certificates JSONB;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant VARCHAR(255);
 		CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 	`
 
@@ -123,6 +133,45 @@ func (store *PostgresStore) createTables() error {
 		CREATE INDEX IF NOT EXISTS idx_policies_name ON policies(name);
 	`
 
+	// Create IAM groups, roles, versioned managed policies and tenants tables
+	iamTables := `
+		CREATE TABLE IF NOT EXISTS iam_groups (
+			name VARCHAR(255) PRIMARY KEY,
+			members JSONB,
+			policy_names JSONB,
+			create_date BIGINT NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS iam_roles (
+			name VARCHAR(255) PRIMARY KEY,
+			description TEXT,
+			assume_role_policy_document TEXT,
+			policy_names JSONB,
+			tags JSONB,
+			create_date BIGINT NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS managed_policies (
+			name VARCHAR(255) PRIMARY KEY,
+			description TEXT,
+			default_version_id VARCHAR(32) NOT NULL,
+			create_date BIGINT NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS managed_policy_versions (
+			policy_name VARCHAR(255) REFERENCES managed_policies(name) ON DELETE CASCADE,
+			version_id VARCHAR(32) NOT NULL,
+			document TEXT NOT NULL,
+			create_date BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (policy_name, version_id)
+		);
+		CREATE TABLE IF NOT EXISTS iam_tenants (
+			name VARCHAR(255) PRIMARY KEY,
+			max_buckets BIGINT NOT NULL DEFAULT 0,
+			quota_bytes BIGINT NOT NULL DEFAULT 0,
+			quota_objects BIGINT NOT NULL DEFAULT 0,
+			max_requests_per_second BIGINT NOT NULL DEFAULT 0,
+			create_date BIGINT NOT NULL DEFAULT 0
+		);
+	`
+
 	// Execute table creation
 	if _, err := store.db.Exec(usersTable); err != nil {
 		return fmt.Errorf("failed to create users table: %w", err)
@@ -136,6 +185,10 @@ func (store *PostgresStore) createTables() error {
 		return fmt.Errorf("failed to create policies table: %w", err)
 	}
 
+	if _, err := store.db.Exec(iamTables); err != nil {
+		return fmt.Errorf("failed to create iam tables: %w", err)
+	}
+
 	return nil
 }
 
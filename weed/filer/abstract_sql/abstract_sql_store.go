@@ -22,6 +22,7 @@ type SqlGenerator interface {
 	GetSqlDeleteFolderChildren(tableName string) string
 	GetSqlListExclusive(tableName string) string
 	GetSqlListInclusive(tableName string) string
+	GetSqlListKv(tableName string) string
 	GetSqlCreateTable(tableName string) string
 	GetSqlDropTable(tableName string) string
 }
@@ -344,7 +345,7 @@ func (store *AbstractSqlStore) Shutdown() {
 }
 
 func isValidBucket(bucket string) bool {
-	if s3bucket.VerifyS3BucketName(bucket) != nil {
+	if s3bucket.VerifyBucketFolderName(bucket) != nil {
 		return false
 	}
 	return bucket != DEFAULT_TABLE && bucket != ""
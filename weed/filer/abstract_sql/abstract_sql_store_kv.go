@@ -103,3 +103,54 @@ func GenDirAndName(key []byte) (dirStr string, dirHash int64, name string) {
 
 	return
 }
+
+func (store *AbstractSqlStore) KvList(ctx context.Context, eachKvFunc func(key, value []byte) error) error {
+
+	db, _, _, err := store.getTxOrDB(ctx, "", false)
+	if err != nil {
+		return fmt.Errorf("findDB: %w", err)
+	}
+
+	// the kv pairs are kept along with the entries, but their directory is not a path
+	rows, err := db.QueryContext(ctx, store.GetSqlListKv(DEFAULT_TABLE))
+	if err != nil {
+		return fmt.Errorf("kv list: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dirStr, name string
+		var value []byte
+		if err = rows.Scan(&dirStr, &name, &value); err != nil {
+			return fmt.Errorf("kv list scan: %w", err)
+		}
+		key, err := genKeyFromDirAndName(dirStr, name)
+		if err != nil {
+			glog.V(0).InfofCtx(ctx, "skip kv %s %s: %v", dirStr, name, err)
+			continue
+		}
+		if err = eachKvFunc(key, value); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// genKeyFromDirAndName reverses GenDirAndName
+func genKeyFromDirAndName(dirStr, name string) ([]byte, error) {
+	prefix, err := base64.StdEncoding.DecodeString(dirStr)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := base64.StdEncoding.DecodeString(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) == 0 {
+		// a short key is padded with zeros
+		for len(prefix) > 0 && prefix[len(prefix)-1] == 0 {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return append(prefix, rest...), nil
+}
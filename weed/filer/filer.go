@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3bucket"
@@ -37,23 +38,29 @@ var (
 )
 
 type Filer struct {
-	UniqueFilerId       int32
-	UniqueFilerEpoch    int32
-	Store               VirtualFilerStore
-	MasterClient        *wdclient.MasterClient
-	fileIdDeletionQueue *util.UnboundedQueue
-	GrpcDialOption      grpc.DialOption
-	DirBucketsPath      string
-	Cipher              bool
-	LocalMetaLogBuffer  *log_buffer.LogBuffer
-	metaLogCollection   string
-	metaLogReplication  string
-	MetaAggregator      *MetaAggregator
-	Signature           int32
-	FilerConf           *FilerConf
-	RemoteStorage       *FilerRemoteStorage
-	Dlm                 *lock_manager.DistributedLockManager
-	MaxFilenameLength   uint32
+	UniqueFilerId        int32
+	UniqueFilerEpoch     int32
+	Store                VirtualFilerStore
+	MasterClient         *wdclient.MasterClient
+	fileIdDeletionQueue  *util.UnboundedQueue
+	GrpcDialOption       grpc.DialOption
+	DirBucketsPath       string
+	Cipher               bool
+	LocalMetaLogBuffer   *log_buffer.LogBuffer
+	metaLogCollection    string
+	metaLogReplication   string
+	MetaAggregator       *MetaAggregator
+	Signature            int32
+	FilerConf            *FilerConf
+	RemoteStorage        *FilerRemoteStorage
+	SnapshotProtection   *SnapshotProtection
+	quota                *directoryQuota
+	chunkKeys            *ChunkKeys
+	audit                auditChain
+	chunkReferenceLock   sync.Mutex
+	snapshotDeferredLock sync.Mutex
+	Dlm                  *lock_manager.DistributedLockManager
+	MaxFilenameLength    uint32
 }
 
 func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerHost pb.ServerAddress, filerGroup string, collection string, replication string, dataCenter string, maxFilenameLength uint32, notifyFn func()) *Filer {
@@ -63,6 +70,8 @@ func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerH
 		GrpcDialOption:      grpcDialOption,
 		FilerConf:           NewFilerConf(),
 		RemoteStorage:       NewFilerRemoteStorage(),
+		SnapshotProtection:  NewSnapshotProtection(),
+		quota:               newDirectoryQuota(),
 		UniqueFilerId:       util.RandomInt32(),
 		Dlm:                 lock_manager.NewDistributedLockManager(filerHost),
 		MaxFilenameLength:   maxFilenameLength,
@@ -70,6 +79,7 @@ func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerH
 	if f.UniqueFilerId < 0 {
 		f.UniqueFilerId = -f.UniqueFilerId
 	}
+	f.chunkKeys = newChunkKeys(func() *FilerConf { return f.FilerConf })
 
 	f.LocalMetaLogBuffer = log_buffer.NewLogBuffer("local", LogFlushInterval, f.logFlushFunc, nil, notifyFn)
 	f.metaLogCollection = collection
@@ -138,7 +148,9 @@ func (f *Filer) ListExistingPeerUpdates(ctx context.Context) (existingNodes []*m
 }
 
 func (f *Filer) SetStore(store FilerStore) (isFresh bool) {
-	f.Store = NewFilerStoreWrapper(store)
+	storeWrapper := NewFilerStoreWrapper(store)
+	storeWrapper.chunkKeys = f.chunkKeys
+	f.Store = storeWrapper
 
 	return f.setOrLoadFilerStoreSignature(store)
 }
@@ -203,6 +215,13 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 
 	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
 
+	if !isFromOtherCluster {
+		if err := f.CheckQuota(ctx, oldEntry, entry); err != nil {
+			return err
+		}
+		f.AdvanceVectorClock(oldEntry, entry)
+	}
+
 	/*
 		if !hasWritePermission(lastDirectoryEntry, entry) {
 			glog.V(0).Infof("directory %s: %v, entry: uid=%d gid=%d",
@@ -239,7 +258,9 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 
 	f.NotifyUpdateEvent(ctx, oldEntry, entry, true, isFromOtherCluster, signatures)
 
-	f.deleteChunksIfNotNew(ctx, oldEntry, entry)
+	if isFromOtherCluster || !f.MaybeSaveVersion(ctx, oldEntry, entry) {
+		f.deleteChunksIfNotNew(ctx, oldEntry, entry)
+	}
 
 	glog.V(4).InfofCtx(ctx, "CreateEntry %s: created", entry.FullPath)
 
@@ -265,7 +286,7 @@ func (f *Filer) ensureParentDirectoryEntry(ctx context.Context, entry *Entry, di
 		// fmt.Printf("dirParts: %v %v %v\n", dirParts[0], dirParts[1], dirParts[2])
 		// dirParts[0] == "" and dirParts[1] == "buckets"
 		if len(dirParts) >= 3 && dirParts[1] == "buckets" {
-			if err := s3bucket.VerifyS3BucketName(dirParts[2]); err != nil {
+			if err := s3bucket.VerifyBucketFolderName(dirParts[2]); err != nil {
 				return fmt.Errorf("invalid bucket name %s: %v", dirParts[2], err)
 			}
 		}
@@ -378,6 +399,44 @@ func (f *Filer) doListDirectoryEntries(ctx context.Context, p util.FullPath, sta
 	return
 }
 
+// withClusterLock runs fn while holding the distributed lock of the key, so that the filers
+// sharing the store do not lose each other's read-modify-write of a key value pair.
+// The caller serializes its own goroutines, so only one of them waits for the lock of each filer.
+func (f *Filer) withClusterLock(key string, fn func()) {
+	if servers := f.Dlm.LockRing.GetSnapshot(); len(servers) <= 1 {
+		// no other filer writes to the store
+		fn()
+		return
+	}
+	lock := cluster.NewLockClient(f.GrpcDialOption, f.Dlm.Host).NewShortLivedLock(key, string(f.Dlm.Host))
+
+	// renew the lock while fn runs longer than the lock lasts
+	done, renewed := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := lock.AttemptToLock(5 * time.Second); err != nil {
+					glog.Warningf("renew lock %s: %v", key, err)
+				}
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		<-renewed
+		if err := lock.StopShortLivedLock(); err != nil {
+			glog.Warningf("unlock %s: %v", key, err)
+		}
+	}()
+	fn()
+}
+
 func (f *Filer) Shutdown() {
 	f.LocalMetaLogBuffer.ShutdownLogBuffer()
 	f.Store.Shutdown()
@@ -0,0 +1,58 @@
+package filer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+)
+
+// AccessRuleEveryone is the identity of the access rules for all clients, including anonymous ones
+const AccessRuleEveryone = "*"
+
+// ParseAccessRules parses access rules in the format "<identity>=<action>,<action>;<identity>=...",
+// e.g. "alice=read,write,delete;*=read"
+func ParseAccessRules(s string) (rules []*filer_pb.FilerConf_AccessRule, err error) {
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		identity, actions, found := strings.Cut(part, "=")
+		identity = strings.TrimSpace(identity)
+		if !found || identity == "" {
+			return nil, fmt.Errorf("access rule %q: expecting \"<identity>=<action>,...\"", part)
+		}
+		rule := &filer_pb.FilerConf_AccessRule{Identity: identity}
+		for _, action := range strings.Split(actions, ",") {
+			action = strings.TrimSpace(action)
+			switch action {
+			case security.FilerActionRead, security.FilerActionWrite, security.FilerActionDelete:
+				rule.Actions = append(rule.Actions, action)
+			default:
+				return nil, fmt.Errorf("access rule %q: unknown action %q", part, action)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// IsAccessAllowed checks the access rules of the location matching the path.
+// Locations without access rules allow everything; otherwise the identity,
+// empty for anonymous clients, needs a rule granting the action.
+// A directory is covered by the location of the same name, e.g. "/home/tenant1" by "/home/tenant1/".
+func (fc *FilerConf) IsAccessAllowed(path, identity, action string) bool {
+	rules := fc.MatchStorageRule(strings.TrimSuffix(path, "/") + "/").AccessRules
+	if len(rules) == 0 {
+		return true
+	}
+	for _, rule := range rules {
+		if (rule.Identity == AccessRuleEveryone || (identity != "" && rule.Identity == identity)) && slices.Contains(rule.Actions, action) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAccessRules(t *testing.T) {
+	rules, err := ParseAccessRules("alice=read,write,delete; *=read")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, "alice", rules[0].Identity)
+	assert.Equal(t, []string{"read", "write", "delete"}, rules[0].Actions)
+	assert.Equal(t, "*", rules[1].Identity)
+
+	rules, err = ParseAccessRules("")
+	assert.Nil(t, err)
+	assert.Nil(t, rules)
+
+	_, err = ParseAccessRules("alice=read,list")
+	assert.NotNil(t, err)
+	_, err = ParseAccessRules("alice")
+	assert.NotNil(t, err)
+}
+
+func TestIsAccessAllowed(t *testing.T) {
+	fc := NewFilerConf()
+	fc.doLoadConf(&filer_pb.FilerConf{Locations: []*filer_pb.FilerConf_PathConf{
+		{
+			LocationPrefix: "/home/",
+			AccessRules: []*filer_pb.FilerConf_AccessRule{
+				{Identity: "*", Actions: []string{"read"}},
+			},
+		},
+		{
+			LocationPrefix: "/home/alice/",
+			AccessRules: []*filer_pb.FilerConf_AccessRule{
+				{Identity: "alice", Actions: []string{"read", "write", "delete"}},
+				{Identity: "backup", Actions: []string{"read"}},
+			},
+		},
+		{
+			LocationPrefix: "/home/alice/tmp/",
+			Ttl:            "1d",
+		},
+	}})
+
+	// no access rules
+	assert.True(t, fc.IsAccessAllowed("/data/a.txt", "", "delete"))
+
+	// everyone
+	assert.True(t, fc.IsAccessAllowed("/home/bob/a.txt", "", "read"))
+	assert.False(t, fc.IsAccessAllowed("/home/bob/a.txt", "bob", "write"))
+
+	// the longest location with access rules wins
+	assert.True(t, fc.IsAccessAllowed("/home/alice/a.txt", "alice", "delete"))
+	assert.True(t, fc.IsAccessAllowed("/home/alice/a.txt", "backup", "read"))
+	assert.False(t, fc.IsAccessAllowed("/home/alice/a.txt", "backup", "write"))
+	assert.False(t, fc.IsAccessAllowed("/home/alice/a.txt", "", "read"))
+	assert.True(t, fc.IsAccessAllowed("/home/alice/tmp/b.txt", "alice", "write"))
+
+	// the directory itself
+	assert.False(t, fc.IsAccessAllowed("/home/alice", "bob", "read"))
+	assert.True(t, fc.IsAccessAllowed("/home/alice", "alice", "read"))
+}
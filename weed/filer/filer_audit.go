@@ -0,0 +1,89 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/audit"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const auditHeadKeyPrefix = "audit.head:"
+
+// auditChain is the last record of the audit chain of this filer, kept in the filer store across restarts
+type auditChain struct {
+	sync.Mutex
+	loaded   bool
+	sequence uint64
+	lastHash string
+}
+
+func AuditHeadKey(host string) []byte {
+	return []byte(auditHeadKeyPrefix + host)
+}
+
+// AppendAuditRecord chains the record after the last record of this filer,
+// and appends it as one json line to the audit log file of the day.
+// The filer sets the time, host, sequence and hashes, whatever the client sent.
+func (f *Filer) AppendAuditRecord(ctx context.Context, host string, record *filer_pb.AuditRecord) (*filer_pb.AuditRecord, error) {
+	f.audit.Lock()
+	defer f.audit.Unlock()
+
+	if !f.audit.loaded {
+		if err := f.loadAuditHead(ctx, host); err != nil {
+			return nil, fmt.Errorf("load audit chain: %w", err)
+		}
+	}
+
+	now := time.Now()
+	record = proto.Clone(record).(*filer_pb.AuditRecord)
+	record.TsNs = now.UnixNano()
+	record.Host = host
+	record.Sequence = f.audit.sequence + 1
+	record.PrevHash = f.audit.lastHash
+	record.Hash = audit.ComputeHash(record)
+
+	line, err := protojson.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.appendToFile(audit.LogFile(host, now), append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("append audit record: %w", err)
+	}
+
+	f.audit.sequence, f.audit.lastHash = record.Sequence, record.Hash
+	head := strconv.FormatUint(record.Sequence, 10) + " " + record.Hash
+	if err = f.Store.KvPut(ctx, AuditHeadKey(host), []byte(head)); err != nil {
+		// the record is stored, and the chain continues from memory until a restart
+		glog.Errorf("save audit chain head %s: %v", head, err)
+	}
+	return record, nil
+}
+
+func (f *Filer) loadAuditHead(ctx context.Context, host string) error {
+	value, err := f.Store.KvGet(ctx, AuditHeadKey(host))
+	if err == ErrKvNotFound || err == nil && len(value) == 0 {
+		f.audit.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	seq, hash, found := strings.Cut(string(value), " ")
+	if !found {
+		return fmt.Errorf("invalid audit chain head %q", value)
+	}
+	if f.audit.sequence, err = strconv.ParseUint(seq, 10, 64); err != nil {
+		return fmt.Errorf("invalid audit chain head %q: %w", value, err)
+	}
+	f.audit.lastHash = hash
+	f.audit.loaded = true
+	return nil
+}
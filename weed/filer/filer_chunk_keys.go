@@ -0,0 +1,330 @@
+package filer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/kms"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ChunkKeysExtendedEnvelope is set on stored entries whose chunk cipher keys are wrapped by a kms data key
+	ChunkKeysExtendedEnvelope = "chunk_keys.envelope"
+
+	chunkDataKeyReuse    = 10 * time.Minute
+	chunkDataKeyCacheTtl = 10 * time.Minute
+	chunkDataKeyCacheMax = 10000
+)
+
+var chunkKeysEncryptionContext = map[string]string{"seaweedfs:filer": "chunk-keys"}
+
+// chunkKeysEnvelope is the kms encrypted data key wrapping the chunk keys of an entry
+type chunkKeysEnvelope struct {
+	Provider string `json:"provider,omitempty"` // empty for the default kms provider
+	KeyId    string `json:"key_id"`
+	DataKey  []byte `json:"data_key"`
+}
+
+type chunkDataKey struct {
+	envelope  []byte
+	plaintext []byte
+	expireAt  time.Time
+}
+
+// ChunkKeys wraps the chunk cipher keys of the entries under locations with a kms key,
+// so that the filer store alone can not decrypt the chunks.
+// The entries are wrapped when stored and unwrapped when read, so the clients only see the plain keys.
+// The events of the metadata log, persisted on the volume servers, are wrapped the same way,
+// and unwrapped when sent to the metadata subscribers. The notification queue gets the plain keys.
+// One data key is reused for a while per kms key, and the unwrapped data keys are cached,
+// to avoid one kms request per entry.
+type ChunkKeys struct {
+	filerConf func() *FilerConf
+
+	mu          sync.Mutex
+	currentKeys map[string]*chunkDataKey // provider/key id -> data key for new entries
+	unwrapped   map[string]*chunkDataKey // envelope -> data key
+	getProvider func(name string) (kms.KMSProvider, error)
+	nowFunc     func() time.Time
+}
+
+func newChunkKeys(filerConf func() *FilerConf) *ChunkKeys {
+	return &ChunkKeys{
+		filerConf:   filerConf,
+		currentKeys: make(map[string]*chunkDataKey),
+		unwrapped:   make(map[string]*chunkDataKey),
+		getProvider: getKmsProvider,
+		nowFunc:     time.Now,
+	}
+}
+
+func getKmsProvider(name string) (kms.KMSProvider, error) {
+	if name == "" {
+		return kms.GetKMSManager().GetKMSProvider("")
+	}
+	return kms.GetKMSManager().GetKMSProviderByName(name)
+}
+
+// wrap returns the entry to store, with its chunk keys encrypted by the data key of the location's kms key.
+// The entry itself is not changed, since the caller keeps using the plain chunk keys.
+func (ck *ChunkKeys) wrap(ctx context.Context, entry *Entry) (*Entry, error) {
+	if ck == nil {
+		return entry, nil
+	}
+	_, hasEnvelope := entry.Extended[ChunkKeysExtendedEnvelope]
+	rule := ck.filerConf().MatchStorageRule(string(entry.FullPath))
+	if rule.KmsKeyId == "" || !hasCipherKeys(entry.GetChunks()) {
+		if !hasEnvelope {
+			return entry, nil
+		}
+		// never store an envelope not matching the chunk keys
+		stored := *entry
+		stored.Extended = maps.Clone(entry.Extended)
+		delete(stored.Extended, ChunkKeysExtendedEnvelope)
+		return &stored, nil
+	}
+
+	dataKey, err := ck.currentDataKey(ctx, rule.KmsProvider, rule.KmsKeyId)
+	if err != nil {
+		return nil, fmt.Errorf("wrap chunk keys of %s: %w", entry.FullPath, err)
+	}
+	stored := *entry
+	stored.Extended = maps.Clone(entry.Extended)
+	if stored.Extended == nil {
+		stored.Extended = make(map[string][]byte)
+	}
+	stored.Extended[ChunkKeysExtendedEnvelope] = dataKey.envelope
+	stored.Chunks = make([]*filer_pb.FileChunk, 0, len(entry.GetChunks()))
+	for _, chunk := range entry.GetChunks() {
+		if len(chunk.CipherKey) > 0 {
+			wrappedKey, err := util.Encrypt(chunk.CipherKey, dataKey.plaintext)
+			if err != nil {
+				return nil, fmt.Errorf("wrap chunk key of %s: %w", entry.FullPath, err)
+			}
+			chunk = proto.Clone(chunk).(*filer_pb.FileChunk)
+			chunk.CipherKey = wrappedKey
+		}
+		stored.Chunks = append(stored.Chunks, chunk)
+	}
+	return &stored, nil
+}
+
+// unwrap decrypts the chunk keys of an entry read from the store, in place
+func (ck *ChunkKeys) unwrap(ctx context.Context, entry *Entry) error {
+	if entry == nil {
+		return nil
+	}
+	envelope, found := entry.Extended[ChunkKeysExtendedEnvelope]
+	if !found {
+		return nil
+	}
+	if err := ck.unwrapChunks(ctx, envelope, entry.GetChunks()); err != nil {
+		return fmt.Errorf("unwrap chunk keys of %s: %w", entry.FullPath, err)
+	}
+	delete(entry.Extended, ChunkKeysExtendedEnvelope)
+	return nil
+}
+
+// UnwrapEventChunkKeys decrypts the chunk keys of the entries of a metadata log event, in place
+func (f *Filer) UnwrapEventChunkKeys(ctx context.Context, dir string, eventNotification *filer_pb.EventNotification) error {
+	for _, entry := range []*filer_pb.Entry{eventNotification.OldEntry, eventNotification.NewEntry} {
+		envelope, found := entry.GetExtended()[ChunkKeysExtendedEnvelope]
+		if !found {
+			continue
+		}
+		if err := f.chunkKeys.unwrapChunks(ctx, envelope, entry.GetChunks()); err != nil {
+			return fmt.Errorf("unwrap chunk keys of %s: %w", util.Join(dir, entry.Name), err)
+		}
+		delete(entry.Extended, ChunkKeysExtendedEnvelope)
+	}
+	return nil
+}
+
+// wrapEventEntry returns the entry to write into the metadata log, with its chunk keys wrapped like in the store.
+// The keys are dropped if they can not be wrapped, since the log must never hold them in plain.
+func (f *Filer) wrapEventEntry(ctx context.Context, entry *Entry) *Entry {
+	if entry == nil {
+		return nil
+	}
+	logged, err := f.chunkKeys.wrap(ctx, entry)
+	if err == nil {
+		return logged
+	}
+	glog.ErrorfCtx(ctx, "metadata log event without chunk keys: %v", err)
+	stripped := *entry
+	logged = &stripped
+	logged.Extended = maps.Clone(entry.Extended)
+	delete(logged.Extended, ChunkKeysExtendedEnvelope)
+	logged.Chunks = make([]*filer_pb.FileChunk, 0, len(entry.GetChunks()))
+	for _, chunk := range entry.GetChunks() {
+		if len(chunk.CipherKey) > 0 {
+			chunk = proto.Clone(chunk).(*filer_pb.FileChunk)
+			chunk.CipherKey = nil
+		}
+		logged.Chunks = append(logged.Chunks, chunk)
+	}
+	return logged
+}
+
+// unwrapChunks decrypts the chunk keys wrapped by the data key of the envelope, in place
+func (ck *ChunkKeys) unwrapChunks(ctx context.Context, envelope []byte, chunks []*filer_pb.FileChunk) error {
+	if ck == nil {
+		return fmt.Errorf("chunk keys are wrapped, but kms is not configured")
+	}
+	dataKey, err := ck.unwrapDataKey(ctx, envelope)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if len(chunk.CipherKey) == 0 {
+			continue
+		}
+		if chunk.CipherKey, err = util.Decrypt(chunk.CipherKey, dataKey); err != nil {
+			return fmt.Errorf("chunk %s: %w", chunk.GetFileIdString(), err)
+		}
+	}
+	return nil
+}
+
+// currentDataKey returns the data key for new entries under the kms key, generating one when expired
+func (ck *ChunkKeys) currentDataKey(ctx context.Context, providerName, keyId string) (*chunkDataKey, error) {
+	ck.mu.Lock()
+	defer ck.mu.Unlock()
+
+	cacheKey := providerName + "/" + keyId
+	if dataKey, found := ck.currentKeys[cacheKey]; found && ck.nowFunc().Before(dataKey.expireAt) {
+		return dataKey, nil
+	}
+	provider, err := ck.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := provider.GenerateDataKey(ctx, &kms.GenerateDataKeyRequest{
+		KeyID:             keyId,
+		KeySpec:           kms.KeySpecAES256,
+		EncryptionContext: chunkKeysEncryptionContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := json.Marshal(&chunkKeysEnvelope{
+		Provider: providerName,
+		KeyId:    resp.KeyID,
+		DataKey:  resp.CiphertextBlob,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dataKey := &chunkDataKey{
+		envelope:  envelope,
+		plaintext: resp.Plaintext,
+		expireAt:  ck.nowFunc().Add(chunkDataKeyReuse),
+	}
+	ck.currentKeys[cacheKey] = dataKey
+	ck.cacheUnwrapped(dataKey)
+	return dataKey, nil
+}
+
+// unwrapDataKey decrypts the data key of an envelope with the kms, or from the cache
+func (ck *ChunkKeys) unwrapDataKey(ctx context.Context, envelope []byte) ([]byte, error) {
+	ck.mu.Lock()
+	dataKey, found := ck.unwrapped[string(envelope)]
+	ck.mu.Unlock()
+	if found && ck.nowFunc().Before(dataKey.expireAt) {
+		return dataKey.plaintext, nil
+	}
+
+	var e chunkKeysEnvelope
+	if err := json.Unmarshal(envelope, &e); err != nil {
+		return nil, fmt.Errorf("parse envelope: %w", err)
+	}
+	provider, err := ck.getProvider(e.Provider)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := provider.Decrypt(ctx, &kms.DecryptRequest{
+		CiphertextBlob:    e.DataKey,
+		EncryptionContext: chunkKeysEncryptionContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ck.mu.Lock()
+	ck.cacheUnwrapped(&chunkDataKey{
+		envelope:  envelope,
+		plaintext: resp.Plaintext,
+		expireAt:  ck.nowFunc().Add(chunkDataKeyCacheTtl),
+	})
+	ck.mu.Unlock()
+	return resp.Plaintext, nil
+}
+
+// cacheUnwrapped keeps a data key, with the lock held
+func (ck *ChunkKeys) cacheUnwrapped(dataKey *chunkDataKey) {
+	if len(ck.unwrapped) >= chunkDataKeyCacheMax {
+		now := ck.nowFunc()
+		for envelope, cached := range ck.unwrapped {
+			if !now.Before(cached.expireAt) {
+				delete(ck.unwrapped, envelope)
+			}
+		}
+		if len(ck.unwrapped) >= chunkDataKeyCacheMax {
+			clear(ck.unwrapped)
+		}
+	}
+	ck.unwrapped[string(dataKey.envelope)] = dataKey
+}
+
+// RewrapChunkKeys stores again the files under the directory, so that their chunk keys are wrapped
+// by the current kms key of their location, or kept plain if the location has no kms key anymore.
+// Only the metadata is rewritten, the chunks stay as they are.
+func (f *Filer) RewrapChunkKeys(ctx context.Context, dir util.FullPath) (fileCount uint64, err error) {
+	lastFileName := ""
+	for {
+		var entries []*Entry
+		lastFileName, err = f.Store.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, func(entry *Entry) bool {
+			entries = append(entries, entry)
+			return true
+		})
+		if err != nil {
+			return fileCount, err
+		}
+		for _, entry := range entries {
+			if entry.IsDirectory() {
+				count, err := f.RewrapChunkKeys(ctx, entry.FullPath)
+				fileCount += count
+				if err != nil {
+					return fileCount, err
+				}
+				continue
+			}
+			if !hasCipherKeys(entry.GetChunks()) {
+				continue
+			}
+			if err = f.Store.UpdateEntry(ctx, entry); err != nil {
+				return fileCount, fmt.Errorf("rewrap %s: %w", entry.FullPath, err)
+			}
+			fileCount++
+		}
+		if len(entries) < PaginationSize {
+			return fileCount, nil
+		}
+	}
+}
+
+func hasCipherKeys(chunks []*filer_pb.FileChunk) bool {
+	for _, chunk := range chunks {
+		if len(chunk.CipherKey) > 0 {
+			return true
+		}
+	}
+	return false
+}
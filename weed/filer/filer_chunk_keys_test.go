@@ -0,0 +1,166 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/kms"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKmsProvider wraps data keys with in-memory master keys, counting the kms requests
+type testKmsProvider struct {
+	masterKeys map[string]util.CipherKey
+	requests   int
+}
+
+func (p *testKmsProvider) GenerateDataKey(ctx context.Context, req *kms.GenerateDataKeyRequest) (*kms.GenerateDataKeyResponse, error) {
+	p.requests++
+	masterKey, found := p.masterKeys[req.KeyID]
+	if !found {
+		return nil, fmt.Errorf("key %s not found", req.KeyID)
+	}
+	plaintext := util.GenCipherKey()
+	ciphertext, err := util.Encrypt(plaintext, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GenerateDataKeyResponse{KeyID: req.KeyID, Plaintext: plaintext, CiphertextBlob: append([]byte(req.KeyID+":"), ciphertext...)}, nil
+}
+
+func (p *testKmsProvider) Decrypt(ctx context.Context, req *kms.DecryptRequest) (*kms.DecryptResponse, error) {
+	p.requests++
+	for keyId, masterKey := range p.masterKeys {
+		prefix := []byte(keyId + ":")
+		if len(req.CiphertextBlob) > len(prefix) && string(req.CiphertextBlob[:len(prefix)]) == string(prefix) {
+			plaintext, err := util.Decrypt(req.CiphertextBlob[len(prefix):], masterKey)
+			return &kms.DecryptResponse{KeyID: keyId, Plaintext: plaintext}, err
+		}
+	}
+	return nil, fmt.Errorf("no key to decrypt")
+}
+
+func (p *testKmsProvider) DescribeKey(ctx context.Context, req *kms.DescribeKeyRequest) (*kms.DescribeKeyResponse, error) {
+	return &kms.DescribeKeyResponse{KeyID: req.KeyID}, nil
+}
+
+func (p *testKmsProvider) GetKeyID(ctx context.Context, keyIdentifier string) (string, error) {
+	return keyIdentifier, nil
+}
+
+func (p *testKmsProvider) Close() error {
+	return nil
+}
+
+func newTestChunkKeys(provider *testKmsProvider) *ChunkKeys {
+	fc := NewFilerConf()
+	fc.doLoadConf(&filer_pb.FilerConf{Locations: []*filer_pb.FilerConf_PathConf{
+		{LocationPrefix: "/home/tenant1/", KmsKeyId: "tenant1"},
+		{LocationPrefix: "/home/tenant2/", KmsKeyId: "tenant2"},
+	}})
+	ck := newChunkKeys(func() *FilerConf { return fc })
+	ck.getProvider = func(name string) (kms.KMSProvider, error) {
+		return provider, nil
+	}
+	return ck
+}
+
+func newEncryptedEntry(path string) *Entry {
+	return &Entry{
+		FullPath: util.FullPath(path),
+		Chunks: []*filer_pb.FileChunk{
+			{FileId: "1,01", Size: 10, CipherKey: util.GenCipherKey()},
+			{FileId: "1,02", Offset: 10, Size: 10, CipherKey: util.GenCipherKey()},
+		},
+	}
+}
+
+func TestChunkKeysWrapAndUnwrap(t *testing.T) {
+	provider := &testKmsProvider{masterKeys: map[string]util.CipherKey{"tenant1": util.GenCipherKey(), "tenant2": util.GenCipherKey()}}
+	ck := newTestChunkKeys(provider)
+	ctx := context.Background()
+
+	entry := newEncryptedEntry("/home/tenant1/a.txt")
+	plainKey := append([]byte(nil), entry.Chunks[0].CipherKey...)
+
+	stored, err := ck.wrap(ctx, entry)
+	assert.Nil(t, err)
+	assert.Contains(t, stored.Extended, ChunkKeysExtendedEnvelope)
+	assert.NotEqual(t, plainKey, []byte(stored.Chunks[0].CipherKey))
+	// the caller's entry keeps the plain keys
+	assert.Equal(t, plainKey, []byte(entry.Chunks[0].CipherKey))
+	assert.NotContains(t, entry.Extended, ChunkKeysExtendedEnvelope)
+
+	// the data key is reused and cached
+	_, err = ck.wrap(ctx, newEncryptedEntry("/home/tenant1/b.txt"))
+	assert.Nil(t, err)
+	assert.Nil(t, ck.unwrap(ctx, stored))
+	assert.Equal(t, 1, provider.requests)
+	assert.Equal(t, plainKey, []byte(stored.Chunks[0].CipherKey))
+	assert.NotContains(t, stored.Extended, ChunkKeysExtendedEnvelope)
+
+	// without the cache, the kms decrypts the data key
+	stored, _ = ck.wrap(ctx, entry)
+	clear(ck.unwrapped)
+	assert.Nil(t, ck.unwrap(ctx, stored))
+	assert.Equal(t, 2, provider.requests)
+	assert.Equal(t, plainKey, []byte(stored.Chunks[0].CipherKey))
+
+	// a missing master key can not unwrap
+	stored, _ = ck.wrap(ctx, entry)
+	clear(ck.unwrapped)
+	delete(provider.masterKeys, "tenant1")
+	assert.NotNil(t, ck.unwrap(ctx, stored))
+}
+
+func TestChunkKeysWithoutKmsKey(t *testing.T) {
+	provider := &testKmsProvider{masterKeys: map[string]util.CipherKey{"tenant1": util.GenCipherKey()}}
+	ck := newTestChunkKeys(provider)
+	ctx := context.Background()
+
+	entry := newEncryptedEntry("/data/a.txt")
+	stored, err := ck.wrap(ctx, entry)
+	assert.Nil(t, err)
+	assert.Equal(t, entry, stored)
+	assert.Equal(t, 0, provider.requests)
+
+	// a stale envelope is dropped
+	entry.Extended = map[string][]byte{ChunkKeysExtendedEnvelope: []byte("{}"), "other": []byte("x")}
+	stored, err = ck.wrap(ctx, entry)
+	assert.Nil(t, err)
+	assert.NotContains(t, stored.Extended, ChunkKeysExtendedEnvelope)
+	assert.Contains(t, stored.Extended, "other")
+	assert.Contains(t, entry.Extended, ChunkKeysExtendedEnvelope)
+
+	// wrapped keys need the kms
+	var noChunkKeys *ChunkKeys
+	wrapped, _ := ck.wrap(ctx, newEncryptedEntry("/home/tenant1/a.txt"))
+	assert.NotNil(t, noChunkKeys.unwrap(ctx, wrapped))
+}
+
+func TestChunkKeysEvent(t *testing.T) {
+	provider := &testKmsProvider{masterKeys: map[string]util.CipherKey{"tenant1": util.GenCipherKey()}}
+	f := &Filer{chunkKeys: newTestChunkKeys(provider)}
+	entry := newEncryptedEntry("/home/tenant1/a.txt")
+
+	// the logged event does not hold the plain chunk keys
+	logged := f.wrapEventEntry(context.Background(), entry)
+	eventNotification := &filer_pb.EventNotification{NewEntry: logged.ToProtoEntry()}
+	assert.Contains(t, eventNotification.NewEntry.Extended, ChunkKeysExtendedEnvelope)
+	assert.NotEqual(t, entry.Chunks[0].CipherKey, eventNotification.NewEntry.Chunks[0].CipherKey)
+
+	// the subscribers get them back
+	assert.NoError(t, f.UnwrapEventChunkKeys(context.Background(), "/home/tenant1", eventNotification))
+	assert.NotContains(t, eventNotification.NewEntry.Extended, ChunkKeysExtendedEnvelope)
+	assert.Equal(t, entry.Chunks[0].CipherKey, eventNotification.NewEntry.Chunks[0].CipherKey)
+	assert.Equal(t, entry.Chunks[1].CipherKey, eventNotification.NewEntry.Chunks[1].CipherKey)
+
+	// without the kms key, the keys are dropped rather than logged in plain
+	f.chunkKeys = newTestChunkKeys(&testKmsProvider{})
+	logged = f.wrapEventEntry(context.Background(), entry)
+	assert.Empty(t, logged.Chunks[0].CipherKey)
+	assert.NotEmpty(t, entry.Chunks[0].CipherKey)
+}
@@ -0,0 +1,181 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// a chunk shared by clones has one reference per entry beyond the first one,
+// so a deletion only frees the chunk once no other entry references it.
+const chunkReferenceKeyPrefix = "chunk.ref:"
+
+func ChunkReferenceKey(fileId string) []byte {
+	return []byte(chunkReferenceKeyPrefix + fileId)
+}
+
+// CloneStats counts what CloneTree has copied
+type CloneStats struct {
+	Files       uint64
+	Directories uint64
+	Bytes       uint64
+}
+
+// CloneTree copies the metadata of the file or directory tree at src to dst.
+// The copies share the chunks with the source, counting the extra references,
+// so deleting either side never frees the chunks the other side still uses.
+// A failed clone leaves the entries copied so far in place.
+func (f *Filer) CloneTree(ctx context.Context, src, dst util.FullPath, signatures []int32) (*CloneStats, error) {
+	if dst == src || dst.IsUnder(src) {
+		return nil, fmt.Errorf("can not clone %s into itself at %s", src, dst)
+	}
+	entry, err := f.FindEntry(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("find %s: %w", src, err)
+	}
+	if _, err = f.FindEntry(ctx, dst); err == nil {
+		return nil, fmt.Errorf("%s already exists", dst)
+	} else if err != filer_pb.ErrNotFound {
+		return nil, fmt.Errorf("find %s: %w", dst, err)
+	}
+
+	stats := &CloneStats{}
+	err = f.cloneEntry(ctx, entry, dst, signatures, stats)
+	return stats, err
+}
+
+func (f *Filer) cloneEntry(ctx context.Context, entry *Entry, dst util.FullPath, signatures []int32, stats *CloneStats) error {
+	clone := entry.ShallowClone()
+	clone.FullPath = dst
+	clone.Attr.Inode = 0
+	// the clone is a file of its own, not one more name of the hard link
+	clone.HardLinkId = nil
+	clone.HardLinkCounter = 0
+
+	if !entry.IsDirectory() {
+		fileIds, err := f.chunkFileIds(ctx, entry.GetChunks())
+		if err != nil {
+			return fmt.Errorf("resolve chunks of %s: %w", entry.FullPath, err)
+		}
+		if err = f.addChunkReferences(ctx, fileIds); err != nil {
+			return fmt.Errorf("reference chunks of %s: %w", entry.FullPath, err)
+		}
+		if err = f.CreateEntry(ctx, clone, true, false, signatures, false, f.MaxFilenameLength); err != nil {
+			f.releaseChunkReferences(ctx, fileIds)
+			return fmt.Errorf("create %s: %w", dst, err)
+		}
+		stats.Files++
+		stats.Bytes += entry.Size()
+		return nil
+	}
+
+	if err := f.CreateEntry(ctx, clone, true, false, signatures, false, f.MaxFilenameLength); err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	stats.Directories++
+
+	lastFileName := ""
+	for {
+		children, _, err := f.ListDirectoryEntries(ctx, entry.FullPath, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			return fmt.Errorf("list %s: %w", entry.FullPath, err)
+		}
+		for _, child := range children {
+			lastFileName = child.Name()
+			if err = f.cloneEntry(ctx, child, dst.Child(child.Name()), signatures, stats); err != nil {
+				return err
+			}
+		}
+		if len(children) < PaginationSize {
+			return nil
+		}
+	}
+}
+
+// chunkFileIds lists the data chunks and the manifest chunks, as the deletion of the entry would
+func (f *Filer) chunkFileIds(ctx context.Context, chunks []*filer_pb.FileChunk) (fileIds []string, err error) {
+	dataChunks, manifestChunks, err := ResolveChunkManifest(ctx, f.MasterClient.GetLookupFileIdFunction(), chunks, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range dataChunks {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	for _, chunk := range manifestChunks {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	return fileIds, nil
+}
+
+func (f *Filer) readChunkReference(ctx context.Context, fileId string) (uint64, error) {
+	value, err := f.Store.KvGet(ctx, ChunkReferenceKey(fileId))
+	if err == ErrKvNotFound || err == nil && len(value) == 0 {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(value), 10, 64)
+}
+
+func (f *Filer) writeChunkReference(ctx context.Context, fileId string, count uint64) error {
+	if count == 0 {
+		return f.Store.KvDelete(ctx, ChunkReferenceKey(fileId))
+	}
+	return f.Store.KvPut(ctx, ChunkReferenceKey(fileId), []byte(strconv.FormatUint(count, 10)))
+}
+
+// addChunkReferences counts one more reference of each chunk.
+// The counts are shared by all filers of the store, so they are only changed under the cluster lock.
+func (f *Filer) addChunkReferences(ctx context.Context, fileIds []string) (err error) {
+	f.chunkReferenceLock.Lock()
+	defer f.chunkReferenceLock.Unlock()
+	f.withClusterLock(chunkReferenceKeyPrefix, func() {
+		for i, fileId := range fileIds {
+			var count uint64
+			count, err = f.readChunkReference(ctx, fileId)
+			if err == nil {
+				err = f.writeChunkReference(ctx, fileId, count+1)
+			}
+			if err != nil {
+				f.doReleaseChunkReferences(ctx, fileIds[:i])
+				return
+			}
+		}
+	})
+	return
+}
+
+// releaseChunkReferences drops one reference of each chunk, and returns the chunks no other entry references
+func (f *Filer) releaseChunkReferences(ctx context.Context, fileIds []string) (unreferenced []string) {
+	f.chunkReferenceLock.Lock()
+	defer f.chunkReferenceLock.Unlock()
+	f.withClusterLock(chunkReferenceKeyPrefix, func() {
+		unreferenced = f.doReleaseChunkReferences(ctx, fileIds)
+	})
+	return
+}
+
+func (f *Filer) doReleaseChunkReferences(ctx context.Context, fileIds []string) (unreferenced []string) {
+	for _, fileId := range fileIds {
+		count, err := f.readChunkReference(ctx, fileId)
+		if err != nil {
+			// rather leak the chunk than free one that may still be in use
+			glog.ErrorfCtx(ctx, "read references of chunk %s: %v", fileId, err)
+			continue
+		}
+		if count == 0 {
+			unreferenced = append(unreferenced, fileId)
+			continue
+		}
+		if err = f.writeChunkReference(ctx, fileId, count-1); err != nil {
+			glog.ErrorfCtx(ctx, "release chunk %s: %v", fileId, err)
+		}
+	}
+	return
+}
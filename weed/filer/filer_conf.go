@@ -196,6 +196,28 @@ func mergePathConf(a, b *filer_pb.FilerConf_PathConf) {
 	if b.WormGracePeriodSeconds > 0 {
 		a.WormGracePeriodSeconds = b.WormGracePeriodSeconds
 	}
+	if b.QuotaBytes > 0 {
+		a.QuotaBytes = b.QuotaBytes
+	}
+	if b.QuotaInodes > 0 {
+		a.QuotaInodes = b.QuotaInodes
+	}
+	a.Versioning = b.Versioning || a.Versioning
+	if b.VersionRetentionCount > 0 {
+		a.VersionRetentionCount = b.VersionRetentionCount
+	}
+	if b.VersionRetentionSeconds > 0 {
+		a.VersionRetentionSeconds = b.VersionRetentionSeconds
+	}
+	a.SyncVectorClock = b.SyncVectorClock || a.SyncVectorClock
+	// the access rules of the longest matching location replace the shorter ones
+	if len(b.AccessRules) > 0 {
+		a.AccessRules = b.AccessRules
+	}
+	if b.KmsKeyId != "" {
+		a.KmsProvider = b.KmsProvider
+		a.KmsKeyId = b.KmsKeyId
+	}
 }
 
 func (fc *FilerConf) ToProto() *filer_pb.FilerConf {
@@ -52,7 +52,7 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p util.FullPath, isR
 		return fmt.Errorf("delete file %s: %v", p, err)
 	}
 
-	if shouldDeleteChunks && !isDeleteCollection {
+	if shouldDeleteChunks && !isDeleteCollection && (isFromOtherCluster || !f.MaybeSaveVersion(ctx, entry, nil)) {
 		f.DeleteChunks(ctx, p, entry.GetChunks())
 	}
 
@@ -94,7 +94,7 @@ func (f *Filer) doBatchDeleteFolderMetaAndData(ctx context.Context, entry *Entry
 						// hard link chunk data are deleted separately
 						err = onHardLinkIdsFn([]HardLinkId{sub.HardLinkId})
 					} else {
-						if shouldDeleteChunks {
+						if shouldDeleteChunks && (isFromOtherCluster || !f.MaybeSaveVersion(ctx, sub, nil)) {
 							chunksToDelete = append(chunksToDelete, sub.GetChunks()...)
 						}
 					}
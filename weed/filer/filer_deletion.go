@@ -38,33 +38,12 @@ func LookupByMasterClientFn(masterClient *wdclient.MasterClient) func(vids []str
 
 func (f *Filer) loopProcessingDeletion() {
 
-	lookupFunc := LookupByMasterClientFn(f.MasterClient)
-
-	DeletionBatchSize := 100000 // roughly 20 bytes cost per file id.
-
 	var deletionCount int
 	for {
 		deletionCount = 0
 		f.fileIdDeletionQueue.Consume(func(fileIds []string) {
-			for len(fileIds) > 0 {
-				var toDeleteFileIds []string
-				if len(fileIds) > DeletionBatchSize {
-					toDeleteFileIds = fileIds[:DeletionBatchSize]
-					fileIds = fileIds[DeletionBatchSize:]
-				} else {
-					toDeleteFileIds = fileIds
-					fileIds = fileIds[:0]
-				}
-				deletionCount = len(toDeleteFileIds)
-				_, err := operation.DeleteFileIdsWithLookupVolumeId(f.GrpcDialOption, toDeleteFileIds, lookupFunc)
-				if err != nil {
-					if !strings.Contains(err.Error(), storage.ErrorDeleted.Error()) {
-						glog.V(0).Infof("deleting fileIds len=%d error: %v", deletionCount, err)
-					}
-				} else {
-					glog.V(2).Infof("deleting fileIds %+v", toDeleteFileIds)
-				}
-			}
+			fileIds = f.releaseChunkReferences(context.Background(), fileIds)
+			deletionCount = f.deleteFileIds(context.Background(), fileIds)
 		})
 
 		if deletionCount == 0 {
@@ -73,6 +52,49 @@ func (f *Filer) loopProcessingDeletion() {
 	}
 }
 
+// deleteFileIds deletes the chunks, except the ones a snapshot still references, which are kept in the store
+// until the snapshot is deleted. It returns the size of the last deletion batch.
+func (f *Filer) deleteFileIds(ctx context.Context, fileIds []string) (deletionCount int) {
+	if len(fileIds) == 0 {
+		return
+	}
+	if err := f.loadSnapshotProtection(ctx); err != nil {
+		// the snapshots are unknown, so keep everything until the next reload checks again
+		glog.ErrorfCtx(ctx, "check snapshots before deleting %d chunks: %v", len(fileIds), err)
+		f.deferFileIds(ctx, fileIds)
+		return
+	}
+	fileIds, protectedFileIds := f.SnapshotProtection.FilterUnprotected(fileIds)
+	if len(protectedFileIds) > 0 {
+		f.deferFileIds(ctx, protectedFileIds)
+	}
+
+	lookupFunc := LookupByMasterClientFn(f.MasterClient)
+
+	DeletionBatchSize := 100000 // roughly 20 bytes cost per file id.
+
+	for len(fileIds) > 0 {
+		var toDeleteFileIds []string
+		if len(fileIds) > DeletionBatchSize {
+			toDeleteFileIds = fileIds[:DeletionBatchSize]
+			fileIds = fileIds[DeletionBatchSize:]
+		} else {
+			toDeleteFileIds = fileIds
+			fileIds = fileIds[:0]
+		}
+		deletionCount = len(toDeleteFileIds)
+		_, err := operation.DeleteFileIdsWithLookupVolumeId(f.GrpcDialOption, toDeleteFileIds, lookupFunc)
+		if err != nil {
+			if !strings.Contains(err.Error(), storage.ErrorDeleted.Error()) {
+				glog.V(0).Infof("deleting fileIds len=%d error: %v", deletionCount, err)
+			}
+		} else {
+			glog.V(2).Infof("deleting fileIds %+v", toDeleteFileIds)
+		}
+	}
+	return
+}
+
 func (f *Filer) DeleteUncommittedChunks(ctx context.Context, chunks []*filer_pb.FileChunk) {
 	f.doDeleteChunks(ctx, chunks)
 }
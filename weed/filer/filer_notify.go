@@ -32,6 +32,9 @@ func (f *Filer) NotifyUpdateEvent(ctx context.Context, oldEntry, newEntry *Entry
 	if strings.HasPrefix(fullpath, SystemLogDir) {
 		return
 	}
+
+	f.updateQuotaUsage(ctx, oldEntry, newEntry)
+
 	foundSelf := false
 	for _, sig := range signatures {
 		if sig == f.Signature {
@@ -63,6 +66,19 @@ func (f *Filer) NotifyUpdateEvent(ctx context.Context, oldEntry, newEntry *Entry
 		}
 	}
 
+	// the metadata log is persisted on the volume servers, so it keeps the chunk keys wrapped like the store
+	loggedOldEntry, loggedNewEntry := f.wrapEventEntry(ctx, oldEntry), f.wrapEventEntry(ctx, newEntry)
+	if loggedOldEntry != oldEntry || loggedNewEntry != newEntry {
+		eventNotification = &filer_pb.EventNotification{
+			OldEntry:           loggedOldEntry.ToProtoEntry(),
+			NewEntry:           loggedNewEntry.ToProtoEntry(),
+			DeleteChunks:       deleteChunks,
+			NewParentPath:      newParentPath,
+			IsFromOtherCluster: isFromOtherCluster,
+			Signatures:         signatures,
+		}
+	}
+
 	f.logMetaEvent(ctx, fullpath, eventNotification)
 
 }
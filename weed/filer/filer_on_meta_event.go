@@ -12,6 +12,7 @@ import (
 func (f *Filer) onMetadataChangeEvent(event *filer_pb.SubscribeMetadataResponse) {
 	f.maybeReloadFilerConfiguration(event)
 	f.maybeReloadRemoteStorageConfigurationAndMapping(event)
+	f.maybeReloadSnapshotProtection(event)
 	f.onBucketEvents(event)
 }
 
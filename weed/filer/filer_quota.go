@@ -0,0 +1,286 @@
+package filer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const quotaUsageKeyPrefix = "quota.usage:"
+
+// ErrQuotaExceeded is returned when a change would take a directory over its quota.
+// It reaches the clients as text over grpc and http, so match it with IsQuotaExceeded.
+var ErrQuotaExceeded = errors.New("EDQUOT: directory quota exceeded")
+
+func IsQuotaExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrQuotaExceeded.Error())
+}
+
+// QuotaUsage is the space and the number of entries used under a quota location prefix
+type QuotaUsage struct {
+	Bytes  int64
+	Inodes int64
+}
+
+func QuotaUsageKey(locationPrefix string) []byte {
+	return []byte(quotaUsageKeyPrefix + locationPrefix)
+}
+
+func (u *QuotaUsage) ToBytes() []byte {
+	b := make([]byte, 16)
+	util.Uint64toBytes(b[0:8], uint64(u.Bytes))
+	util.Uint64toBytes(b[8:16], uint64(u.Inodes))
+	return b
+}
+
+func QuotaUsageFromBytes(b []byte) (*QuotaUsage, error) {
+	if len(b) != 16 {
+		return nil, errors.New("invalid quota usage")
+	}
+	return &QuotaUsage{
+		Bytes:  int64(util.BytesToUint64(b[0:8])),
+		Inodes: int64(util.BytesToUint64(b[8:16])),
+	}, nil
+}
+
+func hasQuota(rule *filer_pb.FilerConf_PathConf) bool {
+	return rule.QuotaBytes > 0 || rule.QuotaInodes > 0
+}
+
+// MatchQuotaRules returns every rule with a quota that covers the path, parents before children
+func (fc *FilerConf) MatchQuotaRules(path string) (rules []*filer_pb.FilerConf_PathConf) {
+	fc.rules.MatchPrefix([]byte(path), func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if hasQuota(value) {
+			rules = append(rules, value)
+		}
+		return true
+	})
+	return
+}
+
+// directoryQuota serializes the usage counter updates of this filer,
+// and tracks the counters being rebuilt by a directory scan.
+type directoryQuota struct {
+	sync.Mutex
+	scanning map[string]struct{}
+}
+
+func newDirectoryQuota() *directoryQuota {
+	return &directoryQuota{
+		scanning: make(map[string]struct{}),
+	}
+}
+
+func quotaEntrySize(entry *Entry) (bytes int64, inodes int64) {
+	if entry == nil {
+		return 0, 0
+	}
+	if entry.IsDirectory() {
+		return 0, 1
+	}
+	return int64(entry.Size()), 1
+}
+
+// CheckQuota fails with ErrQuotaExceeded if replacing oldEntry with newEntry at the same path
+// would take any quota directory over its limits. Changes that do not grow the usage always pass.
+func (f *Filer) CheckQuota(ctx context.Context, oldEntry, newEntry *Entry) error {
+	if newEntry == nil || f.FilerConf == nil {
+		return nil
+	}
+	rules := f.FilerConf.MatchQuotaRules(string(newEntry.FullPath))
+	if len(rules) == 0 {
+		return nil
+	}
+
+	newBytes, newInodes := quotaEntrySize(newEntry)
+	oldBytes, oldInodes := quotaEntrySize(oldEntry)
+	deltaBytes, deltaInodes := newBytes-oldBytes, newInodes-oldInodes
+	if deltaBytes <= 0 && deltaInodes <= 0 {
+		return nil
+	}
+
+	for _, rule := range rules {
+		usage, found := f.readQuotaUsage(ctx, rule.LocationPrefix)
+		if !found {
+			// the usage is still being counted, do not block writes meanwhile
+			continue
+		}
+		if deltaBytes > 0 && rule.QuotaBytes > 0 && usage.Bytes+deltaBytes > int64(rule.QuotaBytes) {
+			glog.V(1).InfofCtx(ctx, "%s: %s uses %d of %d bytes", newEntry.FullPath, rule.LocationPrefix, usage.Bytes, rule.QuotaBytes)
+			return ErrQuotaExceeded
+		}
+		if deltaInodes > 0 && rule.QuotaInodes > 0 && usage.Inodes+deltaInodes > int64(rule.QuotaInodes) {
+			glog.V(1).InfofCtx(ctx, "%s: %s uses %d of %d inodes", newEntry.FullPath, rule.LocationPrefix, usage.Inodes, rule.QuotaInodes)
+			return ErrQuotaExceeded
+		}
+	}
+	return nil
+}
+
+// readQuotaUsage returns the stored usage, or starts counting it if there is none yet
+func (f *Filer) readQuotaUsage(ctx context.Context, locationPrefix string) (*QuotaUsage, bool) {
+	value, err := f.Store.KvGet(ctx, QuotaUsageKey(locationPrefix))
+	if err == nil {
+		if usage, parseErr := QuotaUsageFromBytes(value); parseErr == nil {
+			return usage, true
+		}
+	} else if err != ErrKvNotFound {
+		glog.Errorf("read quota usage of %s: %v", locationPrefix, err)
+		return nil, false
+	}
+	f.startQuotaUsageScan(locationPrefix)
+	return nil, false
+}
+
+// updateQuotaUsage adds the difference between oldEntry and newEntry to the usage of every quota
+// directory covering their paths. A rename across quota directories moves the usage between them.
+func (f *Filer) updateQuotaUsage(ctx context.Context, oldEntry, newEntry *Entry) {
+	fc := f.FilerConf
+	if fc == nil {
+		return
+	}
+	deltas := make(map[string]*QuotaUsage)
+	addDelta := func(entry *Entry, sign int64) {
+		if entry == nil {
+			return
+		}
+		bytes, inodes := quotaEntrySize(entry)
+		for _, rule := range fc.MatchQuotaRules(string(entry.FullPath)) {
+			delta, found := deltas[rule.LocationPrefix]
+			if !found {
+				delta = &QuotaUsage{}
+				deltas[rule.LocationPrefix] = delta
+			}
+			delta.Bytes += sign * bytes
+			delta.Inodes += sign * inodes
+		}
+	}
+	addDelta(oldEntry, -1)
+	addDelta(newEntry, 1)
+
+	if oldEntry != nil && newEntry == nil && oldEntry.IsDirectory() {
+		// the children of a dropped bucket are deleted without events, so recount anything below
+		f.resetQuotaUsageUnder(ctx, fc, oldEntry.FullPath)
+	}
+
+	if len(deltas) == 0 {
+		return
+	}
+
+	f.quota.Lock()
+	defer f.quota.Unlock()
+	// the filers sharing the store update the same usage
+	f.withClusterLock(quotaUsageKeyPrefix, func() {
+		for locationPrefix, delta := range deltas {
+			if delta.Bytes == 0 && delta.Inodes == 0 {
+				continue
+			}
+			if _, found := f.quota.scanning[locationPrefix]; found {
+				continue
+			}
+			key := QuotaUsageKey(locationPrefix)
+			value, err := f.Store.KvGet(ctx, key)
+			if err != nil {
+				// an unknown usage is counted from scratch on the next check
+				continue
+			}
+			usage, err := QuotaUsageFromBytes(value)
+			if err != nil {
+				continue
+			}
+			usage.Bytes = max(usage.Bytes+delta.Bytes, 0)
+			usage.Inodes = max(usage.Inodes+delta.Inodes, 0)
+			if err := f.Store.KvPut(ctx, key, usage.ToBytes()); err != nil {
+				glog.Errorf("update quota usage of %s: %v", locationPrefix, err)
+			}
+		}
+	})
+}
+
+func (f *Filer) resetQuotaUsageUnder(ctx context.Context, fc *FilerConf, dir util.FullPath) {
+	prefix := string(dir) + "/"
+	fc.rules.Walk(func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if hasQuota(value) && strings.HasPrefix(value.LocationPrefix, prefix) {
+			if err := f.Store.KvDelete(ctx, QuotaUsageKey(value.LocationPrefix)); err != nil {
+				glog.Errorf("reset quota usage of %s: %v", value.LocationPrefix, err)
+			}
+		}
+		return true
+	})
+}
+
+func (f *Filer) startQuotaUsageScan(locationPrefix string) {
+	f.quota.Lock()
+	if _, found := f.quota.scanning[locationPrefix]; found {
+		f.quota.Unlock()
+		return
+	}
+	f.quota.scanning[locationPrefix] = struct{}{}
+	f.quota.Unlock()
+
+	go func() {
+		defer func() {
+			f.quota.Lock()
+			delete(f.quota.scanning, locationPrefix)
+			f.quota.Unlock()
+		}()
+		ctx := context.Background()
+		usage, err := f.scanQuotaUsage(ctx, locationPrefix)
+		if err != nil {
+			glog.Errorf("count quota usage of %s: %v", locationPrefix, err)
+			return
+		}
+		if err := f.Store.KvPut(ctx, QuotaUsageKey(locationPrefix), usage.ToBytes()); err != nil {
+			glog.Errorf("save quota usage of %s: %v", locationPrefix, err)
+			return
+		}
+		glog.V(0).Infof("quota %s uses %d bytes in %d entries", locationPrefix, usage.Bytes, usage.Inodes)
+	}()
+}
+
+// scanQuotaUsage counts all entries whose path starts with the location prefix
+func (f *Filer) scanQuotaUsage(ctx context.Context, locationPrefix string) (*QuotaUsage, error) {
+	usage := &QuotaUsage{}
+	dir, namePrefix := util.FullPath(locationPrefix).DirAndName()
+	err := f.walkQuotaUsage(ctx, util.FullPath(dir), namePrefix, usage)
+	return usage, err
+}
+
+func (f *Filer) walkQuotaUsage(ctx context.Context, dir util.FullPath, namePrefix string, usage *QuotaUsage) error {
+	var subDirs []util.FullPath
+	lastFileName := ""
+	for {
+		count := 0
+		var err error
+		lastFileName, err = f.StreamListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, namePrefix, "", "", func(entry *Entry) bool {
+			count++
+			bytes, inodes := quotaEntrySize(entry)
+			usage.Bytes += bytes
+			usage.Inodes += inodes
+			if entry.IsDirectory() {
+				subDirs = append(subDirs, entry.FullPath)
+			}
+			return true
+		})
+		if err != nil && err != filer_pb.ErrNotFound {
+			return err
+		}
+		if count < PaginationSize {
+			break
+		}
+	}
+	for _, subDir := range subDirs {
+		if subDir == util.FullPath(SystemLogDir) {
+			continue
+		}
+		if err := f.walkQuotaUsage(ctx, subDir, "", usage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
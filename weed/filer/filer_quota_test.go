@@ -0,0 +1,41 @@
+package filer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchQuotaRules(t *testing.T) {
+	fc := NewFilerConf()
+	fc.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/", QuotaBytes: 1000})
+	fc.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/team1/", QuotaInodes: 10})
+	fc.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/team1/tmp/", Collection: "tmp"})
+
+	rules := fc.MatchQuotaRules("/home/team1/tmp/a.txt")
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, "/home/", rules[0].LocationPrefix)
+	assert.Equal(t, "/home/team1/", rules[1].LocationPrefix)
+
+	assert.Equal(t, 1, len(fc.MatchQuotaRules("/home/team2/a.txt")))
+	assert.Equal(t, 0, len(fc.MatchQuotaRules("/data/a.txt")))
+}
+
+func TestQuotaUsageBytes(t *testing.T) {
+	usage := &QuotaUsage{Bytes: 123456789, Inodes: 42}
+	decoded, err := QuotaUsageFromBytes(usage.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, usage, decoded)
+
+	_, err = QuotaUsageFromBytes(nil)
+	assert.NotNil(t, err)
+}
+
+func TestIsQuotaExceeded(t *testing.T) {
+	assert.True(t, IsQuotaExceeded(ErrQuotaExceeded))
+	assert.True(t, IsQuotaExceeded(fmt.Errorf("CreateEntry : %v", ErrQuotaExceeded)))
+	assert.False(t, IsQuotaExceeded(fmt.Errorf("CreateEntry : entry already exists")))
+	assert.False(t, IsQuotaExceeded(nil))
+}
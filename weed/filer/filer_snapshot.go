@@ -0,0 +1,292 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SnapshotsDir keeps one file per metadata snapshot, in the same format as fs.meta.save
+	SnapshotsDir = "/etc/seaweedfs/snapshots"
+
+	SnapshotExtendedPath = "snapshot.path"
+	SnapshotExtendedTsNs = "snapshot.tsNs"
+)
+
+// SnapshotInfo describes one metadata snapshot stored in SnapshotsDir
+type SnapshotInfo struct {
+	Name string
+	Path string
+	TsNs int64
+	Size uint64
+}
+
+func NewSnapshotInfo(entry *filer_pb.Entry) (*SnapshotInfo, error) {
+	if entry.IsDirectory || entry.Extended == nil {
+		return nil, fmt.Errorf("%s is not a snapshot", entry.Name)
+	}
+	path, found := entry.Extended[SnapshotExtendedPath]
+	if !found {
+		return nil, fmt.Errorf("%s has no snapshot path", entry.Name)
+	}
+	tsNs, err := strconv.ParseInt(string(entry.Extended[SnapshotExtendedTsNs]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s has invalid snapshot time: %v", entry.Name, err)
+	}
+	return &SnapshotInfo{
+		Name: entry.Name,
+		Path: string(path),
+		TsNs: tsNs,
+		Size: FileSize(entry),
+	}, nil
+}
+
+// ReadSnapshotEntries parses a size-prefixed stream of FullEntry messages, as written by fs.meta.save and fs.snapshot.create
+func ReadSnapshotEntries(reader io.Reader, eachEntryFn func(fullEntry *filer_pb.FullEntry) error) error {
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(reader, sizeBuf); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		data := make([]byte, util.BytesToUint32(sizeBuf))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return err
+		}
+		fullEntry := &filer_pb.FullEntry{}
+		if err := proto.Unmarshal(data, fullEntry); err != nil {
+			return err
+		}
+		if err := eachEntryFn(fullEntry); err != nil {
+			return err
+		}
+	}
+}
+
+// SnapshotProtection tracks the chunks referenced by retained snapshots, so that deleting
+// the live entries does not free data that a snapshot restore still needs.
+// It is loaded from the snapshots in the store, and reloaded whenever they change.
+type SnapshotProtection struct {
+	sync.RWMutex
+	fileIds   map[string]struct{}
+	snapshots map[string]int64 // the modification time of each loaded snapshot, nil before the first load
+	loadLock  sync.Mutex
+}
+
+func NewSnapshotProtection() *SnapshotProtection {
+	return &SnapshotProtection{
+		fileIds: make(map[string]struct{}),
+	}
+}
+
+// FilterUnprotected splits the file ids into the ones not referenced by any snapshot and the protected ones
+func (sp *SnapshotProtection) FilterUnprotected(fileIds []string) (unprotected, protected []string) {
+	sp.RLock()
+	defer sp.RUnlock()
+	if len(sp.fileIds) == 0 {
+		return fileIds, nil
+	}
+	for _, fileId := range fileIds {
+		if _, found := sp.fileIds[fileId]; found {
+			protected = append(protected, fileId)
+		} else {
+			unprotected = append(unprotected, fileId)
+		}
+	}
+	return
+}
+
+func (sp *SnapshotProtection) isLoaded(snapshots map[string]int64) bool {
+	sp.RLock()
+	defer sp.RUnlock()
+	return sp.snapshots != nil && maps.Equal(sp.snapshots, snapshots)
+}
+
+func (sp *SnapshotProtection) replace(fileIds map[string]struct{}, snapshots map[string]int64) {
+	sp.Lock()
+	defer sp.Unlock()
+	sp.fileIds = fileIds
+	sp.snapshots = snapshots
+}
+
+// LoadSnapshotProtection reads all snapshots and protects every chunk they reference
+func (f *Filer) LoadSnapshotProtection() {
+	if err := f.loadSnapshotProtection(context.Background()); err != nil {
+		glog.Errorf("load snapshot protection: %v", err)
+	}
+}
+
+// loadSnapshotProtection reloads the protected chunks if the snapshots in the store changed since the last load.
+// Another filer may have created or deleted a snapshot, so this runs before every deletion of chunks.
+// After a reload, the chunks deferred for the previous snapshots are deleted if no snapshot needs them anymore.
+func (f *Filer) loadSnapshotProtection(ctx context.Context) error {
+	sp := f.SnapshotProtection
+	sp.loadLock.Lock()
+	defer sp.loadLock.Unlock()
+
+	entries, _, err := f.ListDirectoryEntries(ctx, SnapshotsDir, "", false, math.MaxInt32, "", "", "")
+	if err != nil && err != filer_pb.ErrNotFound {
+		return fmt.Errorf("list snapshots %s: %v", SnapshotsDir, err)
+	}
+	snapshots := make(map[string]int64)
+	for _, entry := range entries {
+		if !entry.IsDirectory() {
+			snapshots[entry.Name()] = entry.Mtime.UnixNano()
+		}
+	}
+	if sp.isLoaded(snapshots) {
+		return nil
+	}
+
+	fileIds := make(map[string]struct{})
+	for _, entry := range entries {
+		if entry.IsDirectory() {
+			continue
+		}
+		if err := f.collectSnapshotFileIds(entry, fileIds); err != nil {
+			// keep the previous protection rather than exposing chunks of an unreadable snapshot
+			return fmt.Errorf("read snapshot %s: %v", entry.FullPath, err)
+		}
+	}
+	sp.replace(fileIds, snapshots)
+	glog.V(0).Infof("loaded %d snapshots protecting %d chunks", len(snapshots), len(fileIds))
+
+	go f.requeueDeferredFileIds()
+	return nil
+}
+
+func (f *Filer) collectSnapshotFileIds(entry *Entry, fileIds map[string]struct{}) error {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(StreamContent(f.MasterClient, writer, entry.GetChunks(), 0, int64(entry.Size())))
+	}()
+	defer reader.Close()
+
+	return ReadSnapshotEntries(reader, func(fullEntry *filer_pb.FullEntry) error {
+		for _, chunk := range fullEntry.Entry.GetChunks() {
+			fileIds[chunk.GetFileIdString()] = struct{}{}
+			if !chunk.IsChunkManifest {
+				continue
+			}
+			// the data chunks of a manifest are deleted one by one, so protect them as well
+			dataChunks, manifestChunks, err := ResolveChunkManifest(context.Background(), f.MasterClient.LookupFileId, []*filer_pb.FileChunk{chunk}, 0, math.MaxInt64)
+			if err != nil {
+				return fmt.Errorf("resolve manifest %s: %v", chunk.GetFileIdString(), err)
+			}
+			for _, c := range append(dataChunks, manifestChunks...) {
+				fileIds[c.GetFileIdString()] = struct{}{}
+			}
+		}
+		return nil
+	})
+}
+
+func (f *Filer) maybeReloadSnapshotProtection(event *filer_pb.SubscribeMetadataResponse) {
+	if SnapshotsDir != event.Directory && SnapshotsDir != event.EventNotification.NewParentPath {
+		return
+	}
+	f.LoadSnapshotProtection()
+}
+
+// the chunks kept for snapshots are listed in the store, in batches counted by the deferred chunks key,
+// so that any filer deletes them once the snapshots are gone, even after a restart
+const snapshotDeferredKeyPrefix = "snapshot.deferred:"
+
+func snapshotDeferredCountKey() []byte {
+	return []byte(snapshotDeferredKeyPrefix + "count")
+}
+
+func snapshotDeferredBatchKey(i uint64) []byte {
+	return []byte(snapshotDeferredKeyPrefix + strconv.FormatUint(i, 10))
+}
+
+// deferFileIds keeps the file ids of deleted chunks that a snapshot still references
+func (f *Filer) deferFileIds(ctx context.Context, fileIds []string) {
+	f.snapshotDeferredLock.Lock()
+	defer f.snapshotDeferredLock.Unlock()
+	f.withClusterLock(snapshotDeferredKeyPrefix, func() {
+		count, err := f.readSnapshotDeferredCount(ctx)
+		if err == nil {
+			err = f.Store.KvPut(ctx, snapshotDeferredBatchKey(count), []byte(strings.Join(fileIds, "\n")))
+		}
+		if err == nil {
+			countBytes := make([]byte, 8)
+			util.Uint64toBytes(countBytes, count+1)
+			err = f.Store.KvPut(ctx, snapshotDeferredCountKey(), countBytes)
+		}
+		if err != nil {
+			// the chunks leak until volume.fsck finds them, but are never freed while a snapshot needs them
+			glog.ErrorfCtx(ctx, "keep %d chunks for snapshots: %v", len(fileIds), err)
+			return
+		}
+		glog.V(1).InfofCtx(ctx, "keep %d chunks for snapshots", len(fileIds))
+	})
+}
+
+// requeueDeferredFileIds takes the kept file ids out of the store, and deletes the ones no snapshot references anymore
+func (f *Filer) requeueDeferredFileIds() {
+	ctx := context.Background()
+	var fileIds []string
+	f.snapshotDeferredLock.Lock()
+	f.withClusterLock(snapshotDeferredKeyPrefix, func() {
+		count, err := f.readSnapshotDeferredCount(ctx)
+		if err != nil {
+			glog.Errorf("read chunks kept for snapshots: %v", err)
+			return
+		}
+		for i := uint64(0); i < count; i++ {
+			value, err := f.Store.KvGet(ctx, snapshotDeferredBatchKey(i))
+			if err != nil && err != ErrKvNotFound {
+				glog.Errorf("read chunks kept for snapshots: %v", err)
+				fileIds = nil
+				return
+			}
+			if len(value) > 0 {
+				fileIds = append(fileIds, strings.Split(string(value), "\n")...)
+			}
+		}
+		if err = f.Store.KvDelete(ctx, snapshotDeferredCountKey()); err != nil {
+			glog.Errorf("reset chunks kept for snapshots: %v", err)
+			fileIds = nil
+			return
+		}
+		for i := uint64(0); i < count; i++ {
+			if err := f.Store.KvDelete(ctx, snapshotDeferredBatchKey(i)); err != nil {
+				glog.Warningf("delete chunks kept for snapshots: %v", err)
+			}
+		}
+	})
+	f.snapshotDeferredLock.Unlock()
+
+	if len(fileIds) > 0 {
+		glog.V(0).Infof("recheck %d chunks kept for snapshots", len(fileIds))
+		f.deleteFileIds(ctx, fileIds)
+	}
+}
+
+func (f *Filer) readSnapshotDeferredCount(ctx context.Context) (uint64, error) {
+	value, err := f.Store.KvGet(ctx, snapshotDeferredCountKey())
+	if err == ErrKvNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid count of chunks kept for snapshots")
+	}
+	return util.BytesToUint64(value), nil
+}
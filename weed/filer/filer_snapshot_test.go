@@ -0,0 +1,24 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotProtectionFilterUnprotected(t *testing.T) {
+	sp := NewSnapshotProtection()
+	unprotected, protected := sp.FilterUnprotected([]string{"1,01", "2,02"})
+	assert.Equal(t, []string{"1,01", "2,02"}, unprotected)
+	assert.Empty(t, protected)
+
+	sp.replace(map[string]struct{}{"2,02": {}}, map[string]int64{"s1": 1})
+	unprotected, protected = sp.FilterUnprotected([]string{"1,01", "2,02", "3,03"})
+	assert.Equal(t, []string{"1,01", "3,03"}, unprotected)
+	assert.Equal(t, []string{"2,02"}, protected)
+
+	assert.True(t, sp.isLoaded(map[string]int64{"s1": 1}))
+	// a deleted or rewritten snapshot needs a reload
+	assert.False(t, sp.isLoaded(map[string]int64{}))
+	assert.False(t, sp.isLoaded(map[string]int64{"s1": 2}))
+}
@@ -0,0 +1,118 @@
+package filer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VectorClockKey keeps the vector clock of a file in its extended attributes,
+// counting the local changes made on each filer, as identified by its signature.
+// filer.sync compares the clocks to tell concurrent changes from ordered ones.
+const VectorClockKey = "sync.vclock"
+
+// SyncConflictsDir keeps a SyncConflict record for each conflict filer.sync has resolved on this cluster
+const SyncConflictsDir = "/etc/seaweedfs/sync/conflicts"
+
+type VectorClock map[int32]uint64
+
+// ParseVectorClock reads a clock in the "signature:counter,signature:counter" format
+func ParseVectorClock(text []byte) (VectorClock, error) {
+	vc := make(VectorClock)
+	if len(text) == 0 {
+		return vc, nil
+	}
+	for _, part := range strings.Split(string(text), ",") {
+		signature, counter, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid vector clock %s", text)
+		}
+		sig, err := strconv.ParseInt(signature, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector clock %s: %v", text, err)
+		}
+		n, err := strconv.ParseUint(counter, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector clock %s: %v", text, err)
+		}
+		vc[int32(sig)] = n
+	}
+	return vc, nil
+}
+
+// VectorClockOf is the clock of the entry, empty if it has none
+func VectorClockOf(extended map[string][]byte) VectorClock {
+	vc, err := ParseVectorClock(extended[VectorClockKey])
+	if err != nil {
+		return make(VectorClock)
+	}
+	return vc
+}
+
+func (vc VectorClock) Bytes() []byte {
+	signatures := make([]int32, 0, len(vc))
+	for sig := range vc {
+		signatures = append(signatures, sig)
+	}
+	sort.Slice(signatures, func(i, j int) bool { return signatures[i] < signatures[j] })
+	var parts []string
+	for _, sig := range signatures {
+		parts = append(parts, fmt.Sprintf("%d:%d", sig, vc[sig]))
+	}
+	return []byte(strings.Join(parts, ","))
+}
+
+// Descends is true if vc has seen every change that other has seen
+func (vc VectorClock) Descends(other VectorClock) bool {
+	for sig, n := range other {
+		if vc[sig] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func (vc VectorClock) Equal(other VectorClock) bool {
+	return vc.Descends(other) && other.Descends(vc)
+}
+
+// Merge is the clock having seen the changes of both clocks
+func (vc VectorClock) Merge(other VectorClock) VectorClock {
+	merged := make(VectorClock, len(vc))
+	for sig, n := range vc {
+		merged[sig] = n
+	}
+	for sig, n := range other {
+		if n > merged[sig] {
+			merged[sig] = n
+		}
+	}
+	return merged
+}
+
+// AdvanceVectorClock counts a local change of the entry on this filer,
+// if it is under a syncVectorClock rule or the old entry already has a clock.
+func (f *Filer) AdvanceVectorClock(oldEntry, entry *Entry) {
+	if entry.IsDirectory() {
+		return
+	}
+	var vc VectorClock
+	if oldEntry != nil {
+		vc = VectorClockOf(oldEntry.Extended)
+	} else {
+		vc = make(VectorClock)
+	}
+	if len(vc) == 0 && !f.FilerConf.MatchStorageRule(string(entry.FullPath)).SyncVectorClock {
+		return
+	}
+	vc[f.Signature]++
+
+	// the map may be shared with the caller
+	extended := make(map[string][]byte, len(entry.Extended)+1)
+	for k, v := range entry.Extended {
+		extended[k] = v
+	}
+	extended[VectorClockKey] = vc.Bytes()
+	entry.Extended = extended
+}
@@ -0,0 +1,46 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorClock(t *testing.T) {
+	vc, err := ParseVectorClock([]byte("-7:2,5:1"))
+	assert.Nil(t, err)
+	assert.Equal(t, VectorClock{-7: 2, 5: 1}, vc)
+	assert.Equal(t, "-7:2,5:1", string(vc.Bytes()))
+
+	_, err = ParseVectorClock([]byte("5"))
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(VectorClockOf(nil)))
+
+	newer := VectorClock{-7: 3, 5: 1}
+	concurrent := VectorClock{-7: 2, 5: 2, 9: 1}
+	assert.True(t, newer.Descends(vc))
+	assert.False(t, vc.Descends(newer))
+	assert.False(t, newer.Descends(concurrent))
+	assert.False(t, concurrent.Descends(newer))
+	assert.True(t, vc.Equal(VectorClock{5: 1, -7: 2}))
+
+	merged := newer.Merge(concurrent)
+	assert.Equal(t, VectorClock{-7: 3, 5: 2, 9: 1}, merged)
+	assert.True(t, merged.Descends(newer) && merged.Descends(concurrent))
+}
+
+func TestAdvanceVectorClock(t *testing.T) {
+	f := &Filer{FilerConf: NewFilerConf(), Signature: 5}
+
+	entry := &Entry{FullPath: "/data/a.txt", Extended: map[string][]byte{"k": []byte("v")}}
+	f.AdvanceVectorClock(nil, entry)
+	assert.Nil(t, entry.Extended[VectorClockKey], "no clock outside a syncVectorClock rule")
+
+	// a file already having a clock keeps counting its changes
+	oldEntry := &Entry{FullPath: "/data/a.txt", Extended: map[string][]byte{VectorClockKey: []byte("3:1")}}
+	shared := entry.Extended
+	f.AdvanceVectorClock(oldEntry, entry)
+	assert.Equal(t, "3:1,5:1", string(entry.Extended[VectorClockKey]))
+	assert.Equal(t, "v", string(entry.Extended["k"]))
+	assert.Nil(t, shared[VectorClockKey], "the caller's map is not changed")
+}
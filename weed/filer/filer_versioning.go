@@ -0,0 +1,216 @@
+package filer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	// VersionsDir keeps the previous versions of the files under a versioning rule in filer.conf.
+	// The versions of /a/b.txt are the files in /etc/seaweedfs/versions/a/b.txt.versions/
+	VersionsDir   = "/etc/seaweedfs/versions"
+	VersionSuffix = ".versions"
+
+	// VersionExtendedDeleted marks a version saved when the file was deleted
+	VersionExtendedDeleted = "version.deleted"
+
+	versionPruneInterval = time.Hour
+)
+
+// VersionHolderPath is the directory keeping the versions of the file at p
+func VersionHolderPath(p util.FullPath) util.FullPath {
+	return util.FullPath(VersionsDir + string(p) + VersionSuffix)
+}
+
+// VersionedPath is the file whose versions are kept in the holder directory
+func VersionedPath(holder util.FullPath) (util.FullPath, bool) {
+	if !holder.IsUnder(VersionsDir) || !strings.HasSuffix(string(holder), VersionSuffix) {
+		return "", false
+	}
+	return util.FullPath(strings.TrimSuffix(strings.TrimPrefix(string(holder), VersionsDir), VersionSuffix)), true
+}
+
+// NewVersionId names a version after the time it was replaced, so the names sort by time
+func NewVersionId(t time.Time) string {
+	return fmt.Sprintf("%019d", t.UnixNano())
+}
+
+// VersionTime is when the version was replaced
+func VersionTime(versionId string) (time.Time, error) {
+	tsNs, err := strconv.ParseInt(versionId, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid version id %s", versionId)
+	}
+	return time.Unix(0, tsNs), nil
+}
+
+func isVersionable(entry *Entry) bool {
+	if entry == nil || entry.IsDirectory() || len(entry.HardLinkId) != 0 || entry.Remote != nil {
+		return false
+	}
+	if entry.Size() == 0 {
+		// nothing to undo, and avoids a version for each file first created empty by mount
+		return false
+	}
+	p := string(entry.FullPath)
+	return !strings.HasPrefix(p, VersionsDir+"/") && !strings.HasPrefix(p, SystemLogDir)
+}
+
+func isSameContent(oldEntry, newEntry *Entry) bool {
+	if newEntry == nil || !bytes.Equal(oldEntry.Content, newEntry.Content) || len(oldEntry.GetChunks()) != len(newEntry.GetChunks()) {
+		return false
+	}
+	fileIds := make(map[string]struct{}, len(newEntry.GetChunks()))
+	for _, chunk := range newEntry.GetChunks() {
+		fileIds[chunk.GetFileIdString()] = struct{}{}
+	}
+	for _, chunk := range oldEntry.GetChunks() {
+		if _, found := fileIds[chunk.GetFileIdString()]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// MaybeSaveVersion keeps the content of oldEntry as a version, if it is under a versioning rule
+// and replaced by different content or deleted, with newEntry being nil.
+// When it returns true, the chunks of oldEntry are still in use and must not be deleted.
+func (f *Filer) MaybeSaveVersion(ctx context.Context, oldEntry, newEntry *Entry) bool {
+	if !isVersionable(oldEntry) || isSameContent(oldEntry, newEntry) {
+		return false
+	}
+	rule := f.FilerConf.MatchStorageRule(string(oldEntry.FullPath))
+	if !rule.Versioning {
+		return false
+	}
+
+	holder := VersionHolderPath(oldEntry.FullPath)
+	version := oldEntry.ShallowClone()
+	version.FullPath = holder.Child(NewVersionId(time.Now()))
+	version.Attr.Inode = 0
+	if newEntry == nil {
+		version.Extended = make(map[string][]byte, len(oldEntry.Extended)+1)
+		for k, v := range oldEntry.Extended {
+			version.Extended[k] = v
+		}
+		version.Extended[VersionExtendedDeleted] = []byte("true")
+	}
+	if err := f.CreateEntry(ctx, version, true, false, nil, false, f.MaxFilenameLength); err != nil {
+		// the old content is lost as it would be without versioning
+		glog.ErrorfCtx(ctx, "save version of %s: %v", oldEntry.FullPath, err)
+		return false
+	}
+
+	f.pruneVersions(ctx, oldEntry.FullPath, rule, newEntry)
+	return true
+}
+
+// pruneVersions removes the versions beyond the retention count or age of the rule.
+// The chunks still used by the current file or the remaining versions are kept.
+func (f *Filer) pruneVersions(ctx context.Context, p util.FullPath, rule *filer_pb.FilerConf_PathConf, current *Entry) {
+	if rule.VersionRetentionCount == 0 && rule.VersionRetentionSeconds == 0 {
+		return
+	}
+	holder := VersionHolderPath(p)
+	entries, _, err := f.ListDirectoryEntries(ctx, holder, "", false, math.MaxInt32, "", "", "")
+	if err != nil {
+		glog.ErrorfCtx(ctx, "list versions %s: %v", holder, err)
+		return
+	}
+	var versions []*Entry
+	for _, entry := range entries {
+		if !entry.IsDirectory() {
+			versions = append(versions, entry)
+		}
+	}
+
+	var expired, kept []*Entry
+	now := time.Now()
+	for i, version := range versions {
+		replacedAt, parseErr := VersionTime(version.Name())
+		isTooMany := rule.VersionRetentionCount > 0 && len(versions)-i > int(rule.VersionRetentionCount)
+		isTooOld := parseErr == nil && rule.VersionRetentionSeconds > 0 && now.Sub(replacedAt) > time.Duration(rule.VersionRetentionSeconds)*time.Second
+		if isTooMany || isTooOld {
+			expired = append(expired, version)
+		} else {
+			kept = append(kept, version)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+
+	var inUse []*filer_pb.FileChunk
+	if current == nil {
+		current, _ = f.FindEntry(ctx, p)
+	}
+	if current != nil {
+		inUse = append(inUse, current.GetChunks()...)
+	}
+	for _, version := range kept {
+		inUse = append(inUse, version.GetChunks()...)
+	}
+	for _, version := range expired {
+		if err = f.DeleteEntryMetaAndData(ctx, version.FullPath, false, false, false, false, nil, 0); err != nil {
+			glog.ErrorfCtx(ctx, "delete version %s: %v", version.FullPath, err)
+			continue
+		}
+		garbage, minusErr := MinusChunks(ctx, f.MasterClient.GetLookupFileIdFunction(), version.GetChunks(), inUse)
+		if minusErr != nil {
+			// rather leak the chunks than delete one still in use
+			glog.ErrorfCtx(ctx, "resolve chunks of version %s: %v", version.FullPath, minusErr)
+			continue
+		}
+		f.DeleteChunksNotRecursive(garbage)
+	}
+	if len(kept) == 0 && len(entries) == len(versions) {
+		if err = f.DeleteEntryMetaAndData(ctx, holder, false, false, false, false, nil, 0); err != nil {
+			glog.V(1).InfofCtx(ctx, "delete empty versions %s: %v", holder, err)
+		}
+	}
+}
+
+// LoopPruneVersions applies the version retention also to the files not changed any more.
+func (f *Filer) LoopPruneVersions() {
+	for {
+		time.Sleep(versionPruneInterval)
+		if _, err := f.FindEntry(context.Background(), VersionsDir); err != nil {
+			continue
+		}
+		f.pruneVersionsUnder(context.Background(), VersionsDir)
+	}
+}
+
+func (f *Filer) pruneVersionsUnder(ctx context.Context, dir util.FullPath) {
+	lastFileName := ""
+	for {
+		entries, _, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			glog.ErrorfCtx(ctx, "list %s: %v", dir, err)
+			return
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if !entry.IsDirectory() {
+				continue
+			}
+			if p, found := VersionedPath(entry.FullPath); found {
+				f.pruneVersions(ctx, p, f.FilerConf.MatchStorageRule(string(p)), nil)
+			}
+			// a directory named like a version holder may also have versioned files under it
+			f.pruneVersionsUnder(ctx, entry.FullPath)
+		}
+		if len(entries) < PaginationSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package filer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionHolderPath(t *testing.T) {
+	holder := VersionHolderPath("/home/a/b.txt")
+	assert.Equal(t, util.FullPath("/etc/seaweedfs/versions/home/a/b.txt.versions"), holder)
+
+	p, found := VersionedPath(holder)
+	assert.True(t, found)
+	assert.Equal(t, util.FullPath("/home/a/b.txt"), p)
+
+	_, found = VersionedPath("/etc/seaweedfs/versions/home/a")
+	assert.False(t, found)
+	_, found = VersionedPath("/home/a/b.txt.versions")
+	assert.False(t, found)
+}
+
+func TestVersionId(t *testing.T) {
+	now := time.Now()
+	older, newer := NewVersionId(now), NewVersionId(now.Add(time.Second))
+	assert.Less(t, older, newer)
+
+	replacedAt, err := VersionTime(older)
+	assert.Nil(t, err)
+	assert.Equal(t, now.UnixNano(), replacedAt.UnixNano())
+
+	_, err = VersionTime("not-a-version")
+	assert.NotNil(t, err)
+}
+
+func TestIsVersionable(t *testing.T) {
+	file := func(p string) *Entry {
+		return &Entry{FullPath: util.FullPath(p), Attr: Attr{FileSize: 10}}
+	}
+	assert.True(t, isVersionable(file("/home/a.txt")))
+	assert.False(t, isVersionable(file("/etc/seaweedfs/versions/home/a.txt.versions/1")))
+	assert.False(t, isVersionable(&Entry{FullPath: "/home/empty.txt"}))
+	assert.False(t, isVersionable(nil))
+
+	linked := file("/home/linked.txt")
+	linked.HardLinkId = HardLinkId("id")
+	assert.False(t, isVersionable(linked))
+}
+
+func TestIsSameContent(t *testing.T) {
+	entry := func(fileIds ...string) *Entry {
+		e := &Entry{}
+		for _, fileId := range fileIds {
+			fid, _ := filer_pb.ToFileIdObject(fileId)
+			e.Chunks = append(e.Chunks, &filer_pb.FileChunk{Fid: fid})
+		}
+		return e
+	}
+	assert.True(t, isSameContent(entry("1,01637037d6"), entry("1,01637037d6")))
+	assert.False(t, isSameContent(entry("1,01637037d6"), entry("2,01637037d6")))
+	assert.False(t, isSameContent(entry("1,01637037d6"), entry("1,01637037d6", "2,01637037d6")))
+	assert.False(t, isSameContent(entry("1,01637037d6"), nil))
+}
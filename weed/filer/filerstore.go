@@ -14,6 +14,7 @@ var (
 	ErrUnsupportedSuperLargeDirectoryListing = errors.New("unsupported super large directory listing")
 	ErrKvNotImplemented                      = errors.New("kv not implemented yet")
 	ErrKvNotFound                            = errors.New("kv: not found")
+	ErrUnsupportedKvList                     = errors.New("unsupported kv listing")
 )
 
 type ListEachEntryFunc func(entry *Entry) bool
@@ -49,6 +50,11 @@ type BucketAware interface {
 	CanDropWholeBucket() bool
 }
 
+// KvLister lists all key value pairs of the store, so they can be copied to another store
+type KvLister interface {
+	KvList(ctx context.Context, eachKvFunc func(key, value []byte) error) error
+}
+
 type Debuggable interface {
 	Debug(writer io.Writer)
 }
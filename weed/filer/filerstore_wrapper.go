@@ -22,6 +22,7 @@ var (
 
 type VirtualFilerStore interface {
 	FilerStore
+	KvLister
 	DeleteHardLink(ctx context.Context, hardLinkId HardLinkId) error
 	DeleteOneEntry(ctx context.Context, entry *Entry) error
 	AddPathSpecificStore(path string, storeId string, store FilerStore)
@@ -34,6 +35,7 @@ type FilerStoreWrapper struct {
 	defaultStore   FilerStore
 	pathToStore    ptrie.Trie[string]
 	storeIdToStore map[string]FilerStore
+	chunkKeys      *ChunkKeys
 }
 
 func NewFilerStoreWrapper(store FilerStore) *FilerStoreWrapper {
@@ -125,12 +127,17 @@ func (fsw *FilerStoreWrapper) InsertEntry(ctx context.Context, entry *Entry) err
 		entry.Mime = ""
 	}
 
-	if err := fsw.handleUpdateToHardLinks(ctx, entry); err != nil {
+	storedEntry, err := fsw.chunkKeys.wrap(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if err := fsw.handleUpdateToHardLinks(ctx, storedEntry); err != nil {
 		return err
 	}
 
 	// glog.V(4).Infof("InsertEntry %s", entry.FullPath)
-	return actualStore.InsertEntry(ctx, entry)
+	return actualStore.InsertEntry(ctx, storedEntry)
 }
 
 func (fsw *FilerStoreWrapper) UpdateEntry(ctx context.Context, entry *Entry) error {
@@ -147,12 +154,17 @@ func (fsw *FilerStoreWrapper) UpdateEntry(ctx context.Context, entry *Entry) err
 		entry.Mime = ""
 	}
 
-	if err := fsw.handleUpdateToHardLinks(ctx, entry); err != nil {
+	storedEntry, err := fsw.chunkKeys.wrap(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if err := fsw.handleUpdateToHardLinks(ctx, storedEntry); err != nil {
 		return err
 	}
 
 	// glog.V(4).Infof("UpdateEntry %s", entry.FullPath)
-	return actualStore.UpdateEntry(ctx, entry)
+	return actualStore.UpdateEntry(ctx, storedEntry)
 }
 
 func (fsw *FilerStoreWrapper) FindEntry(ctx context.Context, fp util.FullPath) (entry *Entry, err error) {
@@ -176,6 +188,9 @@ func (fsw *FilerStoreWrapper) FindEntry(ctx context.Context, fp util.FullPath) (
 	fsw.maybeReadHardLink(ctx, entry)
 
 	filer_pb.AfterEntryDeserialization(entry.GetChunks())
+	if err = fsw.chunkKeys.unwrap(ctx, entry); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -254,11 +269,19 @@ func (fsw *FilerStoreWrapper) ListDirectoryEntries(ctx context.Context, dirPath
 	}()
 
 	// glog.V(4).Infof("ListDirectoryEntries %s from %s limit %d", dirPath, startFileName, limit)
-	return actualStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit, func(entry *Entry) bool {
+	var unwrapErr error
+	lastFileName, err := actualStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit, func(entry *Entry) bool {
 		fsw.maybeReadHardLink(ctx, entry)
 		filer_pb.AfterEntryDeserialization(entry.GetChunks())
+		if unwrapErr = fsw.chunkKeys.unwrap(ctx, entry); unwrapErr != nil {
+			return false
+		}
 		return eachEntryFunc(entry)
 	})
+	if err == nil {
+		err = unwrapErr
+	}
+	return lastFileName, err
 }
 
 func (fsw *FilerStoreWrapper) ListDirectoryPrefixedEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int64, prefix string, eachEntryFunc ListEachEntryFunc) (lastFileName string, err error) {
@@ -273,15 +296,22 @@ func (fsw *FilerStoreWrapper) ListDirectoryPrefixedEntries(ctx context.Context,
 		limit = math.MaxInt32 - 1
 	}
 	// glog.V(4).Infof("ListDirectoryPrefixedEntries %s from %s prefix %s limit %d", dirPath, startFileName, prefix, limit)
+	var unwrapErr error
 	adjustedEntryFunc := func(entry *Entry) bool {
 		fsw.maybeReadHardLink(ctx, entry)
 		filer_pb.AfterEntryDeserialization(entry.GetChunks())
+		if unwrapErr = fsw.chunkKeys.unwrap(ctx, entry); unwrapErr != nil {
+			return false
+		}
 		return eachEntryFunc(entry)
 	}
 	lastFileName, err = actualStore.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, prefix, adjustedEntryFunc)
 	if err == ErrUnsupportedListDirectoryPrefixed {
 		lastFileName, err = fsw.prefixFilterEntries(ctx, dirPath, startFileName, includeStartFile, limit, prefix, adjustedEntryFunc)
 	}
+	if err == nil {
+		err = unwrapErr
+	}
 	return lastFileName, err
 }
 
@@ -362,6 +392,13 @@ func (fsw *FilerStoreWrapper) KvDelete(ctx context.Context, key []byte) (err err
 	return fsw.getDefaultStore().KvDelete(ctx, key)
 }
 
+func (fsw *FilerStoreWrapper) KvList(ctx context.Context, eachKvFunc func(key, value []byte) error) error {
+	if lister, ok := fsw.getDefaultStore().(KvLister); ok {
+		return lister.KvList(ctx, eachKvFunc)
+	}
+	return ErrUnsupportedKvList
+}
+
 func (fsw *FilerStoreWrapper) Debug(writer io.Writer) {
 	if debuggable, ok := fsw.getDefaultStore().(Debuggable); ok {
 		debuggable.Debug(writer)
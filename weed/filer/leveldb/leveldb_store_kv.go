@@ -1,6 +1,7 @@
 package leveldb
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
@@ -43,3 +44,23 @@ func (store *LevelDBStore) KvDelete(ctx context.Context, key []byte) (err error)
 
 	return nil
 }
+
+func (store *LevelDBStore) KvList(ctx context.Context, eachKvFunc func(key, value []byte) error) error {
+
+	iter := store.db.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		// the entries are keyed by their directory path and name, see genKey()
+		if len(key) > 0 && key[0] == '/' && bytes.IndexByte(key, DIR_FILE_SEPARATOR) >= 0 {
+			continue
+		}
+		if err := eachKvFunc(bytes.Clone(key), bytes.Clone(iter.Value())); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("kv list: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/filer/store_test"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -87,6 +89,156 @@ func TestEmptyRoot(t *testing.T) {
 
 }
 
+func TestDirectoryQuota(t *testing.T) {
+	testFiler := filer.NewFiler(pb.ServerDiscovery{}, nil, "", "", "", "", "", 255, nil)
+	dir := t.TempDir()
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	testFiler.SetStore(store)
+
+	ctx := context.Background()
+	newFile := func(name string, size uint64) *filer.Entry {
+		return &filer.Entry{
+			FullPath: util.NewFullPath("/home/team", name),
+			Attr: filer.Attr{
+				Crtime:   time.Now(),
+				Mtime:    time.Now(),
+				Mode:     os.FileMode(0644),
+				FileSize: size,
+			},
+		}
+	}
+	createFile := func(name string, size uint64) error {
+		return testFiler.CreateEntry(ctx, newFile(name, size), false, false, nil, false, testFiler.MaxFilenameLength)
+	}
+
+	if err := createFile("a", 40); err != nil {
+		t.Fatalf("create a: %v", err)
+	}
+
+	fc := filer.NewFilerConf()
+	fc.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/team/", QuotaBytes: 100, QuotaInodes: 3})
+	testFiler.FilerConf = fc
+
+	// the first check counts the existing content in the background
+	if err := testFiler.CheckQuota(ctx, nil, newFile("b", 40)); err != nil {
+		t.Fatalf("check before counting: %v", err)
+	}
+	for i := 0; ; i++ {
+		if _, err := store.KvGet(ctx, filer.QuotaUsageKey("/home/team/")); err == nil {
+			break
+		}
+		if i > 100 {
+			t.Fatalf("usage is not counted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := createFile("b", 40); err != nil {
+		t.Fatalf("create b: %v", err)
+	}
+	if err := createFile("c", 40); !filer.IsQuotaExceeded(err) {
+		t.Fatalf("create c over the byte quota: %v", err)
+	}
+	if err := createFile("c", 10); err != nil {
+		t.Fatalf("create c: %v", err)
+	}
+	if err := createFile("d", 0); !filer.IsQuotaExceeded(err) {
+		t.Fatalf("create d over the inode quota: %v", err)
+	}
+	if err := createFile("b", 20); err != nil {
+		t.Fatalf("shrink b: %v", err)
+	}
+	if err := testFiler.DeleteEntryMetaAndData(ctx, "/home/team/a", false, false, false, false, nil, 0); err != nil {
+		t.Fatalf("delete a: %v", err)
+	}
+	if err := createFile("d", 0); err != nil {
+		t.Fatalf("create d: %v", err)
+	}
+
+	value, err := store.KvGet(ctx, filer.QuotaUsageKey("/home/team/"))
+	if err != nil {
+		t.Fatalf("read usage: %v", err)
+	}
+	usage, err := filer.QuotaUsageFromBytes(value)
+	if err != nil {
+		t.Fatalf("parse usage: %v", err)
+	}
+	if usage.Bytes != 30 || usage.Inodes != 3 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
+
+func TestCloneTree(t *testing.T) {
+	testFiler := filer.NewFiler(pb.ServerDiscovery{}, nil, "", "", "", "", "", 255, nil)
+	dir := t.TempDir()
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	testFiler.SetStore(store)
+
+	ctx := context.Background()
+	fid, _ := filer_pb.ToFileIdObject("1,01637037d6")
+	file := &filer.Entry{
+		FullPath: "/data/set/sub/a.bin",
+		Attr: filer.Attr{
+			Crtime:   time.Now(),
+			Mtime:    time.Now(),
+			Mode:     os.FileMode(0644),
+			FileSize: 100,
+		},
+		Chunks: []*filer_pb.FileChunk{{Fid: fid, Size: 100}},
+	}
+	if err := testFiler.CreateEntry(ctx, file, false, false, nil, false, testFiler.MaxFilenameLength); err != nil {
+		t.Fatalf("create %s: %v", file.FullPath, err)
+	}
+
+	if _, err := testFiler.CloneTree(ctx, "/data/set", "/data/set/copy", nil); err == nil {
+		t.Fatalf("clone into itself")
+	}
+	stats, err := testFiler.CloneTree(ctx, "/data/set", "/data/copy", nil)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if stats.Files != 1 || stats.Directories != 2 || stats.Bytes != 100 {
+		t.Errorf("unexpected clone stats %+v", stats)
+	}
+	if _, err = testFiler.CloneTree(ctx, "/data/set", "/data/copy", nil); err == nil {
+		t.Fatalf("clone over an existing entry")
+	}
+
+	clone, err := testFiler.FindEntry(ctx, "/data/copy/sub/a.bin")
+	if err != nil {
+		t.Fatalf("find clone: %v", err)
+	}
+	if len(clone.GetChunks()) != 1 || clone.GetChunks()[0].GetFileIdString() != "1,01637037d6" {
+		t.Errorf("clone does not share the chunk: %v", clone.GetChunks())
+	}
+	if value, err := store.KvGet(ctx, filer.ChunkReferenceKey("1,01637037d6")); err != nil || string(value) != "1" {
+		t.Errorf("chunk references %s: %v", value, err)
+	}
+
+	// deleting the clone only drops the extra reference
+	if err = testFiler.DeleteEntryMetaAndData(ctx, "/data/copy", true, false, true, false, nil, 0); err != nil {
+		t.Fatalf("delete clone: %v", err)
+	}
+	for i := 0; ; i++ {
+		if _, err := store.KvGet(ctx, filer.ChunkReferenceKey("1,01637037d6")); err == filer.ErrKvNotFound {
+			break
+		}
+		if i > 300 {
+			t.Fatalf("chunk reference is not released")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestKvList(t *testing.T) {
+	dir := t.TempDir()
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	store_test.TestKvList(t, store)
+}
+
 func BenchmarkInsertEntry(b *testing.B) {
 	testFiler := filer.NewFiler(pb.ServerDiscovery{}, nil, "", "", "", "", "", 255, nil)
 	dir := b.TempDir()
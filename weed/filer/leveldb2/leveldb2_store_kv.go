@@ -1,10 +1,13 @@
 package leveldb
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"fmt"
 
 	"github.com/seaweedfs/seaweedfs/weed/filer"
+	weed_util "github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
@@ -54,3 +57,54 @@ func (store *LevelDB2Store) KvDelete(ctx context.Context, key []byte) (err error
 func bucketKvKey(key []byte, dbCount int) (partitionId int) {
 	return int(key[len(key)-1]) % dbCount
 }
+
+func (store *LevelDB2Store) KvList(ctx context.Context, eachKvFunc func(key, value []byte) error) error {
+
+	// the entries are keyed by the md5 of their directory, see genKey()
+	directoryHashes := make(map[string]bool)
+	directories := []weed_util.FullPath{"/"}
+	for len(directories) > 0 {
+		dir := directories[0]
+		directories = directories[1:]
+		dirHash, _ := hashToBytes(string(dir), store.dbCount)
+		directoryHashes[string(dirHash)] = true
+		lastFileName := ""
+		for {
+			var count int
+			var err error
+			lastFileName, err = store.ListDirectoryEntries(ctx, dir, lastFileName, false, 1024, func(entry *filer.Entry) bool {
+				count++
+				if entry.IsDirectory() {
+					directories = append(directories, entry.FullPath)
+				}
+				return true
+			})
+			if err != nil {
+				return fmt.Errorf("kv list %s: %w", dir, err)
+			}
+			if count < 1024 {
+				break
+			}
+		}
+	}
+
+	for partitionId, db := range store.dbs {
+		iter := db.NewIterator(nil, nil)
+		for iter.Next() {
+			key := iter.Key()
+			if len(key) >= md5.Size && directoryHashes[string(key[:md5.Size])] {
+				continue
+			}
+			if err := eachKvFunc(bytes.Clone(key), bytes.Clone(iter.Value())); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return fmt.Errorf("kv bucket %d list: %w", partitionId, err)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/filer/store_test"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -83,3 +84,10 @@ func TestEmptyRoot(t *testing.T) {
 	}
 
 }
+
+func TestKvList(t *testing.T) {
+	dir := t.TempDir()
+	store := &LevelDB2Store{}
+	store.initialize(dir, 2)
+	store_test.TestKvList(t, store)
+}
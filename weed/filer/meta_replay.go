@@ -2,6 +2,8 @@ package filer
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -72,3 +74,104 @@ func ParallelProcessDirectoryStructure(entryChan chan *Entry, concurrency int, e
 	wg.Wait()
 	return
 }
+
+// SnapshotTree is an in-memory copy of a directory tree. It is loaded from a metadata snapshot,
+// and moved forward in time by replaying the metadata events recorded after the snapshot.
+type SnapshotTree struct {
+	root    util.FullPath
+	entries map[util.FullPath]*filer_pb.Entry
+}
+
+func NewSnapshotTree(root util.FullPath) *SnapshotTree {
+	return &SnapshotTree{
+		root:    root,
+		entries: make(map[util.FullPath]*filer_pb.Entry),
+	}
+}
+
+func (t *SnapshotTree) contains(path util.FullPath) bool {
+	return t.root == "/" || path == t.root || strings.HasPrefix(string(path), string(t.root)+"/")
+}
+
+// Add puts one snapshot entry into the tree, ignoring entries outside of the tree root
+func (t *SnapshotTree) Add(fullEntry *filer_pb.FullEntry) {
+	path := util.NewFullPath(fullEntry.Dir, fullEntry.Entry.Name)
+	if t.contains(path) {
+		t.entries[path] = fullEntry.Entry
+	}
+}
+
+// Replay applies one metadata event, the same way Replay applies it to a filer store
+func (t *SnapshotTree) Replay(resp *filer_pb.SubscribeMetadataResponse) {
+	message := resp.EventNotification
+	var oldPath, newPath util.FullPath
+	if message.OldEntry != nil {
+		oldPath = util.NewFullPath(resp.Directory, message.OldEntry.Name)
+	}
+	if message.NewEntry != nil {
+		dir := resp.Directory
+		if message.NewParentPath != "" {
+			dir = message.NewParentPath
+		}
+		newPath = util.NewFullPath(dir, message.NewEntry.Name)
+	}
+
+	if message.OldEntry != nil && oldPath != newPath {
+		if message.OldEntry.IsDirectory && message.NewEntry != nil {
+			t.moveChildren(oldPath, newPath)
+		}
+		t.remove(oldPath, message.OldEntry.IsDirectory)
+	}
+	if message.NewEntry != nil && t.contains(newPath) {
+		t.entries[newPath] = message.NewEntry
+	}
+}
+
+func (t *SnapshotTree) moveChildren(oldDir, newDir util.FullPath) {
+	prefix := string(oldDir) + "/"
+	moved := make(map[util.FullPath]*filer_pb.Entry)
+	for path, entry := range t.entries {
+		if strings.HasPrefix(string(path), prefix) {
+			moved[util.FullPath(string(newDir)+"/"+strings.TrimPrefix(string(path), prefix))] = entry
+			delete(t.entries, path)
+		}
+	}
+	for path, entry := range moved {
+		if t.contains(path) {
+			t.entries[path] = entry
+		}
+	}
+}
+
+func (t *SnapshotTree) remove(path util.FullPath, isDirectory bool) {
+	delete(t.entries, path)
+	if !isDirectory {
+		return
+	}
+	prefix := string(path) + "/"
+	for p := range t.entries {
+		if strings.HasPrefix(string(p), prefix) {
+			delete(t.entries, p)
+		}
+	}
+}
+
+func (t *SnapshotTree) Get(path util.FullPath) (entry *filer_pb.Entry, found bool) {
+	entry, found = t.entries[path]
+	return
+}
+
+func (t *SnapshotTree) Len() int {
+	return len(t.entries)
+}
+
+// Paths returns all paths in the tree, parent directories before their children
+func (t *SnapshotTree) Paths() (paths []util.FullPath) {
+	for path := range t.entries {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i] < paths[j]
+	})
+	return
+}
@@ -0,0 +1,99 @@
+package filer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSnapshotTreeReplay(t *testing.T) {
+	tree := NewSnapshotTree("/data")
+	tree.Add(&filer_pb.FullEntry{Dir: "/data", Entry: &filer_pb.Entry{Name: "a", IsDirectory: true}})
+	tree.Add(&filer_pb.FullEntry{Dir: "/data/a", Entry: &filer_pb.Entry{Name: "f1"}})
+	tree.Add(&filer_pb.FullEntry{Dir: "/data/a", Entry: &filer_pb.Entry{Name: "f2"}})
+	tree.Add(&filer_pb.FullEntry{Dir: "/other", Entry: &filer_pb.Entry{Name: "x"}})
+
+	if tree.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", tree.Len())
+	}
+
+	// create a file
+	tree.Replay(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/data/a",
+		EventNotification: &filer_pb.EventNotification{
+			NewEntry:      &filer_pb.Entry{Name: "f3"},
+			NewParentPath: "/data/a",
+		},
+	})
+	// delete a file
+	tree.Replay(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/data/a",
+		EventNotification: &filer_pb.EventNotification{
+			OldEntry: &filer_pb.Entry{Name: "f1"},
+		},
+	})
+	// rename the directory
+	tree.Replay(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/data",
+		EventNotification: &filer_pb.EventNotification{
+			OldEntry:      &filer_pb.Entry{Name: "a", IsDirectory: true},
+			NewEntry:      &filer_pb.Entry{Name: "b", IsDirectory: true},
+			NewParentPath: "/data",
+		},
+	})
+	// events outside of the tree are ignored
+	tree.Replay(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/other",
+		EventNotification: &filer_pb.EventNotification{
+			NewEntry:      &filer_pb.Entry{Name: "y"},
+			NewParentPath: "/other",
+		},
+	})
+
+	var expected = []util.FullPath{"/data/b", "/data/b/f2", "/data/b/f3"}
+	paths := tree.Paths()
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, paths)
+		}
+	}
+
+	// delete the directory recursively
+	tree.Replay(&filer_pb.SubscribeMetadataResponse{
+		Directory: "/data",
+		EventNotification: &filer_pb.EventNotification{
+			OldEntry: &filer_pb.Entry{Name: "b", IsDirectory: true},
+		},
+	})
+	if tree.Len() != 0 {
+		t.Errorf("expected empty tree, got %v", tree.Paths())
+	}
+}
+
+func TestReadSnapshotEntries(t *testing.T) {
+	var buf bytes.Buffer
+	sizeBuf := make([]byte, 4)
+	for _, name := range []string{"a", "b", "c"} {
+		data, _ := proto.Marshal(&filer_pb.FullEntry{Dir: "/data", Entry: &filer_pb.Entry{Name: name}})
+		util.Uint32toBytes(sizeBuf, uint32(len(data)))
+		buf.Write(sizeBuf)
+		buf.Write(data)
+	}
+
+	var names []string
+	if err := ReadSnapshotEntries(&buf, func(fullEntry *filer_pb.FullEntry) error {
+		names = append(names, fullEntry.Entry.Name)
+		return nil
+	}); err != nil {
+		t.Fatalf("read snapshot entries: %v", err)
+	}
+	if len(names) != 3 || names[0] != "a" || names[2] != "c" {
+		t.Errorf("unexpected entries %v", names)
+	}
+}
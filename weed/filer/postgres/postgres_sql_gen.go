@@ -49,6 +49,10 @@ func (gen *SqlGenPostgres) GetSqlListInclusive(tableName string) string {
 	return fmt.Sprintf(`SELECT NAME, meta FROM "%s" WHERE dirhash=$1 AND name>=$2 AND directory=$3 AND name like $4 ORDER BY NAME ASC LIMIT $5`, tableName)
 }
 
+func (gen *SqlGenPostgres) GetSqlListKv(tableName string) string {
+	return fmt.Sprintf(`SELECT directory, name, meta FROM "%s" WHERE directory NOT LIKE '/%%'`, tableName)
+}
+
 func (gen *SqlGenPostgres) GetSqlCreateTable(tableName string) string {
 	return fmt.Sprintf(gen.CreateTableSqlTemplate, tableName)
 }
@@ -0,0 +1,155 @@
+package search_index
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+const maxIndexedValueLength = 256
+
+// Matcher evaluates the conditions of a SearchEntriesRequest against one entry
+type Matcher struct {
+	req   *filer_pb.SearchEntriesRequest
+	terms []string
+}
+
+func NewMatcher(req *filer_pb.SearchEntriesRequest) (*Matcher, error) {
+	if req.NamePattern != "" {
+		if _, err := filepath.Match(req.NamePattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid name pattern %s: %v", req.NamePattern, err)
+		}
+	}
+	if req.FilesOnly && req.DirectoriesOnly {
+		return nil, fmt.Errorf("files only and directories only are exclusive")
+	}
+	m := &Matcher{req: req}
+	for _, term := range req.Terms {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" && term != "*" {
+			m.terms = append(m.terms, term)
+		}
+	}
+	return m, nil
+}
+
+// Terms returns the normalized text terms of the query
+func (m *Matcher) Terms() []string {
+	return m.terms
+}
+
+func (m *Matcher) Match(entry *filer_pb.Entry) bool {
+	req := m.req
+	if req.FilesOnly && entry.IsDirectory || req.DirectoriesOnly && !entry.IsDirectory {
+		return false
+	}
+	if req.NamePattern != "" {
+		if matched, _ := filepath.Match(req.NamePattern, entry.Name); !matched {
+			return false
+		}
+	}
+	attr := entry.Attributes
+	if attr == nil {
+		attr = &filer_pb.FuseAttributes{}
+	}
+	if req.MimePrefix != "" && !strings.HasPrefix(attr.Mime, req.MimePrefix) {
+		return false
+	}
+	size := entrySize(entry)
+	if req.MinSize > 0 && size < req.MinSize {
+		return false
+	}
+	if req.MaxSize > 0 && size > req.MaxSize {
+		return false
+	}
+	if req.MtimeAfter > 0 && attr.Mtime < req.MtimeAfter {
+		return false
+	}
+	if req.MtimeBefore > 0 && attr.Mtime > req.MtimeBefore {
+		return false
+	}
+	for key, value := range req.Extended {
+		actual, found := entry.Extended[key]
+		if !found || value != "" && string(actual) != value {
+			return false
+		}
+	}
+	if len(m.terms) > 0 {
+		entryTerms := make(map[string]struct{})
+		for _, term := range EntryTerms(entry) {
+			entryTerms[term] = struct{}{}
+		}
+		for _, term := range m.terms {
+			if !hasTerm(entryTerms, term) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasTerm(entryTerms map[string]struct{}, term string) bool {
+	if prefix, isPrefix := strings.CutSuffix(term, "*"); isPrefix {
+		for t := range entryTerms {
+			if strings.HasPrefix(t, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	_, found := entryTerms[term]
+	return found
+}
+
+func entrySize(entry *filer_pb.Entry) uint64 {
+	var size uint64
+	if entry.Attributes != nil {
+		size = entry.Attributes.FileSize
+	}
+	for _, chunk := range entry.GetChunks() {
+		size = max(size, uint64(chunk.Offset)+chunk.Size)
+	}
+	return max(size, uint64(len(entry.Content)))
+}
+
+// EntryTerms returns the lower case words of the entry name, mime type, extended attribute names
+// and short text attribute values. The whole name is also a term, so exact names can be found.
+func EntryTerms(entry *filer_pb.Entry) (terms []string) {
+	seen := make(map[string]struct{})
+	add := func(term string) {
+		if term == "" {
+			return
+		}
+		if _, found := seen[term]; !found {
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	addWords := func(text string) {
+		for _, word := range splitWords(strings.ToLower(text)) {
+			add(word)
+		}
+	}
+
+	add(strings.ToLower(entry.Name))
+	addWords(entry.Name)
+	if entry.Attributes != nil {
+		addWords(entry.Attributes.Mime)
+	}
+	for key, value := range entry.Extended {
+		addWords(key)
+		if len(value) <= maxIndexedValueLength && utf8.Valid(value) {
+			addWords(string(value))
+		}
+	}
+	return
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,281 @@
+package search_index
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldb_errors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// The index keeps three kinds of keys in one leveldb:
+//
+//	d<full path>              the entry
+//	t<term>\x00<full path>    a posting of a term
+//	o                         the metadata log offset the index is caught up to
+const (
+	docPrefix     = 'd'
+	termPrefix    = 't'
+	termSeparator = byte(0x00)
+)
+
+var offsetKey = []byte("o")
+
+// SearchIndex is a secondary index of filer entries, kept up to date from the filer metadata log
+type SearchIndex struct {
+	db *leveldb.DB
+}
+
+func Open(dir string) (*SearchIndex, error) {
+	glog.V(0).Infof("search index dir: %s", dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create search index dir %s: %v", dir, err)
+	}
+	opts := &opt.Options{
+		BlockCacheCapacity: 16 * 1024 * 1024,
+		WriteBuffer:        8 * 1024 * 1024,
+	}
+	db, err := leveldb.OpenFile(dir, opts)
+	if err != nil && leveldb_errors.IsCorrupted(err) {
+		db, err = leveldb.RecoverFile(dir, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open search index %s: %v", dir, err)
+	}
+	return &SearchIndex{db: db}, nil
+}
+
+func (si *SearchIndex) Close() error {
+	return si.db.Close()
+}
+
+// Offset returns the timestamp of the last applied metadata event, or 0 for an empty index
+func (si *SearchIndex) Offset() (int64, error) {
+	value, err := si.db.Get(offsetKey, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(util.BytesToUint64(value)), nil
+}
+
+func (si *SearchIndex) SetOffset(tsNs int64) error {
+	return si.db.Put(offsetKey, offsetBytes(tsNs), nil)
+}
+
+func offsetBytes(tsNs int64) []byte {
+	b := make([]byte, 8)
+	util.Uint64toBytes(b, uint64(tsNs))
+	return b
+}
+
+// Put adds or replaces one entry, used when building the index from the filer store
+func (si *SearchIndex) Put(dir string, entry *filer_pb.Entry) error {
+	batch := new(leveldb.Batch)
+	path := string(util.NewFullPath(dir, entry.Name))
+	if err := si.deleteDoc(batch, path); err != nil {
+		return err
+	}
+	if err := putDoc(batch, path, entry); err != nil {
+		return err
+	}
+	return si.db.Write(batch, nil)
+}
+
+// Update applies one metadata event and moves the offset to it
+func (si *SearchIndex) Update(resp *filer_pb.SubscribeMetadataResponse) error {
+	message := resp.EventNotification
+	batch := new(leveldb.Batch)
+
+	var oldPath, newPath string
+	if message.OldEntry != nil {
+		oldPath = string(util.NewFullPath(resp.Directory, message.OldEntry.Name))
+	}
+	if message.NewEntry != nil {
+		newParentPath := message.NewParentPath
+		if newParentPath == "" {
+			newParentPath = resp.Directory
+		}
+		newPath = string(util.NewFullPath(newParentPath, message.NewEntry.Name))
+	}
+
+	if oldPath != "" {
+		if err := si.deleteDoc(batch, oldPath); err != nil {
+			return err
+		}
+		// the children of a dropped bucket go away without their own events
+		if message.OldEntry.IsDirectory && oldPath != newPath {
+			if err := si.deleteTree(batch, oldPath); err != nil {
+				return err
+			}
+		}
+	}
+	if newPath != "" {
+		if newPath != oldPath {
+			if err := si.deleteDoc(batch, newPath); err != nil {
+				return err
+			}
+		}
+		if err := putDoc(batch, newPath, message.NewEntry); err != nil {
+			return err
+		}
+	}
+	batch.Put(offsetKey, offsetBytes(resp.TsNs))
+	return si.db.Write(batch, nil)
+}
+
+func putDoc(batch *leveldb.Batch, path string, entry *filer_pb.Entry) error {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %v", path, err)
+	}
+	batch.Put(docKey(path), data)
+	for _, term := range EntryTerms(entry) {
+		batch.Put(termKey(term, path), nil)
+	}
+	return nil
+}
+
+func (si *SearchIndex) deleteDoc(batch *leveldb.Batch, path string) error {
+	key := docKey(path)
+	data, err := si.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	deleteDocData(batch, path, key, data)
+	return nil
+}
+
+func deleteDocData(batch *leveldb.Batch, path string, key, data []byte) {
+	batch.Delete(key)
+	entry := &filer_pb.Entry{}
+	if err := proto.Unmarshal(data, entry); err != nil {
+		glog.Warningf("search index: unmarshal %s: %v", path, err)
+		return
+	}
+	for _, term := range EntryTerms(entry) {
+		batch.Delete(termKey(term, path))
+	}
+}
+
+func (si *SearchIndex) deleteTree(batch *leveldb.Batch, dir string) error {
+	iter := si.db.NewIterator(leveldb_util.BytesPrefix(docKey(childPrefix(dir))), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := bytes.Clone(iter.Key())
+		deleteDocData(batch, string(key[1:]), key, iter.Value())
+	}
+	return iter.Error()
+}
+
+// Search calls fn for each indexed entry under the request directory that matches the request,
+// up to the request limit. Returning false from fn stops the search.
+func (si *SearchIndex) Search(req *filer_pb.SearchEntriesRequest, fn func(dir string, entry *filer_pb.Entry) bool) error {
+	matcher, err := NewMatcher(req)
+	if err != nil {
+		return err
+	}
+	dirPrefix := childPrefix(req.Directory)
+
+	var count uint32
+	emit := func(path string, entry *filer_pb.Entry) bool {
+		if !matcher.Match(entry) {
+			return true
+		}
+		dir, _ := util.FullPath(path).DirAndName()
+		if !fn(dir, entry) {
+			return false
+		}
+		count++
+		return req.Limit == 0 || count < req.Limit
+	}
+
+	terms := matcher.Terms()
+	if len(terms) == 0 {
+		iter := si.db.NewIterator(leveldb_util.BytesPrefix(docKey(dirPrefix)), nil)
+		defer iter.Release()
+		for iter.Next() {
+			entry := &filer_pb.Entry{}
+			if err := proto.Unmarshal(iter.Value(), entry); err != nil {
+				continue
+			}
+			if !emit(string(iter.Key()[1:]), entry) {
+				break
+			}
+		}
+		return iter.Error()
+	}
+
+	// walk the postings of the first term, and check the other conditions on each entry
+	term, isPrefix := strings.CutSuffix(terms[0], "*")
+	seekPrefix := []byte{termPrefix}
+	seekPrefix = append(seekPrefix, term...)
+	if !isPrefix {
+		seekPrefix = append(seekPrefix, termSeparator)
+	}
+	seen := make(map[string]struct{})
+	iter := si.db.NewIterator(leveldb_util.BytesPrefix(seekPrefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		separatorIndex := bytes.IndexByte(key[len(seekPrefix)-1:], termSeparator)
+		if separatorIndex < 0 {
+			continue
+		}
+		path := string(key[len(seekPrefix)-1+separatorIndex+1:])
+		if !strings.HasPrefix(path, dirPrefix) {
+			continue
+		}
+		if _, found := seen[path]; found {
+			continue
+		}
+		seen[path] = struct{}{}
+		data, err := si.db.Get(docKey(path), nil)
+		if err != nil {
+			continue
+		}
+		entry := &filer_pb.Entry{}
+		if err := proto.Unmarshal(data, entry); err != nil {
+			continue
+		}
+		if !emit(path, entry) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+func childPrefix(dir string) string {
+	if dir == "" || dir == "/" {
+		return "/"
+	}
+	return strings.TrimSuffix(dir, "/") + "/"
+}
+
+func docKey(path string) []byte {
+	key := make([]byte, 0, 1+len(path))
+	key = append(key, docPrefix)
+	return append(key, path...)
+}
+
+func termKey(term, path string) []byte {
+	key := make([]byte, 0, 2+len(term)+len(path))
+	key = append(key, termPrefix)
+	key = append(key, term...)
+	key = append(key, termSeparator)
+	return append(key, path...)
+}
@@ -0,0 +1,105 @@
+package search_index
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func searchPaths(t *testing.T, si *SearchIndex, req *filer_pb.SearchEntriesRequest) map[string]bool {
+	paths := make(map[string]bool)
+	if err := si.Search(req, func(dir string, entry *filer_pb.Entry) bool {
+		paths[string(util.NewFullPath(dir, entry.Name))] = true
+		return true
+	}); err != nil {
+		t.Fatalf("search %v: %v", req, err)
+	}
+	return paths
+}
+
+func TestSearchIndex(t *testing.T) {
+	si, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer si.Close()
+
+	report := &filer_pb.Entry{
+		Name:       "Quarterly-Report.pdf",
+		Attributes: &filer_pb.FuseAttributes{FileSize: 2048, Mime: "application/pdf", Mtime: 1000},
+		Extended:   map[string][]byte{"X-Amz-Tagging-project": []byte("apollo")},
+	}
+	photo := &filer_pb.Entry{
+		Name:       "beach.jpg",
+		Attributes: &filer_pb.FuseAttributes{FileSize: 4096, Mime: "image/jpeg", Mtime: 2000},
+	}
+	if err := si.Put("/docs", report); err != nil {
+		t.Fatal(err)
+	}
+	if err := si.Put("/photos", photo); err != nil {
+		t.Fatal(err)
+	}
+	if err := si.Put("/", &filer_pb.Entry{Name: "docs", IsDirectory: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		req  *filer_pb.SearchEntriesRequest
+		want []string
+	}{
+		{&filer_pb.SearchEntriesRequest{Terms: []string{"report"}}, []string{"/docs/Quarterly-Report.pdf"}},
+		{&filer_pb.SearchEntriesRequest{Terms: []string{"quart*", "pdf"}}, []string{"/docs/Quarterly-Report.pdf"}},
+		{&filer_pb.SearchEntriesRequest{Terms: []string{"apollo"}}, []string{"/docs/Quarterly-Report.pdf"}},
+		{&filer_pb.SearchEntriesRequest{Directory: "/photos", Terms: []string{"report"}}, nil},
+		{&filer_pb.SearchEntriesRequest{MimePrefix: "image/"}, []string{"/photos/beach.jpg"}},
+		{&filer_pb.SearchEntriesRequest{MinSize: 3000}, []string{"/photos/beach.jpg"}},
+		{&filer_pb.SearchEntriesRequest{MtimeBefore: 1500, FilesOnly: true}, []string{"/docs/Quarterly-Report.pdf"}},
+		{&filer_pb.SearchEntriesRequest{NamePattern: "*.jpg"}, []string{"/photos/beach.jpg"}},
+		{&filer_pb.SearchEntriesRequest{DirectoriesOnly: true}, []string{"/docs"}},
+		{&filer_pb.SearchEntriesRequest{Extended: map[string]string{"X-Amz-Tagging-project": "apollo"}}, []string{"/docs/Quarterly-Report.pdf"}},
+		{&filer_pb.SearchEntriesRequest{Extended: map[string]string{"X-Amz-Tagging-project": "gemini"}}, nil},
+	}
+	for _, tt := range tests {
+		got := searchPaths(t, si, tt.req)
+		if len(got) != len(tt.want) {
+			t.Errorf("search %v: got %v, want %v", tt.req, got, tt.want)
+			continue
+		}
+		for _, path := range tt.want {
+			if !got[path] {
+				t.Errorf("search %v: got %v, want %v", tt.req, got, tt.want)
+			}
+		}
+	}
+
+	// rename the report, then drop the docs directory
+	renamed := &filer_pb.Entry{Name: "summary.pdf", Attributes: report.Attributes}
+	if err := si.Update(&filer_pb.SubscribeMetadataResponse{
+		Directory:         "/docs",
+		TsNs:              10,
+		EventNotification: &filer_pb.EventNotification{OldEntry: report, NewEntry: renamed, NewParentPath: "/docs"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchPaths(t, si, &filer_pb.SearchEntriesRequest{Terms: []string{"report"}}); len(got) != 0 {
+		t.Errorf("renamed entry still found by old name: %v", got)
+	}
+	if got := searchPaths(t, si, &filer_pb.SearchEntriesRequest{Terms: []string{"summary"}}); !got["/docs/summary.pdf"] {
+		t.Errorf("renamed entry not found by new name: %v", got)
+	}
+
+	if err := si.Update(&filer_pb.SubscribeMetadataResponse{
+		Directory:         "/",
+		TsNs:              20,
+		EventNotification: &filer_pb.EventNotification{OldEntry: &filer_pb.Entry{Name: "docs", IsDirectory: true}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchPaths(t, si, &filer_pb.SearchEntriesRequest{}); len(got) != 1 || !got["/photos/beach.jpg"] {
+		t.Errorf("after deleting /docs: %v", got)
+	}
+	if offset, err := si.Offset(); err != nil || offset != 20 {
+		t.Errorf("offset %d %v, want 20", offset, err)
+	}
+}
@@ -66,3 +66,32 @@ func makeEntry(fullPath util.FullPath, isDirectory bool) *filer.Entry {
 		},
 	}
 }
+
+// TestKvList checks that a store listing its key value pairs lists the pairs, but not the entries
+func TestKvList(t *testing.T, store filer.FilerStore) {
+	ctx := context.Background()
+	lister, ok := store.(filer.KvLister)
+	if !ok {
+		t.Fatalf("store %s can not list key value pairs", store.GetName())
+	}
+
+	assert.Nil(t, store.InsertEntry(ctx, makeEntry(util.FullPath("/home"), true)), "insert directory")
+	assert.Nil(t, store.InsertEntry(ctx, makeEntry(util.FullPath("/home/chris"), true)), "insert directory")
+	assert.Nil(t, store.InsertEntry(ctx, makeEntry(util.FullPath("/home/chris/file1.jpg"), false)), "insert entry")
+	expected := map[string]string{
+		"key1":          "value1",
+		"/not/an/entry": "value2",
+	}
+	for k, v := range expected {
+		assert.Nil(t, store.KvPut(ctx, []byte(k), []byte(v)), "KV put")
+	}
+
+	listed := make(map[string]string)
+	err := lister.KvList(ctx, func(key, value []byte) error {
+		listed[string(key)] = string(value)
+		return nil
+	})
+	assert.Nil(t, err, "KV list")
+	delete(listed, filer.FilerStoreId)
+	assert.Equal(t, expected, listed, "KV listed")
+}
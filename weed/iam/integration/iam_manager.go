@@ -275,6 +275,21 @@ func (m *IAMManager) AssumeRoleWithCredentials(ctx context.Context, request *sts
 	return m.stsService.AssumeRoleWithCredentials(ctx, request)
 }
 
+// AssumeRole assumes a role for an authenticated caller, like an IAM user
+func (m *IAMManager) AssumeRole(ctx context.Context, request *sts.AssumeRoleRequest) (*sts.AssumeRoleResponse, error) {
+	if !m.initialized {
+		return nil, fmt.Errorf("IAM manager not initialized")
+	}
+
+	// Validate trust policy before allowing STS to assume the role
+	if err := m.ValidateTrustPolicyForCaller(ctx, request.RoleArn, request.CallerArn); err != nil {
+		return nil, fmt.Errorf("trust policy validation failed: %w", err)
+	}
+
+	// Use STS service to assume the role
+	return m.stsService.AssumeRole(ctx, request)
+}
+
 // IsActionAllowed checks if a principal is allowed to perform an action on a resource
 func (m *IAMManager) IsActionAllowed(ctx context.Context, request *ActionRequest) (bool, error) {
 	if !m.initialized {
@@ -660,3 +675,38 @@ func (m *IAMManager) ValidateTrustPolicyForCredentials(ctx context.Context, role
 	// Use existing trust policy validation logic
 	return m.validateTrustPolicyForCredentials(ctx, roleDef, mockRequest)
 }
+
+// ValidateTrustPolicyForCaller implements the sts.CallerTrustPolicyValidator interface.
+// The caller must be an AWS principal of an Allow statement for sts:AssumeRole.
+func (m *IAMManager) ValidateTrustPolicyForCaller(ctx context.Context, roleArn string, callerArn string) error {
+	if !m.initialized {
+		return fmt.Errorf("IAM manager not initialized")
+	}
+
+	// Extract role name from ARN
+	roleName := utils.ExtractRoleNameFromArn(roleArn)
+
+	// Get role definition
+	roleDef, err := m.roleStore.GetRole(ctx, m.getFilerAddress(), roleName)
+	if err != nil {
+		return fmt.Errorf("role not found: %s", roleName)
+	}
+
+	if roleDef.TrustPolicy == nil {
+		return fmt.Errorf("role has no trust policy")
+	}
+
+	evalCtx := &policy.EvaluationContext{
+		Principal: callerArn,
+		Action:    sts.ActionAssumeRole,
+		Resource:  roleDef.RoleArn,
+		RequestContext: map[string]interface{}{
+			"seaweed:AWSPrincipal": callerArn,
+		},
+	}
+	if !m.evaluateTrustPolicy(roleDef.TrustPolicy, evalCtx) {
+		return fmt.Errorf("trust policy does not allow %s to assume the role", callerArn)
+	}
+
+	return nil
+}
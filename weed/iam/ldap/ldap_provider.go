@@ -0,0 +1,687 @@
+package ldap
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
+)
+
+const (
+	defaultUserFilter         = "(uid=%s)"
+	defaultGroupFilter        = "(member=%s)"
+	defaultUserIdAttribute    = "uid"
+	defaultEmailAttribute     = "mail"
+	defaultDisplayNameAttr    = "cn"
+	defaultMemberOfAttribute  = "memberOf"
+	defaultGroupNameAttribute = "cn"
+	defaultPoolSize           = 4
+	defaultTimeout            = 10 * time.Second
+	defaultCacheTTL           = 5 * time.Minute
+	maxCacheEntries           = 10000
+)
+
+var (
+	errUserNotFound       = errors.New("user not found")
+	errInvalidCredentials = errors.New("invalid credentials")
+)
+
+// LDAPConfig holds the configuration of an LDAP or Active Directory provider
+type LDAPConfig struct {
+	// Servers are the LDAP URLs tried in order, e.g. ldap://dc1:389 or ldaps://dc1:636
+	Servers []string `json:"servers"`
+
+	// StartTLS upgrades ldap:// connections with the StartTLS extended operation
+	StartTLS bool `json:"startTLS,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CACertFile is a PEM file with the certificate authorities of the server certificate
+	CACertFile string `json:"caCertFile,omitempty"`
+
+	// BindDN and BindPassword are the service account searching the directory (anonymous if empty)
+	BindDN       string `json:"bindDN,omitempty"`
+	BindPassword string `json:"bindPassword,omitempty"`
+
+	// BaseDN is where the users are searched
+	BaseDN string `json:"baseDN"`
+
+	// UserFilter finds a user, %s is replaced by the escaped username (default "(uid=%s)";
+	// use "(sAMAccountName=%s)" for Active Directory)
+	UserFilter string `json:"userFilter,omitempty"`
+
+	// UserIdAttribute, EmailAttribute and DisplayNameAttribute map the user entry to the identity
+	UserIdAttribute      string `json:"userIdAttribute,omitempty"`
+	EmailAttribute       string `json:"emailAttribute,omitempty"`
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+
+	// MemberOfAttribute lists the group DNs of a user entry (default "memberOf")
+	MemberOfAttribute string `json:"memberOfAttribute,omitempty"`
+
+	// GroupBaseDN enables searching the groups of a user, for directories without memberOf
+	GroupBaseDN string `json:"groupBaseDN,omitempty"`
+
+	// GroupFilter finds the groups of a user, %s is replaced by the user DN and %u by the username
+	// (default "(member=%s)"; use "(memberUid=%u)" for posixGroup)
+	GroupFilter string `json:"groupFilter,omitempty"`
+
+	// GroupNameAttribute is the attribute naming a group (default "cn")
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+
+	// Attributes are additional user attributes copied to the identity
+	Attributes []string `json:"attributes,omitempty"`
+
+	// PoolSize is the number of idle connections kept open (default 4)
+	PoolSize int `json:"poolSize,omitempty"`
+
+	// TimeoutSeconds limits dialing and each request (default 10 seconds)
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+
+	// CacheTTLSeconds sets how long successful lookups are cached (default 300 seconds, -1 disables caching)
+	CacheTTLSeconds int `json:"cacheTTLSeconds,omitempty"`
+
+	// RoleMapping maps the groups of a user to roles
+	RoleMapping *providers.RoleMapping `json:"roleMapping,omitempty"`
+}
+
+// LDAPProvider authenticates users against an LDAP directory or Active Directory
+type LDAPProvider struct {
+	name        string
+	config      *LDAPConfig
+	initialized bool
+	tlsConfig   *tls.Config
+	timeout     time.Duration
+	cacheTTL    time.Duration
+	pool        chan *goldap.Conn
+
+	cacheLock sync.Mutex
+	cache     map[string]cachedIdentity
+}
+
+type cachedIdentity struct {
+	identity  *providers.ExternalIdentity
+	expiresAt time.Time
+}
+
+// NewLDAPProvider creates a new LDAP provider
+func NewLDAPProvider(name string) *LDAPProvider {
+	return &LDAPProvider{
+		name: name,
+	}
+}
+
+// NewLDAPProviderFromFile creates an LDAP provider configured by a JSON file of LDAPConfig
+func NewLDAPProviderFromFile(name, configFile string) (*LDAPProvider, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("read ldap config %s: %w", configFile, err)
+	}
+	config := &LDAPConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse ldap config %s: %w", configFile, err)
+	}
+	provider := NewLDAPProvider(name)
+	if err := provider.Initialize(config); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Name returns the provider name
+func (p *LDAPProvider) Name() string {
+	return p.name
+}
+
+// Initialize initializes the LDAP provider with configuration
+func (p *LDAPProvider) Initialize(config interface{}) error {
+	if config == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+
+	ldapConfig, ok := config.(*LDAPConfig)
+	if !ok {
+		return fmt.Errorf("invalid config type for LDAP provider")
+	}
+
+	if err := p.validateConfig(ldapConfig); err != nil {
+		return fmt.Errorf("invalid LDAP configuration: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: ldapConfig.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if ldapConfig.CACertFile != "" {
+		pem, err := os.ReadFile(ldapConfig.CACertFile)
+		if err != nil {
+			return fmt.Errorf("read CA certificate %s: %w", ldapConfig.CACertFile, err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in %s", ldapConfig.CACertFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	p.config = ldapConfig
+	p.tlsConfig = tlsConfig
+	p.timeout = defaultTimeout
+	if ldapConfig.TimeoutSeconds > 0 {
+		p.timeout = time.Duration(ldapConfig.TimeoutSeconds) * time.Second
+	}
+	p.cacheTTL = defaultCacheTTL
+	if ldapConfig.CacheTTLSeconds > 0 {
+		p.cacheTTL = time.Duration(ldapConfig.CacheTTLSeconds) * time.Second
+	} else if ldapConfig.CacheTTLSeconds < 0 {
+		p.cacheTTL = 0
+	}
+	poolSize := defaultPoolSize
+	if ldapConfig.PoolSize > 0 {
+		poolSize = ldapConfig.PoolSize
+	}
+	p.pool = make(chan *goldap.Conn, poolSize)
+	p.cache = make(map[string]cachedIdentity)
+	p.initialized = true
+
+	return nil
+}
+
+// validateConfig validates the LDAP configuration
+func (p *LDAPProvider) validateConfig(config *LDAPConfig) error {
+	if len(config.Servers) == 0 {
+		return fmt.Errorf("at least one server is required")
+	}
+
+	for _, server := range config.Servers {
+		u, err := url.Parse(server)
+		if err != nil {
+			return fmt.Errorf("invalid server URL %s: %w", server, err)
+		}
+		switch u.Scheme {
+		case "ldap":
+		case "ldaps":
+			if config.StartTLS {
+				return fmt.Errorf("startTLS cannot be used with ldaps server %s", server)
+			}
+		default:
+			return fmt.Errorf("unsupported server URL %s, expected ldap:// or ldaps://", server)
+		}
+	}
+
+	if config.BaseDN == "" {
+		return fmt.Errorf("baseDN is required")
+	}
+
+	if config.BindDN != "" && config.BindPassword == "" {
+		return fmt.Errorf("bindPassword is required with bindDN")
+	}
+
+	return nil
+}
+
+// Close closes the pooled connections
+func (p *LDAPProvider) Close() {
+	if p.pool == nil {
+		return
+	}
+	for {
+		select {
+		case conn := <-p.pool:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// Authenticate verifies the credentials of a user, in username:password format,
+// by binding as the user, and returns the user's identity
+func (p *LDAPProvider) Authenticate(ctx context.Context, credentials string) (*providers.ExternalIdentity, error) {
+	if !p.initialized {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+
+	if credentials == "" {
+		return nil, fmt.Errorf("credentials cannot be empty")
+	}
+
+	username, password, found := strings.Cut(credentials, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid credentials format (expected username:password)")
+	}
+	// an empty password would be an unauthenticated bind, which most servers accept
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	cacheKey := credentialsCacheKey(username, password)
+	if identity, found := p.getCached(cacheKey); found {
+		return identity, nil
+	}
+
+	var identity *providers.ExternalIdentity
+	err := p.withConnection(func(conn *goldap.Conn) error {
+		entry, err := p.searchUser(conn, username)
+		if err != nil {
+			return err
+		}
+
+		bindErr := conn.Bind(entry.DN, password)
+		// the pooled connections stay bound as the service account
+		if err := p.bindServiceAccount(conn); err != nil {
+			return err
+		}
+		if bindErr != nil {
+			if goldap.IsErrorWithCode(bindErr, goldap.LDAPResultInvalidCredentials) {
+				return errInvalidCredentials
+			}
+			return fmt.Errorf("bind as %s: %w", entry.DN, bindErr)
+		}
+
+		identity, err = p.toIdentity(conn, username, entry)
+		return err
+	})
+	if err != nil {
+		glog.V(2).Infof("ldap provider %s: authenticate %s: %v", p.name, username, err)
+		return nil, err
+	}
+
+	p.putCached(cacheKey, identity)
+	return identity, nil
+}
+
+// GetUserInfo looks up a user by username
+func (p *LDAPProvider) GetUserInfo(ctx context.Context, userID string) (*providers.ExternalIdentity, error) {
+	if !p.initialized {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	cacheKey := "user:" + userID
+	if identity, found := p.getCached(cacheKey); found {
+		return identity, nil
+	}
+
+	var identity *providers.ExternalIdentity
+	err := p.withConnection(func(conn *goldap.Conn) error {
+		entry, err := p.searchUser(conn, userID)
+		if err != nil {
+			return err
+		}
+		identity, err = p.toIdentity(conn, userID, entry)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.putCached(cacheKey, identity)
+	return identity, nil
+}
+
+// ValidateToken verifies credentials in username:password format and returns them as claims
+func (p *LDAPProvider) ValidateToken(ctx context.Context, token string) (*providers.TokenClaims, error) {
+	identity, err := p.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := p.identityClaims(identity)
+	claims.IssuedAt = time.Now()
+	return claims, nil
+}
+
+func (p *LDAPProvider) identityClaims(identity *providers.ExternalIdentity) *providers.TokenClaims {
+	return &providers.TokenClaims{
+		Subject: identity.UserID,
+		Issuer:  p.name,
+		Claims: map[string]interface{}{
+			"ldap_dn":  identity.Attributes["dn"],
+			"email":    identity.Email,
+			"name":     identity.DisplayName,
+			"groups":   identity.Groups,
+			"provider": p.name,
+		},
+	}
+}
+
+// searchUser finds the single entry of a user
+func (p *LDAPProvider) searchUser(conn *goldap.Conn, username string) (*goldap.Entry, error) {
+	filter := p.config.UserFilter
+	if filter == "" {
+		filter = defaultUserFilter
+	}
+	filter = strings.ReplaceAll(filter, "%s", goldap.EscapeFilter(username))
+
+	attributes := []string{
+		p.userIdAttribute(),
+		p.attributeOrDefault(p.config.EmailAttribute, defaultEmailAttribute),
+		p.attributeOrDefault(p.config.DisplayNameAttribute, defaultDisplayNameAttr),
+		p.attributeOrDefault(p.config.MemberOfAttribute, defaultMemberOfAttribute),
+	}
+	attributes = append(attributes, p.config.Attributes...)
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		p.config.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, int(p.timeout/time.Second), false,
+		filter, attributes, nil,
+	))
+	if err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultNoSuchObject) {
+			return nil, errUserNotFound
+		}
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+			return nil, fmt.Errorf("filter %s matches more than one user", filter)
+		}
+		return nil, fmt.Errorf("search %s: %w", filter, err)
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return nil, errUserNotFound
+	case 1:
+		return result.Entries[0], nil
+	default:
+		return nil, fmt.Errorf("filter %s matches more than one user", filter)
+	}
+}
+
+// searchGroups finds the names of the groups listing a user as a member
+func (p *LDAPProvider) searchGroups(conn *goldap.Conn, username, userDN string) ([]string, error) {
+	filter := p.config.GroupFilter
+	if filter == "" {
+		filter = defaultGroupFilter
+	}
+	filter = strings.ReplaceAll(filter, "%s", goldap.EscapeFilter(userDN))
+	filter = strings.ReplaceAll(filter, "%u", goldap.EscapeFilter(username))
+	groupNameAttribute := p.attributeOrDefault(p.config.GroupNameAttribute, defaultGroupNameAttribute)
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		p.config.GroupBaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, int(p.timeout/time.Second), false,
+		filter, []string{groupNameAttribute}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("search groups %s: %w", filter, err)
+	}
+
+	var groups []string
+	for _, entry := range result.Entries {
+		if name := entry.GetAttributeValue(groupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// toIdentity converts a user entry to an identity, with its groups and mapped roles
+func (p *LDAPProvider) toIdentity(conn *goldap.Conn, username string, entry *goldap.Entry) (*providers.ExternalIdentity, error) {
+	userID := entry.GetAttributeValue(p.userIdAttribute())
+	if userID == "" {
+		userID = username
+	}
+
+	var groups []string
+	for _, groupDN := range entry.GetAttributeValues(p.attributeOrDefault(p.config.MemberOfAttribute, defaultMemberOfAttribute)) {
+		groups = append(groups, groupNameOf(groupDN))
+	}
+	if p.config.GroupBaseDN != "" {
+		searched, err := p.searchGroups(conn, username, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, searched...)
+	}
+
+	attributes := map[string]string{
+		"dn": entry.DN,
+	}
+	for _, name := range p.config.Attributes {
+		if value := entry.GetAttributeValue(name); value != "" {
+			attributes[name] = value
+		}
+	}
+
+	identity := &providers.ExternalIdentity{
+		UserID:      userID,
+		Email:       entry.GetAttributeValue(p.attributeOrDefault(p.config.EmailAttribute, defaultEmailAttribute)),
+		DisplayName: entry.GetAttributeValue(p.attributeOrDefault(p.config.DisplayNameAttribute, defaultDisplayNameAttr)),
+		Groups:      dedupe(groups),
+		Attributes:  attributes,
+		Provider:    p.name,
+	}
+
+	if roles := p.mapGroupsToRoles(identity); len(roles) > 0 {
+		// Store roles as a comma-separated string in attributes, as the OIDC provider does
+		attributes["roles"] = strings.Join(roles, ",")
+	}
+
+	return identity, nil
+}
+
+// mapGroupsToRoles maps the identity to roles using the configured role mapping
+func (p *LDAPProvider) mapGroupsToRoles(identity *providers.ExternalIdentity) []string {
+	if p.config.RoleMapping == nil {
+		return nil
+	}
+
+	claims := p.identityClaims(identity)
+	var roles []string
+	for _, rule := range p.config.RoleMapping.Rules {
+		if rule.Matches(claims) {
+			roles = append(roles, rule.Role)
+		}
+	}
+	if len(roles) == 0 && p.config.RoleMapping.DefaultRole != "" {
+		roles = []string{p.config.RoleMapping.DefaultRole}
+	}
+	return dedupe(roles)
+}
+
+// withConnection runs fn on a pooled connection bound as the service account.
+// A connection is put back into the pool unless fn failed unexpectedly,
+// and a broken pooled connection is retried once on a new connection.
+func (p *LDAPProvider) withConnection(fn func(conn *goldap.Conn) error) error {
+	for attempt := 0; ; attempt++ {
+		conn, pooled, err := p.getConnection()
+		if err != nil {
+			return err
+		}
+
+		err = fn(conn)
+		if err == nil || errors.Is(err, errUserNotFound) || errors.Is(err, errInvalidCredentials) {
+			p.putConnection(conn)
+			return err
+		}
+
+		conn.Close()
+		if pooled && attempt == 0 && goldap.IsErrorWithCode(err, goldap.ErrorNetwork) {
+			glog.V(1).Infof("ldap provider %s: retry on a new connection: %v", p.name, err)
+			continue
+		}
+		return err
+	}
+}
+
+func (p *LDAPProvider) getConnection() (conn *goldap.Conn, pooled bool, err error) {
+	for {
+		select {
+		case conn = <-p.pool:
+			if conn.IsClosing() {
+				conn.Close()
+				continue
+			}
+			return conn, true, nil
+		default:
+			conn, err = p.dial()
+			return conn, false, err
+		}
+	}
+}
+
+func (p *LDAPProvider) putConnection(conn *goldap.Conn) {
+	if conn.IsClosing() {
+		return
+	}
+	select {
+	case p.pool <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// dial connects to the first reachable server and binds as the service account
+func (p *LDAPProvider) dial() (*goldap.Conn, error) {
+	var lastErr error
+	for _, server := range p.config.Servers {
+		conn, err := p.dialServer(server)
+		if err != nil {
+			glog.V(1).Infof("ldap provider %s: %v", p.name, err)
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	return nil, lastErr
+}
+
+func (p *LDAPProvider) dialServer(server string) (*goldap.Conn, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %s: %w", server, err)
+	}
+	tlsConfig := p.tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	conn, err := goldap.DialURL(server,
+		goldap.DialWithDialer(&net.Dialer{Timeout: p.timeout}),
+		goldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", server, err)
+	}
+	conn.SetTimeout(p.timeout)
+
+	if p.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start tls with %s: %w", server, err)
+		}
+	}
+
+	if err := p.bindServiceAccount(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (p *LDAPProvider) bindServiceAccount(conn *goldap.Conn) error {
+	if p.config.BindDN == "" {
+		if err := conn.UnauthenticatedBind(""); err != nil {
+			return fmt.Errorf("anonymous bind: %w", err)
+		}
+		return nil
+	}
+	if err := conn.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+		return fmt.Errorf("bind as %s: %w", p.config.BindDN, err)
+	}
+	return nil
+}
+
+func (p *LDAPProvider) getCached(key string) (*providers.ExternalIdentity, bool) {
+	if p.cacheTTL <= 0 {
+		return nil, false
+	}
+	p.cacheLock.Lock()
+	defer p.cacheLock.Unlock()
+	cached, found := p.cache[key]
+	if !found {
+		return nil, false
+	}
+	if time.Now().After(cached.expiresAt) {
+		delete(p.cache, key)
+		return nil, false
+	}
+	return cached.identity, true
+}
+
+func (p *LDAPProvider) putCached(key string, identity *providers.ExternalIdentity) {
+	if p.cacheTTL <= 0 {
+		return
+	}
+	p.cacheLock.Lock()
+	defer p.cacheLock.Unlock()
+	now := time.Now()
+	if len(p.cache) >= maxCacheEntries {
+		for k, cached := range p.cache {
+			if now.After(cached.expiresAt) {
+				delete(p.cache, k)
+			}
+		}
+		if len(p.cache) >= maxCacheEntries {
+			p.cache = make(map[string]cachedIdentity)
+		}
+	}
+	p.cache[key] = cachedIdentity{
+		identity:  identity,
+		expiresAt: now.Add(p.cacheTTL),
+	}
+}
+
+func (p *LDAPProvider) userIdAttribute() string {
+	return p.attributeOrDefault(p.config.UserIdAttribute, defaultUserIdAttribute)
+}
+
+func (p *LDAPProvider) attributeOrDefault(attribute, defaultAttribute string) string {
+	if attribute == "" {
+		return defaultAttribute
+	}
+	return attribute
+}
+
+// credentialsCacheKey keys the cache without keeping the password in memory
+func credentialsCacheKey(username, password string) string {
+	sum := sha256.Sum256([]byte(username + "\x00" + password))
+	return "auth:" + hex.EncodeToString(sum[:])
+}
+
+// groupNameOf returns the value of the first RDN of a group DN, e.g. admins of CN=admins,OU=Groups,DC=example,DC=com
+func groupNameOf(groupDN string) string {
+	dn, err := goldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return groupDN
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
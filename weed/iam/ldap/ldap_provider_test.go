@@ -0,0 +1,504 @@
+package ldap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseDN      = "dc=example,dc=com"
+	testServiceDN   = "cn=service,dc=example,dc=com"
+	testServicePass = "service-secret"
+	startTLSOID     = "1.3.6.1.4.1.1466.20037"
+)
+
+// testDirectoryEntry is an entry of the in-process LDAP server
+type testDirectoryEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// testLDAPServer is a minimal in-process LDAP server, answering simple binds, StartTLS
+// and searches with and, or, not, equality and present filters
+type testLDAPServer struct {
+	listener    net.Listener
+	entries     []*testDirectoryEntry
+	tlsConfig   *tls.Config
+	connections atomic.Int32
+	searches    atomic.Int32
+}
+
+func newTestDirectory() []*testDirectoryEntry {
+	return []*testDirectoryEntry{
+		{
+			dn:       testServiceDN,
+			password: testServicePass,
+		},
+		{
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+			password: "alice-password",
+			attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"uid":         {"alice"},
+				"cn":          {"Alice Admin"},
+				"mail":        {"alice@example.com"},
+				"department":  {"Storage"},
+				"memberOf":    {"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"},
+			},
+		},
+		{
+			dn:       "uid=bob,ou=people,dc=example,dc=com",
+			password: "bob-password",
+			attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"uid":         {"bob"},
+				"cn":          {"Bob User"},
+				"mail":        {"bob@example.com"},
+				"memberOf":    {"cn=users,ou=groups,dc=example,dc=com"},
+			},
+		},
+		{
+			dn: "cn=developers,ou=groups,dc=example,dc=com",
+			attributes: map[string][]string{
+				"objectClass": {"posixGroup"},
+				"cn":          {"developers"},
+				"memberUid":   {"bob"},
+			},
+		},
+	}
+}
+
+func startTestLDAPServer(t *testing.T, useTLS bool) *testLDAPServer {
+	server := &testLDAPServer{
+		entries:   newTestDirectory(),
+		tlsConfig: newTestServerTLSConfig(t),
+	}
+
+	var err error
+	if useTLS {
+		server.listener, err = tls.Listen("tcp", "127.0.0.1:0", server.tlsConfig)
+	} else {
+		server.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { server.listener.Close() })
+
+	go func() {
+		for {
+			conn, err := server.listener.Accept()
+			if err != nil {
+				return
+			}
+			server.connections.Add(1)
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *testLDAPServer) url(scheme string) string {
+	return scheme + "://" + s.listener.Addr().String()
+}
+
+func (s *testLDAPServer) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		switch request.Tag {
+		case goldap.ApplicationBindRequest:
+			name := string(request.Children[1].Data.Bytes())
+			password := string(request.Children[2].Data.Bytes())
+			s.reply(conn, messageID, ldapResult(goldap.ApplicationBindResponse, s.bind(name, password)))
+		case goldap.ApplicationSearchRequest:
+			s.search(conn, messageID, request)
+		case goldap.ApplicationExtendedRequest:
+			if string(request.Children[0].Data.Bytes()) != startTLSOID {
+				s.reply(conn, messageID, ldapResult(goldap.ApplicationExtendedResponse, goldap.LDAPResultProtocolError))
+				continue
+			}
+			s.reply(conn, messageID, ldapResult(goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess))
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+		case goldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (s *testLDAPServer) bind(name, password string) int64 {
+	if name == "" && password == "" {
+		return goldap.LDAPResultSuccess
+	}
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.dn, name) && entry.password != "" && entry.password == password {
+			return goldap.LDAPResultSuccess
+		}
+	}
+	return goldap.LDAPResultInvalidCredentials
+}
+
+func (s *testLDAPServer) search(conn net.Conn, messageID int64, request *ber.Packet) {
+	s.searches.Add(1)
+	baseDN := strings.ToLower(string(request.Children[0].Data.Bytes()))
+	sizeLimit := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+	var requested []string
+	for _, attribute := range request.Children[7].Children {
+		requested = append(requested, string(attribute.Data.Bytes()))
+	}
+
+	found := 0
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.dn), baseDN) || !entry.matches(filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(found) == sizeLimit {
+			s.reply(conn, messageID, ldapResult(goldap.ApplicationSearchResultDone, goldap.LDAPResultSizeLimitExceeded))
+			return
+		}
+		found++
+		s.reply(conn, messageID, entry.toSearchResultEntry(requested))
+	}
+	s.reply(conn, messageID, ldapResult(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess))
+}
+
+func (e *testDirectoryEntry) values(attribute string) []string {
+	for name, values := range e.attributes {
+		if strings.EqualFold(name, attribute) {
+			return values
+		}
+	}
+	return nil
+}
+
+func (e *testDirectoryEntry) matches(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !e.matches(child) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			if e.matches(child) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return !e.matches(filter.Children[0])
+	case goldap.FilterEqualityMatch:
+		expected := string(filter.Children[1].Data.Bytes())
+		for _, value := range e.values(string(filter.Children[0].Data.Bytes())) {
+			if strings.EqualFold(value, expected) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterPresent:
+		return len(e.values(string(filter.Data.Bytes()))) > 0
+	default:
+		return false
+	}
+}
+
+func (e *testDirectoryEntry) toSearchResultEntry(requested []string) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+	attributes := ber.NewSequence("Attributes")
+	for name, values := range e.attributes {
+		if len(requested) > 0 && !containsFold(requested, name) {
+			continue
+		}
+		attribute := ber.NewSequence("Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+	response.AppendChild(attributes)
+	return response
+}
+
+func (s *testLDAPServer) reply(conn net.Conn, messageID int64, response *ber.Packet) {
+	envelope := ber.NewSequence("LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	envelope.AppendChild(response)
+	conn.Write(envelope.Bytes())
+}
+
+func ldapResult(tag ber.Tag, resultCode int64) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, resultCode, "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return result
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// newTestServerTLSConfig creates a self-signed certificate for 127.0.0.1
+func newTestServerTLSConfig(t *testing.T) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
+
+func writeTestCACert(t *testing.T, server *testLDAPServer) string {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	der := server.tlsConfig.Certificates[0].Certificate[0]
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	return caFile
+}
+
+func newTestLDAPProvider(t *testing.T, config *LDAPConfig) *LDAPProvider {
+	if config.BaseDN == "" {
+		config.BaseDN = testBaseDN
+	}
+	provider := NewLDAPProvider("test-ldap")
+	require.NoError(t, provider.Initialize(config))
+	t.Cleanup(provider.Close)
+	return provider
+}
+
+func TestLDAPProviderInitialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  interface{}
+		wantErr string
+	}{
+		{"nil config", nil, "config cannot be nil"},
+		{"wrong config type", "ldap://localhost", "invalid config type"},
+		{"no servers", &LDAPConfig{BaseDN: testBaseDN}, "at least one server"},
+		{"unsupported scheme", &LDAPConfig{Servers: []string{"http://localhost"}, BaseDN: testBaseDN}, "unsupported server URL"},
+		{"startTLS with ldaps", &LDAPConfig{Servers: []string{"ldaps://localhost"}, StartTLS: true, BaseDN: testBaseDN}, "startTLS cannot be used"},
+		{"no base DN", &LDAPConfig{Servers: []string{"ldap://localhost"}}, "baseDN is required"},
+		{"bind DN without password", &LDAPConfig{Servers: []string{"ldap://localhost"}, BaseDN: testBaseDN, BindDN: testServiceDN}, "bindPassword is required"},
+		{"valid", &LDAPConfig{Servers: []string{"ldap://localhost"}, BaseDN: testBaseDN}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewLDAPProvider("test-ldap").Initialize(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLDAPProviderAuthenticate(t *testing.T) {
+	server := startTestLDAPServer(t, false)
+	provider := newTestLDAPProvider(t, &LDAPConfig{
+		Servers:      []string{"ldap://127.0.0.1:1", server.url("ldap")}, // the first server is down
+		BindDN:       testServiceDN,
+		BindPassword: testServicePass,
+		UserFilter:   "(&(objectClass=inetOrgPerson)(|(uid=%s)(mail=%s)))",
+		Attributes:   []string{"department"},
+		RoleMapping: &providers.RoleMapping{
+			Rules: []providers.MappingRule{
+				{Claim: "groups", Value: "admins", Role: "arn:seaweed:iam::role/AdminRole"},
+			},
+			DefaultRole: "arn:seaweed:iam::role/ReadOnlyRole",
+		},
+	})
+	ctx := context.Background()
+
+	identity, err := provider.Authenticate(ctx, "alice:alice-password")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", identity.UserID)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.Equal(t, "Alice Admin", identity.DisplayName)
+	assert.Equal(t, []string{"admins", "users"}, identity.Groups)
+	assert.Equal(t, "test-ldap", identity.Provider)
+	assert.Equal(t, "uid=alice,ou=people,dc=example,dc=com", identity.Attributes["dn"])
+	assert.Equal(t, "Storage", identity.Attributes["department"])
+	assert.Equal(t, "arn:seaweed:iam::role/AdminRole", identity.Attributes["roles"])
+
+	// users can login with their mail as well
+	identity, err = provider.Authenticate(ctx, "bob@example.com:bob-password")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", identity.UserID)
+	assert.Equal(t, "arn:seaweed:iam::role/ReadOnlyRole", identity.Attributes["roles"])
+
+	claims, err := provider.ValidateToken(ctx, "bob:bob-password")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", claims.Subject)
+	assert.True(t, claims.IsValid())
+
+	info, err := provider.GetUserInfo(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", info.Email)
+
+	for _, credentials := range []string{
+		"alice:wrong-password",
+		"alice:",
+		"nobody:password",
+		"*:alice-password",
+		"alice)(uid=*:alice-password",
+		"alice",
+	} {
+		_, err := provider.Authenticate(ctx, credentials)
+		assert.Error(t, err, credentials)
+	}
+}
+
+func TestLDAPProviderGroupSearch(t *testing.T) {
+	server := startTestLDAPServer(t, false)
+	provider := newTestLDAPProvider(t, &LDAPConfig{
+		Servers:      []string{server.url("ldap")},
+		BindDN:       testServiceDN,
+		BindPassword: testServicePass,
+		GroupBaseDN:  "ou=groups," + testBaseDN,
+		GroupFilter:  "(&(objectClass=posixGroup)(memberUid=%u))",
+	})
+
+	identity, err := provider.Authenticate(context.Background(), "bob:bob-password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users", "developers"}, identity.Groups)
+	assert.Empty(t, identity.Attributes["roles"])
+}
+
+func TestLDAPProviderPoolAndCache(t *testing.T) {
+	server := startTestLDAPServer(t, false)
+	provider := newTestLDAPProvider(t, &LDAPConfig{
+		Servers:      []string{server.url("ldap")},
+		BindDN:       testServiceDN,
+		BindPassword: testServicePass,
+	})
+	ctx := context.Background()
+
+	_, err := provider.Authenticate(ctx, "alice:alice-password")
+	require.NoError(t, err)
+	_, err = provider.Authenticate(ctx, "bob:wrong-password")
+	require.Error(t, err)
+	_, err = provider.Authenticate(ctx, "bob:bob-password")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), server.connections.Load(), "requests reuse the pooled connection")
+
+	searches := server.searches.Load()
+	_, err = provider.Authenticate(ctx, "alice:alice-password")
+	require.NoError(t, err)
+	assert.Equal(t, searches, server.searches.Load(), "successful authentication is cached")
+
+	// a failed password is not cached, and a cached password does not match another one
+	_, err = provider.Authenticate(ctx, "alice:other-password")
+	require.Error(t, err)
+	assert.Equal(t, searches+1, server.searches.Load())
+
+	// closing drops the pooled connections
+	provider.Close()
+	_, err = provider.GetUserInfo(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), server.connections.Load())
+
+	uncached := newTestLDAPProvider(t, &LDAPConfig{
+		Servers:         []string{server.url("ldap")},
+		BindDN:          testServiceDN,
+		BindPassword:    testServicePass,
+		CacheTTLSeconds: -1,
+	})
+	searches = server.searches.Load()
+	for i := 0; i < 2; i++ {
+		_, err = uncached.Authenticate(ctx, "alice:alice-password")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, searches+2, server.searches.Load())
+}
+
+func TestLDAPProviderTLS(t *testing.T) {
+	t.Run("StartTLS", func(t *testing.T) {
+		server := startTestLDAPServer(t, false)
+		provider := newTestLDAPProvider(t, &LDAPConfig{
+			Servers:    []string{server.url("ldap")},
+			StartTLS:   true,
+			CACertFile: writeTestCACert(t, server),
+		})
+		identity, err := provider.Authenticate(context.Background(), "alice:alice-password")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", identity.UserID)
+	})
+
+	t.Run("LDAPS", func(t *testing.T) {
+		server := startTestLDAPServer(t, true)
+		provider := newTestLDAPProvider(t, &LDAPConfig{
+			Servers:    []string{server.url("ldaps")},
+			CACertFile: writeTestCACert(t, server),
+		})
+		identity, err := provider.Authenticate(context.Background(), "bob:bob-password")
+		require.NoError(t, err)
+		assert.Equal(t, "bob", identity.UserID)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		server := startTestLDAPServer(t, true)
+		provider := newTestLDAPProvider(t, &LDAPConfig{
+			Servers: []string{server.url("ldaps")},
+		})
+		_, err := provider.Authenticate(context.Background(), "bob:bob-password")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Endpoints are the endpoints of an OIDC issuer used by the authorization code flow
+type Endpoints struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSUri               string `json:"jwks_uri"`
+}
+
+// DiscoverEndpoints reads the endpoints from the OpenID configuration of the issuer
+func (p *OIDCProvider) DiscoverEndpoints(ctx context.Context) (*Endpoints, error) {
+	if !p.initialized {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %v", err)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery endpoint returned status: %d", resp.StatusCode)
+	}
+
+	endpoints := &Endpoints{}
+	if err := json.NewDecoder(resp.Body).Decode(endpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery response: %v", err)
+	}
+	if endpoints.AuthorizationEndpoint == "" || endpoints.TokenEndpoint == "" {
+		return nil, fmt.Errorf("issuer %s has no authorization or token endpoint", p.config.Issuer)
+	}
+	if p.config.JWKSUri == "" {
+		p.config.JWKSUri = endpoints.JWKSUri
+	}
+	return endpoints, nil
+}
+
+// AuthCodeURL returns the URL to send the user to for login, coming back to redirectURL with a code
+func (p *OIDCProvider) AuthCodeURL(endpoints *Endpoints, redirectURL, state, nonce string) string {
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.config.ClientID},
+		"redirect_uri":  {redirectURL},
+		"scope":         {strings.Join(scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	separator := "?"
+	if strings.Contains(endpoints.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return endpoints.AuthorizationEndpoint + separator + params.Encode()
+}
+
+// ExchangeCode trades the code of a login for the ID token of the user
+func (p *OIDCProvider) ExchangeCode(ctx context.Context, endpoints *Endpoints, redirectURL, code string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURL},
+		"client_id":    {p.config.ClientID},
+	}
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoints.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange code: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %v", err)
+	}
+	if tokenResponse.Error != "" {
+		return "", fmt.Errorf("token endpoint: %s %s", tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned status: %d", resp.StatusCode)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("token endpoint returned no id_token")
+	}
+	return tokenResponse.IDToken, nil
+}
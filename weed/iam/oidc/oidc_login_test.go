@@ -0,0 +1,72 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOIDCProviderLogin tests the discovery, the login URL and the code exchange of the authorization code flow
+func TestOIDCProviderLogin(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 server.URL,
+				"authorization_endpoint": server.URL + "/authorize",
+				"token_endpoint":         server.URL + "/token",
+				"jwks_uri":               server.URL + "/keys",
+			})
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			if r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "https://admin.example.com/login/oidc/callback", r.PostForm.Get("redirect_uri"))
+			json.NewEncoder(w).Encode(map[string]string{"id_token": "the-id-token"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOIDCProvider("test-provider")
+	require.NoError(t, provider.Initialize(&OIDCConfig{
+		Issuer:       server.URL,
+		ClientID:     "admin-ui",
+		ClientSecret: "secret",
+	}))
+
+	endpoints, err := provider.DiscoverEndpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/token", endpoints.TokenEndpoint)
+	assert.Equal(t, server.URL+"/keys", provider.config.JWKSUri)
+
+	redirectURL := "https://admin.example.com/login/oidc/callback"
+	loginURL, err := url.Parse(provider.AuthCodeURL(endpoints, redirectURL, "the-state", "the-nonce"))
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", loginURL.Path)
+	assert.Equal(t, "code", loginURL.Query().Get("response_type"))
+	assert.Equal(t, "admin-ui", loginURL.Query().Get("client_id"))
+	assert.Equal(t, redirectURL, loginURL.Query().Get("redirect_uri"))
+	assert.Equal(t, "openid profile email", loginURL.Query().Get("scope"))
+	assert.Equal(t, "the-state", loginURL.Query().Get("state"))
+	assert.Equal(t, "the-nonce", loginURL.Query().Get("nonce"))
+
+	idToken, err := provider.ExchangeCode(context.Background(), endpoints, redirectURL, "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, "the-id-token", idToken)
+
+	_, err = provider.ExchangeCode(context.Background(), endpoints, redirectURL, "bad-code")
+	assert.ErrorContains(t, err, "invalid_grant")
+}
@@ -100,6 +100,8 @@ const (
 	ActionAssumeRoleWithWebIdentity = "sts:AssumeRoleWithWebIdentity"
 	ActionAssumeRoleWithCredentials = "sts:AssumeRoleWithCredentials"
 	ActionValidateSession           = "sts:ValidateSession"
+	ActionGetSessionToken           = "sts:GetSessionToken"
+	ActionGetCallerIdentity         = "sts:GetCallerIdentity"
 )
 
 // Session File Prefixes
@@ -1,9 +1,11 @@
 package sts
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/ldap"
 	"github.com/seaweedfs/seaweedfs/weed/iam/oidc"
 	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 )
@@ -66,8 +68,17 @@ func (f *ProviderFactory) createOIDCProvider(config *ProviderConfig) (providers.
 
 // createLDAPProvider creates an LDAP provider from configuration
 func (f *ProviderFactory) createLDAPProvider(config *ProviderConfig) (providers.IdentityProvider, error) {
-	// TODO: Implement LDAP provider when available
-	return nil, fmt.Errorf("LDAP provider not implemented yet")
+	ldapConfig, err := f.convertToLDAPConfig(config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert LDAP config: %w", err)
+	}
+
+	provider := ldap.NewLDAPProvider(config.Name)
+	if err := provider.Initialize(ldapConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize LDAP provider: %w", err)
+	}
+
+	return provider, nil
 }
 
 // createSAMLProvider creates a SAML provider from configuration
@@ -139,6 +150,28 @@ func (f *ProviderFactory) convertToOIDCConfig(configMap map[string]interface{})
 	return config, nil
 }
 
+// convertToLDAPConfig converts generic config map to LDAP config struct
+func (f *ProviderFactory) convertToLDAPConfig(configMap map[string]interface{}) (*ldap.LDAPConfig, error) {
+	if err := f.validateLDAPConfig(configMap); err != nil {
+		return nil, err
+	}
+
+	// The config map is decoded from JSON, so it converts back through the JSON tags of LDAPConfig
+	data, err := json.Marshal(configMap)
+	if err != nil {
+		return nil, err
+	}
+	config := &ldap.LDAPConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+
+	glog.V(3).Infof("Converted LDAP config: servers=%v, baseDN=%s, bindDN=%s",
+		config.Servers, config.BaseDN, config.BindDN)
+
+	return config, nil
+}
+
 // convertToStringSlice converts interface{} to []string
 func (f *ProviderFactory) convertToStringSlice(value interface{}) ([]string, error) {
 	switch v := value.(type) {
@@ -309,7 +342,14 @@ func (f *ProviderFactory) validateOIDCConfig(config map[string]interface{}) erro
 
 // validateLDAPConfig validates LDAP provider configuration
 func (f *ProviderFactory) validateLDAPConfig(config map[string]interface{}) error {
-	// TODO: Implement when LDAP provider is available
+	if _, ok := config["servers"]; !ok {
+		return fmt.Errorf("LDAP provider requires 'servers' field")
+	}
+
+	if _, ok := config["baseDN"]; !ok {
+		return fmt.Errorf("LDAP provider requires 'baseDN' field")
+	}
+
 	return nil
 }
 
@@ -321,5 +361,5 @@ func (f *ProviderFactory) validateSAMLConfig(config map[string]interface{}) erro
 
 // GetSupportedProviderTypes returns list of supported provider types
 func (f *ProviderFactory) GetSupportedProviderTypes() []string {
-	return []string{ProviderTypeOIDC}
+	return []string{ProviderTypeOIDC, ProviderTypeLDAP}
 }
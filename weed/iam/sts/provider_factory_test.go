@@ -30,6 +30,45 @@ func TestProviderFactory_CreateOIDCProvider(t *testing.T) {
 	assert.Equal(t, "test-oidc", provider.Name())
 }
 
+func TestProviderFactory_CreateLDAPProvider(t *testing.T) {
+	factory := NewProviderFactory()
+
+	config := &ProviderConfig{
+		Name:    "test-ldap",
+		Type:    "ldap",
+		Enabled: true,
+		Config: map[string]interface{}{
+			"servers":      []interface{}{"ldaps://ldap.example.com:636"},
+			"bindDN":       "cn=service,dc=example,dc=com",
+			"bindPassword": "service-secret",
+			"baseDN":       "dc=example,dc=com",
+			"userFilter":   "(sAMAccountName=%s)",
+			"roleMapping": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"claim": "groups", "value": "admins", "role": "arn:seaweed:iam::role/AdminRole"},
+				},
+			},
+		},
+	}
+
+	provider, err := factory.CreateProvider(config)
+	require.NoError(t, err)
+	assert.Equal(t, "test-ldap", provider.Name())
+
+	ldapConfig, err := factory.convertToLDAPConfig(config.Config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ldaps://ldap.example.com:636"}, ldapConfig.Servers)
+	assert.Equal(t, "(sAMAccountName=%s)", ldapConfig.UserFilter)
+	require.NotNil(t, ldapConfig.RoleMapping)
+	assert.Equal(t, "arn:seaweed:iam::role/AdminRole", ldapConfig.RoleMapping.Rules[0].Role)
+
+	// baseDN is required
+	delete(config.Config, "baseDN")
+	_, err = factory.CreateProvider(config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "baseDN")
+}
+
 // Note: Mock provider tests removed - mock providers are now test-only
 // and not available through the production ProviderFactory
 
@@ -262,7 +301,8 @@ func TestProviderFactory_GetSupportedProviderTypes(t *testing.T) {
 
 	supportedTypes := factory.GetSupportedProviderTypes()
 	assert.Contains(t, supportedTypes, "oidc")
-	assert.Len(t, supportedTypes, 1) // Currently only OIDC is supported in production
+	assert.Contains(t, supportedTypes, "ldap")
+	assert.Len(t, supportedTypes, 2)
 }
 
 func TestSTSService_LoadProvidersFromConfig(t *testing.T) {
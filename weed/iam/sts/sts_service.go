@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -22,6 +23,13 @@ type TrustPolicyValidator interface {
 	ValidateTrustPolicyForCredentials(ctx context.Context, roleArn string, identity *providers.ExternalIdentity) error
 }
 
+// CallerTrustPolicyValidator is implemented by trust policy validators that also validate
+// role assumption by an already authenticated caller, like an IAM user signing AssumeRole
+type CallerTrustPolicyValidator interface {
+	// ValidateTrustPolicyForCaller validates if the caller can assume a role
+	ValidateTrustPolicyForCaller(ctx context.Context, roleArn string, callerArn string) error
+}
+
 // FlexibleDuration wraps time.Duration to support both integer nanoseconds and duration strings in JSON
 type FlexibleDuration struct {
 	time.Duration
@@ -151,6 +159,36 @@ type AssumeRoleWithCredentialsRequest struct {
 	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
 }
 
+// AssumeRoleRequest represents a request to assume a role by an authenticated caller
+type AssumeRoleRequest struct {
+	// RoleArn is the ARN of the role to assume
+	RoleArn string `json:"RoleArn"`
+
+	// RoleSessionName is a name for the assumed role session
+	RoleSessionName string `json:"RoleSessionName"`
+
+	// CallerArn is the ARN of the authenticated caller
+	CallerArn string `json:"CallerArn"`
+
+	// DurationSeconds is the duration of the role session (optional)
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+
+	// Policy is an optional session policy (optional)
+	Policy *string `json:"Policy,omitempty"`
+}
+
+// GetSessionTokenRequest represents a request for temporary credentials of the caller itself
+type GetSessionTokenRequest struct {
+	// CallerArn is the ARN of the authenticated caller
+	CallerArn string `json:"CallerArn"`
+
+	// CallerName is the name of the authenticated caller
+	CallerName string `json:"CallerName"`
+
+	// DurationSeconds is the duration of the session (optional)
+	DurationSeconds *int64 `json:"DurationSeconds,omitempty"`
+}
+
 // AssumeRoleResponse represents the response from assume role operations
 type AssumeRoleResponse struct {
 	// Credentials contains the temporary security credentials
@@ -432,7 +470,7 @@ func (s *STSService) AssumeRoleWithWebIdentity(ctx context.Context, request *Ass
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
-	credGenerator := NewCredentialGenerator()
+	credGenerator := NewCredentialGenerator(s.Config.SigningKey)
 	credentials, err := credGenerator.GenerateTemporaryCredentials(sessionId, expiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate credentials: %w", err)
@@ -511,7 +549,7 @@ func (s *STSService) AssumeRoleWithCredentials(ctx context.Context, request *Ass
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
-	credGenerator := NewCredentialGenerator()
+	credGenerator := NewCredentialGenerator(s.Config.SigningKey)
 	tempCredentials, err := credGenerator.GenerateTemporaryCredentials(sessionId, expiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate credentials: %w", err)
@@ -546,6 +584,114 @@ func (s *STSService) AssumeRoleWithCredentials(ctx context.Context, request *Ass
 	}, nil
 }
 
+// AssumeRole assumes a role for an authenticated caller, like an IAM user signing the request with its access key
+func (s *STSService) AssumeRole(ctx context.Context, request *AssumeRoleRequest) (*AssumeRoleResponse, error) {
+	if !s.initialized {
+		return nil, fmt.Errorf(ErrSTSServiceNotInitialized)
+	}
+
+	if request == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	// Validate request parameters
+	if err := s.validateAssumeRoleRequest(request); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if request.Policy != nil {
+		return nil, fmt.Errorf("session policies are not currently supported - Policy parameter must be omitted")
+	}
+
+	// 1. Check if the role can be assumed by the caller
+	if err := s.validateRoleAssumptionForCaller(ctx, request.RoleArn, request.CallerArn); err != nil {
+		return nil, fmt.Errorf("role assumption denied: %w", err)
+	}
+
+	// 2. Generate the session and its credentials
+	sessionDuration := s.calculateSessionDuration(request.DurationSeconds)
+	expiresAt := time.Now().Add(sessionDuration)
+
+	assumedRoleArn := GenerateAssumedRoleArn(request.RoleArn, request.RoleSessionName)
+	sessionClaims := func(sessionId string) *STSSessionClaims {
+		return NewSTSSessionClaims(sessionId, s.Config.Issuer, expiresAt).
+			WithSessionName(request.RoleSessionName).
+			WithRoleInfo(request.RoleArn, assumedRoleArn, assumedRoleArn).
+			WithIdentityProvider("", request.CallerArn, "").
+			WithMaxDuration(sessionDuration)
+	}
+	credentials, err := s.issueTemporaryCredentials(expiresAt, sessionClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AssumeRoleResponse{
+		Credentials: credentials,
+		AssumedRoleUser: &AssumedRoleUser{
+			AssumedRoleId: request.RoleArn,
+			Arn:           assumedRoleArn,
+			Subject:       request.CallerArn,
+		},
+	}, nil
+}
+
+// GetSessionToken issues temporary credentials with the same permissions as the authenticated caller
+func (s *STSService) GetSessionToken(ctx context.Context, request *GetSessionTokenRequest) (*Credentials, error) {
+	if !s.initialized {
+		return nil, fmt.Errorf(ErrSTSServiceNotInitialized)
+	}
+
+	if request == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	if request.CallerArn == "" || request.CallerName == "" {
+		return nil, fmt.Errorf("invalid request: CallerArn and CallerName are required")
+	}
+	if err := validateDurationSeconds(request.DurationSeconds); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	sessionDuration := s.calculateSessionDuration(request.DurationSeconds)
+	expiresAt := time.Now().Add(sessionDuration)
+
+	// the session acts as the caller itself, and not as a role
+	sessionClaims := func(sessionId string) *STSSessionClaims {
+		return NewSTSSessionClaims(sessionId, s.Config.Issuer, expiresAt).
+			WithSessionName(request.CallerName).
+			WithRoleInfo(request.CallerArn, request.CallerArn, request.CallerArn).
+			WithIdentityProvider("", request.CallerName, "").
+			WithMaxDuration(sessionDuration)
+	}
+	return s.issueTemporaryCredentials(expiresAt, sessionClaims)
+}
+
+// issueTemporaryCredentials generates a new session, with its credentials and its session token
+func (s *STSService) issueTemporaryCredentials(expiresAt time.Time, sessionClaims func(sessionId string) *STSSessionClaims) (*Credentials, error) {
+	sessionId, err := GenerateSessionId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	credentials, err := NewCredentialGenerator(s.Config.SigningKey).GenerateTemporaryCredentials(sessionId, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate credentials: %w", err)
+	}
+
+	jwtToken, err := s.tokenGenerator.GenerateJWTWithClaims(sessionClaims(sessionId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT session token: %w", err)
+	}
+	credentials.SessionToken = jwtToken
+
+	return credentials, nil
+}
+
+// TemporaryCredentialKeys returns the access key ID and the secret access key issued with a session
+func (s *STSService) TemporaryCredentialKeys(sessionId string) (accessKeyId, secretAccessKey string) {
+	return NewCredentialGenerator(s.Config.SigningKey).TemporaryCredentialKeys(sessionId)
+}
+
 // ValidateSessionToken validates a session token and returns session information
 // This method is now completely stateless - all session information is extracted from the JWT token
 func (s *STSService) ValidateSessionToken(ctx context.Context, sessionToken string) (*SessionInfo, error) {
@@ -742,6 +888,67 @@ func (s *STSService) validateRoleAssumptionForCredentials(ctx context.Context, r
 		return fmt.Errorf("trust policy validation not available - role assumption denied for security")
 	}
 
+	// A provider mapping the identity to roles, like the LDAP provider mapping groups, limits it to those roles
+	if mappedRoles := identity.Attributes["roles"]; mappedRoles != "" {
+		mapped := false
+		for _, mappedRole := range strings.Split(mappedRoles, ",") {
+			if mappedRole == roleArn || mappedRole == roleName {
+				mapped = true
+				break
+			}
+		}
+		if !mapped {
+			return fmt.Errorf("identity %s is not mapped to role %s", identity.UserID, roleName)
+		}
+	}
+
+	return nil
+}
+
+// validateAssumeRoleRequest validates the parameters of a role assumption by an authenticated caller
+func (s *STSService) validateAssumeRoleRequest(request *AssumeRoleRequest) error {
+	if request.RoleArn == "" {
+		return fmt.Errorf("RoleArn is required")
+	}
+
+	if request.RoleSessionName == "" {
+		return fmt.Errorf("RoleSessionName is required")
+	}
+
+	if request.CallerArn == "" {
+		return fmt.Errorf("CallerArn is required")
+	}
+
+	return validateDurationSeconds(request.DurationSeconds)
+}
+
+// validateDurationSeconds validates the requested session duration, if provided
+func validateDurationSeconds(durationSeconds *int64) error {
+	if durationSeconds != nil {
+		if *durationSeconds < 900 || *durationSeconds > 43200 { // 15min to 12 hours
+			return fmt.Errorf("DurationSeconds must be between 900 and 43200 seconds")
+		}
+	}
+	return nil
+}
+
+// validateRoleAssumptionForCaller validates role assumption by an authenticated caller
+func (s *STSService) validateRoleAssumptionForCaller(ctx context.Context, roleArn string, callerArn string) error {
+	// Basic role ARN format validation
+	expectedPrefix := "arn:seaweed:iam::role/"
+	if !strings.HasPrefix(roleArn, expectedPrefix) || utils.ExtractRoleNameFromArn(roleArn) == "" {
+		return fmt.Errorf("invalid role ARN format: got %s, expected format: %s*", roleArn, expectedPrefix)
+	}
+
+	// CRITICAL SECURITY: Perform trust policy validation, and fail closed without a validator
+	validator, ok := s.trustPolicyValidator.(CallerTrustPolicyValidator)
+	if !ok {
+		return fmt.Errorf("trust policy validation not available - role assumption denied for security")
+	}
+	if err := validator.ValidateTrustPolicyForCaller(ctx, roleArn, callerArn); err != nil {
+		return fmt.Errorf("trust policy validation failed: %w", err)
+	}
+
 	return nil
 }
 
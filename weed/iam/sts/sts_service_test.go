@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/seaweedfs/seaweedfs/weed/iam/ldap"
 	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -224,6 +225,43 @@ func TestAssumeRoleWithLDAP(t *testing.T) {
 	}
 }
 
+// TestAssumeRoleWithCredentialsMappedRoles tests that an identity mapped to roles can only assume those roles
+func TestAssumeRoleWithCredentialsMappedRoles(t *testing.T) {
+	service := setupTestSTSService(t)
+	ctx := context.Background()
+
+	provider := ldap.NewMockLDAPProvider("mapped-ldap")
+	provider.AddTestUser("alice", "alice-pass", &providers.ExternalIdentity{
+		UserID:     "alice",
+		Groups:     []string{"admins"},
+		Provider:   "mapped-ldap",
+		Attributes: map[string]string{"roles": "arn:seaweed:iam::role/AdminRole,ReadOnlyRole"},
+	})
+	service.RegisterProvider(provider)
+
+	for _, roleArn := range []string{"arn:seaweed:iam::role/AdminRole", "arn:seaweed:iam::role/ReadOnlyRole"} {
+		response, err := service.AssumeRoleWithCredentials(ctx, &AssumeRoleWithCredentialsRequest{
+			RoleArn:         roleArn,
+			Username:        "alice",
+			Password:        "alice-pass",
+			RoleSessionName: "alice-session",
+			ProviderName:    "mapped-ldap",
+		})
+		require.NoError(t, err, roleArn)
+		assert.NotNil(t, response.Credentials)
+	}
+
+	_, err := service.AssumeRoleWithCredentials(ctx, &AssumeRoleWithCredentialsRequest{
+		RoleArn:         "arn:seaweed:iam::role/LDAPRole",
+		Username:        "alice",
+		Password:        "alice-pass",
+		RoleSessionName: "alice-session",
+		ProviderName:    "mapped-ldap",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not mapped to role LDAPRole")
+}
+
 // TestSessionTokenValidation tests session token validation
 func TestSessionTokenValidation(t *testing.T) {
 	service := setupTestSTSService(t)
@@ -1,6 +1,7 @@
 package sts
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -135,11 +136,17 @@ type SessionTokenClaims struct {
 }
 
 // CredentialGenerator generates AWS-compatible temporary credentials
-type CredentialGenerator struct{}
+type CredentialGenerator struct {
+	signingKey []byte
+}
 
-// NewCredentialGenerator creates a new credential generator
-func NewCredentialGenerator() *CredentialGenerator {
-	return &CredentialGenerator{}
+// NewCredentialGenerator creates a new credential generator.
+// The secret access key is derived from the signing key and the session ID,
+// so any instance sharing the signing key can verify requests signed with it.
+func NewCredentialGenerator(signingKey []byte) *CredentialGenerator {
+	return &CredentialGenerator{
+		signingKey: signingKey,
+	}
 }
 
 // GenerateTemporaryCredentials creates temporary AWS credentials
@@ -149,10 +156,7 @@ func (c *CredentialGenerator) GenerateTemporaryCredentials(sessionId string, exp
 		return nil, fmt.Errorf("failed to generate access key ID: %w", err)
 	}
 
-	secretAccessKey, err := c.generateSecretAccessKey()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate secret access key: %w", err)
-	}
+	secretAccessKey := c.generateSecretAccessKey(sessionId)
 
 	sessionToken, err := c.generateSessionTokenId(sessionId)
 	if err != nil {
@@ -174,16 +178,19 @@ func (c *CredentialGenerator) generateAccessKeyId(sessionId string) (string, err
 	return "AKIA" + hex.EncodeToString(hash[:8]), nil // AWS format: AKIA + 16 chars
 }
 
-// generateSecretAccessKey generates a random secret access key
-func (c *CredentialGenerator) generateSecretAccessKey() (string, error) {
-	// Generate 32 random bytes for secret key
-	secretBytes := make([]byte, 32)
-	_, err := rand.Read(secretBytes)
-	if err != nil {
-		return "", err
-	}
+// generateSecretAccessKey derives the secret access key of a session
+func (c *CredentialGenerator) generateSecretAccessKey(sessionId string) string {
+	mac := hmac.New(sha256.New, c.signingKey)
+	mac.Write([]byte("secret-key:" + sessionId))
+	// 30 bytes encode to the 40 characters of an AWS secret access key
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)[:30])
+}
 
-	return base64.StdEncoding.EncodeToString(secretBytes), nil
+// TemporaryCredentialKeys returns the access key ID and the secret access key of a session,
+// used to verify requests signed with its temporary credentials
+func (c *CredentialGenerator) TemporaryCredentialKeys(sessionId string) (accessKeyId, secretAccessKey string) {
+	accessKeyId, _ = c.generateAccessKeyId(sessionId)
+	return accessKeyId, c.generateSecretAccessKey(sessionId)
 }
 
 // generateSessionTokenId generates a session token identifier
@@ -0,0 +1,48 @@
+package iamapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/audit"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+)
+
+// auditedParameters name the target of an action, in this order; documents and secrets are left out
+var auditedParameters = []string{"UserName", "AccessKeyId", "Status", "GroupName", "RoleName", "PolicyName", "PolicyArn", "VersionId", "NewUserName"}
+
+// auditActions records the actions changing users, access keys, groups, roles and policies in the audit log
+func (iama *IamApiServer) auditActions(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statusRecorder := stats.NewStatusResponseWriter(w)
+		fn(statusRecorder, r)
+
+		action := r.Form.Get("Action")
+		if iama.option == nil || action == "" || strings.HasPrefix(action, "List") || strings.HasPrefix(action, "Get") {
+			return
+		}
+		var err error
+		if statusRecorder.Status >= http.StatusBadRequest {
+			err = fmt.Errorf("%d %s", statusRecorder.Status, http.StatusText(statusRecorder.Status))
+		}
+		record := audit.NewRecord(audit.ComponentIam, r.Header.Get(s3_constants.AmzIdentityId), security.GetActualRemoteHost(r), action, auditTarget(r), err)
+		audit.Log(func(fn func(filer_pb.SeaweedFilerClient) error) error {
+			return pb.WithGrpcFilerClient(false, 0, iama.option.Filer, iama.option.GrpcDialOption, fn)
+		}, record)
+	}
+}
+
+func auditTarget(r *http.Request) string {
+	var parts []string
+	for _, name := range auditedParameters {
+		if value := r.Form.Get(name); value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,135 @@
+package iamapi
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	GROUP_DOES_NOT_EXIST = "the group with name %s cannot be found."
+)
+
+func findGroup(s3cfg *iam_pb.S3ApiConfiguration, groupName string) (*iam_pb.Group, *IamError) {
+	for _, group := range s3cfg.Groups {
+		if group.Name == groupName {
+			return group, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(GROUP_DOES_NOT_EXIST, groupName)}
+}
+
+func toIamGroup(group *iam_pb.Group) *iam.Group {
+	arn := fmt.Sprintf("arn:aws:iam:::group/%s", group.Name)
+	groupId := iamEntityId("AGPA", group.Name)
+	path := "/"
+	createDate := time.Unix(group.CreateDate, 0).UTC()
+	return &iam.Group{
+		GroupName:  &group.Name,
+		GroupId:    &groupId,
+		Arn:        &arn,
+		Path:       &path,
+		CreateDate: &createDate,
+	}
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateGroup.html
+func (iama *IamApiServer) CreateGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreateGroupResponse, iamError *IamError) {
+	groupName := values.Get("GroupName")
+	if groupName == "" {
+		return resp, &IamError{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf("group name is required")}
+	}
+	if _, iamError = findGroup(s3cfg, groupName); iamError == nil {
+		return resp, &IamError{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("the group with name %s already exists.", groupName)}
+	}
+	group := &iam_pb.Group{Name: groupName, CreateDate: time.Now().Unix()}
+	s3cfg.Groups = append(s3cfg.Groups, group)
+	resp.CreateGroupResult.Group = *toIamGroup(group)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetGroup.html
+func (iama *IamApiServer) GetGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetGroupResult.Group = *toIamGroup(group)
+	for _, member := range group.Members {
+		userName := member
+		resp.GetGroupResult.Users = append(resp.GetGroupResult.Users, &iam.User{UserName: &userName})
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListGroups.html
+func (iama *IamApiServer) ListGroups(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListGroupsResponse) {
+	for _, group := range s3cfg.Groups {
+		resp.ListGroupsResult.Groups = append(resp.ListGroupsResult.Groups, toIamGroup(group))
+	}
+	return resp
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListGroupsForUser.html
+func (iama *IamApiServer) ListGroupsForUser(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListGroupsForUserResponse, iamError *IamError) {
+	userName := values.Get("UserName")
+	if _, iamError = findIdentity(s3cfg, userName); iamError != nil {
+		return resp, iamError
+	}
+	for _, group := range s3cfg.Groups {
+		if slices.Contains(group.Members, userName) {
+			resp.ListGroupsForUserResult.Groups = append(resp.ListGroupsForUserResult.Groups, toIamGroup(group))
+		}
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeleteGroup.html
+func (iama *IamApiServer) DeleteGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeleteGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if len(group.Members) > 0 || len(group.PolicyNames) > 0 {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("the group %s still has members or attached policies", group.Name)}
+	}
+	s3cfg.Groups = slices.DeleteFunc(s3cfg.Groups, func(g *iam_pb.Group) bool {
+		return g == group
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AddUserToGroup.html
+func (iama *IamApiServer) AddUserToGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AddUserToGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	userName := values.Get("UserName")
+	if _, iamError = findIdentity(s3cfg, userName); iamError != nil {
+		return resp, iamError
+	}
+	if !slices.Contains(group.Members, userName) {
+		group.Members = append(group.Members, userName)
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_RemoveUserFromGroup.html
+func (iama *IamApiServer) RemoveUserFromGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp RemoveUserFromGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	userName := values.Get("UserName")
+	i := slices.Index(group.Members, userName)
+	if i < 0 {
+		return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the user %s is not a member of group %s", userName, group.Name)}
+	}
+	group.Members = slices.Delete(group.Members, i, i+1)
+	return resp, nil
+}
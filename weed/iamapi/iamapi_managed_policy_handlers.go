@@ -0,0 +1,408 @@
+package iamapi
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	POLICY_DOES_NOT_EXIST         = "the policy with arn %s cannot be found."
+	POLICY_VERSION_DOES_NOT_EXIST = "the policy %s has no version %s."
+	maxPolicyVersions             = 5
+)
+
+func policyArn(policyName string) string {
+	return fmt.Sprintf("arn:aws:iam:::policy/%s", policyName)
+}
+
+// policyNameFromArn extracts the policy name from "arn:aws:iam:::policy/path/name"
+func policyNameFromArn(arn string) string {
+	_, resource, found := strings.Cut(arn, ":policy/")
+	if !found {
+		return ""
+	}
+	return resource[strings.LastIndex(resource, "/")+1:]
+}
+
+// iamEntityId derives a stable identifier in the AWS format, e.g. "AGPA..." for groups
+func iamEntityId(prefix string, name string) string {
+	return prefix + strings.ToUpper(Hash(&name))[:17]
+}
+
+func findManagedPolicy(s3cfg *iam_pb.S3ApiConfiguration, arn string) (*iam_pb.ManagedPolicy, *IamError) {
+	policyName := policyNameFromArn(arn)
+	for _, policy := range s3cfg.Policies {
+		if policy.Name == policyName {
+			return policy, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(POLICY_DOES_NOT_EXIST, arn)}
+}
+
+func findPolicyVersion(policy *iam_pb.ManagedPolicy, versionId string) (int, *IamError) {
+	for i, version := range policy.Versions {
+		if version.VersionId == versionId {
+			return i, nil
+		}
+	}
+	return -1, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(POLICY_VERSION_DOES_NOT_EXIST, policy.Name, versionId)}
+}
+
+func policyAttachmentCount(s3cfg *iam_pb.S3ApiConfiguration, policyName string) (count int64) {
+	for _, ident := range s3cfg.Identities {
+		if slices.Contains(ident.PolicyNames, policyName) {
+			count++
+		}
+	}
+	for _, group := range s3cfg.Groups {
+		if slices.Contains(group.PolicyNames, policyName) {
+			count++
+		}
+	}
+	for _, role := range s3cfg.Roles {
+		if slices.Contains(role.PolicyNames, policyName) {
+			count++
+		}
+	}
+	return count
+}
+
+func toIamPolicy(s3cfg *iam_pb.S3ApiConfiguration, policy *iam_pb.ManagedPolicy) *iam.Policy {
+	arn := policyArn(policy.Name)
+	policyId := iamEntityId("ANPA", policy.Name)
+	path := "/"
+	isAttachable := true
+	attachmentCount := policyAttachmentCount(s3cfg, policy.Name)
+	createDate := time.Unix(policy.CreateDate, 0).UTC()
+	updateDate := createDate
+	for _, version := range policy.Versions {
+		if version.CreateDate > updateDate.Unix() {
+			updateDate = time.Unix(version.CreateDate, 0).UTC()
+		}
+	}
+	iamPolicy := &iam.Policy{
+		PolicyName:       &policy.Name,
+		Arn:              &arn,
+		PolicyId:         &policyId,
+		Path:             &path,
+		DefaultVersionId: &policy.DefaultVersionId,
+		IsAttachable:     &isAttachable,
+		AttachmentCount:  &attachmentCount,
+		CreateDate:       &createDate,
+		UpdateDate:       &updateDate,
+	}
+	if policy.Description != "" {
+		iamPolicy.Description = &policy.Description
+	}
+	return iamPolicy
+}
+
+func toIamPolicyVersion(policy *iam_pb.ManagedPolicy, version *iam_pb.PolicyVersion, withDocument bool) *iam.PolicyVersion {
+	isDefault := version.VersionId == policy.DefaultVersionId
+	createDate := time.Unix(version.CreateDate, 0).UTC()
+	policyVersion := &iam.PolicyVersion{
+		VersionId:        &version.VersionId,
+		IsDefaultVersion: &isDefault,
+		CreateDate:       &createDate,
+	}
+	if withDocument {
+		// policy documents are returned URL-encoded, as AWS does
+		document := url.QueryEscape(version.Document)
+		policyVersion.Document = &document
+	}
+	return policyVersion
+}
+
+// syncPolicyFile keeps policies.json in line with the default version of a managed policy
+func (iama *IamApiServer) syncPolicyFile(policyName string, document *string) *IamError {
+	policies := Policies{}
+	policyLock.Lock()
+	defer policyLock.Unlock()
+	if err := iama.s3ApiConfig.GetPolicies(&policies); err != nil {
+		return &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
+	}
+	if document == nil {
+		delete(policies.Policies, policyName)
+	} else {
+		policyDocument, err := GetPolicyDocument(document)
+		if err != nil {
+			return &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+		}
+		policies.Policies[policyName] = policyDocument
+	}
+	if err := iama.s3ApiConfig.PutPolicies(&policies); err != nil {
+		return &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
+	}
+	return nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetPolicy.html
+func (iama *IamApiServer) GetPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetPolicyResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetPolicyResult.Policy = *toIamPolicy(s3cfg, policy)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListPolicies.html
+func (iama *IamApiServer) ListPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListPoliciesResponse) {
+	onlyAttached := values.Get("OnlyAttached") == "true"
+	for _, policy := range s3cfg.Policies {
+		iamPolicy := toIamPolicy(s3cfg, policy)
+		if onlyAttached && *iamPolicy.AttachmentCount == 0 {
+			continue
+		}
+		resp.ListPoliciesResult.Policies = append(resp.ListPoliciesResult.Policies, iamPolicy)
+	}
+	return resp
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeletePolicy.html
+func (iama *IamApiServer) DeletePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeletePolicyResponse, iamError *IamError) {
+	arn := values.Get("PolicyArn")
+	policy, iamError := findManagedPolicy(s3cfg, arn)
+	if iamError != nil {
+		return resp, iamError
+	}
+	if policyAttachmentCount(s3cfg, policy.Name) > 0 {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("the policy %s is still attached", arn)}
+	}
+	s3cfg.Policies = slices.DeleteFunc(s3cfg.Policies, func(p *iam_pb.ManagedPolicy) bool {
+		return p == policy
+	})
+	return resp, iama.syncPolicyFile(policy.Name, nil)
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreatePolicyVersion.html
+func (iama *IamApiServer) CreatePolicyVersion(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreatePolicyVersionResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	policyDocumentString := values.Get("PolicyDocument")
+	if _, err := GetPolicyDocument(&policyDocumentString); err != nil {
+		return resp, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+	}
+	if len(policy.Versions) >= maxPolicyVersions {
+		return resp, &IamError{Code: iam.ErrCodeLimitExceededException, Error: fmt.Errorf("the policy %s already has %d versions", policy.Name, maxPolicyVersions)}
+	}
+	lastVersion := 0
+	for _, version := range policy.Versions {
+		if n, err := strconv.Atoi(strings.TrimPrefix(version.VersionId, "v")); err == nil && n > lastVersion {
+			lastVersion = n
+		}
+	}
+	version := &iam_pb.PolicyVersion{
+		VersionId:  fmt.Sprintf("v%d", lastVersion+1),
+		Document:   policyDocumentString,
+		CreateDate: time.Now().Unix(),
+	}
+	policy.Versions = append(policy.Versions, version)
+	if values.Get("SetAsDefault") == "true" {
+		policy.DefaultVersionId = version.VersionId
+		if iamError = iama.syncPolicyFile(policy.Name, &version.Document); iamError != nil {
+			return resp, iamError
+		}
+	}
+	resp.CreatePolicyVersionResult.PolicyVersion = *toIamPolicyVersion(policy, version, false)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetPolicyVersion.html
+func (iama *IamApiServer) GetPolicyVersion(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetPolicyVersionResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	i, iamError := findPolicyVersion(policy, values.Get("VersionId"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetPolicyVersionResult.PolicyVersion = *toIamPolicyVersion(policy, policy.Versions[i], true)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListPolicyVersions.html
+func (iama *IamApiServer) ListPolicyVersions(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListPolicyVersionsResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	for _, version := range policy.Versions {
+		resp.ListPolicyVersionsResult.Versions = append(resp.ListPolicyVersionsResult.Versions,
+			toIamPolicyVersion(policy, version, false))
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_SetDefaultPolicyVersion.html
+func (iama *IamApiServer) SetDefaultPolicyVersion(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp SetDefaultPolicyVersionResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	i, iamError := findPolicyVersion(policy, values.Get("VersionId"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	policy.DefaultVersionId = policy.Versions[i].VersionId
+	return resp, iama.syncPolicyFile(policy.Name, &policy.Versions[i].Document)
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeletePolicyVersion.html
+func (iama *IamApiServer) DeletePolicyVersion(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeletePolicyVersionResponse, iamError *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	i, iamError := findPolicyVersion(policy, values.Get("VersionId"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if policy.Versions[i].VersionId == policy.DefaultVersionId {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("cannot delete the default version %s of policy %s", policy.DefaultVersionId, policy.Name)}
+	}
+	policy.Versions = slices.Delete(policy.Versions, i, i+1)
+	return resp, nil
+}
+
+// attachPolicy adds the policy named by PolicyArn to policyNames, once
+func attachPolicy(s3cfg *iam_pb.S3ApiConfiguration, policyNames []string, values url.Values) ([]string, *IamError) {
+	policy, iamError := findManagedPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return policyNames, iamError
+	}
+	if slices.Contains(policyNames, policy.Name) {
+		return policyNames, nil
+	}
+	return append(policyNames, policy.Name), nil
+}
+
+func detachPolicy(policyNames []string, values url.Values) ([]string, *IamError) {
+	arn := values.Get("PolicyArn")
+	i := slices.Index(policyNames, policyNameFromArn(arn))
+	if i < 0 {
+		return policyNames, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the policy %s is not attached", arn)}
+	}
+	return slices.Delete(policyNames, i, i+1), nil
+}
+
+func listAttachedPolicies(policyNames []string) (result ListAttachedPoliciesResult) {
+	for _, policyName := range policyNames {
+		name := policyName
+		arn := policyArn(policyName)
+		result.AttachedPolicies = append(result.AttachedPolicies, &iam.AttachedPolicy{PolicyName: &name, PolicyArn: &arn})
+	}
+	return result
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AttachUserPolicy.html
+func (iama *IamApiServer) AttachUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AttachUserPolicyResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	ident.PolicyNames, iamError = attachPolicy(s3cfg, ident.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DetachUserPolicy.html
+func (iama *IamApiServer) DetachUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DetachUserPolicyResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	ident.PolicyNames, iamError = detachPolicy(ident.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListAttachedUserPolicies.html
+func (iama *IamApiServer) ListAttachedUserPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAttachedUserPoliciesResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListAttachedUserPoliciesResult = listAttachedPolicies(ident.PolicyNames)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AttachGroupPolicy.html
+func (iama *IamApiServer) AttachGroupPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AttachGroupPolicyResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	group.PolicyNames, iamError = attachPolicy(s3cfg, group.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DetachGroupPolicy.html
+func (iama *IamApiServer) DetachGroupPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DetachGroupPolicyResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	group.PolicyNames, iamError = detachPolicy(group.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListAttachedGroupPolicies.html
+func (iama *IamApiServer) ListAttachedGroupPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAttachedGroupPoliciesResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListAttachedGroupPoliciesResult = listAttachedPolicies(group.PolicyNames)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AttachRolePolicy.html
+func (iama *IamApiServer) AttachRolePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AttachRolePolicyResponse, iamError *IamError) {
+	role, iamError := findRole(s3cfg, values.Get("RoleName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	role.PolicyNames, iamError = attachPolicy(s3cfg, role.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DetachRolePolicy.html
+func (iama *IamApiServer) DetachRolePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DetachRolePolicyResponse, iamError *IamError) {
+	role, iamError := findRole(s3cfg, values.Get("RoleName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	role.PolicyNames, iamError = detachPolicy(role.PolicyNames, values)
+	return resp, iamError
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListAttachedRolePolicies.html
+func (iama *IamApiServer) ListAttachedRolePolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAttachedRolePoliciesResponse, iamError *IamError) {
+	role, iamError := findRole(s3cfg, values.Get("RoleName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListAttachedRolePoliciesResult = listAttachedPolicies(role.PolicyNames)
+	return resp, nil
+}
+
+// newManagedPolicy records a managed policy with the document as its default version v1
+func newManagedPolicy(policyName string, description string, document string) *iam_pb.ManagedPolicy {
+	now := time.Now().Unix()
+	return &iam_pb.ManagedPolicy{
+		Name:             policyName,
+		Description:      description,
+		DefaultVersionId: "v1",
+		Versions:         []*iam_pb.PolicyVersion{{VersionId: "v1", Document: document, CreateDate: now}},
+		CreateDate:       now,
+	}
+}
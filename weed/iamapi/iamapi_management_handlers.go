@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -146,6 +148,11 @@ func (iama *IamApiServer) DeleteUser(s3cfg *iam_pb.S3ApiConfiguration, userName
 	for i, ident := range s3cfg.Identities {
 		if userName == ident.Name {
 			s3cfg.Identities = append(s3cfg.Identities[:i], s3cfg.Identities[i+1:]...)
+			for _, group := range s3cfg.Groups {
+				group.Members = slices.DeleteFunc(group.Members, func(member string) bool {
+					return member == userName
+				})
+			}
 			return resp, nil
 		}
 	}
@@ -169,6 +176,11 @@ func (iama *IamApiServer) UpdateUser(s3cfg *iam_pb.S3ApiConfiguration, values ur
 		for _, ident := range s3cfg.Identities {
 			if userName == ident.Name {
 				ident.Name = newUserName
+				for _, group := range s3cfg.Groups {
+					if i := slices.Index(group.Members, userName); i >= 0 {
+						group.Members[i] = newUserName
+					}
+				}
 				return resp, nil
 			}
 		}
@@ -178,6 +190,75 @@ func (iama *IamApiServer) UpdateUser(s3cfg *iam_pb.S3ApiConfiguration, values ur
 	return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(USER_DOES_NOT_EXIST, userName)}
 }
 
+func findIdentity(s3cfg *iam_pb.S3ApiConfiguration, userName string) (*iam_pb.Identity, *IamError) {
+	for _, ident := range s3cfg.Identities {
+		if userName == ident.Name {
+			return ident, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(USER_DOES_NOT_EXIST, userName)}
+}
+
+// parseTags reads the "Tags.member.N.Key" and "Tags.member.N.Value" request parameters
+func parseTags(values url.Values) map[string]string {
+	tags := make(map[string]string)
+	for i := 1; ; i++ {
+		key := values.Get(fmt.Sprintf("Tags.member.%d.Key", i))
+		if key == "" {
+			break
+		}
+		tags[key] = values.Get(fmt.Sprintf("Tags.member.%d.Value", i))
+	}
+	return tags
+}
+
+func toIamTags(tags map[string]string) (iamTags []*iam.Tag) {
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		tagKey, tagValue := key, tags[key]
+		iamTags = append(iamTags, &iam.Tag{Key: &tagKey, Value: &tagValue})
+	}
+	return iamTags
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_TagUser.html
+func (iama *IamApiServer) TagUser(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp TagUserResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if ident.Tags == nil {
+		ident.Tags = make(map[string]string)
+	}
+	maps.Copy(ident.Tags, parseTags(values))
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_UntagUser.html
+func (iama *IamApiServer) UntagUser(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp UntagUserResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	for i := 1; ; i++ {
+		key := values.Get(fmt.Sprintf("TagKeys.member.%d", i))
+		if key == "" {
+			break
+		}
+		delete(ident.Tags, key)
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListUserTags.html
+func (iama *IamApiServer) ListUserTags(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListUserTagsResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListUserTagsResult.Tags = toIamTags(ident.Tags)
+	return resp, nil
+}
+
 func GetPolicyDocument(policy *string) (policy_engine.PolicyDocument, error) {
 	var policyDocument policy_engine.PolicyDocument
 	if err := json.Unmarshal([]byte(*policy), &policyDocument); err != nil {
@@ -193,11 +274,12 @@ func (iama *IamApiServer) CreatePolicy(s3cfg *iam_pb.S3ApiConfiguration, values
 	if err != nil {
 		return CreatePolicyResponse{}, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
 	}
-	policyId := Hash(&policyDocumentString)
-	arn := fmt.Sprintf("arn:aws:iam:::policy/%s", policyName)
-	resp.CreatePolicyResult.Policy.PolicyName = &policyName
-	resp.CreatePolicyResult.Policy.Arn = &arn
-	resp.CreatePolicyResult.Policy.PolicyId = &policyId
+	if _, iamError = findManagedPolicy(s3cfg, policyArn(policyName)); iamError == nil {
+		return resp, &IamError{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("a policy called %s already exists.", policyName)}
+	}
+	managedPolicy := newManagedPolicy(policyName, values.Get("Description"), policyDocumentString)
+	s3cfg.Policies = append(s3cfg.Policies, managedPolicy)
+	resp.CreatePolicyResult.Policy = *toIamPolicy(s3cfg, managedPolicy)
 	policies := Policies{}
 	policyLock.Lock()
 	defer policyLock.Unlock()
@@ -311,43 +393,9 @@ func (iama *IamApiServer) DeleteUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, val
 	return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(USER_DOES_NOT_EXIST, userName)}
 }
 
+// GetActions converts a user policy into identity actions, the same way as the managed policies
 func GetActions(policy *policy_engine.PolicyDocument) ([]string, error) {
-	var actions []string
-
-	for _, statement := range policy.Statement {
-		if statement.Effect != policy_engine.PolicyEffectAllow {
-			return nil, fmt.Errorf("not a valid effect: '%s'. Only 'Allow' is possible", statement.Effect)
-		}
-		for _, resource := range statement.Resource.Strings() {
-			// Parse "arn:aws:s3:::my-bucket/shared/*"
-			res := strings.Split(resource, ":")
-			if len(res) != 6 || res[0] != "arn" || res[1] != "aws" || res[2] != "s3" {
-				glog.Infof("not a valid resource: %s", res)
-				continue
-			}
-			for _, action := range statement.Action.Strings() {
-				// Parse "s3:Get*"
-				act := strings.Split(action, ":")
-				if len(act) != 2 || act[0] != "s3" {
-					glog.Infof("not a valid action: %s", act)
-					continue
-				}
-				statementAction := MapToStatementAction(act[1])
-
-				if statementAction == "" {
-					return nil, fmt.Errorf("not a valid action: '%s'", act[1])
-				}
-
-				path := res[5]
-				if path == "*" {
-					actions = append(actions, statementAction)
-					continue
-				}
-				actions = append(actions, fmt.Sprintf("%s:%s", statementAction, path))
-			}
-		}
-	}
-	return actions, nil
+	return policy_engine.ConvertPolicyToLegacyActions(policy)
 }
 
 func (iama *IamApiServer) CreateAccessKey(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreateAccessKeyResponse) {
@@ -446,16 +494,39 @@ func (iama *IamApiServer) DoActions(w http.ResponseWriter, r *http.Request) {
 	var response interface{}
 	var iamError *IamError
 	changed := true
-	switch r.Form.Get("Action") {
+	action := r.Form.Get("Action")
+	tenant := requestTenant(r)
+	if tenant != "" {
+		switch action {
+		case "ListAccessKeys", "CreateAccessKey", "DeleteAccessKey":
+			handleImplicitUsername(r, values)
+		}
+		if iamError = authorizeTenantAction(s3cfg, tenant, action, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	}
+	switch action {
 	case "ListUsers":
-		response = iama.ListUsers(s3cfg, values)
+		if tenant != "" {
+			response = iama.ListUsers(tenantConfiguration(s3cfg, tenant), values)
+		} else {
+			response = iama.ListUsers(s3cfg, values)
+		}
 		changed = false
 	case "ListAccessKeys":
 		handleImplicitUsername(r, values)
-		response = iama.ListAccessKeys(s3cfg, values)
+		if tenant != "" {
+			response = iama.ListAccessKeys(tenantConfiguration(s3cfg, tenant), values)
+		} else {
+			response = iama.ListAccessKeys(s3cfg, values)
+		}
 		changed = false
 	case "CreateUser":
 		response = iama.CreateUser(s3cfg, values)
+		if tenant != "" {
+			assignTenant(s3cfg, tenant, values.Get("UserName"))
+		}
 	case "GetUser":
 		userName := values.Get("UserName")
 		response, iamError = iama.GetUser(s3cfg, userName)
@@ -488,9 +559,172 @@ func (iama *IamApiServer) DoActions(w http.ResponseWriter, r *http.Request) {
 		response, iamError = iama.CreatePolicy(s3cfg, values)
 		if iamError != nil {
 			glog.Errorf("CreatePolicy:  %+v", iamError.Error)
+			if iamError.Code == iam.ErrCodeEntityAlreadyExistsException {
+				writeIamErrorResponse(w, r, iamError)
+				return
+			}
 			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
 			return
 		}
+	case "GetPolicy":
+		if response, iamError = iama.GetPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListPolicies":
+		response = iama.ListPolicies(s3cfg, values)
+		changed = false
+	case "DeletePolicy":
+		if response, iamError = iama.DeletePolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "CreatePolicyVersion":
+		if response, iamError = iama.CreatePolicyVersion(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "GetPolicyVersion":
+		if response, iamError = iama.GetPolicyVersion(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListPolicyVersions":
+		if response, iamError = iama.ListPolicyVersions(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "SetDefaultPolicyVersion":
+		if response, iamError = iama.SetDefaultPolicyVersion(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DeletePolicyVersion":
+		if response, iamError = iama.DeletePolicyVersion(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "AttachUserPolicy":
+		if response, iamError = iama.AttachUserPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DetachUserPolicy":
+		if response, iamError = iama.DetachUserPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListAttachedUserPolicies":
+		if response, iamError = iama.ListAttachedUserPolicies(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "AttachGroupPolicy":
+		if response, iamError = iama.AttachGroupPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DetachGroupPolicy":
+		if response, iamError = iama.DetachGroupPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListAttachedGroupPolicies":
+		if response, iamError = iama.ListAttachedGroupPolicies(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "AttachRolePolicy":
+		if response, iamError = iama.AttachRolePolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DetachRolePolicy":
+		if response, iamError = iama.DetachRolePolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListAttachedRolePolicies":
+		if response, iamError = iama.ListAttachedRolePolicies(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "CreateGroup":
+		if response, iamError = iama.CreateGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "GetGroup":
+		if response, iamError = iama.GetGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListGroups":
+		response = iama.ListGroups(s3cfg, values)
+		changed = false
+	case "ListGroupsForUser":
+		if response, iamError = iama.ListGroupsForUser(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "DeleteGroup":
+		if response, iamError = iama.DeleteGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "AddUserToGroup":
+		if response, iamError = iama.AddUserToGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "RemoveUserFromGroup":
+		if response, iamError = iama.RemoveUserFromGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "CreateRole":
+		if response, iamError = iama.CreateRole(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "GetRole":
+		if response, iamError = iama.GetRole(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListRoles":
+		response = iama.ListRoles(s3cfg, values)
+		changed = false
+	case "DeleteRole":
+		if response, iamError = iama.DeleteRole(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "TagUser":
+		if response, iamError = iama.TagUser(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "UntagUser":
+		if response, iamError = iama.UntagUser(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListUserTags":
+		if response, iamError = iama.ListUserTags(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
 	case "PutUserPolicy":
 		var iamError *IamError
 		response, iamError = iama.PutUserPolicy(s3cfg, values)
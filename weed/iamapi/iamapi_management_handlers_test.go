@@ -72,3 +72,23 @@ func TestGetActionsInvalidAction(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "not a valid action: 'InvalidAction'", err.Error())
 }
+
+func TestGetActionsCondition(t *testing.T) {
+	policyDocument := policy_engine.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []policy_engine.PolicyStatement{
+			{
+				Effect:   policy_engine.PolicyEffectAllow,
+				Action:   policy_engine.NewStringOrStringSlice("s3:Get*"),
+				Resource: policy_engine.NewStringOrStringSlice("arn:aws:s3:::shared/*"),
+				Condition: policy_engine.PolicyConditions{
+					"IpAddress": {"aws:SourceIp": policy_engine.NewStringOrStringSlice("10.0.0.0/8")},
+				},
+			},
+		},
+	}
+
+	// the condition can not be enforced on identity actions, as for managed policies
+	_, err := GetActions(&policyDocument)
+	assert.NotNil(t, err)
+}
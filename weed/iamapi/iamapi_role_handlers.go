@@ -0,0 +1,107 @@
+package iamapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	ROLE_DOES_NOT_EXIST = "the role with name %s cannot be found."
+)
+
+func findRole(s3cfg *iam_pb.S3ApiConfiguration, roleName string) (*iam_pb.Role, *IamError) {
+	for _, role := range s3cfg.Roles {
+		if role.Name == roleName {
+			return role, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(ROLE_DOES_NOT_EXIST, roleName)}
+}
+
+func toIamRole(role *iam_pb.Role) *Role {
+	arn := fmt.Sprintf("arn:aws:iam:::role/%s", role.Name)
+	roleId := iamEntityId("AROA", role.Name)
+	path := "/"
+	createDate := time.Unix(role.CreateDate, 0).UTC()
+	// policy documents are returned URL-encoded, as AWS does
+	document := url.QueryEscape(role.AssumeRolePolicyDocument)
+	iamRole := &Role{
+		Role: iam.Role{
+			RoleName:                 &role.Name,
+			RoleId:                   &roleId,
+			Arn:                      &arn,
+			Path:                     &path,
+			AssumeRolePolicyDocument: &document,
+			CreateDate:               &createDate,
+		},
+		Tags: toIamTags(role.Tags),
+	}
+	if role.Description != "" {
+		iamRole.Description = &role.Description
+	}
+	return iamRole
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateRole.html
+func (iama *IamApiServer) CreateRole(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreateRoleResponse, iamError *IamError) {
+	roleName := values.Get("RoleName")
+	if roleName == "" {
+		return resp, &IamError{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf("role name is required")}
+	}
+	if _, iamError = findRole(s3cfg, roleName); iamError == nil {
+		return resp, &IamError{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("the role with name %s already exists.", roleName)}
+	}
+	document := values.Get("AssumeRolePolicyDocument")
+	if !json.Valid([]byte(document)) {
+		return resp, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: fmt.Errorf("the assume role policy document is not valid JSON")}
+	}
+	role := &iam_pb.Role{
+		Name:                     roleName,
+		Description:              values.Get("Description"),
+		AssumeRolePolicyDocument: document,
+		Tags:                     parseTags(values),
+		CreateDate:               time.Now().Unix(),
+	}
+	s3cfg.Roles = append(s3cfg.Roles, role)
+	resp.CreateRoleResult.Role = *toIamRole(role)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetRole.html
+func (iama *IamApiServer) GetRole(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetRoleResponse, iamError *IamError) {
+	role, iamError := findRole(s3cfg, values.Get("RoleName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetRoleResult.Role = *toIamRole(role)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListRoles.html
+func (iama *IamApiServer) ListRoles(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListRolesResponse) {
+	for _, role := range s3cfg.Roles {
+		resp.ListRolesResult.Roles = append(resp.ListRolesResult.Roles, toIamRole(role))
+	}
+	return resp
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeleteRole.html
+func (iama *IamApiServer) DeleteRole(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeleteRoleResponse, iamError *IamError) {
+	role, iamError := findRole(s3cfg, values.Get("RoleName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if len(role.PolicyNames) > 0 {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("the role %s still has attached policies", role.Name)}
+	}
+	s3cfg.Roles = slices.DeleteFunc(s3cfg.Roles, func(r *iam_pb.Role) bool {
+		return r == role
+	})
+	return resp, nil
+}
@@ -47,6 +47,7 @@ type IamServerOption struct {
 type IamApiServer struct {
 	s3ApiConfig IamS3ApiConfig
 	iam         *s3api.IdentityAccessManagement
+	option      *IamServerOption
 }
 
 var s3ApiConfigure IamS3ApiConfig
@@ -74,6 +75,7 @@ func NewIamApiServerWithStore(router *mux.Router, option *IamServerOption, expli
 	iamApiServer = &IamApiServer{
 		s3ApiConfig: s3ApiConfigure,
 		iam:         iam,
+		option:      option,
 	}
 
 	iamApiServer.registerRouter(router)
@@ -87,7 +89,7 @@ func (iama *IamApiServer) registerRouter(router *mux.Router) {
 	// ListBuckets
 
 	// apiRouter.Methods("GET").Path("/").HandlerFunc(track(s3a.iam.Auth(s3a.ListBucketsHandler, ACTION_ADMIN), "LIST"))
-	apiRouter.Methods(http.MethodPost).Path("/").HandlerFunc(iama.iam.Auth(iama.DoActions, ACTION_ADMIN))
+	apiRouter.Methods(http.MethodPost).Path("/").HandlerFunc(iama.iam.Auth(iama.auditActions(iama.DoActions), ACTION_ADMIN))
 	//
 	// NotFound
 	apiRouter.NotFoundHandler = http.HandlerFunc(s3err.NotFoundHandler)
@@ -0,0 +1,46 @@
+package iamapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeTenantAction(t *testing.T) {
+	s3cfg := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{Name: "admin1", Tenant: "team1"},
+			{Name: "alice", Tenant: "team1"},
+			{Name: "bob", Tenant: "team2"},
+			{Name: "root"},
+		},
+	}
+	user := func(name string) url.Values {
+		return url.Values{"UserName": []string{name}}
+	}
+
+	assert.Nil(t, authorizeTenantAction(s3cfg, "team1", "GetUser", user("alice")))
+	assert.Nil(t, authorizeTenantAction(s3cfg, "team1", "CreateUser", user("carol")))
+	assert.Nil(t, authorizeTenantAction(s3cfg, "team1", "ListUsers", url.Values{}))
+
+	iamError := authorizeTenantAction(s3cfg, "team1", "DeleteUser", user("bob"))
+	assert.Equal(t, iam.ErrCodeNoSuchEntityException, iamError.Code)
+	iamError = authorizeTenantAction(s3cfg, "team1", "CreateAccessKey", user("root"))
+	assert.Equal(t, iam.ErrCodeNoSuchEntityException, iamError.Code)
+	iamError = authorizeTenantAction(s3cfg, "team1", "CreateUser", user("bob"))
+	assert.Equal(t, iam.ErrCodeEntityAlreadyExistsException, iamError.Code)
+	iamError = authorizeTenantAction(s3cfg, "team1", "CreatePolicy", url.Values{})
+	assert.Equal(t, ErrCodeAccessDenied, iamError.Code)
+	iamError = authorizeTenantAction(s3cfg, "team1", "AddUserToGroup", user("alice"))
+	assert.Equal(t, ErrCodeAccessDenied, iamError.Code)
+
+	scoped := tenantConfiguration(s3cfg, "team1")
+	assert.Len(t, scoped.Identities, 2)
+
+	s3cfg.Identities = append(s3cfg.Identities, &iam_pb.Identity{Name: "carol"})
+	assignTenant(s3cfg, "team1", "carol")
+	assert.Equal(t, "team1", s3cfg.Identities[4].Tenant)
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/gorilla/mux"
@@ -16,6 +17,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 var GetS3ApiConfiguration func(s3cfg *iam_pb.S3ApiConfiguration) (err error)
@@ -30,12 +32,14 @@ var ias = IamApiServer{s3ApiConfig: iamS3ApiConfigureMock{}}
 type iamS3ApiConfigureMock struct{}
 
 func (iam iamS3ApiConfigureMock) GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
-	_ = copier.Copy(&s3cfg.Identities, &s3config.Identities)
+	proto.Reset(s3cfg)
+	proto.Merge(s3cfg, &s3config)
 	return nil
 }
 
 func (iam iamS3ApiConfigureMock) PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
-	_ = copier.Copy(&s3config.Identities, &s3cfg.Identities)
+	proto.Reset(&s3config)
+	proto.Merge(&s3config, s3cfg)
 	return nil
 }
 
@@ -263,3 +267,103 @@ func TestHandleImplicitUsername(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupsAndManagedPolicies(t *testing.T) {
+	svc := iam.New(session.New())
+	call := func(req *request.Request, v interface{}) *httptest.ResponseRecorder {
+		_ = req.Build()
+		response, err := executeRequest(req.HTTPRequest, v)
+		assert.Equal(t, nil, err)
+		return response
+	}
+	policyDocument := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::team-bucket/*"]}]}`
+
+	req, _ := svc.CreateUserRequest(&iam.CreateUserInput{UserName: aws.String("GroupUser")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+
+	createPolicy := CreatePolicyResponse{}
+	req, _ = svc.CreatePolicyRequest(&iam.CreatePolicyInput{PolicyName: aws.String("team-read"), PolicyDocument: aws.String(policyDocument)})
+	assert.Equal(t, http.StatusOK, call(req, &createPolicy).Code)
+	policyArn := aws.StringValue(createPolicy.CreatePolicyResult.Policy.Arn)
+	assert.Equal(t, "arn:aws:iam:::policy/team-read", policyArn)
+	assert.Equal(t, "v1", aws.StringValue(createPolicy.CreatePolicyResult.Policy.DefaultVersionId))
+
+	req, _ = svc.CreatePolicyRequest(&iam.CreatePolicyInput{PolicyName: aws.String("team-read"), PolicyDocument: aws.String(policyDocument)})
+	assert.Equal(t, http.StatusConflict, call(req, nil).Code)
+
+	req, _ = svc.CreateGroupRequest(&iam.CreateGroupInput{GroupName: aws.String("team")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.AddUserToGroupRequest(&iam.AddUserToGroupInput{GroupName: aws.String("team"), UserName: aws.String("GroupUser")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.AddUserToGroupRequest(&iam.AddUserToGroupInput{GroupName: aws.String("team"), UserName: aws.String("NoSuchUser")})
+	assert.Equal(t, http.StatusNotFound, call(req, nil).Code)
+	req, _ = svc.AttachGroupPolicyRequest(&iam.AttachGroupPolicyInput{GroupName: aws.String("team"), PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.AttachUserPolicyRequest(&iam.AttachUserPolicyInput{UserName: aws.String("GroupUser"), PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+
+	getGroup := GetGroupResponse{}
+	req, _ = svc.GetGroupRequest(&iam.GetGroupInput{GroupName: aws.String("team")})
+	assert.Equal(t, http.StatusOK, call(req, &getGroup).Code)
+	assert.Equal(t, 1, len(getGroup.GetGroupResult.Users))
+	assert.Equal(t, "GroupUser", aws.StringValue(getGroup.GetGroupResult.Users[0].UserName))
+
+	attached := ListAttachedUserPoliciesResponse{}
+	req, _ = svc.ListAttachedUserPoliciesRequest(&iam.ListAttachedUserPoliciesInput{UserName: aws.String("GroupUser")})
+	assert.Equal(t, http.StatusOK, call(req, &attached).Code)
+	assert.Equal(t, 1, len(attached.ListAttachedUserPoliciesResult.AttachedPolicies))
+	assert.Equal(t, policyArn, aws.StringValue(attached.ListAttachedUserPoliciesResult.AttachedPolicies[0].PolicyArn))
+
+	newVersion := CreatePolicyVersionResponse{}
+	req, _ = svc.CreatePolicyVersionRequest(&iam.CreatePolicyVersionInput{PolicyArn: aws.String(policyArn), PolicyDocument: aws.String(policyDocument), SetAsDefault: aws.Bool(true)})
+	assert.Equal(t, http.StatusOK, call(req, &newVersion).Code)
+	assert.Equal(t, "v2", aws.StringValue(newVersion.CreatePolicyVersionResult.PolicyVersion.VersionId))
+
+	getPolicy := GetPolicyResponse{}
+	req, _ = svc.GetPolicyRequest(&iam.GetPolicyInput{PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusOK, call(req, &getPolicy).Code)
+	assert.Equal(t, "v2", aws.StringValue(getPolicy.GetPolicyResult.Policy.DefaultVersionId))
+	assert.Equal(t, int64(2), aws.Int64Value(getPolicy.GetPolicyResult.Policy.AttachmentCount))
+
+	listPolicies := ListPoliciesResponse{}
+	req, _ = svc.ListPoliciesRequest(&iam.ListPoliciesInput{})
+	assert.Equal(t, http.StatusOK, call(req, &listPolicies).Code)
+	var policyNames []string
+	for _, policy := range listPolicies.ListPoliciesResult.Policies {
+		policyNames = append(policyNames, aws.StringValue(policy.PolicyName))
+	}
+	assert.Contains(t, policyNames, "team-read")
+
+	req, _ = svc.DeletePolicyVersionRequest(&iam.DeletePolicyVersionInput{PolicyArn: aws.String(policyArn), VersionId: aws.String("v2")})
+	assert.Equal(t, http.StatusConflict, call(req, nil).Code)
+	req, _ = svc.DeletePolicyRequest(&iam.DeletePolicyInput{PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusConflict, call(req, nil).Code)
+
+	req, _ = svc.TagUserRequest(&iam.TagUserInput{UserName: aws.String("GroupUser"), Tags: []*iam.Tag{{Key: aws.String("team"), Value: aws.String("storage")}}})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	userTags := ListUserTagsResponse{}
+	req, _ = svc.ListUserTagsRequest(&iam.ListUserTagsInput{UserName: aws.String("GroupUser")})
+	assert.Equal(t, http.StatusOK, call(req, &userTags).Code)
+	assert.Equal(t, 1, len(userTags.ListUserTagsResult.Tags))
+	assert.Equal(t, "storage", aws.StringValue(userTags.ListUserTagsResult.Tags[0].Value))
+
+	assumeRolePolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+	req, _ = svc.CreateRoleRequest(&iam.CreateRoleInput{RoleName: aws.String("deployer"), AssumeRolePolicyDocument: aws.String(assumeRolePolicy)})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	getRole := GetRoleResponse{}
+	req, _ = svc.GetRoleRequest(&iam.GetRoleInput{RoleName: aws.String("deployer")})
+	assert.Equal(t, http.StatusOK, call(req, &getRole).Code)
+	assert.Equal(t, "arn:aws:iam:::role/deployer", aws.StringValue(getRole.GetRoleResult.Role.Arn))
+
+	// deleting the user drops its group membership
+	req, _ = svc.DeleteUserRequest(&iam.DeleteUserInput{UserName: aws.String("GroupUser")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.DetachGroupPolicyRequest(&iam.DetachGroupPolicyInput{GroupName: aws.String("team"), PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.DeleteGroupRequest(&iam.DeleteGroupInput{GroupName: aws.String("team")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.DeletePolicyRequest(&iam.DeletePolicyInput{PolicyArn: aws.String(policyArn)})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+	req, _ = svc.DeleteRoleRequest(&iam.DeleteRoleInput{RoleName: aws.String("deployer")})
+	assert.Equal(t, http.StatusOK, call(req, nil).Code)
+}
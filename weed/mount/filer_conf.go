@@ -39,6 +39,8 @@ func (wfs *WFS) subscribeFilerConfEvents() (*meta_cache.MetadataFollower, error)
 	if err != nil {
 		if errors.Is(err, filer_pb.ErrNotFound) {
 			glog.V(0).Infof("fuse filer conf %s not found", confFullName)
+		} else if errors.Is(err, errFilerOffline) {
+			glog.V(0).Infof("fuse filer conf %s not read, the filer is offline", confFullName)
 		} else {
 			return nil, err
 		}
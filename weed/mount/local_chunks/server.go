@@ -0,0 +1,72 @@
+package local_chunks
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// ReadChunkFunc returns the data of a chunk kept on local disk.
+type ReadChunkFunc func(fileId string) ([]byte, error)
+
+// Server serves chunks kept on local disk on the loopback interface,
+// so they are read the same way as the chunks on the volume servers.
+type Server struct {
+	address string
+	server  *http.Server
+	readFn  ReadChunkFunc
+}
+
+func NewServer(name string, readFn ReadChunkFunc) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		address: listener.Addr().String(),
+		readFn:  readFn,
+	}
+	s.server = &http.Server{
+		Handler:           http.HandlerFunc(s.serveChunk),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			glog.Errorf("%s chunk server: %v", name, err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *Server) serveChunk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	fileId := strings.TrimPrefix(r.URL.Path, "/")
+	data, err := s.readFn(fileId)
+	if err != nil {
+		glog.V(1).Infof("read local chunk %s: %v", fileId, err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+// Address is the host:port the chunks are served on.
+func (s *Server) Address() string {
+	return s.address
+}
+
+// Url is where the chunk can be read from.
+func (s *Server) Url(fileId string) string {
+	return "http://" + s.address + "/" + fileId
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
@@ -24,16 +24,23 @@ type MetaCache struct {
 	markCachedFn   func(fullpath util.FullPath)
 	isCachedFn     func(fullpath util.FullPath) bool
 	invalidateFunc func(fullpath util.FullPath, entry *filer_pb.Entry)
+	offlineListFn  OfflineListFunc
 }
 
+// OfflineListFunc lists a directory kept locally, when it can not be read from the filer.
+// It returns false if the directory is not kept locally.
+type OfflineListFunc func(dir util.FullPath, fn func(entry *filer_pb.Entry) error) (bool, error)
+
 func NewMetaCache(dbFolder string, uidGidMapper *UidGidMapper, root util.FullPath,
-	markCachedFn func(path util.FullPath), isCachedFn func(path util.FullPath) bool, invalidateFunc func(util.FullPath, *filer_pb.Entry)) *MetaCache {
+	markCachedFn func(path util.FullPath), isCachedFn func(path util.FullPath) bool, invalidateFunc func(util.FullPath, *filer_pb.Entry),
+	offlineListFn OfflineListFunc) *MetaCache {
 	return &MetaCache{
-		root:         root,
-		localStore:   openMetaStore(dbFolder),
-		markCachedFn: markCachedFn,
-		isCachedFn:   isCachedFn,
-		uidGidMapper: uidGidMapper,
+		root:          root,
+		localStore:    openMetaStore(dbFolder),
+		markCachedFn:  markCachedFn,
+		isCachedFn:    isCachedFn,
+		uidGidMapper:  uidGidMapper,
+		offlineListFn: offlineListFn,
 		invalidateFunc: func(fullpath util.FullPath, entry *filer_pb.Entry) {
 			invalidateFunc(fullpath, entry)
 		},
@@ -43,20 +43,32 @@ func doEnsureVisited(mc *MetaCache, client filer_pb.FilerClient, path util.FullP
 
 	glog.V(4).Infof("ReadDirAllEntries %s ...", path)
 
+	insertFn := func(pbEntry *filer_pb.Entry) error {
+		entry := filer.FromPbEntry(string(path), pbEntry)
+		if IsHiddenSystemEntry(string(path), entry.Name()) {
+			return nil
+		}
+		if err := mc.doInsertEntry(context.Background(), entry); err != nil {
+			glog.V(0).Infof("read %s: %v", entry.FullPath, err)
+			return err
+		}
+		return nil
+	}
+
 	err := util.Retry("ReadDirAllEntries", func() error {
 		return filer_pb.ReadDirAllEntries(context.Background(), client, path, "", func(pbEntry *filer_pb.Entry, isLast bool) error {
-			entry := filer.FromPbEntry(string(path), pbEntry)
-			if IsHiddenSystemEntry(string(path), entry.Name()) {
-				return nil
-			}
-			if err := mc.doInsertEntry(context.Background(), entry); err != nil {
-				glog.V(0).Infof("read %s: %v", entry.FullPath, err)
-				return err
-			}
-			return nil
+			return insertFn(pbEntry)
 		})
 	})
 
+	if err != nil && mc.offlineListFn != nil {
+		// not marked as cached, so the directory is read again from the filer when it is back
+		if found, listErr := mc.offlineListFn(path, insertFn); found {
+			glog.V(1).Infof("list %s offline: %v", path, err)
+			return listErr
+		}
+	}
+
 	if err != nil {
 		err = fmt.Errorf("list %s: %v", path, err)
 	} else {
@@ -0,0 +1,482 @@
+package pin_cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/local_chunks"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mount_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+)
+
+// keys in the pin database
+const (
+	pinPrefix   = "pin\x00"   // + pinned path => PinStatus
+	refsPrefix  = "refs\x00"  // + pinned path => chunk file ids needed by the pinned path, one per line
+	entryPrefix = "entry\x00" // + directory + "\x00" + name => Entry
+)
+
+// PinCache keeps a copy of the metadata and the chunks under the pinned paths on local disk.
+// Unlike the chunk cache, nothing is evicted until the path is unpinned,
+// and the copy is used to serve the pinned paths when the filer is offline.
+type PinCache struct {
+	chunksDir string
+	db        *leveldb.DB
+	syncLock  sync.Mutex
+	server    *local_chunks.Server
+}
+
+func Open(dir string) (*PinCache, error) {
+	chunksDir := filepath.Join(dir, "chunks")
+	if err := os.MkdirAll(chunksDir, 0755); err != nil {
+		return nil, err
+	}
+	db, err := leveldb.OpenFile(filepath.Join(dir, "meta"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("open pin database: %w", err)
+	}
+	return &PinCache{
+		chunksDir: chunksDir,
+		db:        db,
+	}, nil
+}
+
+// StartChunkServer starts serving the pinned chunks.
+func (pc *PinCache) StartChunkServer() error {
+	server, err := local_chunks.NewServer("pin cache", pc.ReadChunk)
+	if err != nil {
+		return err
+	}
+	pc.server = server
+	return nil
+}
+
+// ChunkUrl is where a pinned chunk can be read from, if it is stored locally.
+func (pc *PinCache) ChunkUrl(fileId string) (string, bool) {
+	if pc.server == nil || !pc.HasChunk(fileId) {
+		return "", false
+	}
+	return pc.server.Url(fileId), true
+}
+
+func (pc *PinCache) Close() {
+	if pc.server != nil {
+		pc.server.Close()
+	}
+	pc.db.Close()
+}
+
+// AddPin remembers the path to be pinned. The data is fetched by Sync.
+func (pc *PinCache) AddPin(p util.FullPath) error {
+	if _, found := pc.getPin(p); found {
+		return nil
+	}
+	return pc.putPin(&mount_pb.PinStatus{Path: string(p)})
+}
+
+// RemovePin forgets the path, and removes the local copy not needed by other pinned paths.
+func (pc *PinCache) RemovePin(p util.FullPath) error {
+	pc.syncLock.Lock()
+	defer pc.syncLock.Unlock()
+
+	if _, found := pc.getPin(p); !found {
+		return fmt.Errorf("%s is not pinned", p)
+	}
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(pinPrefix + string(p)))
+	batch.Delete([]byte(refsPrefix + string(p)))
+	if err := pc.db.Write(batch, nil); err != nil {
+		return err
+	}
+	if !pc.IsPinned(p) {
+		if err := pc.deleteEntry(p); err != nil {
+			return err
+		}
+	}
+	return pc.collectGarbage()
+}
+
+// Pins lists the pinned paths and how they were synced.
+func (pc *PinCache) Pins() (pins []*mount_pb.PinStatus) {
+	iter := pc.db.NewIterator(leveldb_util.BytesPrefix([]byte(pinPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		status := &mount_pb.PinStatus{}
+		if err := proto.Unmarshal(iter.Value(), status); err != nil {
+			glog.Warningf("pin %s: %v", iter.Key()[len(pinPrefix):], err)
+			continue
+		}
+		pins = append(pins, status)
+	}
+	return
+}
+
+// IsPinned tells whether the path is one of the pinned paths, or under one of them.
+func (pc *PinCache) IsPinned(p util.FullPath) bool {
+	for _, pin := range pc.Pins() {
+		if pinPath := util.FullPath(pin.Path); p == pinPath || p.IsUnder(pinPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// List calls fn with the stored entries of the directory.
+// It returns false if the directory is neither pinned nor leading to a pinned path.
+func (pc *PinCache) List(dir util.FullPath, fn func(entry *filer_pb.Entry) error) (bool, error) {
+	found := false
+	for _, pin := range pc.Pins() {
+		if pinPath := util.FullPath(pin.Path); dir == pinPath || dir.IsUnder(pinPath) || pinPath.IsUnder(dir) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false, nil
+	}
+	iter := pc.db.NewIterator(leveldb_util.BytesPrefix(entryDirKey(dir)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		entry := &filer_pb.Entry{}
+		if err := proto.Unmarshal(iter.Value(), entry); err != nil {
+			return true, fmt.Errorf("pinned entry %s: %w", iter.Key()[len(entryPrefix):], err)
+		}
+		if err := fn(entry); err != nil {
+			return true, err
+		}
+	}
+	return true, iter.Error()
+}
+
+// HasChunk tells whether the chunk is stored locally.
+func (pc *PinCache) HasChunk(fileId string) bool {
+	_, err := os.Stat(pc.chunkFile(fileId))
+	return err == nil
+}
+
+func (pc *PinCache) ReadChunk(fileId string) ([]byte, error) {
+	return os.ReadFile(pc.chunkFile(fileId))
+}
+
+// Sync copies the current metadata and the missing chunks under the pinned path from the filer,
+// and removes what was deleted on the filer since the last sync.
+func (pc *PinCache) Sync(ctx context.Context, client filer_pb.FilerClient, lookupFn wdclient.LookupFileIdFunctionType, p util.FullPath) error {
+	pc.syncLock.Lock()
+	defer pc.syncLock.Unlock()
+
+	status, found := pc.getPin(p)
+	if !found {
+		return fmt.Errorf("%s is not pinned", p)
+	}
+	syncer := &pinSyncer{pc: pc, client: client, lookupFn: lookupFn, refs: make(map[string]struct{})}
+	err := syncer.syncPath(ctx, p)
+
+	status.FileCount, status.ByteCount = syncer.fileCount, syncer.byteCount
+	if err != nil {
+		status.Error = err.Error()
+		// keep the chunks of the last complete sync, as well as the ones fetched so far
+		for _, fileId := range pc.getRefs(p) {
+			syncer.refs[fileId] = struct{}{}
+		}
+	} else {
+		status.Error = ""
+		status.SyncedAtNs = time.Now().UnixNano()
+	}
+	if refErr := pc.putRefs(p, syncer.refs); refErr != nil {
+		return refErr
+	}
+	if putErr := pc.putPin(status); putErr != nil {
+		return putErr
+	}
+	return err
+}
+
+// CollectGarbage removes the chunks no longer needed by any pinned path.
+func (pc *PinCache) CollectGarbage() error {
+	pc.syncLock.Lock()
+	defer pc.syncLock.Unlock()
+	return pc.collectGarbage()
+}
+
+func (pc *PinCache) collectGarbage() error {
+	refs := make(map[string]struct{})
+	iter := pc.db.NewIterator(leveldb_util.BytesPrefix([]byte(refsPrefix)), nil)
+	for iter.Next() {
+		for _, fileId := range strings.Split(string(iter.Value()), "\n") {
+			refs[fileId] = struct{}{}
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	files, err := os.ReadDir(pc.chunksDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, found := refs[chunkFileId(file.Name())]; found {
+			continue
+		}
+		if err = os.Remove(filepath.Join(pc.chunksDir, file.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+type pinSyncer struct {
+	pc        *PinCache
+	client    filer_pb.FilerClient
+	lookupFn  wdclient.LookupFileIdFunctionType
+	refs      map[string]struct{}
+	fileCount int64
+	byteCount int64
+}
+
+func (s *pinSyncer) syncPath(ctx context.Context, p util.FullPath) error {
+	// the parent directories are kept, so the pinned path can be found when offline
+	var entry *filer_pb.Entry
+	for _, ancestor := range ancestorsOf(p) {
+		current, err := filer_pb.GetEntry(ctx, s.client, ancestor)
+		if err == nil && current == nil {
+			err = filer_pb.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", ancestor, err)
+		}
+		dir, _ := ancestor.DirAndName()
+		if err = s.pc.putEntry(util.FullPath(dir), current); err != nil {
+			return err
+		}
+		entry = current
+	}
+	if entry == nil || !entry.IsDirectory {
+		return s.syncFile(ctx, p, entry)
+	}
+
+	queue := []util.FullPath{p}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		var entries []*filer_pb.Entry
+		err := filer_pb.ReadDirAllEntries(ctx, s.client, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("list %s: %w", dir, err)
+		}
+		names := make(map[string]struct{}, len(entries))
+		for _, entry := range entries {
+			names[entry.Name] = struct{}{}
+		}
+		if err = s.pc.deleteEntriesExcept(dir, names); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err = s.pc.putEntry(dir, entry); err != nil {
+				return err
+			}
+			if entry.IsDirectory {
+				queue = append(queue, dir.Child(entry.Name))
+			} else if err = s.syncFile(ctx, dir.Child(entry.Name), entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *pinSyncer) syncFile(ctx context.Context, p util.FullPath, entry *filer_pb.Entry) error {
+	if entry == nil {
+		return nil
+	}
+	s.fileCount++
+	s.byteCount += int64(filer.FileSize(entry))
+
+	dataChunks, manifestChunks, err := filer.ResolveChunkManifest(ctx, s.lookupFn, entry.GetChunks(), math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return fmt.Errorf("resolve chunks of %s: %w", p, err)
+	}
+	for _, chunk := range append(dataChunks, manifestChunks...) {
+		fileId := chunk.GetFileIdString()
+		s.refs[fileId] = struct{}{}
+		if s.pc.HasChunk(fileId) {
+			continue
+		}
+		if err = s.fetchChunk(ctx, fileId); err != nil {
+			return fmt.Errorf("fetch chunk %s of %s: %w", fileId, p, err)
+		}
+	}
+	return nil
+}
+
+// fetchChunk stores the chunk as it is read from the volume server, still encrypted if it was.
+func (s *pinSyncer) fetchChunk(ctx context.Context, fileId string) error {
+	urls, err := s.lookupFn(ctx, fileId)
+	if err != nil {
+		return err
+	}
+	jwt := readJwt(fileId)
+	for _, url := range urls {
+		var data []byte
+		data, _, err = util_http.GetAuthenticated(url, jwt)
+		if err != nil {
+			glog.V(1).Infof("read %s: %v", url, err)
+			continue
+		}
+		return s.pc.writeChunk(fileId, data)
+	}
+	if err == nil {
+		err = fmt.Errorf("no location found")
+	}
+	return err
+}
+
+func readJwt(fileId string) string {
+	v := util.GetViper()
+	signingKey := security.SigningKey(v.GetString("jwt.signing.read.key"))
+	if len(signingKey) == 0 {
+		return ""
+	}
+	return string(security.GenJwtForVolumeServer(signingKey, v.GetInt("jwt.signing.read.expires_after_seconds"), fileId))
+}
+
+func (pc *PinCache) writeChunk(fileId string, data []byte) error {
+	target := pc.chunkFile(fileId)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+func (pc *PinCache) chunkFile(fileId string) string {
+	return filepath.Join(pc.chunksDir, strings.ReplaceAll(fileId, ",", "_"))
+}
+
+func chunkFileId(name string) string {
+	return strings.Replace(name, "_", ",", 1)
+}
+
+func (pc *PinCache) getPin(p util.FullPath) (*mount_pb.PinStatus, bool) {
+	data, err := pc.db.Get([]byte(pinPrefix+string(p)), nil)
+	if err != nil {
+		return nil, false
+	}
+	status := &mount_pb.PinStatus{}
+	if err = proto.Unmarshal(data, status); err != nil {
+		return nil, false
+	}
+	return status, true
+}
+
+func (pc *PinCache) putPin(status *mount_pb.PinStatus) error {
+	data, err := proto.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return pc.db.Put([]byte(pinPrefix+status.Path), data, nil)
+}
+
+func (pc *PinCache) getRefs(p util.FullPath) []string {
+	data, err := pc.db.Get([]byte(refsPrefix+string(p)), nil)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+func (pc *PinCache) putRefs(p util.FullPath, refs map[string]struct{}) error {
+	fileIds := make([]string, 0, len(refs))
+	for fileId := range refs {
+		fileIds = append(fileIds, fileId)
+	}
+	sort.Strings(fileIds)
+	return pc.db.Put([]byte(refsPrefix+string(p)), []byte(strings.Join(fileIds, "\n")), nil)
+}
+
+func (pc *PinCache) putEntry(dir util.FullPath, entry *filer_pb.Entry) error {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return pc.db.Put(entryKey(dir, entry.Name), data, nil)
+}
+
+// deleteEntriesExcept removes the entries of the directory deleted on the filer.
+func (pc *PinCache) deleteEntriesExcept(dir util.FullPath, names map[string]struct{}) error {
+	var deleted []string
+	iter := pc.db.NewIterator(leveldb_util.BytesPrefix(entryDirKey(dir)), nil)
+	for iter.Next() {
+		name := string(iter.Key()[len(entryDirKey(dir)):])
+		if _, found := names[name]; !found {
+			deleted = append(deleted, name)
+		}
+	}
+	iter.Release()
+	for _, name := range deleted {
+		if err := pc.deleteEntry(dir.Child(name)); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// deleteEntry removes the entry and everything under it.
+func (pc *PinCache) deleteEntry(p util.FullPath) error {
+	dir, name := p.DirAndName()
+	batch := new(leveldb.Batch)
+	batch.Delete(entryKey(util.FullPath(dir), name))
+	// the children of p are listed under p, and p/..., all of which start with p
+	iter := pc.db.NewIterator(leveldb_util.BytesPrefix([]byte(entryPrefix+string(p))), nil)
+	for iter.Next() {
+		childDir := string(iter.Key()[len(entryPrefix):])
+		childDir = childDir[:strings.IndexByte(childDir, 0)]
+		if childDir == string(p) || util.FullPath(childDir).IsUnder(p) {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return pc.db.Write(batch, nil)
+}
+
+func entryDirKey(dir util.FullPath) []byte {
+	return []byte(entryPrefix + string(dir) + "\x00")
+}
+
+func entryKey(dir util.FullPath, name string) []byte {
+	return append(entryDirKey(dir), name...)
+}
+
+// ancestorsOf lists the paths from the top directory down to p, without the root.
+func ancestorsOf(p util.FullPath) (paths []util.FullPath) {
+	for p != "/" && p != "" {
+		paths = append([]util.FullPath{p}, paths...)
+		dir, _ := p.DirAndName()
+		p = util.FullPath(dir)
+	}
+	return
+}
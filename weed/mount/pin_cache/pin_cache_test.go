@@ -0,0 +1,98 @@
+package pin_cache
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestPinCacheListAndUnpin(t *testing.T) {
+	dir := t.TempDir()
+	pc, err := Open(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err = pc.AddPin("/data/docs"); err != nil {
+		t.Fatalf("add pin: %v", err)
+	}
+	pc.putEntry("/", &filer_pb.Entry{Name: "data", IsDirectory: true})
+	pc.putEntry("/data", &filer_pb.Entry{Name: "docs", IsDirectory: true})
+	pc.putEntry("/data/docs", &filer_pb.Entry{Name: "a.txt", Chunks: []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 3}}})
+	pc.putEntry("/data/docs", &filer_pb.Entry{Name: "sub", IsDirectory: true})
+	pc.putEntry("/data/docs/sub", &filer_pb.Entry{Name: "b.txt"})
+	pc.putEntry("/data/docsx", &filer_pb.Entry{Name: "c.txt"})
+	pc.writeChunk("3,01637037d6", []byte("abc"))
+	pc.writeChunk("4,02637037d6", []byte("orphan"))
+	pc.putRefs("/data/docs", map[string]struct{}{"3,01637037d6": {}})
+	pc.Close()
+
+	// the pins are kept across restarts
+	if pc, err = Open(dir); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer pc.Close()
+	if pins := pc.Pins(); len(pins) != 1 || pins[0].Path != "/data/docs" {
+		t.Fatalf("unexpected pins %+v", pins)
+	}
+	if !pc.IsPinned("/data/docs/sub/b.txt") || pc.IsPinned("/data/docsx") || pc.IsPinned("/data") {
+		t.Errorf("wrong pinned paths")
+	}
+
+	list := func(dir util.FullPath) (names []string, found bool) {
+		found, err := pc.List(dir, func(entry *filer_pb.Entry) error {
+			names = append(names, entry.Name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("list %s: %v", dir, err)
+		}
+		return
+	}
+	if names, found := list("/"); !found || len(names) != 1 || names[0] != "data" {
+		t.Errorf("the parent directories of pinned paths should be listed, got %v %v", names, found)
+	}
+	if names, found := list("/data/docs"); !found || len(names) != 2 {
+		t.Errorf("unexpected pinned entries %v", names)
+	}
+	if _, found := list("/data/docsx"); found {
+		t.Errorf("a directory not pinned should not be listed")
+	}
+
+	if err = pc.CollectGarbage(); err != nil {
+		t.Fatalf("collect garbage: %v", err)
+	}
+	if data, err := pc.ReadChunk("3,01637037d6"); err != nil || string(data) != "abc" {
+		t.Errorf("read pinned chunk: %q %v", data, err)
+	}
+	if pc.HasChunk("4,02637037d6") {
+		t.Errorf("a chunk not needed by any pin should be removed")
+	}
+
+	if err = pc.RemovePin("/data/docs"); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if _, found := list("/data/docs/sub"); found || len(pc.Pins()) != 0 {
+		t.Errorf("unpinned directory is still listed")
+	}
+	if pc.HasChunk("3,01637037d6") {
+		t.Errorf("the chunks of an unpinned path should be removed")
+	}
+	pc.AddPin("/data")
+	if names, _ := list("/data/docs"); len(names) != 0 {
+		t.Errorf("entries under an unpinned path should be removed, got %v", names)
+	}
+	if names, _ := list("/data/docsx"); len(names) != 1 {
+		t.Errorf("entries next to an unpinned path should be kept, got %v", names)
+	}
+}
+
+func TestAncestorsOf(t *testing.T) {
+	paths := ancestorsOf("/a/b/c")
+	if len(paths) != 3 || paths[0] != "/a" || paths[2] != "/a/b/c" {
+		t.Errorf("unexpected ancestors %v", paths)
+	}
+	if len(ancestorsOf("/")) != 0 {
+		t.Errorf("the root has no ancestors")
+	}
+}
@@ -0,0 +1,128 @@
+package mount
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// posixLock is one byte range held by one lock owner of one mount.
+// The range end is inclusive, as in fuse.FileLock.
+type posixLock struct {
+	Owner      string `json:"owner"`
+	Pid        uint32 `json:"pid,omitempty"`
+	Start      uint64 `json:"start"`
+	End        uint64 `json:"end"`
+	Exclusive  bool   `json:"exclusive,omitempty"`
+	ExpireAtNs int64  `json:"expireAtNs"`
+}
+
+// posixLockTable is all locks on one file, shared by all mounts through the filer
+type posixLockTable struct {
+	Locks []*posixLock `json:"locks"`
+}
+
+func (l *posixLock) overlaps(start, end uint64) bool {
+	return l.Start <= end && start <= l.End
+}
+
+func parsePosixLockTable(data []byte) (*posixLockTable, error) {
+	table := &posixLockTable{}
+	if len(data) == 0 {
+		return table, nil
+	}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func (t *posixLockTable) toBytes() ([]byte, error) {
+	if len(t.Locks) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// removeExpired drops the locks of owners that stopped renewing them
+func (t *posixLockTable) removeExpired(nowNs int64) {
+	locks := t.Locks[:0]
+	for _, l := range t.Locks {
+		if l.ExpireAtNs > nowNs {
+			locks = append(locks, l)
+		}
+	}
+	t.Locks = locks
+}
+
+// findConflict returns a lock of another owner that prevents owner from taking the range
+func (t *posixLockTable) findConflict(owner string, start, end uint64, exclusive bool) *posixLock {
+	for _, l := range t.Locks {
+		if l.Owner == owner || !l.overlaps(start, end) {
+			continue
+		}
+		if exclusive || l.Exclusive {
+			return l
+		}
+	}
+	return nil
+}
+
+// setLock replaces whatever owner holds in the range with the new lock, splitting its
+// existing locks at the range boundaries. A nil lock only unlocks the range.
+func (t *posixLockTable) setLock(owner string, start, end uint64, lock *posixLock) {
+	var locks []*posixLock
+	for _, l := range t.Locks {
+		if l.Owner != owner || !l.overlaps(start, end) {
+			locks = append(locks, l)
+			continue
+		}
+		if l.Start < start {
+			left := *l
+			left.End = start - 1
+			locks = append(locks, &left)
+		}
+		if l.End > end {
+			right := *l
+			right.Start = end + 1
+			locks = append(locks, &right)
+		}
+	}
+	if lock != nil {
+		locks = append(locks, lock)
+	}
+	t.Locks = locks
+}
+
+// removeOwner drops all locks of the owner, and returns whether there were any
+func (t *posixLockTable) removeOwner(owner string) (found bool) {
+	locks := t.Locks[:0]
+	for _, l := range t.Locks {
+		if l.Owner == owner {
+			found = true
+			continue
+		}
+		locks = append(locks, l)
+	}
+	t.Locks = locks
+	return
+}
+
+func (t *posixLockTable) hasOwner(owner string) bool {
+	for _, l := range t.Locks {
+		if l.Owner == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// renew extends the locks whose owner has the prefix, and returns how many there are
+func (t *posixLockTable) renew(ownerPrefix string, expireAtNs int64) (count int) {
+	for _, l := range t.Locks {
+		if strings.HasPrefix(l.Owner, ownerPrefix) {
+			l.ExpireAtNs = expireAtNs
+			count++
+		}
+	}
+	return
+}
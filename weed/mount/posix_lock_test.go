@@ -0,0 +1,60 @@
+package mount
+
+import (
+	"testing"
+)
+
+func TestPosixLockTable(t *testing.T) {
+	table := &posixLockTable{}
+	lock := func(owner string, start, end uint64, exclusive bool) *posixLock {
+		return &posixLock{Owner: owner, Start: start, End: end, Exclusive: exclusive, ExpireAtNs: 100}
+	}
+
+	table.setLock("a", 0, 99, lock("a", 0, 99, false))
+	if conflict := table.findConflict("b", 50, 60, false); conflict != nil {
+		t.Errorf("shared locks should not conflict: %+v", conflict)
+	}
+	if conflict := table.findConflict("b", 50, 60, true); conflict == nil {
+		t.Errorf("exclusive lock should conflict with a shared lock")
+	}
+	if conflict := table.findConflict("b", 100, 200, true); conflict != nil {
+		t.Errorf("disjoint ranges should not conflict: %+v", conflict)
+	}
+	if conflict := table.findConflict("a", 0, 99, true); conflict != nil {
+		t.Errorf("an owner should not conflict with itself: %+v", conflict)
+	}
+
+	// unlocking the middle splits the lock in two
+	table.setLock("a", 40, 59, nil)
+	if len(table.Locks) != 2 {
+		t.Fatalf("expected 2 locks after split, got %+v", table.Locks)
+	}
+	if table.Locks[0].End != 39 || table.Locks[1].Start != 60 {
+		t.Errorf("unexpected split %+v %+v", table.Locks[0], table.Locks[1])
+	}
+	if conflict := table.findConflict("b", 40, 59, true); conflict != nil {
+		t.Errorf("unlocked range should be free: %+v", conflict)
+	}
+
+	// upgrading a range replaces the owner's lock on it
+	table.setLock("a", 0, 39, lock("a", 0, 39, true))
+	if conflict := table.findConflict("b", 10, 10, false); conflict == nil || !conflict.Exclusive {
+		t.Errorf("expected the exclusive lock to conflict, got %+v", conflict)
+	}
+
+	table.setLock("b", 200, 300, lock("b", 200, 300, true))
+	if count := table.renew("a", 500); count != 2 {
+		t.Errorf("renewed %d locks of a, want 2", count)
+	}
+	table.removeExpired(200)
+	if table.hasOwner("b") || !table.hasOwner("a") {
+		t.Errorf("expected only the locks of b to expire: %+v", table.Locks)
+	}
+
+	if !table.removeOwner("a") || len(table.Locks) != 0 {
+		t.Errorf("expected no locks left: %+v", table.Locks)
+	}
+	if data, err := table.toBytes(); err != nil || data != nil {
+		t.Errorf("an empty table should be stored as no value: %v %v", data, err)
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/mount/meta_cache"
+	"github.com/seaweedfs/seaweedfs/weed/mount/pin_cache"
+	"github.com/seaweedfs/seaweedfs/weed/mount/writeback"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mount_pb"
@@ -51,6 +53,9 @@ type Option struct {
 	Quota              int64
 	DisableXAttr       bool
 	IsMacOs            bool
+	PosixLocks         bool     // share fcntl and flock locks with other mounts through the filer
+	WritebackCacheDir  string   // keep unsent writes in this directory, across restarts and filer outages
+	Pins               []string // filer paths to keep a full local copy of, served when the filer is offline
 
 	MountUid         uint32
 	MountGid         uint32
@@ -73,6 +78,8 @@ type Option struct {
 
 	uniqueCacheDirForRead  string
 	uniqueCacheDirForWrite string
+	uniqueWritebackDir     string
+	uniquePinDir           string
 }
 
 type WFS struct {
@@ -97,6 +104,15 @@ type WFS struct {
 	fhLockTable          *util.LockTable[FileHandleId]
 	rdmaClient           *RDMAMountClient
 	FilerConf            *filer.FilerConf
+	posixLocks           *posixLockManager
+	writeback            *writeback.Journal
+	writebackAddress     string
+	writebackLock        sync.Mutex
+	writebackConflicts   map[util.FullPath]writebackConflict
+	pinCache             *pin_cache.PinCache
+	pinSyncNeeded        chan struct{}
+	pinLoopsOnce         sync.Once
+	filerOffline         atomic.Bool
 }
 
 func NewSeaweedFileSystem(option *Option) *WFS {
@@ -110,11 +126,18 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 		fhLockTable:   util.NewLockTable[FileHandleId](),
 	}
 
+	if option.PosixLocks {
+		wfs.posixLocks = newPosixLockManager(wfs.signature)
+	}
+
 	wfs.option.filerIndex = int32(rand.IntN(len(option.FilerAddresses)))
 	wfs.option.setupUniqueCacheDirectory()
 	if option.CacheSizeMBForRead > 0 {
 		wfs.chunkCache = chunk_cache.NewTieredChunkCache(256, option.getUniqueCacheDirForRead(), option.CacheSizeMBForRead, 1024*1024)
 	}
+	if option.WritebackCacheDir != "" {
+		wfs.openWriteback()
+	}
 
 	wfs.metaCache = meta_cache.NewMetaCache(path.Join(option.getUniqueCacheDirForRead(), "meta"), option.UidGidMapper,
 		util.FullPath(option.FilerMountRootPath),
@@ -143,7 +166,8 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 					}
 				}
 			}
-		})
+		}, wfs.listPinned)
+	wfs.openPinCache()
 	grace.OnInterrupt(func() {
 		wfs.metaCache.Shutdown()
 		os.RemoveAll(option.getUniqueCacheDirForWrite())
@@ -151,6 +175,13 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 		if wfs.rdmaClient != nil {
 			wfs.rdmaClient.Close()
 		}
+		if wfs.posixLocks != nil {
+			wfs.releaseAllPosixLocks()
+		}
+		if wfs.writeback != nil {
+			wfs.writeback.Close()
+		}
+		wfs.pinCache.Close()
 	})
 
 	// Initialize RDMA client if enabled
@@ -181,6 +212,10 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 }
 
 func (wfs *WFS) StartBackgroundTasks() error {
+	if len(wfs.pinCache.Pins()) > 0 {
+		wfs.startPinLoops()
+	}
+
 	follower, err := wfs.subscribeFilerConfEvents()
 	if err != nil {
 		return err
@@ -189,6 +224,12 @@ func (wfs *WFS) StartBackgroundTasks() error {
 	startTime := time.Now()
 	go meta_cache.SubscribeMetaEvents(wfs.metaCache, wfs.signature, wfs, wfs.option.FilerMountRootPath, startTime.UnixNano(), follower)
 	go wfs.loopCheckQuota()
+	if wfs.posixLocks != nil {
+		go wfs.loopRenewPosixLocks()
+	}
+	if wfs.writeback != nil {
+		wfs.startWriteback()
+	}
 
 	return nil
 }
@@ -248,6 +289,18 @@ func (wfs *WFS) maybeLoadEntry(fullpath util.FullPath) (*filer_pb.Entry, fuse.St
 }
 
 func (wfs *WFS) LookupFn() wdclient.LookupFileIdFunctionType {
+	lookupFn := wfs.volumeLookupFn()
+	if wfs.writeback != nil {
+		lookupFn = wfs.writebackLookupFn(lookupFn)
+	}
+	if wfs.pinCache != nil {
+		lookupFn = wfs.pinnedLookupFn(lookupFn)
+	}
+	return lookupFn
+}
+
+// volumeLookupFn only finds the chunks on the volume servers.
+func (wfs *WFS) volumeLookupFn() wdclient.LookupFileIdFunctionType {
 	if wfs.option.VolumeServerAccess == "filerProxy" {
 		return func(ctx context.Context, fileId string) (targetUrls []string, err error) {
 			return []string{"http://" + wfs.getCurrentFiler().ToHttpAddress() + "/?proxyChunkId=" + fileId}, nil
@@ -273,6 +326,14 @@ func (option *Option) setupUniqueCacheDirectory() {
 	os.MkdirAll(option.uniqueCacheDirForRead, os.FileMode(0777)&^option.Umask)
 	option.uniqueCacheDirForWrite = filepath.Join(path.Join(option.CacheDirForWrite, cacheUniqueId), "swap")
 	os.MkdirAll(option.uniqueCacheDirForWrite, os.FileMode(0777)&^option.Umask)
+	if option.WritebackCacheDir != "" {
+		// unlike the other cache directories, this one must be found again after an upgrade
+		writebackId := util.Md5String([]byte(option.MountDirectory + string(option.FilerAddresses[0]) + option.FilerMountRootPath))[0:8]
+		option.uniqueWritebackDir = path.Join(option.WritebackCacheDir, writebackId)
+	}
+	// the pinned copy is also kept across upgrades
+	pinId := util.Md5String([]byte(option.MountDirectory + string(option.FilerAddresses[0]) + option.FilerMountRootPath))[0:8]
+	option.uniquePinDir = path.Join(option.CacheDirForRead, "pinned", pinId)
 }
 
 func (option *Option) getUniqueCacheDirForWrite() string {
@@ -282,3 +343,11 @@ func (option *Option) getUniqueCacheDirForWrite() string {
 func (option *Option) getUniqueCacheDirForRead() string {
 	return option.uniqueCacheDirForRead
 }
+
+func (option *Option) getUniqueWritebackDir() string {
+	return option.uniqueWritebackDir
+}
+
+func (option *Option) getUniquePinDir() string {
+	return option.uniquePinDir
+}
@@ -106,5 +106,10 @@ func (wfs *WFS) Open(cancel <-chan struct{}, in *fuse.OpenIn, out *fuse.OpenOut)
  * @param fi file information
  */
 func (wfs *WFS) Release(cancel <-chan struct{}, in *fuse.ReleaseIn) {
+	if in.ReleaseFlags&fuse.FUSE_RELEASE_FLOCK_UNLOCK != 0 {
+		if fh := wfs.GetHandle(FileHandleId(in.Fh)); fh != nil {
+			wfs.releasePosixLocks(fh.inode, in.LockOwner)
+		}
+	}
 	wfs.ReleaseHandle(FileHandleId(in.Fh))
 }
@@ -0,0 +1,369 @@
+package mount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster/lock_manager"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// POSIX and flock locks are shared by all mounts through the filer.
+// The locks on one file are kept as a table in the filer KV store, next to the
+// distributed lock that guards the table while a mount reads and changes it.
+// Each mount renews its locks periodically, so the locks of a dead mount expire.
+
+const (
+	posixLockKeyPrefix   = "mount.posix_lock:"
+	posixLockGuardTtlSec = 5
+	posixLockGuardWait   = 10 * time.Second
+)
+
+var errPosixLockBusy = errors.New("posix lock table is busy")
+
+type posixLockManager struct {
+	sync.Mutex
+	ownerPrefix string
+	held        map[uint64]*heldPosixLocks // by inode
+}
+
+// heldPosixLocks remembers the key of a locked file, so locks are still released
+// after the file is renamed, and the lock owners of this mount holding locks on it.
+type heldPosixLocks struct {
+	key    string
+	owners map[uint64]struct{}
+}
+
+func newPosixLockManager(signature int32) *posixLockManager {
+	return &posixLockManager{
+		ownerPrefix: fmt.Sprintf("mount:%d:", signature),
+		held:        make(map[uint64]*heldPosixLocks),
+	}
+}
+
+func (m *posixLockManager) owner(lockOwner uint64) string {
+	return fmt.Sprintf("%s%x", m.ownerPrefix, lockOwner)
+}
+
+func (wfs *WFS) GetLk(cancel <-chan struct{}, in *fuse.LkIn, out *fuse.LkOut) (code fuse.Status) {
+	if wfs.posixLocks == nil {
+		return fuse.ENOSYS
+	}
+	key, status := wfs.posixLockKey(in.NodeId)
+	if status != fuse.OK {
+		return status
+	}
+	owner := wfs.posixLocks.owner(in.Owner)
+
+	out.Lk = fuse.FileLock{Typ: syscall.F_UNLCK}
+	err := wfs.withPosixLockTable(cancel, key, func(table *posixLockTable) (bool, error) {
+		table.removeExpired(time.Now().UnixNano())
+		conflict := table.findConflict(owner, in.Lk.Start, in.Lk.End, in.Lk.Typ == syscall.F_WRLCK)
+		if conflict == nil {
+			return false, nil
+		}
+		out.Lk = fuse.FileLock{
+			Start: conflict.Start,
+			End:   conflict.End,
+			Typ:   syscall.F_RDLCK,
+		}
+		if conflict.Exclusive {
+			out.Lk.Typ = syscall.F_WRLCK
+		}
+		// the process id is only meaningful on the same host
+		if strings.HasPrefix(conflict.Owner, wfs.posixLocks.ownerPrefix) {
+			out.Lk.Pid = conflict.Pid
+		}
+		return false, nil
+	})
+	return posixLockErrorToStatus(key, err)
+}
+
+func (wfs *WFS) SetLk(cancel <-chan struct{}, in *fuse.LkIn) (code fuse.Status) {
+	if wfs.posixLocks == nil {
+		return fuse.ENOSYS
+	}
+	return wfs.setPosixLock(cancel, in)
+}
+
+func (wfs *WFS) SetLkw(cancel <-chan struct{}, in *fuse.LkIn) (code fuse.Status) {
+	if wfs.posixLocks == nil {
+		return fuse.ENOSYS
+	}
+	backoff := 50 * time.Millisecond
+	for {
+		if code = wfs.setPosixLock(cancel, in); code != fuse.EAGAIN {
+			return code
+		}
+		select {
+		case <-cancel:
+			return fuse.EINTR
+		case <-time.After(backoff):
+		}
+		if backoff < time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (wfs *WFS) setPosixLock(cancel <-chan struct{}, in *fuse.LkIn) fuse.Status {
+	key, status := wfs.posixLockKey(in.NodeId)
+	if status != fuse.OK {
+		return status
+	}
+	owner := wfs.posixLocks.owner(in.Owner)
+	start, end := in.Lk.Start, in.Lk.End
+
+	var lock *posixLock
+	if in.Lk.Typ != syscall.F_UNLCK {
+		lock = &posixLock{
+			Owner:     owner,
+			Pid:       in.Lk.Pid,
+			Start:     start,
+			End:       end,
+			Exclusive: in.Lk.Typ == syscall.F_WRLCK,
+		}
+	}
+
+	conflicted, stillHeld := false, false
+	err := wfs.withPosixLockTable(cancel, key, func(table *posixLockTable) (bool, error) {
+		table.removeExpired(time.Now().UnixNano())
+		if lock != nil {
+			if table.findConflict(owner, start, end, lock.Exclusive) != nil {
+				conflicted = true
+				return false, nil
+			}
+			lock.ExpireAtNs = time.Now().Add(lock_manager.LiveLockTTL).UnixNano()
+		}
+		table.setLock(owner, start, end, lock)
+		stillHeld = table.hasOwner(owner)
+		return true, nil
+	})
+	if err != nil {
+		return posixLockErrorToStatus(key, err)
+	}
+	if conflicted {
+		return fuse.EAGAIN
+	}
+	wfs.posixLocks.setHeld(in.NodeId, key, in.Owner, stillHeld)
+	return fuse.OK
+}
+
+// releasePosixLocks drops all locks of the lock owner on the file, when it is closed
+func (wfs *WFS) releasePosixLocks(inode uint64, lockOwner uint64) {
+	if wfs.posixLocks == nil {
+		return
+	}
+	key, found := wfs.posixLocks.heldKey(inode, lockOwner)
+	if !found {
+		return
+	}
+	owner := wfs.posixLocks.owner(lockOwner)
+	if err := wfs.withPosixLockTable(nil, key, func(table *posixLockTable) (bool, error) {
+		return table.removeOwner(owner), nil
+	}); err != nil {
+		glog.Warningf("release locks of %s on %s: %v", owner, key, err)
+	}
+	wfs.posixLocks.setHeld(inode, key, lockOwner, false)
+}
+
+// loopRenewPosixLocks keeps the locks of this mount alive
+func (wfs *WFS) loopRenewPosixLocks() {
+	for {
+		time.Sleep(lock_manager.RenewInterval)
+		for inode, key := range wfs.posixLocks.heldKeys() {
+			count := 0
+			if err := wfs.withPosixLockTable(nil, key, func(table *posixLockTable) (bool, error) {
+				table.removeExpired(time.Now().UnixNano())
+				count = table.renew(wfs.posixLocks.ownerPrefix, time.Now().Add(lock_manager.LiveLockTTL).UnixNano())
+				return true, nil
+			}); err != nil {
+				glog.Warningf("renew locks on %s: %v", key, err)
+				continue
+			}
+			if count == 0 {
+				glog.Warningf("locks on %s expired before they were renewed", key)
+				wfs.posixLocks.forget(inode)
+			}
+		}
+	}
+}
+
+// releaseAllPosixLocks lets other mounts take the locks right away on unmount
+func (wfs *WFS) releaseAllPosixLocks() {
+	for _, key := range wfs.posixLocks.heldKeys() {
+		if err := wfs.withPosixLockTable(nil, key, func(table *posixLockTable) (bool, error) {
+			table.renew(wfs.posixLocks.ownerPrefix, 0)
+			table.removeExpired(time.Now().UnixNano())
+			return true, nil
+		}); err != nil {
+			glog.Warningf("release locks on %s: %v", key, err)
+		}
+	}
+}
+
+func (wfs *WFS) posixLockKey(inode uint64) (string, fuse.Status) {
+	if key, found := wfs.posixLocks.heldKey(inode, 0); found {
+		return key, fuse.OK
+	}
+	path, status := wfs.inodeToPath.GetPath(inode)
+	if status != fuse.OK {
+		return "", status
+	}
+	return posixLockKeyPrefix + string(path), fuse.OK
+}
+
+// withPosixLockTable runs fn on the lock table of the file while holding its distributed lock,
+// and saves the table if fn changed it
+func (wfs *WFS) withPosixLockTable(cancel <-chan struct{}, key string, fn func(table *posixLockTable) (changed bool, err error)) error {
+	var renewToken, lockHost string
+	deadline := time.Now().Add(posixLockGuardWait)
+	for {
+		var resp *filer_pb.LockResponse
+		err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+			resp, err = client.DistributedLock(context.Background(), &filer_pb.LockRequest{
+				Name:          key,
+				SecondsToLock: posixLockGuardTtlSec,
+				Owner:         wfs.posixLocks.ownerPrefix,
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error == "" {
+			renewToken, lockHost = resp.RenewToken, resp.LockHostMovedTo
+			break
+		}
+		if time.Now().After(deadline) {
+			return errPosixLockBusy
+		}
+		select {
+		case <-cancel:
+			return errPosixLockBusy
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	defer func() {
+		if err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			_, err := client.DistributedUnlock(context.Background(), &filer_pb.UnlockRequest{
+				Name:       key,
+				RenewToken: renewToken,
+			})
+			return err
+		}); err != nil {
+			glog.V(1).Infof("unlock %s: %v", key, err)
+		}
+	}()
+
+	// keep the table on the filer holding the distributed lock, in case the filers do not share a store
+	withTableFiler := wfs.WithFilerClient
+	if lockHost != "" {
+		withTableFiler = func(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+			return pb.WithFilerClient(streamingMode, wfs.signature, pb.ServerAddress(lockHost), wfs.option.GrpcDialOption, fn)
+		}
+	}
+
+	return withTableFiler(false, func(client filer_pb.SeaweedFilerClient) error {
+		getResp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: []byte(key)})
+		if err != nil {
+			return err
+		}
+		if getResp.Error != "" {
+			return errors.New(getResp.Error)
+		}
+		table, err := parsePosixLockTable(getResp.Value)
+		if err != nil {
+			glog.Warningf("discard lock table %s: %v", key, err)
+			table = &posixLockTable{}
+		}
+		changed, err := fn(table)
+		if err != nil || !changed {
+			return err
+		}
+		value, err := table.toBytes()
+		if err != nil {
+			return err
+		}
+		putResp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{Key: []byte(key), Value: value})
+		if err != nil {
+			return err
+		}
+		if putResp.Error != "" {
+			return errors.New(putResp.Error)
+		}
+		return nil
+	})
+}
+
+func posixLockErrorToStatus(key string, err error) fuse.Status {
+	if err == nil {
+		return fuse.OK
+	}
+	if err == errPosixLockBusy {
+		return fuse.EAGAIN
+	}
+	glog.Errorf("lock table %s: %v", key, err)
+	return fuse.EIO
+}
+
+func (m *posixLockManager) setHeld(inode uint64, key string, lockOwner uint64, isHeld bool) {
+	m.Lock()
+	defer m.Unlock()
+	held, found := m.held[inode]
+	if !isHeld {
+		if found {
+			delete(held.owners, lockOwner)
+			if len(held.owners) == 0 {
+				delete(m.held, inode)
+			}
+		}
+		return
+	}
+	if !found {
+		held = &heldPosixLocks{key: key, owners: make(map[uint64]struct{})}
+		m.held[inode] = held
+	}
+	held.owners[lockOwner] = struct{}{}
+}
+
+// heldKey returns the key of a file with locks of this mount, and of the lock owner unless it is 0
+func (m *posixLockManager) heldKey(inode uint64, lockOwner uint64) (string, bool) {
+	m.Lock()
+	defer m.Unlock()
+	held, found := m.held[inode]
+	if !found {
+		return "", false
+	}
+	if lockOwner != 0 {
+		if _, found = held.owners[lockOwner]; !found {
+			return "", false
+		}
+	}
+	return held.key, true
+}
+
+func (m *posixLockManager) heldKeys() map[uint64]string {
+	m.Lock()
+	defer m.Unlock()
+	keys := make(map[uint64]string, len(m.held))
+	for inode, held := range m.held {
+		keys[inode] = held.key
+	}
+	return keys
+}
+
+func (m *posixLockManager) forget(inode uint64) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.held, inode)
+}
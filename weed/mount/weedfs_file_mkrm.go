@@ -70,35 +70,40 @@ func (wfs *WFS) Mknod(cancel <-chan struct{}, in *fuse.MknodIn, name string, out
 		},
 	}
 
-	err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-
-		wfs.mapPbIdFromLocalToFiler(newEntry)
-		defer wfs.mapPbIdFromFilerToLocal(newEntry)
-
-		request := &filer_pb.CreateEntryRequest{
-			Directory:                string(dirFullPath),
-			Entry:                    newEntry,
-			Signatures:               []int32{wfs.signature},
-			SkipCheckParentDirectory: true,
-		}
-
-		glog.V(1).Infof("mknod: %v", request)
-		if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
-			glog.V(0).Infof("mknod %s: %v", entryFullPath, err)
-			return err
-		}
-
-		if err := wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry)); err != nil {
-			return fmt.Errorf("local mknod %s: %v", entryFullPath, err)
-		}
-
-		return nil
-	})
+	var err error
+	if wfs.writeback != nil {
+		err = wfs.journalEntry(dirFullPath, newEntry)
+	} else {
+		err = wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+
+			wfs.mapPbIdFromLocalToFiler(newEntry)
+			defer wfs.mapPbIdFromFilerToLocal(newEntry)
+
+			request := &filer_pb.CreateEntryRequest{
+				Directory:                string(dirFullPath),
+				Entry:                    newEntry,
+				Signatures:               []int32{wfs.signature},
+				SkipCheckParentDirectory: true,
+			}
+
+			glog.V(1).Infof("mknod: %v", request)
+			if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
+				glog.V(0).Infof("mknod %s: %v", entryFullPath, err)
+				return err
+			}
+
+			if err := wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry)); err != nil {
+				return fmt.Errorf("local mknod %s: %v", entryFullPath, err)
+			}
+
+			return nil
+		})
+	}
 
 	glog.V(3).Infof("mknod %s: %v", entryFullPath, err)
 
 	if err != nil {
-		return fuse.EIO
+		return filerErrorToStatus(err)
 	}
 
 	// this is to increase nlookup counter
@@ -134,6 +139,13 @@ func (wfs *WFS) Unlink(cancel <-chan struct{}, header *fuse.InHeader, name strin
 		return fuse.EPERM
 	}
 
+	if code = wfs.syncWriteback(entryFullPath); code != fuse.OK {
+		return code
+	}
+	if wfs.filerOffline.Load() {
+		return fuse.EROFS
+	}
+
 	// first, ensure the filer store can correctly delete
 	glog.V(3).Infof("remove file: %v", entryFullPath)
 	isDeleteData := entry != nil && entry.HardLinkCounter <= 1
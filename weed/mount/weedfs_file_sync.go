@@ -59,6 +59,9 @@ func (wfs *WFS) Flush(cancel <-chan struct{}, in *fuse.FlushIn) fuse.Status {
 		return fuse.OK
 	}
 
+	// closing any descriptor of the file drops the POSIX locks of the process
+	wfs.releasePosixLocks(fh.inode, in.LockOwner)
+
 	return wfs.doFlush(fh, in.Uid, in.Gid)
 }
 
@@ -118,6 +121,15 @@ func (wfs *WFS) doFlush(fh *FileHandle, uid, gid uint32) fuse.Status {
 	fhActiveLock := fh.wfs.fhLockTable.AcquireLock("doFlush", fh.fh, util.ExclusiveLock)
 	defer fh.wfs.fhLockTable.ReleaseLock(fh.fh, fhActiveLock)
 
+	if wfs.writeback != nil {
+		if err := wfs.flushToWriteback(fh, util.FullPath(dir), name, uid, gid); err != nil {
+			glog.Errorf("%v fh %d flush to write back cache: %v", fileFullPath, fh.fh, err)
+			return fuse.EIO
+		}
+		fh.dirtyMetadata = false
+		return fuse.OK
+	}
+
 	err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
 
 		entry := fh.GetEntry()
@@ -175,7 +187,7 @@ func (wfs *WFS) doFlush(fh *FileHandle, uid, gid uint32) fuse.Status {
 
 	if err != nil {
 		glog.Errorf("%v fh %d flush: %v", fileFullPath, fh.fh, err)
-		return fuse.EIO
+		return filerErrorToStatus(err)
 	}
 
 	if IsDebugFileReadWrite {
@@ -15,3 +15,26 @@ func (wfs *WFS) Configure(ctx context.Context, request *mount_pb.ConfigureReques
 	wfs.option.Quota = request.GetCollectionCapacity()
 	return &mount_pb.ConfigureResponse{}, nil
 }
+
+func (wfs *WFS) Pin(ctx context.Context, request *mount_pb.PinRequest) (*mount_pb.PinResponse, error) {
+	if request.Path != "" {
+		pinPath, err := wfs.toPinPath(request.Path)
+		if err != nil {
+			return nil, err
+		}
+		if request.Unpin {
+			glog.V(0).Infof("unpin %s", pinPath)
+			if err = wfs.pinCache.RemovePin(pinPath); err != nil {
+				return nil, err
+			}
+		} else {
+			glog.V(0).Infof("pin %s", pinPath)
+			if err = wfs.pinCache.AddPin(pinPath); err != nil {
+				return nil, err
+			}
+			wfs.startPinLoops()
+			wfs.requestPinSync()
+		}
+	}
+	return &mount_pb.PinResponse{Pins: wfs.pinCache.Pins()}, nil
+}
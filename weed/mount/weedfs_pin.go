@@ -0,0 +1,151 @@
+package mount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/pin_cache"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+)
+
+const (
+	pinRefreshInterval = 5 * time.Minute
+	filerProbeInterval = 10 * time.Second
+	filerProbeTimeout  = 5 * time.Second
+)
+
+// errFilerOffline fails the filer requests right away while the filer is known to be unreachable.
+// It is not a transport error, so it is not retried.
+var errFilerOffline = errors.New("filer is offline")
+
+func isFilerOffline(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errFilerOffline.Error())
+}
+
+func (wfs *WFS) openPinCache() {
+	pinCache, err := pin_cache.Open(wfs.option.getUniquePinDir())
+	if err != nil {
+		glog.Fatalf("open pin cache %s: %v", wfs.option.getUniquePinDir(), err)
+	}
+	if err = pinCache.StartChunkServer(); err != nil {
+		glog.Fatalf("serve pin cache %s: %v", wfs.option.getUniquePinDir(), err)
+	}
+	for _, p := range wfs.option.Pins {
+		pinPath, err := wfs.toPinPath(p)
+		if err != nil {
+			glog.Fatalf("pin %s: %v", p, err)
+		}
+		if err = pinCache.AddPin(pinPath); err != nil {
+			glog.Fatalf("pin %s: %v", p, err)
+		}
+	}
+	wfs.pinCache = pinCache
+	wfs.pinSyncNeeded = make(chan struct{}, 1)
+	if len(pinCache.Pins()) > 0 {
+		wfs.probeFiler()
+	}
+}
+
+// toPinPath checks the path to pin is a filer path under the mount root.
+func (wfs *WFS) toPinPath(p string) (util.FullPath, error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("%s is not an absolute filer path", p)
+	}
+	pinPath := util.FullPath(util.Join(p))
+	if root := util.FullPath(wfs.option.FilerMountRootPath); pinPath != root && !pinPath.IsUnder(root) {
+		return "", fmt.Errorf("%s is not under the mounted %s", p, root)
+	}
+	return pinPath, nil
+}
+
+// pinnedLookupFn reads the pinned chunks from the local copy.
+func (wfs *WFS) pinnedLookupFn(lookupFn wdclient.LookupFileIdFunctionType) wdclient.LookupFileIdFunctionType {
+	return func(ctx context.Context, fileId string) (targetUrls []string, err error) {
+		if url, found := wfs.pinCache.ChunkUrl(fileId); found {
+			return []string{url}, nil
+		}
+		return lookupFn(ctx, fileId)
+	}
+}
+
+// listPinned lists a pinned directory from the local copy, when it can not be read from the filer.
+func (wfs *WFS) listPinned(dir util.FullPath, fn func(entry *filer_pb.Entry) error) (bool, error) {
+	if wfs.pinCache == nil {
+		return false, nil
+	}
+	return wfs.pinCache.List(dir, fn)
+}
+
+// startPinLoops keeps the pinned paths in sync, and watches whether the filer is reachable.
+func (wfs *WFS) startPinLoops() {
+	wfs.pinLoopsOnce.Do(func() {
+		go wfs.loopProbeFiler()
+		go wfs.loopSyncPins()
+	})
+}
+
+func (wfs *WFS) requestPinSync() {
+	select {
+	case wfs.pinSyncNeeded <- struct{}{}:
+	default:
+	}
+}
+
+func (wfs *WFS) loopProbeFiler() {
+	for {
+		time.Sleep(filerProbeInterval)
+		wfs.probeFiler()
+	}
+}
+
+func (wfs *WFS) probeFiler() {
+	err := pb.WithOneOfGrpcFilerClients(false, wfs.option.FilerAddresses, wfs.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		ctx, cancel := context.WithTimeout(context.Background(), filerProbeTimeout)
+		defer cancel()
+		_, err := client.GetFilerConfiguration(ctx, &filer_pb.GetFilerConfigurationRequest{})
+		return err
+	})
+	offline := err != nil
+	if wfs.filerOffline.Swap(offline) == offline {
+		return
+	}
+	if offline {
+		glog.Warningf("filer %v is offline, serving the pinned paths from the local copy: %v", wfs.option.FilerAddresses, err)
+	} else {
+		glog.V(0).Infof("filer %v is back online", wfs.option.FilerAddresses)
+		wfs.requestPinSync()
+	}
+}
+
+func (wfs *WFS) loopSyncPins() {
+	for {
+		if !wfs.filerOffline.Load() {
+			wfs.syncPins()
+		}
+		select {
+		case <-wfs.pinSyncNeeded:
+		case <-time.After(pinRefreshInterval):
+		}
+	}
+}
+
+func (wfs *WFS) syncPins() {
+	for _, pin := range wfs.pinCache.Pins() {
+		start := time.Now()
+		if err := wfs.pinCache.Sync(context.Background(), wfs, wfs.volumeLookupFn(), util.FullPath(pin.Path)); err != nil {
+			glog.Warningf("sync pinned %s: %v", pin.Path, err)
+			continue
+		}
+		glog.V(1).Infof("synced pinned %s in %v", pin.Path, time.Since(start))
+	}
+	if err := wfs.pinCache.CollectGarbage(); err != nil {
+		glog.Warningf("remove unpinned chunks: %v", err)
+	}
+}
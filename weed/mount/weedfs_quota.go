@@ -3,11 +3,28 @@ package mount
 import (
 	"context"
 	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
-	"time"
 )
 
+// filerErrorToStatus reports a directory quota rejected by the filer as EDQUOT,
+// changes while the filer is offline as EROFS, and other failures as EIO
+func filerErrorToStatus(err error) fuse.Status {
+	if filer.IsQuotaExceeded(err) {
+		return fuse.Status(syscall.EDQUOT)
+	}
+	if isFilerOffline(err) {
+		return fuse.EROFS
+	}
+	return fuse.EIO
+}
+
 func (wfs *WFS) loopCheckQuota() {
 
 	for {
@@ -170,6 +170,15 @@ func (wfs *WFS) Rename(cancel <-chan struct{}, in *fuse.RenameIn, oldName string
 		return fuse.EPERM
 	}
 
+	for _, p := range []util.FullPath{oldPath, newPath} {
+		if code = wfs.syncWriteback(p); code != fuse.OK {
+			return code
+		}
+	}
+	if wfs.filerOffline.Load() {
+		return fuse.EROFS
+	}
+
 	glog.V(4).Infof("dir Rename %s => %s", oldPath, newPath)
 
 	// update remote filer
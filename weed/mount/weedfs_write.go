@@ -12,6 +12,13 @@ import (
 )
 
 func (wfs *WFS) saveDataAsChunk(fullPath util.FullPath) filer.SaveDataAsChunkFunctionType {
+	if wfs.writeback != nil {
+		return wfs.writeback.SaveDataAsChunk
+	}
+	return wfs.uploadDataAsChunk(fullPath)
+}
+
+func (wfs *WFS) uploadDataAsChunk(fullPath util.FullPath) filer.SaveDataAsChunkFunctionType {
 
 	return func(reader io.Reader, filename string, offset int64, tsNs int64) (chunk *filer_pb.FileChunk, err error) {
 		uploader, err := operation.NewUploader()
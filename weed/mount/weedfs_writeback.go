@@ -0,0 +1,311 @@
+package mount
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/meta_cache"
+	"github.com/seaweedfs/seaweedfs/weed/mount/writeback"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+)
+
+const (
+	writebackRetryMin  = time.Second
+	writebackRetryMax  = 30 * time.Second
+	writebackDataGrace = time.Minute
+)
+
+// a local version that conflicted with a change on the filer, and was saved next to it
+type writebackConflict struct {
+	path  util.FullPath
+	mtime int64
+}
+
+func (wfs *WFS) openWriteback() {
+	journal, err := writeback.Open(wfs.option.getUniqueWritebackDir())
+	if err != nil {
+		glog.Fatalf("open write back cache %s: %v", wfs.option.WritebackCacheDir, err)
+	}
+	address, err := journal.StartDataServer()
+	if err != nil {
+		glog.Fatalf("serve write back cache %s: %v", wfs.option.WritebackCacheDir, err)
+	}
+	wfs.writeback = journal
+	wfs.writebackAddress = address
+	wfs.writebackConflicts = make(map[util.FullPath]writebackConflict)
+}
+
+// writebackLookupFn reads the chunks still in the write back cache from the local data server.
+func (wfs *WFS) writebackLookupFn(lookupFn wdclient.LookupFileIdFunctionType) wdclient.LookupFileIdFunctionType {
+	return func(ctx context.Context, fileId string) (targetUrls []string, err error) {
+		if writeback.IsLocalFileId(fileId) {
+			return []string{"http://" + wfs.writebackAddress + "/" + fileId}, nil
+		}
+		return lookupFn(ctx, fileId)
+	}
+}
+
+// journalEntry records a change to a file entry in the write back cache, and shows it locally right away.
+// The entry is based on the locally cached version, which is checked against the filer when it is replayed.
+func (wfs *WFS) journalEntry(dir util.FullPath, entry *filer_pb.Entry) error {
+	wfs.mapPbIdFromLocalToFiler(entry)
+	defer wfs.mapPbIdFromFilerToLocal(entry)
+
+	var baseMtime int64
+	if cached, err := wfs.metaCache.FindEntry(context.Background(), dir.Child(entry.Name)); err == nil {
+		baseMtime = cached.Attr.Mtime.Unix()
+	}
+	if err := wfs.writeback.Append(string(dir), entry, baseMtime); err != nil {
+		return err
+	}
+	return wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(string(dir), entry))
+}
+
+func (wfs *WFS) flushToWriteback(fh *FileHandle, dir util.FullPath, name string, uid, gid uint32) error {
+	entry := fh.GetEntry()
+	entry.Name = name // this flush may be just after a rename operation
+
+	if entry.Attributes != nil {
+		entry.Attributes.Mime = fh.contentType
+		if entry.Attributes.Uid == 0 {
+			entry.Attributes.Uid = uid
+		}
+		if entry.Attributes.Gid == 0 {
+			entry.Attributes.Gid = gid
+		}
+		entry.Attributes.Mtime = time.Now().Unix()
+	}
+
+	// the chunks are combined into manifests when they are replayed
+	manifestChunks, nonManifestChunks := filer.SeparateManifestChunks(entry.GetChunks())
+	chunks, _ := filer.CompactFileChunks(context.Background(), wfs.LookupFn(), nonManifestChunks)
+	entry.Chunks = append(chunks, manifestChunks...)
+
+	return wfs.journalEntry(dir, entry.GetEntry())
+}
+
+func (wfs *WFS) saveEntryToWriteback(path util.FullPath, entry *filer_pb.Entry) fuse.Status {
+	dir, _ := path.DirAndName()
+	if err := wfs.journalEntry(util.FullPath(dir), entry); err != nil {
+		glog.Errorf("saveEntry %s to write back cache: %v", path, err)
+		return fuse.EIO
+	}
+	return fuse.OK
+}
+
+// syncWriteback sends the pending changes under the path to the filer, before it is renamed or removed.
+func (wfs *WFS) syncWriteback(p util.FullPath) fuse.Status {
+	if wfs.writeback == nil || !wfs.writeback.HasPendingUnder(p) {
+		return fuse.OK
+	}
+	if err := wfs.replayWriteback(); err != nil {
+		glog.V(0).Infof("%s has changes not on the filer yet: %v", p, err)
+		return fuse.Status(syscall.EBUSY)
+	}
+	if wfs.writeback.HasPendingUnder(p) {
+		return fuse.Status(syscall.EBUSY)
+	}
+	return fuse.OK
+}
+
+// startWriteback replays what was left in the write back cache by the previous mount,
+// and keeps uploading the new changes in the background.
+func (wfs *WFS) startWriteback() {
+	if err := wfs.replayWriteback(); err != nil {
+		glog.Warningf("write back cache: %v", err)
+		wfs.showPendingWriteback()
+	}
+	go wfs.loopReplayWriteback()
+}
+
+// showPendingWriteback puts the changes that are not on the filer yet into the meta cache.
+func (wfs *WFS) showPendingWriteback() {
+	for _, record := range wfs.writeback.Pending() {
+		if err := meta_cache.EnsureVisited(wfs.metaCache, wfs, util.FullPath(record.Directory)); err != nil {
+			glog.V(1).Infof("visit %s: %v", record.Directory, err)
+		}
+		if err := wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(record.Directory, record.Entry)); err != nil {
+			glog.Warningf("cache pending %s: %v", record.FullPath(), err)
+		}
+	}
+}
+
+func (wfs *WFS) loopReplayWriteback() {
+	delay := writebackRetryMin
+	for {
+		if err := wfs.replayWriteback(); err != nil {
+			glog.V(0).Infof("write back cache: %v, retry in %v", err, delay)
+			time.Sleep(delay)
+			if delay *= 2; delay > writebackRetryMax {
+				delay = writebackRetryMax
+			}
+			continue
+		}
+		delay = writebackRetryMin
+		wfs.writeback.PurgeObsoleteData()
+		select {
+		case <-wfs.writeback.Changed():
+		case <-time.After(writebackDataGrace):
+		}
+	}
+}
+
+// replayWriteback sends the pending changes to the filer in order, and stops at the first failure.
+func (wfs *WFS) replayWriteback() error {
+	wfs.writebackLock.Lock()
+	defer wfs.writebackLock.Unlock()
+
+	for _, record := range wfs.writeback.Pending() {
+		if err := wfs.replayRecord(record); err != nil {
+			return fmt.Errorf("replay %s: %w", record.FullPath(), err)
+		}
+		if err := wfs.writeback.Complete(record, writebackDataGrace); err != nil {
+			return fmt.Errorf("complete %s: %w", record.FullPath(), err)
+		}
+	}
+	return nil
+}
+
+func (wfs *WFS) replayRecord(record *writeback.Record) error {
+	fullPath := record.FullPath()
+	entry := proto.Clone(record.Entry).(*filer_pb.Entry)
+
+	// upload the local chunks
+	var chunks []*filer_pb.FileChunk
+	for _, chunk := range entry.GetChunks() {
+		fileId := chunk.GetFileIdString()
+		if !writeback.IsLocalFileId(fileId) {
+			chunks = append(chunks, chunk)
+			continue
+		}
+		if _, found := wfs.writeback.Uploaded(fileId); !found {
+			data, err := wfs.writeback.ReadData(fileId)
+			if os.IsNotExist(err) {
+				glog.Errorf("%s lost the local data of chunk %s [%d,%d)", fullPath, fileId, chunk.Offset, chunk.Offset+int64(chunk.Size))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			uploaded, err := wfs.uploadDataAsChunk(fullPath)(bytes.NewReader(data), entry.Name, chunk.Offset, chunk.ModifiedTsNs)
+			if err != nil {
+				return err
+			}
+			wfs.writeback.SetUploaded(fileId, uploaded)
+		}
+		chunks = append(chunks, chunk)
+	}
+	entry.Chunks = chunks
+	wfs.writeback.ReplaceUploadedChunks(entry)
+
+	manifestChunks, nonManifestChunks := filer.SeparateManifestChunks(entry.GetChunks())
+	chunks, manifestErr := filer.MaybeManifestize(wfs.uploadDataAsChunk(fullPath), nonManifestChunks)
+	if manifestErr != nil {
+		return manifestErr
+	}
+	entry.Chunks = append(chunks, manifestChunks...)
+
+	// detect changes made on the filer since the local version was based on it
+	target := fullPath
+	if conflict, found := wfs.writebackConflicts[fullPath]; found && conflict.mtime == record.BaseMtime {
+		target = conflict.path
+	} else {
+		current, err := filer_pb.GetEntry(context.Background(), wfs, fullPath)
+		if err != nil && err != filer_pb.ErrNotFound {
+			return err
+		}
+		if current != nil && (current.IsDirectory || current.Attributes.GetMtime() != record.BaseMtime) {
+			target = conflictCopyPath(fullPath, time.Now())
+			glog.Warningf("%s was changed on the filer while it was changed locally, saving the local version to %s", fullPath, target)
+		}
+	}
+
+	dir, name := target.DirAndName()
+	entry.Name = name
+	if target != fullPath && entry.Attributes != nil {
+		entry.Attributes.Inode = 0
+	}
+	err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{wfs.signature},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if target != fullPath {
+		wfs.writebackConflicts[fullPath] = writebackConflict{path: target, mtime: entry.Attributes.GetMtime()}
+		if err := wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(dir, entry)); err != nil {
+			glog.Warningf("cache conflict copy %s: %v", target, err)
+		}
+	} else {
+		delete(wfs.writebackConflicts, fullPath)
+	}
+	wfs.useUploadedChunks(fullPath)
+	return nil
+}
+
+// useUploadedChunks points the cached entry and the open file handle to the uploaded chunks,
+// so the local data can be removed.
+func (wfs *WFS) useUploadedChunks(fullPath util.FullPath) {
+	if cached, err := wfs.metaCache.FindEntry(context.Background(), fullPath); err == nil {
+		entry := cached.ToProtoEntry()
+		if wfs.writeback.ReplaceUploadedChunks(entry) {
+			dir, _ := fullPath.DirAndName()
+			wfs.mapPbIdFromLocalToFiler(entry)
+			if err = wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(dir, entry)); err != nil {
+				glog.Warningf("cache uploaded %s: %v", fullPath, err)
+			}
+		}
+	}
+
+	inode, found := wfs.inodeToPath.GetInode(fullPath)
+	if !found {
+		return
+	}
+	fh, found := wfs.fhMap.FindFileHandle(inode)
+	if !found {
+		return
+	}
+	fhActiveLock := wfs.fhLockTable.AcquireLock("useUploadedChunks", fh.fh, util.ExclusiveLock)
+	defer wfs.fhLockTable.ReleaseLock(fh.fh, fhActiveLock)
+
+	var replaced bool
+	entry := fh.UpdateEntry(func(entry *filer_pb.Entry) {
+		replaced = wfs.writeback.ReplaceUploadedChunks(entry)
+	})
+	if replaced && fh.entryChunkGroup != nil {
+		fh.entryChunkGroup.SetChunks(entry.GetChunks())
+	}
+}
+
+// conflictCopyPath names the conflict copy after the host and the time, keeping the file extension.
+func conflictCopyPath(p util.FullPath, now time.Time) util.FullPath {
+	dir, name := p.DirAndName()
+	ext := filepath.Ext(name)
+	if ext == name {
+		ext = ""
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "localhost"
+	}
+	host = strings.ReplaceAll(host, "/", "_")
+	return util.NewFullPath(dir, fmt.Sprintf("%s.conflict-%s-%s%s", strings.TrimSuffix(name, ext), host, now.Format("20060102-150405"), ext))
+}
@@ -15,6 +15,10 @@ var _ = filer_pb.FilerClient(&WFS{})
 
 func (wfs *WFS) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) (err error) {
 
+	if wfs.filerOffline.Load() {
+		return errFilerOffline
+	}
+
 	return util.Retry("filer grpc", func() error {
 
 		i := atomic.LoadInt32(&wfs.option.filerIndex)
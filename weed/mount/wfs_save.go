@@ -13,6 +13,10 @@ import (
 
 func (wfs *WFS) saveEntry(path util.FullPath, entry *filer_pb.Entry) (code fuse.Status) {
 
+	if wfs.writeback != nil && !entry.IsDirectory {
+		return wfs.saveEntryToWriteback(path, entry)
+	}
+
 	parentDir, _ := path.DirAndName()
 
 	err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
@@ -40,7 +44,7 @@ func (wfs *WFS) saveEntry(path util.FullPath, entry *filer_pb.Entry) (code fuse.
 	})
 	if err != nil {
 		glog.Errorf("saveEntry %s: %v", path, err)
-		return fuse.EIO
+		return filerErrorToStatus(err)
 	}
 
 	return fuse.OK
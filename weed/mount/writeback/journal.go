@@ -0,0 +1,397 @@
+package writeback
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/mount/local_chunks"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Chunks kept in the journal use volume id 0, which is never assigned to a real volume.
+const localFileIdPrefix = "0,"
+
+const recordSuffix = ".rec"
+
+// Record is one change to a file entry that has not reached the filer yet.
+type Record struct {
+	Seq       uint64
+	Directory string
+	Entry     *filer_pb.Entry
+	// BaseMtime is the mtime of the version this change was made on, 0 if the file is new.
+	BaseMtime int64
+}
+
+func (r *Record) FullPath() util.FullPath {
+	return util.NewFullPath(r.Directory, r.Entry.Name)
+}
+
+// Journal persists file data and entry changes in a local directory,
+// until they are uploaded to the volume servers and the filer.
+type Journal struct {
+	dir      string
+	dataDir  string
+	lock     sync.Mutex
+	nextSeq  uint64
+	records  []*Record
+	uploaded map[string]*filer_pb.FileChunk // local file id => uploaded chunk
+	obsolete map[string]time.Time           // uploaded local file id => when it is no longer needed
+	changed  chan struct{}
+	server   *local_chunks.Server
+}
+
+// Open loads the pending records in dir, and removes the data not referenced by any of them.
+func Open(dir string) (*Journal, error) {
+	j := &Journal{
+		dir:      filepath.Join(dir, "journal"),
+		dataDir:  filepath.Join(dir, "data"),
+		uploaded: make(map[string]*filer_pb.FileChunk),
+		obsolete: make(map[string]time.Time),
+		changed:  make(chan struct{}, 1),
+	}
+	for _, d := range []string{j.dir, j.dataDir} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return nil, err
+		}
+	}
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+	j.removeOrphanData()
+	return j, nil
+}
+
+func (j *Journal) load() error {
+	files, err := os.ReadDir(j.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasSuffix(name, recordSuffix) {
+			// an unfinished append
+			os.Remove(filepath.Join(j.dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, recordSuffix), 16, 64)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(j.dir, name))
+		if err != nil {
+			return err
+		}
+		record, err := decodeRecord(seq, data)
+		if err != nil {
+			return fmt.Errorf("journal record %s: %v", name, err)
+		}
+		j.records = append(j.records, record)
+		if seq >= j.nextSeq {
+			j.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(j.records, func(a, b int) bool {
+		return j.records[a].Seq < j.records[b].Seq
+	})
+	if j.nextSeq == 0 {
+		j.nextSeq = 1
+	}
+	return nil
+}
+
+func (j *Journal) removeOrphanData() {
+	referenced := make(map[string]bool)
+	for _, r := range j.records {
+		for _, chunk := range r.Entry.GetChunks() {
+			referenced[chunk.GetFileIdString()] = true
+		}
+	}
+	files, err := os.ReadDir(j.dataDir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if !referenced[localFileIdPrefix+file.Name()] {
+			os.Remove(filepath.Join(j.dataDir, file.Name()))
+		}
+	}
+}
+
+func encodeRecord(r *Record) ([]byte, error) {
+	data, err := proto.Marshal(&filer_pb.CreateEntryRequest{
+		Directory: r.Directory,
+		Entry:     r.Entry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(buf, uint64(r.BaseMtime))
+	return append(buf, data...), nil
+}
+
+func decodeRecord(seq uint64, data []byte) (*Record, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("truncated")
+	}
+	request := &filer_pb.CreateEntryRequest{}
+	if err := proto.Unmarshal(data[8:], request); err != nil {
+		return nil, err
+	}
+	if request.Entry == nil {
+		return nil, fmt.Errorf("missing entry")
+	}
+	return &Record{
+		Seq:       seq,
+		Directory: request.Directory,
+		Entry:     request.Entry,
+		BaseMtime: int64(binary.BigEndian.Uint64(data)),
+	}, nil
+}
+
+// writeFileSync writes the file under a temporary name and renames it in place after it is on disk.
+func writeFileSync(name string, data []byte) error {
+	tmp := name + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err = os.Rename(tmp, name); err != nil {
+		return err
+	}
+	if d, err := os.Open(filepath.Dir(name)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// Append persists a change to the entry, to be sent to the filer after the preceding ones.
+func (j *Journal) Append(directory string, entry *filer_pb.Entry, baseMtime int64) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	record := &Record{
+		Seq:       j.nextSeq,
+		Directory: directory,
+		Entry:     proto.Clone(entry).(*filer_pb.Entry),
+		BaseMtime: baseMtime,
+	}
+	data, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	if err = writeFileSync(j.recordFile(record.Seq), data); err != nil {
+		return fmt.Errorf("append journal: %v", err)
+	}
+	j.nextSeq++
+	j.records = append(j.records, record)
+	for _, chunk := range record.Entry.GetChunks() {
+		delete(j.obsolete, chunk.GetFileIdString())
+	}
+
+	select {
+	case j.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (j *Journal) recordFile(seq uint64) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%016x%s", seq, recordSuffix))
+}
+
+// Changed is notified after a record is appended.
+func (j *Journal) Changed() <-chan struct{} {
+	return j.changed
+}
+
+// Pending returns the records not sent to the filer yet, oldest first.
+func (j *Journal) Pending() []*Record {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return append([]*Record(nil), j.records...)
+}
+
+// HasPendingUnder tells whether there are pending records for the path or anything under it.
+func (j *Journal) HasPendingUnder(p util.FullPath) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for _, r := range j.records {
+		if fullPath := r.FullPath(); fullPath == p || strings.HasPrefix(string(fullPath), string(p)+"/") || p == "/" {
+			return true
+		}
+	}
+	return false
+}
+
+// Complete removes a record after it has been applied on the filer.
+// The local data of its chunks is removed after grace, if no later record needs it.
+func (j *Journal) Complete(record *Record, grace time.Duration) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if err := os.Remove(j.recordFile(record.Seq)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i, r := range j.records {
+		if r.Seq == record.Seq {
+			j.records = append(j.records[:i], j.records[i+1:]...)
+			break
+		}
+	}
+
+	stillReferenced := make(map[string]bool)
+	for _, r := range j.records {
+		for _, chunk := range r.Entry.GetChunks() {
+			stillReferenced[chunk.GetFileIdString()] = true
+		}
+	}
+	for _, chunk := range record.Entry.GetChunks() {
+		fileId := chunk.GetFileIdString()
+		if IsLocalFileId(fileId) && !stillReferenced[fileId] {
+			j.obsolete[fileId] = time.Now().Add(grace)
+		}
+	}
+	return nil
+}
+
+// PurgeObsoleteData removes the local data that was uploaded and whose grace period has passed.
+func (j *Journal) PurgeObsoleteData() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	now := time.Now()
+	for fileId, deadline := range j.obsolete {
+		if now.Before(deadline) {
+			continue
+		}
+		os.Remove(j.dataFile(fileId))
+		delete(j.obsolete, fileId)
+		delete(j.uploaded, fileId)
+	}
+}
+
+// IsLocalFileId tells whether the chunk data is only kept in the journal.
+func IsLocalFileId(fileId string) bool {
+	return strings.HasPrefix(fileId, localFileIdPrefix)
+}
+
+func (j *Journal) dataFile(fileId string) string {
+	return filepath.Join(j.dataDir, strings.TrimPrefix(fileId, localFileIdPrefix))
+}
+
+// SaveDataAsChunk keeps the data in the journal, and returns a chunk with a local file id.
+// It has the signature of filer.SaveDataAsChunkFunctionType.
+func (j *Journal) SaveDataAsChunk(reader io.Reader, filename string, offset int64, tsNs int64) (*filer_pb.FileChunk, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		fileId := needle.NewFileId(0, rand.Uint64(), rand.Uint32()).String()
+		if _, err = os.Stat(j.dataFile(fileId)); err == nil {
+			continue
+		}
+		if err = writeFileSync(j.dataFile(fileId), data); err != nil {
+			return nil, fmt.Errorf("save %s data to journal: %v", filename, err)
+		}
+		fid, _ := filer_pb.ToFileIdObject(fileId)
+		hash := md5.Sum(data)
+		return &filer_pb.FileChunk{
+			FileId:       fileId,
+			Offset:       offset,
+			Size:         uint64(len(data)),
+			ModifiedTsNs: tsNs,
+			ETag:         base64.StdEncoding.EncodeToString(hash[:]),
+			Fid:          fid,
+		}, nil
+	}
+}
+
+// ReadData returns the data of a local chunk.
+func (j *Journal) ReadData(fileId string) ([]byte, error) {
+	if !IsLocalFileId(fileId) {
+		return nil, fmt.Errorf("%s is not a local chunk", fileId)
+	}
+	return os.ReadFile(j.dataFile(fileId))
+}
+
+// SetUploaded remembers where the data of a local chunk has been uploaded to.
+func (j *Journal) SetUploaded(localFileId string, chunk *filer_pb.FileChunk) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.uploaded[localFileId] = chunk
+}
+
+// Uploaded returns the uploaded chunk for the local chunk, if any.
+func (j *Journal) Uploaded(localFileId string) (*filer_pb.FileChunk, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	chunk, found := j.uploaded[localFileId]
+	return chunk, found
+}
+
+// ReplaceUploadedChunks points the local chunks of the entry to their uploaded copies.
+// It returns whether anything was replaced.
+func (j *Journal) ReplaceUploadedChunks(entry *filer_pb.Entry) (replaced bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for i, chunk := range entry.GetChunks() {
+		if uploaded, found := j.uploaded[chunk.GetFileIdString()]; found {
+			entry.Chunks[i] = withUploadedData(chunk, uploaded)
+			replaced = true
+		}
+	}
+	return
+}
+
+// withUploadedData keeps the position of the local chunk in the file, with the data location of the uploaded one.
+func withUploadedData(local, uploaded *filer_pb.FileChunk) *filer_pb.FileChunk {
+	chunk := proto.Clone(uploaded).(*filer_pb.FileChunk)
+	chunk.Offset = local.Offset
+	chunk.Size = local.Size
+	chunk.ModifiedTsNs = local.ModifiedTsNs
+	return chunk
+}
+
+// StartDataServer starts serving the local chunks. It returns the address to read them from.
+func (j *Journal) StartDataServer() (string, error) {
+	server, err := local_chunks.NewServer("write back cache", j.ReadData)
+	if err != nil {
+		return "", err
+	}
+	j.server = server
+	return server.Address(), nil
+}
+
+// Close stops serving the local data.
+func (j *Journal) Close() {
+	if j.server != nil {
+		j.server.Close()
+	}
+}
@@ -0,0 +1,126 @@
+package writeback
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestJournalReplayAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	chunk, err := j.SaveDataAsChunk(bytes.NewReader([]byte("hello world")), "a.txt", 0, 1)
+	if err != nil {
+		t.Fatalf("save data: %v", err)
+	}
+	if !IsLocalFileId(chunk.FileId) || chunk.Size != 11 || chunk.Fid == nil {
+		t.Fatalf("unexpected local chunk %+v", chunk)
+	}
+	orphan, _ := j.SaveDataAsChunk(bytes.NewReader([]byte("never flushed")), "b.txt", 0, 1)
+
+	entry := &filer_pb.Entry{Name: "a.txt", Attributes: &filer_pb.FuseAttributes{Mtime: 200}, Chunks: []*filer_pb.FileChunk{chunk}}
+	if err = j.Append("/dir", entry, 100); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	entry.Attributes.Mtime = 300
+	if err = j.Append("/dir", entry, 200); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	select {
+	case <-j.Changed():
+	default:
+		t.Errorf("appending should notify")
+	}
+	j.Close()
+
+	// open again, as after a crash
+	j, err = Open(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	pending := j.Pending()
+	if len(pending) != 2 || pending[0].BaseMtime != 100 || pending[1].BaseMtime != 200 || pending[1].Entry.Attributes.Mtime != 300 {
+		t.Fatalf("unexpected pending records %+v", pending)
+	}
+	if pending[0].FullPath() != "/dir/a.txt" || !j.HasPendingUnder("/dir") || j.HasPendingUnder("/di") {
+		t.Errorf("pending paths are wrong")
+	}
+	if data, err := j.ReadData(chunk.FileId); err != nil || string(data) != "hello world" {
+		t.Errorf("read data: %q %v", data, err)
+	}
+	if _, err := j.ReadData(orphan.FileId); !os.IsNotExist(err) {
+		t.Errorf("data not referenced by any record should be removed, got %v", err)
+	}
+
+	// the first record is replayed
+	uploaded := &filer_pb.FileChunk{FileId: "3,01637037d6", Size: 11, Offset: 0}
+	j.SetUploaded(chunk.FileId, uploaded)
+	if err = j.Complete(pending[0], 0); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	j.PurgeObsoleteData()
+	if _, err := j.ReadData(chunk.FileId); err != nil {
+		t.Errorf("data still needed by the second record was removed: %v", err)
+	}
+
+	replaced := &filer_pb.Entry{Chunks: []*filer_pb.FileChunk{{FileId: chunk.FileId, Offset: 5, Size: 6}}}
+	if !j.ReplaceUploadedChunks(replaced) || replaced.Chunks[0].FileId != uploaded.FileId || replaced.Chunks[0].Offset != 5 || replaced.Chunks[0].Size != 6 {
+		t.Errorf("unexpected replaced chunk %+v", replaced.Chunks[0])
+	}
+
+	if err = j.Complete(pending[1], 0); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	j.PurgeObsoleteData()
+	if _, err := j.ReadData(chunk.FileId); !os.IsNotExist(err) {
+		t.Errorf("uploaded data should be removed, got %v", err)
+	}
+	if len(j.Pending()) != 0 {
+		t.Errorf("all records should be completed")
+	}
+}
+
+func TestJournalDataServer(t *testing.T) {
+	j, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer j.Close()
+	address, err := j.StartDataServer()
+	if err != nil {
+		t.Fatalf("start data server: %v", err)
+	}
+	chunk, err := j.SaveDataAsChunk(bytes.NewReader([]byte("0123456789")), "a.txt", 0, 1)
+	if err != nil {
+		t.Fatalf("save data: %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "http://"+address+"/"+chunk.FileId+"?readDeleted=true", nil)
+	request.Header.Set("Range", "bytes=3-5")
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent || string(data) != "345" {
+		t.Errorf("range read: %d %q", resp.StatusCode, data)
+	}
+
+	resp, err = http.Get("http://" + address + "/3,01637037d6")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("a chunk not in the journal should not be found, got %d", resp.StatusCode)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // ConfigureTopic Runs on any broker, but proxied to the balancer if not the balancer
@@ -48,6 +49,12 @@ func (b *MessageQueueBroker) ConfigureTopic(ctx context.Context, request *mq_pb.
 
 	if readErr == nil && assignErr == nil && len(resp.BrokerPartitionAssignments) == int(request.PartitionCount) {
 		glog.V(0).Infof("existing topic partitions %d: %+v", len(resp.BrokerPartitionAssignments), resp.BrokerPartitionAssignments)
+		if request.Retention != nil && !proto.Equal(request.Retention, resp.Retention) {
+			resp.Retention = request.Retention
+			if err := b.fca.SaveTopicConfToFiler(t, resp); err != nil {
+				return nil, fmt.Errorf("update topic %s retention: %w", t, err)
+			}
+		}
 		return
 	}
 
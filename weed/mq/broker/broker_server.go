@@ -49,6 +49,7 @@ type MessageQueueBroker struct {
 	lockAsBalancer    *cluster.LiveLock
 	SubCoordinator    *sub_coordinator.SubCoordinator
 	accessLock        sync.Mutex
+	retentionLock     sync.Mutex
 	fca               *filer_client.FilerClientAccessor
 }
 
@@ -100,6 +101,8 @@ func NewMessageBroker(option *MessageQueueBrokerOption, grpcDialOption grpc.Dial
 		mqBroker.KeepConnectedToBrokerBalancer(newBrokerBalancerCh)
 	}()
 
+	go mqBroker.loopEnforceTopicRetention()
+
 	return mqBroker, nil
 }
 
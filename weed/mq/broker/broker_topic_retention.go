@@ -0,0 +1,93 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/logstore"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+)
+
+const topicRetentionCheckInterval = 5 * time.Minute
+
+// loopEnforceTopicRetention periodically applies the topic cleanup policies, and is the only place deleting expired topic data.
+// Only the balancer broker does the work, so that segments are not compacted twice at the same time.
+func (b *MessageQueueBroker) loopEnforceTopicRetention() {
+	for {
+		time.Sleep(topicRetentionCheckInterval)
+		if b.lockAsBalancer == nil || !b.isLockOwner() {
+			continue
+		}
+		b.enforceTopicRetention(nil)
+	}
+}
+
+// EnforceTopicRetention applies the cleanup policies now, e.g. for the retention purge of the admin server.
+// Other brokers pass the request to the balancer broker, which enforces the retention.
+func (b *MessageQueueBroker) EnforceTopicRetention(ctx context.Context, request *mq_pb.EnforceTopicRetentionRequest) (resp *mq_pb.EnforceTopicRetentionResponse, err error) {
+	if b.lockAsBalancer == nil {
+		return nil, fmt.Errorf("no balancer broker")
+	}
+	if !b.isLockOwner() {
+		proxyErr := b.withBrokerClient(false, pb.ServerAddress(b.lockAsBalancer.LockOwner()), func(client mq_pb.SeaweedMessagingClient) error {
+			resp, err = client.EnforceTopicRetention(ctx, request)
+			return nil
+		})
+		if proxyErr != nil {
+			return nil, proxyErr
+		}
+		return resp, err
+	}
+
+	if err = b.enforceTopicRetention(request.Topic); err != nil {
+		return nil, err
+	}
+	return &mq_pb.EnforceTopicRetentionResponse{}, nil
+}
+
+// enforceTopicRetention applies the cleanup policy of one topic, or of all topics if pbTopic is nil
+func (b *MessageQueueBroker) enforceTopicRetention(pbTopic *schema_pb.Topic) error {
+	b.retentionLock.Lock()
+	defer b.retentionLock.Unlock()
+
+	pbTopics := []*schema_pb.Topic{pbTopic}
+	if pbTopic == nil {
+		resp, err := b.ListTopics(context.Background(), &mq_pb.ListTopicsRequest{})
+		if err != nil {
+			glog.V(0).Infof("retention: list topics: %v", err)
+			return fmt.Errorf("list topics: %w", err)
+		}
+		pbTopics = resp.Topics
+	}
+
+	preference := &operation.StoragePreference{
+		Replication: b.option.DefaultReplication,
+		Collection:  "topics",
+		DataCenter:  b.option.DataCenter,
+	}
+
+	var lastErr error
+	for _, pbTopic := range pbTopics {
+		t := topic.FromPbTopic(pbTopic)
+		conf, err := b.fca.ReadTopicConfFromFiler(t)
+		if err != nil {
+			glog.V(0).Infof("retention: read topic %s conf: %v", t, err)
+			lastErr = fmt.Errorf("read topic %s conf: %w", t, err)
+			continue
+		}
+		if !logstore.HasCleanupWork(conf.Retention) {
+			continue
+		}
+		if err := logstore.EnforceTopicRetention(b, t, conf.Retention, preference); err != nil {
+			glog.Errorf("retention: topic %s: %v", t, err)
+			lastErr = fmt.Errorf("topic %s: %w", t, err)
+		}
+	}
+	return lastErr
+}
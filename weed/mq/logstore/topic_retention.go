@@ -0,0 +1,390 @@
+package logstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SW_EXTENDED_KEY_COMPACTED marks a log segment that was rewritten by key compaction
+	SW_EXTENDED_KEY_COMPACTED = "compacted"
+
+	// log segments modified within this window may still be appended by the broker
+	minSegmentSealAge = 2 * time.Minute
+
+	// compacted segments are split into chunks of about this size, on log entry boundaries
+	compactedChunkSize = 4 * 1024 * 1024
+)
+
+// partitionSegment is one log file or parquet file stored in a partition directory
+type partitionSegment struct {
+	entry      *filer_pb.Entry
+	isParquet  bool
+	startTsNs  int64
+	stopTsNs   int64
+	size       int64
+	isSealed   bool
+	compacted  bool
+	modifiedAt time.Time
+}
+
+// HasCleanupWork tells whether the topic retention asks for any cleanup at all
+func HasCleanupWork(retention *mq_pb.TopicRetention) bool {
+	if retention == nil || !retention.Enabled {
+		return false
+	}
+	if isCompactPolicy(retention.CleanupPolicy) {
+		return true
+	}
+	return retention.RetentionSeconds > 0 || retention.RetentionBytes > 0
+}
+
+// IsKeyCompacted tells whether the brokers compact the topic by key.
+// Parquet segments are not compacted, so such a topic should not be converted to parquet.
+func IsKeyCompacted(retention *mq_pb.TopicRetention) bool {
+	return retention != nil && retention.Enabled && isCompactPolicy(retention.CleanupPolicy)
+}
+
+func isCompactPolicy(policy mq_pb.TopicCleanupPolicy) bool {
+	return policy == mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT || policy == mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT_DELETE
+}
+
+func isDeletePolicy(policy mq_pb.TopicCleanupPolicy) bool {
+	return policy == mq_pb.TopicCleanupPolicy_CLEANUP_DELETE || policy == mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT_DELETE
+}
+
+// EnforceTopicRetention applies the topic cleanup policy to all partitions of all topic versions stored on the filer.
+// Compaction runs before deletion, so a compact+delete topic first drops superseded values and then old segments.
+func EnforceTopicRetention(filerClient filer_pb.FilerClient, t topic.Topic, retention *mq_pb.TopicRetention, preference *operation.StoragePreference) error {
+	if !HasCleanupWork(retention) {
+		return nil
+	}
+
+	topicVersions, err := collectTopicVersions(filerClient, t, 0)
+	if err != nil {
+		return fmt.Errorf("list topic versions: %w", err)
+	}
+
+	for _, topicVersion := range topicVersions {
+		partitions, err := collectTopicVersionsPartitions(filerClient, t, topicVersion)
+		if err != nil {
+			return fmt.Errorf("list partitions %s/%s/%s: %v", t.Namespace, t.Name, topicVersion, err)
+		}
+		for _, partition := range partitions {
+			partitionDir := topic.PartitionDir(t, partition)
+			if err := enforcePartitionRetention(filerClient, partitionDir, retention, preference, time.Now()); err != nil {
+				return fmt.Errorf("enforce retention on %s: %v", partitionDir, err)
+			}
+		}
+	}
+	return nil
+}
+
+func enforcePartitionRetention(filerClient filer_pb.FilerClient, partitionDir string, retention *mq_pb.TopicRetention, preference *operation.StoragePreference, now time.Time) error {
+	segments, err := listPartitionSegments(filerClient, partitionDir, now)
+	if err != nil {
+		return err
+	}
+
+	if isCompactPolicy(retention.CleanupPolicy) {
+		var tombstoneCutoffTsNs int64
+		if retention.TombstoneRetentionSeconds > 0 {
+			tombstoneCutoffTsNs = now.Add(-time.Duration(retention.TombstoneRetentionSeconds) * time.Second).UnixNano()
+		}
+		compactedAny, err := compactPartitionByKey(filerClient, partitionDir, segments, tombstoneCutoffTsNs, preference)
+		if err != nil {
+			return fmt.Errorf("compact by key: %w", err)
+		}
+		if compactedAny {
+			if segments, err = listPartitionSegments(filerClient, partitionDir, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if isDeletePolicy(retention.CleanupPolicy) {
+		for _, segment := range segmentsToDelete(segments, retention, now) {
+			glog.V(0).Infof("retention: delete %s/%s", partitionDir, segment.entry.Name)
+			if err := filer_pb.Remove(context.Background(), filerClient, partitionDir, segment.entry.Name, true, false, false, false, nil); err != nil {
+				return fmt.Errorf("delete %s/%s: %v", partitionDir, segment.entry.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// listPartitionSegments returns the log and parquet files of a partition, ordered by start time
+func listPartitionSegments(filerClient filer_pb.FilerClient, partitionDir string, now time.Time) (segments []*partitionSegment, err error) {
+	err = filer_pb.ReadDirAllEntries(context.Background(), filerClient, util.FullPath(partitionDir), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if entry.IsDirectory || len(entry.Content) > 0 || strings.HasSuffix(entry.Name, ".offset") {
+			return nil
+		}
+		segment := &partitionSegment{
+			entry:      entry,
+			size:       int64(entry.Attributes.FileSize),
+			modifiedAt: time.Unix(entry.Attributes.Mtime, 0),
+		}
+		if segment.size == 0 {
+			segment.size = int64(filer.TotalSize(entry.Chunks))
+		}
+		if strings.HasSuffix(entry.Name, ".parquet") {
+			minTsBytes, maxTsBytes := entry.Extended["min"], entry.Extended["max"]
+			if len(minTsBytes) != 8 || len(maxTsBytes) != 8 {
+				return nil
+			}
+			segment.isParquet = true
+			segment.isSealed = true
+			segment.startTsNs = int64(binary.BigEndian.Uint64(minTsBytes))
+			segment.stopTsNs = int64(binary.BigEndian.Uint64(maxTsBytes))
+		} else {
+			logTime, parseErr := time.Parse(topic.TIME_FORMAT, entry.Name)
+			if parseErr != nil {
+				return nil
+			}
+			segment.startTsNs = logTime.UnixNano()
+			segment.stopTsNs = segment.modifiedAt.UnixNano()
+			segment.isSealed = now.Sub(segment.modifiedAt) >= minSegmentSealAge
+			_, segment.compacted = entry.Extended[SW_EXTENDED_KEY_COMPACTED]
+		}
+		segments = append(segments, segment)
+		return nil
+	})
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].startTsNs < segments[j].startTsNs
+	})
+	return
+}
+
+// segmentsToDelete picks the oldest segments that are past the time retention or beyond the size retention.
+// The newest segment is always kept so that a partition never loses its latest position.
+func segmentsToDelete(segments []*partitionSegment, retention *mq_pb.TopicRetention, now time.Time) (toDelete []*partitionSegment) {
+	if len(segments) <= 1 {
+		return nil
+	}
+
+	var totalSize int64
+	for _, segment := range segments {
+		totalSize += segment.size
+	}
+
+	var cutoffTsNs int64
+	if retention.RetentionSeconds > 0 {
+		cutoffTsNs = now.Add(-time.Duration(retention.RetentionSeconds) * time.Second).UnixNano()
+	}
+
+	for _, segment := range segments[:len(segments)-1] {
+		if !segment.isSealed {
+			break
+		}
+		expired := cutoffTsNs > 0 && segment.stopTsNs < cutoffTsNs
+		oversized := retention.RetentionBytes > 0 && totalSize > retention.RetentionBytes
+		if !expired && !oversized {
+			break
+		}
+		toDelete = append(toDelete, segment)
+		totalSize -= segment.size
+	}
+	return
+}
+
+// compactPartitionByKey rewrites the sealed log segments of a partition into one segment that keeps only the latest entry per key.
+// The new segment takes the name of the oldest replaced segment, and is written before the others are removed,
+// so an interrupted compaction leaves duplicates that the next run cleans up, but never loses data.
+// Parquet segments are left as they are, with all their keys, see IsKeyCompacted; only the delete rules remove them.
+func compactPartitionByKey(filerClient filer_pb.FilerClient, partitionDir string, segments []*partitionSegment, tombstoneCutoffTsNs int64, preference *operation.StoragePreference) (compacted bool, err error) {
+	var candidates []*partitionSegment
+	var hasNewData bool
+	for _, segment := range segments {
+		if segment.isParquet {
+			glog.V(1).Infof("compact by key skips parquet segment %s/%s", partitionDir, segment.entry.Name)
+			continue
+		}
+		if !segment.isSealed {
+			// keep the order of entries: never compact past a segment that is still being written
+			break
+		}
+		candidates = append(candidates, segment)
+		if !segment.compacted {
+			hasNewData = true
+		}
+	}
+	if !hasNewData {
+		// expired tombstones are dropped together with the next batch of new data
+		return false, nil
+	}
+
+	var logEntries []*filer_pb.LogEntry
+	for _, segment := range candidates {
+		if err = iterateLogEntries(filerClient, segment.entry, func(logEntry *filer_pb.LogEntry) error {
+			logEntries = append(logEntries, logEntry)
+			return nil
+		}); err != nil {
+			return false, fmt.Errorf("read %s/%s: %w", partitionDir, segment.entry.Name, err)
+		}
+	}
+
+	remaining := compactLogEntries(logEntries, tombstoneCutoffTsNs)
+
+	targetName := candidates[0].entry.Name
+	if len(remaining) > 0 {
+		if err = saveCompactedLogSegment(filerClient, partitionDir, targetName, remaining, preference); err != nil {
+			return false, fmt.Errorf("save compacted segment %s/%s: %w", partitionDir, targetName, err)
+		}
+	}
+
+	for _, segment := range candidates {
+		if segment.entry.Name == targetName && len(remaining) > 0 {
+			continue
+		}
+		if err = filer_pb.Remove(context.Background(), filerClient, partitionDir, segment.entry.Name, true, false, false, false, nil); err != nil {
+			return true, fmt.Errorf("delete %s/%s: %v", partitionDir, segment.entry.Name, err)
+		}
+	}
+
+	glog.V(0).Infof("compacted %d segments of %s: %d => %d entries", len(candidates), partitionDir, len(logEntries), len(remaining))
+	return true, nil
+}
+
+// compactLogEntries keeps the latest entry for each key, in timestamp order.
+// A keyed entry with an empty value is a tombstone, so an empty value can not be kept as a value in a compacted topic.
+// Tombstones are kept so that readers learn about the deletion,
+// until they are older than tombstoneCutoffTsNs; then both the tombstone and the key disappear.
+// Entries without a key can not be compacted and are kept as is.
+func compactLogEntries(logEntries []*filer_pb.LogEntry, tombstoneCutoffTsNs int64) (remaining []*filer_pb.LogEntry) {
+	latest := make(map[string]*filer_pb.LogEntry)
+	for _, logEntry := range logEntries {
+		if len(logEntry.Key) == 0 {
+			continue
+		}
+		if existing, found := latest[string(logEntry.Key)]; found && existing.TsNs > logEntry.TsNs {
+			continue
+		}
+		latest[string(logEntry.Key)] = logEntry
+	}
+
+	for _, logEntry := range logEntries {
+		if len(logEntry.Key) == 0 {
+			remaining = append(remaining, logEntry)
+			continue
+		}
+		if latest[string(logEntry.Key)] != logEntry {
+			continue
+		}
+		if len(logEntry.Data) == 0 && tombstoneCutoffTsNs > 0 && logEntry.TsNs < tombstoneCutoffTsNs {
+			continue
+		}
+		remaining = append(remaining, logEntry)
+	}
+
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].TsNs < remaining[j].TsNs
+	})
+	return
+}
+
+// encodeLogEntries serializes log entries in the size-prefixed format flushed by the broker,
+// cutting a new block whenever the current one reaches maxBlockSize. Each block is readable on its own.
+func encodeLogEntries(logEntries []*filer_pb.LogEntry, maxBlockSize int) (blocks [][]byte, err error) {
+	var buf bytes.Buffer
+	sizeBuf := make([]byte, 4)
+	for _, logEntry := range logEntries {
+		data, marshalErr := proto.Marshal(logEntry)
+		if marshalErr != nil {
+			return nil, fmt.Errorf("marshal log entry: %w", marshalErr)
+		}
+		if buf.Len() > 0 && buf.Len()+4+len(data) > maxBlockSize {
+			blocks = append(blocks, bytes.Clone(buf.Bytes()))
+			buf.Reset()
+		}
+		util.Uint32toBytes(sizeBuf, uint32(len(data)))
+		buf.Write(sizeBuf)
+		buf.Write(data)
+	}
+	if buf.Len() > 0 {
+		blocks = append(blocks, buf.Bytes())
+	}
+	return
+}
+
+func saveCompactedLogSegment(filerClient filer_pb.FilerClient, partitionDir, name string, logEntries []*filer_pb.LogEntry, preference *operation.StoragePreference) error {
+	blocks, err := encodeLogEntries(logEntries, compactedChunkSize)
+	if err != nil {
+		return err
+	}
+
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return fmt.Errorf("new uploader: %w", err)
+	}
+
+	now := time.Now()
+	compactedAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(compactedAt, uint64(now.UnixNano()))
+	entry := &filer_pb.Entry{
+		Name: name,
+		Attributes: &filer_pb.FuseAttributes{
+			Crtime:   now.Unix(),
+			Mtime:    logEntries[len(logEntries)-1].TsNs / int64(time.Second),
+			FileMode: uint32(os.FileMode(0644)),
+			Uid:      uint32(os.Getuid()),
+			Gid:      uint32(os.Getgid()),
+		},
+		Extended: map[string][]byte{
+			SW_EXTENDED_KEY_COMPACTED: compactedAt,
+		},
+	}
+
+	var offset int64
+	for i, block := range blocks {
+		fileId, uploadResult, err, _ := uploader.UploadWithRetry(
+			filerClient,
+			&filer_pb.AssignVolumeRequest{
+				Count:       1,
+				Replication: preference.Replication,
+				Collection:  preference.Collection,
+				DataCenter:  preference.DataCenter,
+				DiskType:    preference.DiskType,
+				Path:        partitionDir + "/" + name,
+			},
+			&operation.UploadOption{
+				Filename: name,
+			},
+			func(host, fileId string) string {
+				return fmt.Sprintf("http://%s/%s", host, fileId)
+			},
+			util.NewBytesReader(block),
+		)
+		if err != nil {
+			return fmt.Errorf("upload block %d: %v", i, err)
+		}
+		if uploadResult.Error != "" {
+			return fmt.Errorf("upload result: %v", uploadResult.Error)
+		}
+		entry.Chunks = append(entry.Chunks, uploadResult.ToPbFileChunk(fileId, offset, now.UnixNano()))
+		offset += int64(len(block))
+	}
+	entry.Attributes.FileSize = uint64(offset)
+
+	return filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: partitionDir,
+			Entry:     entry,
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package logstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+func TestCompactLogEntries(t *testing.T) {
+	entries := []*filer_pb.LogEntry{
+		{TsNs: 1, Key: []byte("a"), Data: []byte("a1")},
+		{TsNs: 2, Key: []byte("b"), Data: []byte("b1")},
+		{TsNs: 3, Data: []byte("no key")},
+		{TsNs: 4, Key: []byte("a"), Data: []byte("a2")},
+		{TsNs: 5, Key: []byte("b")}, // tombstone
+		{TsNs: 6, Key: []byte("c"), Data: []byte("c1")},
+		{TsNs: 7, Key: []byte("d")}, // tombstone
+	}
+
+	remaining := compactLogEntries(entries, 0)
+	expected := []int64{3, 4, 5, 6, 7}
+	if len(remaining) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(remaining))
+	}
+	for i, ts := range expected {
+		if remaining[i].TsNs != ts {
+			t.Errorf("entry %d: expected ts %d, got %d", i, ts, remaining[i].TsNs)
+		}
+	}
+
+	// tombstones older than the cutoff are dropped together with their key
+	remaining = compactLogEntries(entries, 6)
+	expected = []int64{3, 4, 6, 7}
+	if len(remaining) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(remaining))
+	}
+	for i, ts := range expected {
+		if remaining[i].TsNs != ts {
+			t.Errorf("entry %d: expected ts %d, got %d", i, ts, remaining[i].TsNs)
+		}
+	}
+}
+
+func TestIsKeyCompacted(t *testing.T) {
+	tests := []struct {
+		retention *mq_pb.TopicRetention
+		expected  bool
+	}{
+		{nil, false},
+		{&mq_pb.TopicRetention{Enabled: true, RetentionSeconds: 3600}, false},
+		{&mq_pb.TopicRetention{CleanupPolicy: mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT}, false},
+		{&mq_pb.TopicRetention{Enabled: true, CleanupPolicy: mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT_DELETE}, true},
+	}
+	for _, tt := range tests {
+		if got := IsKeyCompacted(tt.retention); got != tt.expected {
+			t.Errorf("IsKeyCompacted(%v) = %v, expected %v", tt.retention, got, tt.expected)
+		}
+	}
+}
+
+func TestEncodeLogEntries(t *testing.T) {
+	var entries []*filer_pb.LogEntry
+	for i := 0; i < 100; i++ {
+		entries = append(entries, &filer_pb.LogEntry{TsNs: int64(i + 1), Key: []byte{byte(i)}, Data: make([]byte, 50)})
+	}
+
+	blocks, err := encodeLogEntries(entries, 1024)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if len(blocks) < 2 {
+		t.Fatalf("expected multiple blocks, got %d", len(blocks))
+	}
+
+	var decoded []*filer_pb.LogEntry
+	for _, block := range blocks {
+		if len(block) > 1024 {
+			t.Errorf("block size %d exceeds limit", len(block))
+		}
+		if _, err := eachChunk(block, func(logEntry *filer_pb.LogEntry) (bool, error) {
+			decoded = append(decoded, logEntry)
+			return false, nil
+		}); err != nil {
+			t.Fatalf("decode block: %v", err)
+		}
+	}
+	if len(decoded) != len(entries) {
+		t.Fatalf("expected %d decoded entries, got %d", len(entries), len(decoded))
+	}
+	for i := range entries {
+		if decoded[i].TsNs != entries[i].TsNs {
+			t.Errorf("entry %d: expected ts %d, got %d", i, entries[i].TsNs, decoded[i].TsNs)
+		}
+	}
+}
+
+func TestSegmentsToDelete(t *testing.T) {
+	now := time.Now()
+	newSegment := func(age time.Duration, size int64) *partitionSegment {
+		stop := now.Add(-age)
+		return &partitionSegment{
+			entry:     &filer_pb.Entry{Name: stop.Format(time.RFC3339)},
+			startTsNs: stop.Add(-time.Minute).UnixNano(),
+			stopTsNs:  stop.UnixNano(),
+			size:      size,
+			isSealed:  age >= minSegmentSealAge,
+		}
+	}
+	segments := []*partitionSegment{
+		newSegment(5*time.Hour, 100),
+		newSegment(3*time.Hour, 100),
+		newSegment(time.Hour, 100),
+		newSegment(time.Second, 100),
+	}
+
+	toDelete := segmentsToDelete(segments, &mq_pb.TopicRetention{Enabled: true, RetentionSeconds: 2 * 3600}, now)
+	if len(toDelete) != 2 {
+		t.Errorf("time retention: expected 2 segments, got %d", len(toDelete))
+	}
+
+	toDelete = segmentsToDelete(segments, &mq_pb.TopicRetention{Enabled: true, RetentionBytes: 250}, now)
+	if len(toDelete) != 2 {
+		t.Errorf("size retention: expected 2 segments, got %d", len(toDelete))
+	}
+
+	// the newest segment is never deleted, even when everything expired
+	toDelete = segmentsToDelete(segments[:1], &mq_pb.TopicRetention{Enabled: true, RetentionSeconds: 1}, now)
+	if len(toDelete) != 0 {
+		t.Errorf("single segment: expected 0 segments, got %d", len(toDelete))
+	}
+
+	// segments still being written stop the deletion
+	segments = append(segments[:2], newSegment(time.Second, 100), newSegment(0, 100))
+	toDelete = segmentsToDelete(segments, &mq_pb.TopicRetention{Enabled: true, RetentionBytes: 1}, now)
+	if len(toDelete) != 2 {
+		t.Errorf("unsealed: expected 2 segments, got %d", len(toDelete))
+	}
+}
@@ -0,0 +1,953 @@
+package nfs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// The NFS protocol version 3, RFC 1813.
+const (
+	nfsProgram = 100003
+
+	nfsProcNull        = 0
+	nfsProcGetattr     = 1
+	nfsProcSetattr     = 2
+	nfsProcLookup      = 3
+	nfsProcAccess      = 4
+	nfsProcReadlink    = 5
+	nfsProcRead        = 6
+	nfsProcWrite       = 7
+	nfsProcCreate      = 8
+	nfsProcMkdir       = 9
+	nfsProcSymlink     = 10
+	nfsProcMknod       = 11
+	nfsProcRemove      = 12
+	nfsProcRmdir       = 13
+	nfsProcRename      = 14
+	nfsProcLink        = 15
+	nfsProcReaddir     = 16
+	nfsProcReaddirplus = 17
+	nfsProcFsstat      = 18
+	nfsProcFsinfo      = 19
+	nfsProcPathconf    = 20
+	nfsProcCommit      = 21
+
+	// stable_how
+	writeUnstable = 0
+	writeFileSync = 2
+
+	// createmode3
+	createUnchecked = 0
+	createGuarded   = 1
+	createExclusive = 2
+
+	maxHandleSize = 64
+	maxNameLen    = 255
+	maxPathLen    = 4096
+	maxReadSize   = 1024 * 1024
+	maxWriteSize  = 1024 * 1024
+
+	createVerifierKey = "nfs.create_verifier"
+)
+
+// nfsFile is an entry resolved from a file handle, with the export rule of the client
+type nfsFile struct {
+	inode  uint64
+	path   util.FullPath
+	entry  *filer_pb.Entry
+	export *Export
+	rule   *ExportRule
+	cred   rpcCredential
+}
+
+func (f *nfsFile) isDir() bool {
+	return fileType(f.entry) == nf3Dir
+}
+
+func (f *nfsFile) isOwner() bool {
+	return f.cred.uid == 0 || f.cred.uid == f.entry.Attributes.Uid
+}
+
+func (s *NfsServer) handleNfs(req *rpcRequest, res *xdrWriter) acceptStat {
+	var handler func(req *rpcRequest, res *xdrWriter) acceptStat
+	switch req.proc {
+	case nfsProcNull:
+		return rpcSuccess
+	case nfsProcGetattr:
+		handler = s.getattr
+	case nfsProcSetattr:
+		handler = s.setattr
+	case nfsProcLookup:
+		handler = s.lookup
+	case nfsProcAccess:
+		handler = s.access
+	case nfsProcReadlink:
+		handler = s.readlink
+	case nfsProcRead:
+		handler = s.read
+	case nfsProcWrite:
+		handler = s.write
+	case nfsProcCreate:
+		handler = s.create
+	case nfsProcMkdir:
+		handler = s.mkdir
+	case nfsProcSymlink:
+		handler = s.symlink
+	case nfsProcMknod:
+		handler = s.mknod
+	case nfsProcRemove:
+		handler = s.remove
+	case nfsProcRmdir:
+		handler = s.rmdir
+	case nfsProcRename:
+		handler = s.rename
+	case nfsProcLink:
+		handler = s.link
+	case nfsProcReaddir:
+		handler = s.readdir
+	case nfsProcReaddirplus:
+		handler = s.readdirplus
+	case nfsProcFsstat:
+		handler = s.fsstat
+	case nfsProcFsinfo:
+		handler = s.fsinfo
+	case nfsProcPathconf:
+		handler = s.pathconf
+	case nfsProcCommit:
+		handler = s.commit
+	default:
+		return rpcProcUnavail
+	}
+	return handler(req, res)
+}
+
+// resolve finds the entry of a file handle, if the client may access it
+func (s *NfsServer) resolve(req *rpcRequest, handle []byte) (*nfsFile, nfsStatus) {
+	inode, ok := fromHandle(handle)
+	if !ok {
+		return nil, nfs3ErrBadHandle
+	}
+	p, found := s.handles.path(inode)
+	if !found {
+		return nil, nfs3ErrStale
+	}
+	return s.resolvePath(req, inode, p)
+}
+
+func (s *NfsServer) resolvePath(req *rpcRequest, inode uint64, p util.FullPath) (*nfsFile, nfsStatus) {
+	export, rule := s.exports.lookup(p, req.clientIP)
+	if export == nil {
+		return nil, nfs3ErrAcces
+	}
+	entry, err := s.lookupEntry(p)
+	if err != nil {
+		if status := toStatus(err); status != nfs3ErrNoEnt {
+			return nil, status
+		}
+		return nil, nfs3ErrStale
+	}
+	// the path was deleted and created again
+	if inode != rootInode && entry.Attributes.Inode != 0 && entry.Attributes.Inode != inode {
+		return nil, nfs3ErrStale
+	}
+	return &nfsFile{
+		inode:  inode,
+		path:   p,
+		entry:  entry,
+		export: export,
+		rule:   rule,
+		cred:   rule.squash(req.cred),
+	}, nfs3Ok
+}
+
+// child looks up a name in the directory
+func (s *NfsServer) child(req *rpcRequest, dir *nfsFile, name string) (*nfsFile, nfsStatus) {
+	p := dir.path.Child(name)
+	entry, err := s.lookupEntry(p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	inode, err := s.handles.inode(p, entry)
+	if err != nil {
+		glog.Errorf("nfs handle of %s: %v", p, err)
+		return nil, nfs3ErrServerFault
+	}
+	export, rule := s.exports.lookup(p, req.clientIP)
+	if export == nil {
+		return nil, nfs3ErrAcces
+	}
+	return &nfsFile{
+		inode:  inode,
+		path:   p,
+		entry:  entry,
+		export: export,
+		rule:   rule,
+		cred:   rule.squash(req.cred),
+	}, nfs3Ok
+}
+
+func (s *NfsServer) parent(req *rpcRequest, dir *nfsFile) (*nfsFile, nfsStatus) {
+	parent, _ := dir.path.DirAndName()
+	entry, err := s.lookupEntry(util.FullPath(parent))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	inode, err := s.handles.inode(util.FullPath(parent), entry)
+	if err != nil {
+		glog.Errorf("nfs handle of %s: %v", parent, err)
+		return nil, nfs3ErrServerFault
+	}
+	return s.resolvePath(req, inode, util.FullPath(parent))
+}
+
+func checkName(name string) nfsStatus {
+	switch {
+	case len(name) > maxNameLen:
+		return nfs3ErrNameTooLong
+	case name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\x00"):
+		return nfs3ErrInval
+	}
+	return nfs3Ok
+}
+
+// checkDirChange checks that the client may add or remove names in the directory
+func checkDirChange(dir *nfsFile) nfsStatus {
+	switch {
+	case dir.rule.ReadOnly:
+		return nfs3ErrRoFs
+	case !dir.isDir():
+		return nfs3ErrNotDir
+	case !hasPermission(dir.entry, dir.cred, permWrite|permExecute):
+		return nfs3ErrAcces
+	}
+	return nfs3Ok
+}
+
+func (s *NfsServer) getattr(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	res.uint32(uint32(status))
+	if status == nfs3Ok {
+		s.writeFattr(res, f.inode, f.entry)
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) setattr(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	attr := readSattr(req.args)
+	guard, guardMtime := req.args.bool(), uint32(0)
+	if guard {
+		guardMtime = req.args.uint32()
+		req.args.uint32()
+	}
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = s.doSetattr(f, attr, guard, guardMtime)
+	}
+	res.uint32(uint32(status))
+	s.writeWcc(res, f)
+	return rpcSuccess
+}
+
+func (s *NfsServer) doSetattr(f *nfsFile, attr *sattr3, guard bool, guardMtime uint32) nfsStatus {
+	switch {
+	case f.rule.ReadOnly:
+		return nfs3ErrRoFs
+	case guard && uint32(f.entry.Attributes.Mtime) != guardMtime:
+		return nfs3ErrNotSync
+	case attr.uid != nil && *attr.uid != f.entry.Attributes.Uid && f.cred.uid != 0:
+		return nfs3ErrPerm
+	case (attr.mode != nil || attr.gid != nil) && !f.isOwner():
+		return nfs3ErrPerm
+	case attr.setMtime && !f.isOwner() && !hasPermission(f.entry, f.cred, permWrite):
+		return nfs3ErrAcces
+	}
+	if attr.size != nil {
+		if f.isDir() {
+			return nfs3ErrIsDir
+		}
+		if !f.isOwner() && !hasPermission(f.entry, f.cred, permWrite) {
+			return nfs3ErrAcces
+		}
+		if err := s.writers.flush(f.inode); err != nil {
+			return toStatus(err)
+		}
+		entry, err := s.lookupEntry(f.path)
+		if err != nil {
+			return toStatus(err)
+		}
+		f.entry = entry
+		truncate(f.entry, *attr.size)
+	}
+	attr.apply(f.entry)
+	dir, _ := f.path.DirAndName()
+	return toStatus(s.updateEntry(util.FullPath(dir), f.entry))
+}
+
+// truncate changes the file size, dropping the chunks after it as weed mount does
+func truncate(entry *filer_pb.Entry, size uint64) {
+	if size < filer.FileSize(entry) {
+		if len(entry.Content) > 0 && uint64(len(entry.Content)) > size {
+			entry.Content = entry.Content[:size]
+		}
+		var chunks []*filer_pb.FileChunk
+		for _, chunk := range entry.GetChunks() {
+			if chunk.Offset >= int64(size) {
+				continue
+			}
+			if chunk.Offset+int64(chunk.Size) > int64(size) {
+				chunk.Size = size - uint64(chunk.Offset)
+			}
+			chunks = append(chunks, chunk)
+		}
+		entry.Chunks = chunks
+	}
+	entry.Attributes.FileSize = size
+	entry.Attributes.Mtime = time.Now().Unix()
+}
+
+func (s *NfsServer) lookup(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	name := req.args.string(maxPathLen)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	dir, status := s.resolve(req, handle)
+	var f *nfsFile
+	if status == nfs3Ok {
+		switch {
+		case !dir.isDir():
+			status = nfs3ErrNotDir
+		case !hasPermission(dir.entry, dir.cred, permExecute):
+			status = nfs3ErrAcces
+		case len(name) > maxNameLen:
+			status = nfs3ErrNameTooLong
+		case name == ".":
+			f = dir
+		case name == "..":
+			// clients can not go above the exported directory
+			if dir.path == dir.export.Path {
+				f = dir
+				break
+			}
+			f, status = s.parent(req, dir)
+		case checkName(name) != nfs3Ok:
+			status = nfs3ErrNoEnt
+		default:
+			f, status = s.child(req, dir, name)
+		}
+	}
+	res.uint32(uint32(status))
+	if status == nfs3Ok {
+		res.opaque(toHandle(f.inode))
+		s.writePostOpAttr(res, f)
+	}
+	s.writePostOpAttr(res, dir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) access(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	want := req.args.uint32()
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status != nfs3Ok {
+		return rpcSuccess
+	}
+	var granted uint32
+	if hasPermission(f.entry, f.cred, permRead) {
+		granted |= access3Read
+	}
+	if hasPermission(f.entry, f.cred, permWrite) && !f.rule.ReadOnly {
+		granted |= access3Modify | access3Extend | access3Delete
+	}
+	if hasPermission(f.entry, f.cred, permExecute) {
+		if f.isDir() {
+			granted |= access3Lookup
+		} else {
+			granted |= access3Execute
+		}
+	}
+	res.uint32(granted & want)
+	return rpcSuccess
+}
+
+func (s *NfsServer) readlink(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	if status == nfs3Ok && fileType(f.entry) != nf3Lnk {
+		status = nfs3ErrInval
+	}
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status == nfs3Ok {
+		res.string(f.entry.Attributes.SymlinkTarget)
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) read(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	offset := req.args.uint64()
+	count := req.args.uint32()
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	var data []byte
+	var eof bool
+	if status == nfs3Ok {
+		data, eof, status = s.doRead(f, offset, count)
+	}
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status == nfs3Ok {
+		res.uint32(uint32(len(data)))
+		res.bool(eof)
+		res.opaque(data)
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) doRead(f *nfsFile, offset uint64, count uint32) ([]byte, bool, nfsStatus) {
+	switch {
+	case f.isDir():
+		return nil, false, nfs3ErrIsDir
+	case fileType(f.entry) != nf3Reg:
+		return nil, false, nfs3ErrInval
+	case !f.isOwner() && !hasPermission(f.entry, f.cred, permRead):
+		return nil, false, nfs3ErrAcces
+	}
+	// read the writes buffered by this server
+	if s.writers.bufferedEnd(f.inode) > 0 {
+		if err := s.writers.flush(f.inode); err != nil {
+			return nil, false, toStatus(err)
+		}
+		entry, err := s.lookupEntry(f.path)
+		if err != nil {
+			return nil, false, toStatus(err)
+		}
+		f.entry = entry
+	}
+
+	size := filer.FileSize(f.entry)
+	if offset >= size {
+		return nil, true, nfs3Ok
+	}
+	if count > maxReadSize {
+		count = maxReadSize
+	}
+	n := uint64(count)
+	if offset+n > size {
+		n = size - offset
+	}
+	data := make([]byte, n)
+	if err := s.readAt(f.entry, data, int64(offset)); err != nil {
+		glog.Errorf("nfs read %s: %v", f.path, err)
+		return nil, false, nfs3ErrIO
+	}
+	return data, offset+n >= size, nfs3Ok
+}
+
+// readAt fills the buffer from the file content, the parts not covered by chunks read as zeros
+func (s *NfsServer) readAt(entry *filer_pb.Entry, data []byte, offset int64) error {
+	if len(entry.Content) > 0 {
+		if offset < int64(len(entry.Content)) {
+			copy(data, entry.Content[offset:])
+		}
+		return nil
+	}
+	ctx := context.Background()
+	lookupFileIdFn := filer.LookupFn(s)
+	chunkViews := filer.ViewFromChunks(ctx, lookupFileIdFn, entry.GetChunks(), offset, int64(len(data)))
+	for x := chunkViews.Front(); x != nil; x = x.Next {
+		chunkView := x.Value
+		urlStrings, err := lookupFileIdFn(ctx, chunkView.FileId)
+		if err != nil {
+			return err
+		}
+		var buffer bytes.Buffer
+		for _, urlString := range urlStrings {
+			buffer.Reset()
+			var shouldRetry bool
+			shouldRetry, err = util_http.ReadUrlAsStream(ctx, urlString+"?readDeleted=true", chunkView.CipherKey, chunkView.IsGzipped, chunkView.IsFullChunk(), chunkView.OffsetInChunk, int(chunkView.ViewSize), func(chunkData []byte) {
+				buffer.Write(chunkData)
+			})
+			if err == nil || !shouldRetry {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+		copy(data[chunkView.ViewOffset-offset:], buffer.Bytes())
+	}
+	return nil
+}
+
+func (s *NfsServer) write(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	offset := req.args.uint64()
+	count := req.args.uint32()
+	stable := req.args.uint32()
+	data := req.args.opaque(maxWriteSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	if uint32(len(data)) > count {
+		data = data[:count]
+	}
+	f, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		switch {
+		case f.rule.ReadOnly:
+			status = nfs3ErrRoFs
+		case f.isDir():
+			status = nfs3ErrIsDir
+		case fileType(f.entry) != nf3Reg:
+			status = nfs3ErrInval
+		case !f.isOwner() && !hasPermission(f.entry, f.cred, permWrite):
+			status = nfs3ErrAcces
+		default:
+			status = toStatus(s.writers.write(f.inode, int64(offset), data, stable != writeUnstable))
+		}
+	}
+	res.uint32(uint32(status))
+	s.writeWcc(res, f)
+	if status == nfs3Ok {
+		res.uint32(uint32(len(data)))
+		if stable == writeUnstable {
+			res.uint32(writeUnstable)
+		} else {
+			res.uint32(writeFileSync)
+		}
+		res.fixedOpaque(s.writeVerifier[:])
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) commit(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	req.args.uint64() // offset
+	req.args.uint32() // count
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = toStatus(s.writers.flush(f.inode))
+	}
+	res.uint32(uint32(status))
+	s.writeWcc(res, f)
+	if status == nfs3Ok {
+		res.fixedOpaque(s.writeVerifier[:])
+	}
+	return rpcSuccess
+}
+
+// writeCreated writes the result of CREATE, MKDIR and SYMLINK
+func (s *NfsServer) writeCreated(res *xdrWriter, status nfsStatus, f *nfsFile, dir *nfsFile) {
+	res.uint32(uint32(status))
+	if status == nfs3Ok {
+		res.bool(true)
+		res.opaque(toHandle(f.inode))
+		s.writePostOpAttr(res, f)
+	}
+	s.writeWcc(res, dir)
+}
+
+// newEntry creates an entry owned by the client, with an inode recorded in its attributes
+func (s *NfsServer) newEntry(req *rpcRequest, dir *nfsFile, name string, entry *filer_pb.Entry, attr *sattr3, exclusive bool) (*nfsFile, nfsStatus) {
+	p := dir.path.Child(name)
+	now := time.Now()
+	entry.Name = name
+	entry.Attributes.Uid = dir.cred.uid
+	entry.Attributes.Gid = dir.cred.gid
+	entry.Attributes.Crtime = now.Unix()
+	entry.Attributes.Mtime = now.Unix()
+	if attr != nil {
+		attr.apply(entry)
+	}
+	inode, err := s.handles.inode(p, &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Crtime: now.UnixNano()}})
+	if err != nil {
+		glog.Errorf("nfs handle of %s: %v", p, err)
+		return nil, nfs3ErrServerFault
+	}
+	entry.Attributes.Inode = inode
+	if err = s.createEntry(dir.path, entry, exclusive); err != nil {
+		s.handles.remove(p)
+		return nil, toStatus(err)
+	}
+	return &nfsFile{
+		inode:  inode,
+		path:   p,
+		entry:  entry,
+		export: dir.export,
+		rule:   dir.rule,
+		cred:   dir.cred,
+	}, nfs3Ok
+}
+
+func (s *NfsServer) create(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	name := req.args.string(maxPathLen)
+	how := req.args.uint32()
+	var attr *sattr3
+	var verifier []byte
+	if how == createExclusive {
+		verifier = req.args.fixedOpaque(8)
+	} else {
+		attr = readSattr(req.args)
+	}
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+
+	dir, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = checkDirChange(dir)
+	}
+	if status == nfs3Ok {
+		status = checkName(name)
+	}
+	var f *nfsFile
+	if status == nfs3Ok {
+		f, status = s.child(req, dir, name)
+		switch {
+		case status == nfs3ErrNoEnt:
+			entry := &filer_pb.Entry{
+				Attributes: &filer_pb.FuseAttributes{FileMode: 0644},
+			}
+			if verifier != nil {
+				entry.Extended = map[string][]byte{createVerifierKey: verifier}
+			}
+			f, status = s.newEntry(req, dir, name, entry, attr, true)
+			if status == nfs3Ok && attr != nil && attr.size != nil && *attr.size > 0 {
+				truncate(f.entry, *attr.size)
+				status = toStatus(s.updateEntry(dir.path, f.entry))
+			}
+		case status != nfs3Ok:
+		case how == createGuarded:
+			status = nfs3ErrExist
+		case how == createExclusive:
+			// a retransmitted exclusive create finds the file it created
+			if !bytes.Equal(f.entry.Extended[createVerifierKey], verifier) {
+				status = nfs3ErrExist
+			}
+		case f.isDir():
+			status = nfs3ErrIsDir
+		case attr != nil && attr.size != nil:
+			status = s.doSetattr(f, &sattr3{size: attr.size}, false, 0)
+		}
+	}
+	s.writeCreated(res, status, f, dir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) mkdir(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	name := req.args.string(maxPathLen)
+	attr := readSattr(req.args)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	dir, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = checkDirChange(dir)
+	}
+	if status == nfs3Ok {
+		status = checkName(name)
+	}
+	var f *nfsFile
+	if status == nfs3Ok {
+		f, status = s.newEntry(req, dir, name, &filer_pb.Entry{
+			IsDirectory: true,
+			Attributes:  &filer_pb.FuseAttributes{FileMode: uint32(os.ModeDir | 0755)},
+		}, attr, true)
+	}
+	s.writeCreated(res, status, f, dir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) symlink(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	name := req.args.string(maxPathLen)
+	attr := readSattr(req.args)
+	target := req.args.string(maxPathLen)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	dir, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = checkDirChange(dir)
+	}
+	if status == nfs3Ok {
+		status = checkName(name)
+	}
+	var f *nfsFile
+	if status == nfs3Ok {
+		// the mode of a symlink is not used
+		attr.mode = nil
+		f, status = s.newEntry(req, dir, name, &filer_pb.Entry{
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode:      uint32(os.ModeSymlink | 0777),
+				SymlinkTarget: target,
+			},
+		}, attr, true)
+	}
+	s.writeCreated(res, status, f, dir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) mknod(req *rpcRequest, res *xdrWriter) acceptStat {
+	res.uint32(uint32(nfs3ErrNotSupp))
+	s.writeWcc(res, nil)
+	return rpcSuccess
+}
+
+func (s *NfsServer) link(req *rpcRequest, res *xdrWriter) acceptStat {
+	res.uint32(uint32(nfs3ErrNotSupp))
+	s.writePostOpAttr(res, nil)
+	s.writeWcc(res, nil)
+	return rpcSuccess
+}
+
+func (s *NfsServer) remove(req *rpcRequest, res *xdrWriter) acceptStat {
+	return s.removeEntry(req, res, false)
+}
+
+func (s *NfsServer) rmdir(req *rpcRequest, res *xdrWriter) acceptStat {
+	return s.removeEntry(req, res, true)
+}
+
+func (s *NfsServer) removeEntry(req *rpcRequest, res *xdrWriter, isDir bool) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	name := req.args.string(maxPathLen)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	dir, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		status = checkDirChange(dir)
+	}
+	if status == nfs3Ok {
+		status = checkName(name)
+	}
+	var f *nfsFile
+	if status == nfs3Ok {
+		f, status = s.child(req, dir, name)
+	}
+	if status == nfs3Ok {
+		switch {
+		case isDir && !f.isDir():
+			status = nfs3ErrNotDir
+		case !isDir && f.isDir():
+			status = nfs3ErrIsDir
+		default:
+			s.writers.discard(f.inode)
+			status = toStatus(s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+				return filer_pb.DoRemove(context.Background(), client, string(dir.path), name, true, false, false, false, []int32{s.signature})
+			}))
+			if status == nfs3Ok {
+				if err := s.handles.remove(f.path); err != nil {
+					glog.Warningf("nfs forget handle of %s: %v", f.path, err)
+				}
+			}
+		}
+	}
+	res.uint32(uint32(status))
+	s.writeWcc(res, dir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) rename(req *rpcRequest, res *xdrWriter) acceptStat {
+	fromHandle := req.args.opaque(maxHandleSize)
+	fromName := req.args.string(maxPathLen)
+	toHandle := req.args.opaque(maxHandleSize)
+	toName := req.args.string(maxPathLen)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	fromDir, status := s.resolve(req, fromHandle)
+	var toDir *nfsFile
+	if status == nfs3Ok {
+		toDir, status = s.resolve(req, toHandle)
+	}
+	if status == nfs3Ok {
+		status = s.doRename(req, fromDir, fromName, toDir, toName)
+	}
+	res.uint32(uint32(status))
+	s.writeWcc(res, fromDir)
+	s.writeWcc(res, toDir)
+	return rpcSuccess
+}
+
+func (s *NfsServer) doRename(req *rpcRequest, fromDir *nfsFile, fromName string, toDir *nfsFile, toName string) nfsStatus {
+	for _, status := range []nfsStatus{checkDirChange(fromDir), checkDirChange(toDir), checkName(fromName), checkName(toName)} {
+		if status != nfs3Ok {
+			return status
+		}
+	}
+	if fromDir.export != toDir.export {
+		return nfs3ErrXDev
+	}
+	from, status := s.child(req, fromDir, fromName)
+	if status != nfs3Ok {
+		return status
+	}
+	toPath := toDir.path.Child(toName)
+	if from.path == toPath {
+		return nfs3Ok
+	}
+	if from.isDir() && isUnder(toPath, from.path) {
+		return nfs3ErrInval
+	}
+
+	// replace the target, as rename(2) does
+	if to, status := s.child(req, toDir, toName); status == nfs3Ok {
+		switch {
+		case from.isDir() && !to.isDir():
+			return nfs3ErrNotDir
+		case !from.isDir() && to.isDir():
+			return nfs3ErrIsDir
+		}
+		s.writers.discard(to.inode)
+		if err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			return filer_pb.DoRemove(context.Background(), client, string(toDir.path), toName, true, false, false, false, []int32{s.signature})
+		}); err != nil {
+			return toStatus(err)
+		}
+		if err := s.handles.remove(toPath); err != nil {
+			glog.Warningf("nfs forget handle of %s: %v", toPath, err)
+		}
+	} else if status != nfs3ErrNoEnt {
+		return status
+	}
+
+	if err := s.writers.flush(from.inode); err != nil {
+		return toStatus(err)
+	}
+	err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: string(fromDir.path),
+			OldName:      fromName,
+			NewDirectory: string(toDir.path),
+			NewName:      toName,
+			Signatures:   []int32{s.signature},
+		})
+		return err
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	if err = s.handles.rename(from.path, toPath); err != nil {
+		glog.Errorf("nfs move handles from %s to %s: %v", from.path, toPath, err)
+		return nfs3ErrServerFault
+	}
+	return nfs3Ok
+}
+
+func (s *NfsServer) fsstat(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	var stats *filer_pb.StatisticsResponse
+	if status == nfs3Ok {
+		err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			var err error
+			stats, err = client.Statistics(context.Background(), &filer_pb.StatisticsRequest{
+				Replication: s.option.Replication,
+				Collection:  s.option.Collection,
+				DiskType:    s.option.DiskType,
+			})
+			return err
+		})
+		status = toStatus(err)
+	}
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status == nfs3Ok {
+		free := uint64(0)
+		if stats.TotalSize > stats.UsedSize {
+			free = stats.TotalSize - stats.UsedSize
+		}
+		const totalFiles = 1 << 40
+		res.uint64(stats.TotalSize)
+		res.uint64(free)
+		res.uint64(free)
+		res.uint64(totalFiles)
+		res.uint64(totalFiles - stats.FileCount)
+		res.uint64(totalFiles - stats.FileCount)
+		res.uint32(0) // invarsec
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) fsinfo(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status == nfs3Ok {
+		res.uint32(maxReadSize)  // rtmax
+		res.uint32(maxReadSize)  // rtpref
+		res.uint32(blockSize)    // rtmult
+		res.uint32(maxWriteSize) // wtmax
+		res.uint32(maxWriteSize) // wtpref
+		res.uint32(blockSize)    // wtmult
+		res.uint32(64 * 1024)    // dtpref
+		res.uint64(1 << 62)      // maxfilesize
+		res.uint32(1)            // time_delta seconds
+		res.uint32(0)
+		// FSF3_SYMLINK | FSF3_HOMOGENEOUS | FSF3_CANSETTIME
+		res.uint32(0x0002 | 0x0008 | 0x0010)
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) pathconf(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	f, status := s.resolve(req, handle)
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, f)
+	if status == nfs3Ok {
+		res.uint32(1)          // linkmax
+		res.uint32(maxNameLen) // name_max
+		res.bool(true)         // no_trunc
+		res.bool(true)         // chown_restricted
+		res.bool(false)        // case_insensitive
+		res.bool(true)         // case_preserving
+	}
+	return rpcSuccess
+}
@@ -0,0 +1,297 @@
+package nfs
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+type nfsStatus uint32
+
+const (
+	nfs3Ok             nfsStatus = 0
+	nfs3ErrPerm        nfsStatus = 1
+	nfs3ErrNoEnt       nfsStatus = 2
+	nfs3ErrIO          nfsStatus = 5
+	nfs3ErrAcces       nfsStatus = 13
+	nfs3ErrExist       nfsStatus = 17
+	nfs3ErrXDev        nfsStatus = 18
+	nfs3ErrNotDir      nfsStatus = 20
+	nfs3ErrIsDir       nfsStatus = 21
+	nfs3ErrInval       nfsStatus = 22
+	nfs3ErrFBig        nfsStatus = 27
+	nfs3ErrRoFs        nfsStatus = 30
+	nfs3ErrNameTooLong nfsStatus = 63
+	nfs3ErrNotEmpty    nfsStatus = 66
+	nfs3ErrDQuot       nfsStatus = 69
+	nfs3ErrStale       nfsStatus = 70
+	nfs3ErrBadHandle   nfsStatus = 10001
+	nfs3ErrNotSync     nfsStatus = 10002
+	nfs3ErrBadCookie   nfsStatus = 10003
+	nfs3ErrNotSupp     nfsStatus = 10004
+	nfs3ErrTooSmall    nfsStatus = 10005
+	nfs3ErrServerFault nfsStatus = 10006
+)
+
+// ftype3
+const (
+	nf3Reg  = 1
+	nf3Dir  = 2
+	nf3Blk  = 3
+	nf3Chr  = 4
+	nf3Lnk  = 5
+	nf3Sock = 6
+	nf3Fifo = 7
+)
+
+// access bits of ACCESS3
+const (
+	access3Read    = 0x01
+	access3Lookup  = 0x02
+	access3Modify  = 0x04
+	access3Extend  = 0x08
+	access3Delete  = 0x10
+	access3Execute = 0x20
+)
+
+// permission bits checked against the mode of an entry
+const (
+	permRead    = 4
+	permWrite   = 2
+	permExecute = 1
+)
+
+const blockSize = 4096
+
+func fileType(entry *filer_pb.Entry) uint32 {
+	if entry.IsDirectory {
+		return nf3Dir
+	}
+	switch os.FileMode(entry.Attributes.FileMode) & os.ModeType {
+	case os.ModeDir:
+		return nf3Dir
+	case os.ModeSymlink:
+		return nf3Lnk
+	case os.ModeNamedPipe:
+		return nf3Fifo
+	case os.ModeSocket:
+		return nf3Sock
+	case os.ModeDevice | os.ModeCharDevice:
+		return nf3Chr
+	case os.ModeDevice:
+		return nf3Blk
+	}
+	return nf3Reg
+}
+
+// unixMode returns the permission bits, with setuid, setgid and sticky as in chmod
+func unixMode(mode uint32) uint32 {
+	m := mode & 07777
+	fileMode := os.FileMode(mode)
+	if fileMode&os.ModeSetuid != 0 {
+		m |= 04000
+	}
+	if fileMode&os.ModeSetgid != 0 {
+		m |= 02000
+	}
+	if fileMode&os.ModeSticky != 0 {
+		m |= 01000
+	}
+	return m
+}
+
+// chmod keeps the file type of the existing mode, as weed mount does
+func chmod(existing uint32, mode uint32) uint32 {
+	return existing&^(07777|uint32(os.ModeSetuid|os.ModeSetgid|os.ModeSticky)) | mode&07777
+}
+
+// entrySize returns the size of the entry, including writes still buffered by this server
+func (s *NfsServer) entrySize(inode uint64, entry *filer_pb.Entry) uint64 {
+	switch fileType(entry) {
+	case nf3Dir:
+		return blockSize
+	case nf3Lnk:
+		return uint64(len(entry.Attributes.SymlinkTarget))
+	}
+	size := filer.FileSize(entry)
+	if end := uint64(s.writers.bufferedEnd(inode)); end > size {
+		size = end
+	}
+	return size
+}
+
+// writeFattr writes fattr3
+func (s *NfsServer) writeFattr(w *xdrWriter, inode uint64, entry *filer_pb.Entry) {
+	attr := entry.Attributes
+	size := s.entrySize(inode, entry)
+	w.uint32(fileType(entry))
+	w.uint32(unixMode(attr.FileMode))
+	switch {
+	case entry.HardLinkCounter > 0:
+		w.uint32(uint32(entry.HardLinkCounter))
+	case entry.IsDirectory:
+		w.uint32(2)
+	default:
+		w.uint32(1)
+	}
+	w.uint32(attr.Uid)
+	w.uint32(attr.Gid)
+	w.uint64(size)
+	w.uint64((size + blockSize - 1) / blockSize * blockSize)
+	w.uint32(attr.Rdev >> 8 & 0xfff) // specdata3 major
+	w.uint32(attr.Rdev & 0xff)       // specdata3 minor
+	w.uint64(1)                      // fsid
+	w.uint64(inode)
+	mtime := uint32(attr.Mtime)
+	for i := 0; i < 3; i++ { // atime, mtime, ctime
+		w.uint32(mtime)
+		w.uint32(0)
+	}
+}
+
+// writePostOpAttr writes post_op_attr, without attributes if the entry is nil
+func (s *NfsServer) writePostOpAttr(w *xdrWriter, f *nfsFile) {
+	if f == nil || f.entry == nil {
+		w.bool(false)
+		return
+	}
+	w.bool(true)
+	s.writeFattr(w, f.inode, f.entry)
+}
+
+// writeWcc writes wcc_data, without the attributes before the change
+func (s *NfsServer) writeWcc(w *xdrWriter, after *nfsFile) {
+	w.bool(false)
+	s.writePostOpAttr(w, after)
+}
+
+// sattr3 is the attributes a client sets
+type sattr3 struct {
+	mode, uid, gid *uint32
+	size           *uint64
+	setMtime       bool
+	mtime          time.Time
+}
+
+const (
+	timeDontChange = 0
+	timeServerTime = 1
+	timeClientTime = 2
+)
+
+func readSattr(r *xdrReader) *sattr3 {
+	a := &sattr3{}
+	readUint32 := func() *uint32 {
+		if !r.bool() {
+			return nil
+		}
+		v := r.uint32()
+		return &v
+	}
+	a.mode, a.uid, a.gid = readUint32(), readUint32(), readUint32()
+	if r.bool() {
+		size := r.uint64()
+		a.size = &size
+	}
+	readTime := func() (time.Time, bool) {
+		switch r.uint32() {
+		case timeServerTime:
+			return time.Now(), true
+		case timeClientTime:
+			seconds, nanos := r.uint32(), r.uint32()
+			return time.Unix(int64(seconds), int64(nanos)), true
+		}
+		return time.Time{}, false
+	}
+	readTime() // the filer keeps no access time
+	a.mtime, a.setMtime = readTime()
+	return a
+}
+
+// apply sets the attributes except the size on the entry
+func (a *sattr3) apply(entry *filer_pb.Entry) {
+	if a.mode != nil {
+		entry.Attributes.FileMode = chmod(entry.Attributes.FileMode, *a.mode)
+	}
+	if a.uid != nil {
+		entry.Attributes.Uid = *a.uid
+	}
+	if a.gid != nil {
+		entry.Attributes.Gid = *a.gid
+	}
+	if a.setMtime {
+		entry.Attributes.Mtime = a.mtime.Unix()
+	}
+}
+
+// hasPermission checks the mode bits of the entry for the user
+func hasPermission(entry *filer_pb.Entry, cred rpcCredential, want uint32) bool {
+	if cred.uid == 0 {
+		return true
+	}
+	mode := entry.Attributes.FileMode
+	switch {
+	case cred.uid == entry.Attributes.Uid:
+		mode >>= 6
+	case inGroup(cred, entry.Attributes.Gid):
+		mode >>= 3
+	}
+	return mode&want == want
+}
+
+func inGroup(cred rpcCredential, gid uint32) bool {
+	if cred.gid == gid {
+		return true
+	}
+	for _, g := range cred.gids {
+		if g == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// cookieCache remembers where each READDIR reply stopped, so the next call continues
+// from the entry name. Cookies 1 and 2 are the "." and ".." entries.
+type cookieCache struct {
+	sync.Mutex
+	next    uint64
+	cookies []dirCookie
+}
+
+type dirCookie struct {
+	cookie   uint64
+	dirInode uint64
+	name     string
+}
+
+const firstCookie = 3
+
+func newCookieCache(size int) *cookieCache {
+	return &cookieCache{
+		next:    firstCookie,
+		cookies: make([]dirCookie, size),
+	}
+}
+
+func (c *cookieCache) put(dirInode uint64, name string) uint64 {
+	c.Lock()
+	defer c.Unlock()
+	cookie := c.next
+	c.next++
+	c.cookies[cookie%uint64(len(c.cookies))] = dirCookie{cookie: cookie, dirInode: dirInode, name: name}
+	return cookie
+}
+
+func (c *cookieCache) get(dirInode uint64, cookie uint64) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	dc := c.cookies[cookie%uint64(len(c.cookies))]
+	if dc.cookie != cookie || dc.dirInode != dirInode {
+		return "", false
+	}
+	return dc.name, true
+}
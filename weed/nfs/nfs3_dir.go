@@ -0,0 +1,175 @@
+package nfs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// the reply without entries, with some room to spare
+	readdirReplyOverhead = 128
+	readdirListLimit     = 1024
+)
+
+var errReaddirFull = errors.New("readdir reply is full")
+
+type dirEntry struct {
+	name   string
+	inode  uint64
+	cookie uint64
+	file   *nfsFile // only for READDIRPLUS
+}
+
+func (s *NfsServer) readdir(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	cookie := req.args.uint64()
+	req.args.fixedOpaque(8) // cookie verifier
+	count := req.args.uint32()
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	return s.doReaddir(req, res, handle, cookie, count, false)
+}
+
+func (s *NfsServer) readdirplus(req *rpcRequest, res *xdrWriter) acceptStat {
+	handle := req.args.opaque(maxHandleSize)
+	cookie := req.args.uint64()
+	req.args.fixedOpaque(8) // cookie verifier
+	req.args.uint32()       // dircount
+	maxCount := req.args.uint32()
+	if req.args.err != nil {
+		return rpcGarbageArgs
+	}
+	return s.doReaddir(req, res, handle, cookie, maxCount, true)
+}
+
+func entrySizeInReply(name string, plus bool) int {
+	size := 4 + 8 + 4 + len(name) + pad(len(name)) + 8
+	if plus {
+		size += 4 + 84 + 4 + 4 + handleSize
+	}
+	return size
+}
+
+func (s *NfsServer) doReaddir(req *rpcRequest, res *xdrWriter, handle []byte, cookie uint64, maxBytes uint32, plus bool) acceptStat {
+	dir, status := s.resolve(req, handle)
+	if status == nfs3Ok {
+		switch {
+		case !dir.isDir():
+			status = nfs3ErrNotDir
+		case !hasPermission(dir.entry, dir.cred, permRead):
+			status = nfs3ErrAcces
+		}
+	}
+	var entries []*dirEntry
+	var eof bool
+	if status == nfs3Ok {
+		entries, eof, status = s.listDir(req, dir, cookie, int(maxBytes), plus)
+	}
+
+	res.uint32(uint32(status))
+	s.writePostOpAttr(res, dir)
+	if status != nfs3Ok {
+		return rpcSuccess
+	}
+	res.fixedOpaque(make([]byte, 8)) // cookie verifier
+	for _, e := range entries {
+		res.bool(true)
+		res.uint64(e.inode)
+		res.string(e.name)
+		res.uint64(e.cookie)
+		if plus {
+			s.writePostOpAttr(res, e.file)
+			if e.file != nil {
+				res.bool(true)
+				res.opaque(toHandle(e.file.inode))
+			} else {
+				res.bool(false)
+			}
+		}
+	}
+	res.bool(false)
+	res.bool(eof)
+	return rpcSuccess
+}
+
+// listDir lists the directory after the cookie, as many entries as fit in maxBytes
+func (s *NfsServer) listDir(req *rpcRequest, dir *nfsFile, cookie uint64, maxBytes int, plus bool) (entries []*dirEntry, eof bool, status nfsStatus) {
+	budget := maxBytes - readdirReplyOverhead
+	add := func(e *dirEntry) bool {
+		size := entrySizeInReply(e.name, plus)
+		if size > budget {
+			return false
+		}
+		budget -= size
+		entries = append(entries, e)
+		return true
+	}
+
+	startFrom := ""
+	switch {
+	case cookie == 0:
+		if !add(&dirEntry{name: ".", inode: dir.inode, cookie: 1}) {
+			return nil, false, nfs3ErrTooSmall
+		}
+		fallthrough
+	case cookie == 1:
+		parentInode := dir.inode
+		if dir.path != dir.export.Path {
+			if parent, status := s.parent(req, dir); status == nfs3Ok {
+				parentInode = parent.inode
+			}
+		}
+		if !add(&dirEntry{name: "..", inode: parentInode, cookie: 2}) {
+			return entries, false, nfs3Ok
+		}
+	case cookie == 2:
+	default:
+		var found bool
+		if startFrom, found = s.cookies.get(dir.inode, cookie); !found {
+			return nil, false, nfs3ErrBadCookie
+		}
+	}
+
+	limit := budget / entrySizeInReply("", plus)
+	if limit > readdirListLimit {
+		limit = readdirListLimit
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	listed, sawLast, full := 0, false, false
+	err := filer_pb.List(context.Background(), s, string(dir.path), "", func(entry *filer_pb.Entry, isLast bool) error {
+		listed++
+		sawLast = isLast
+		p := dir.path.Child(entry.Name)
+		if entry.Attributes == nil {
+			entry.Attributes = &filer_pb.FuseAttributes{}
+		}
+		inode, err := s.handles.inode(p, entry)
+		if err != nil {
+			return err
+		}
+		e := &dirEntry{name: entry.Name, inode: inode}
+		if plus {
+			e.file = &nfsFile{inode: inode, path: p, entry: entry}
+		}
+		if !add(e) {
+			full = true
+			return errReaddirFull
+		}
+		e.cookie = s.cookies.put(dir.inode, entry.Name)
+		return nil
+	}, startFrom, false, uint32(limit))
+	if err != nil && err != errReaddirFull {
+		glog.Errorf("nfs list %s: %v", dir.path, err)
+		return nil, false, toStatus(err)
+	}
+	if len(entries) == 0 && full {
+		return nil, false, nfs3ErrTooSmall
+	}
+	return entries, !full && (listed == 0 || sawLast), nfs3Ok
+}
@@ -0,0 +1,169 @@
+package nfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Export is a filer directory shared over NFS, with the clients allowed to mount it.
+// Exports are listed one per line, in the format of /etc/exports:
+//
+//	/buckets/data 10.0.0.0/8(rw) 192.168.1.5(ro,no_root_squash) *(ro)
+//
+// A client is an IP address, a CIDR, or * for every client. Without options,
+// a client gets read-only access with root squashed to nobody.
+type Export struct {
+	Path  util.FullPath
+	Rules []*ExportRule
+}
+
+type ExportRule struct {
+	Network    *net.IPNet // nil for every client
+	ReadOnly   bool
+	RootSquash bool
+}
+
+const nobody = 65534
+
+func (r *ExportRule) matches(ip net.IP) bool {
+	return r.Network == nil || ip != nil && r.Network.Contains(ip)
+}
+
+// squash maps the root user of the client to nobody
+func (r *ExportRule) squash(cred rpcCredential) rpcCredential {
+	if !r.RootSquash {
+		return cred
+	}
+	if cred.uid == 0 {
+		cred.uid = nobody
+	}
+	if cred.gid == 0 {
+		cred.gid = nobody
+	}
+	return cred
+}
+
+// ParseExports reads exports in the /etc/exports format
+func ParseExports(reader io.Reader) ([]*Export, error) {
+	var exports []*Export
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(fields[0], "/") {
+			return nil, fmt.Errorf("line %d: export path %s is not absolute", lineNumber, fields[0])
+		}
+		export := &Export{Path: util.FullPath(strings.TrimSuffix(fields[0], "/"))}
+		if export.Path == "" {
+			export.Path = "/"
+		}
+		clients := fields[1:]
+		if len(clients) == 0 {
+			clients = []string{"*"}
+		}
+		for _, client := range clients {
+			rule, err := parseExportRule(client)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			export.Rules = append(export.Rules, rule)
+		}
+		exports = append(exports, export)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func parseExportRule(client string) (*ExportRule, error) {
+	rule := &ExportRule{ReadOnly: true, RootSquash: true}
+	var options string
+	if i := strings.Index(client, "("); i >= 0 {
+		if !strings.HasSuffix(client, ")") {
+			return nil, fmt.Errorf("unterminated options in %s", client)
+		}
+		client, options = client[:i], client[i+1:len(client)-1]
+	}
+	switch {
+	case client == "*" || client == "":
+	case strings.Contains(client, "/"):
+		_, network, err := net.ParseCIDR(client)
+		if err != nil {
+			return nil, fmt.Errorf("client %s: %v", client, err)
+		}
+		rule.Network = network
+	default:
+		ip := net.ParseIP(client)
+		if ip == nil {
+			return nil, fmt.Errorf("client %s is not an ip address or cidr", client)
+		}
+		bits := 8 * net.IPv4len
+		if ip.To4() == nil {
+			bits = 8 * net.IPv6len
+		}
+		rule.Network = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+	}
+	for _, option := range strings.Split(options, ",") {
+		switch option {
+		case "":
+		case "rw":
+			rule.ReadOnly = false
+		case "ro":
+			rule.ReadOnly = true
+		case "root_squash":
+			rule.RootSquash = true
+		case "no_root_squash":
+			rule.RootSquash = false
+		default:
+			return nil, fmt.Errorf("unknown export option %s", option)
+		}
+	}
+	return rule, nil
+}
+
+// exportTable finds the export of a path, preferring the innermost export
+type exportTable struct {
+	exports []*Export
+}
+
+func newExportTable(exports []*Export) *exportTable {
+	sorted := append([]*Export(nil), exports...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Path) > len(sorted[j].Path)
+	})
+	return &exportTable{exports: sorted}
+}
+
+// lookup returns the export containing the path that the client may access
+func (t *exportTable) lookup(p util.FullPath, ip net.IP) (*Export, *ExportRule) {
+	for _, export := range t.exports {
+		if !isUnder(p, export.Path) {
+			continue
+		}
+		for _, rule := range export.Rules {
+			if rule.matches(ip) {
+				return export, rule
+			}
+		}
+	}
+	return nil, nil
+}
+
+func isUnder(p, dir util.FullPath) bool {
+	return dir == "/" || p == dir || strings.HasPrefix(string(p), string(dir)+"/")
+}
@@ -0,0 +1,102 @@
+package nfs
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestParseExports(t *testing.T) {
+	exports, err := ParseExports(strings.NewReader(`
+# shared data
+/buckets/data/  10.0.0.0/8(rw) 192.168.1.5(ro,no_root_squash)
+/buckets/data/private 10.1.0.0/16(rw,no_root_squash)
+/archive
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(exports) != 3 || exports[0].Path != "/buckets/data" || len(exports[0].Rules) != 2 {
+		t.Fatalf("unexpected exports %+v", exports)
+	}
+	if rule := exports[2].Rules[0]; rule.Network != nil || !rule.ReadOnly || !rule.RootSquash {
+		t.Errorf("a client without options should be read only with root squash: %+v", rule)
+	}
+
+	table := newExportTable(exports)
+	export, rule := table.lookup("/buckets/data/private/a.txt", net.ParseIP("10.1.2.3"))
+	if export == nil || export.Path != "/buckets/data/private" || rule.RootSquash {
+		t.Errorf("expected the inner export, got %+v %+v", export, rule)
+	}
+	export, rule = table.lookup("/buckets/data/private/a.txt", net.ParseIP("10.2.0.1"))
+	if export == nil || export.Path != "/buckets/data" || rule.ReadOnly {
+		t.Errorf("expected the outer export, got %+v %+v", export, rule)
+	}
+	if export, _ = table.lookup("/buckets/data", net.ParseIP("192.168.1.6")); export != nil {
+		t.Errorf("client should not match any export: %+v", export)
+	}
+	if export, _ = table.lookup("/buckets/database", net.ParseIP("10.0.0.1")); export != nil {
+		t.Errorf("a sibling path should not be exported: %+v", export)
+	}
+
+	squashed := (&ExportRule{RootSquash: true}).squash(rpcCredential{uid: 0, gid: 0})
+	if squashed.uid != nobody || squashed.gid != nobody {
+		t.Errorf("root should be squashed to nobody: %+v", squashed)
+	}
+
+	for _, bad := range []string{"relative *(rw)", "/a 10.0.0.0/33(rw)", "/a *(rw,fast)", "/a host(rw"} {
+		if _, err := ParseExports(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestHandleTable(t *testing.T) {
+	table, err := newHandleTable("")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer table.close()
+
+	entry := func(inode uint64) *filer_pb.Entry {
+		return &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Crtime: 100, Inode: inode}}
+	}
+	dirInode, _ := table.inode("/a", entry(0))
+	fileInode, _ := table.inode("/a/b/c.txt", entry(0))
+	if again, _ := table.inode("/a", entry(0)); again != dirInode {
+		t.Errorf("inode of /a changed from %d to %d", dirInode, again)
+	}
+	if inode, _ := table.inode("/x", entry(42)); inode != 42 {
+		t.Errorf("expected the inode in the attributes, got %d", inode)
+	}
+
+	if err = table.rename("/a", "/z"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	for inode, expected := range map[uint64]util.FullPath{dirInode: "/z", fileInode: "/z/b/c.txt", 42: "/x", rootInode: "/"} {
+		if p, found := table.path(inode); !found || p != expected {
+			t.Errorf("inode %d: got %s %v, want %s", inode, p, found, expected)
+		}
+	}
+	if again, _ := table.inode("/z/b/c.txt", entry(0)); again != fileInode {
+		t.Errorf("a renamed file should keep its inode %d, got %d", fileInode, again)
+	}
+
+	if err = table.remove("/z/b/c.txt"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, found := table.path(fileInode); found {
+		t.Errorf("removed inode %d should be stale", fileInode)
+	}
+
+	handle := toHandle(fileInode)
+	if inode, ok := fromHandle(handle); !ok || inode != fileInode {
+		t.Errorf("handle round trip: %d %v", inode, ok)
+	}
+	if _, ok := fromHandle([]byte{1, 2, 3}); ok {
+		t.Errorf("a short handle should be rejected")
+	}
+}
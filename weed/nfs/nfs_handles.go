@@ -0,0 +1,187 @@
+package nfs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldb_errors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// A file handle is the inode of the entry. NFS clients keep file handles across
+// server restarts, so the inodes handed out are kept on disk with their paths:
+//
+//	i<inode>        the full path
+//	p<full path>    the inode
+//
+// Entries created over NFS record their inode in the entry attributes. Other entries
+// get an inode derived from the path and creation time, as in weed mount.
+const (
+	handleSize  = 8
+	rootInode   = 1
+	inodePrefix = 'i'
+	pathPrefix  = 'p'
+)
+
+type handleTable struct {
+	db *leveldb.DB
+}
+
+// newHandleTable keeps the handles in dir, or only in memory if dir is empty
+func newHandleTable(dir string) (*handleTable, error) {
+	if dir == "" {
+		db, err := leveldb.Open(storage.NewMemStorage(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &handleTable{db: db}, nil
+	}
+	glog.V(0).Infof("nfs file handles dir: %s", dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create file handles dir %s: %v", dir, err)
+	}
+	opts := &opt.Options{
+		BlockCacheCapacity: 8 * 1024 * 1024,
+	}
+	db, err := leveldb.OpenFile(dir, opts)
+	if err != nil && leveldb_errors.IsCorrupted(err) {
+		db, err = leveldb.RecoverFile(dir, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open file handles %s: %v", dir, err)
+	}
+	return &handleTable{db: db}, nil
+}
+
+func (t *handleTable) close() error {
+	return t.db.Close()
+}
+
+func inodeKey(inode uint64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = inodePrefix
+	binary.BigEndian.PutUint64(key[1:], inode)
+	return key
+}
+
+func pathKey(p util.FullPath) []byte {
+	return append([]byte{pathPrefix}, p...)
+}
+
+func toHandle(inode uint64) []byte {
+	handle := make([]byte, handleSize)
+	binary.BigEndian.PutUint64(handle, inode)
+	return handle
+}
+
+func fromHandle(handle []byte) (uint64, bool) {
+	if len(handle) != handleSize {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(handle), true
+}
+
+// path returns the path of an inode handed out before
+func (t *handleTable) path(inode uint64) (util.FullPath, bool) {
+	if inode == rootInode {
+		return "/", true
+	}
+	value, err := t.db.Get(inodeKey(inode), nil)
+	if err != nil {
+		return "", false
+	}
+	return util.FullPath(value), true
+}
+
+// inode returns the inode of the entry at the path, and remembers it
+func (t *handleTable) inode(p util.FullPath, entry *filer_pb.Entry) (uint64, error) {
+	if p == "/" {
+		return rootInode, nil
+	}
+	if entry != nil && entry.Attributes != nil && entry.Attributes.Inode != 0 {
+		inode := entry.Attributes.Inode
+		if known, found := t.path(inode); found && known == p {
+			return inode, nil
+		}
+		return inode, t.put(inode, p)
+	}
+	if value, err := t.db.Get(pathKey(p), nil); err == nil && len(value) == 8 {
+		return binary.BigEndian.Uint64(value), nil
+	}
+	var crtime int64
+	if entry != nil && entry.Attributes != nil {
+		crtime = entry.Attributes.Crtime
+	}
+	inode := p.AsInode(crtime)
+	for {
+		if inode > rootInode {
+			if _, found := t.path(inode); !found {
+				break
+			}
+		}
+		inode++
+	}
+	return inode, t.put(inode, p)
+}
+
+func (t *handleTable) put(inode uint64, p util.FullPath) error {
+	var value [8]byte
+	binary.BigEndian.PutUint64(value[:], inode)
+	batch := new(leveldb.Batch)
+	if old, err := t.db.Get(pathKey(p), nil); err == nil && len(old) == 8 {
+		if oldInode := binary.BigEndian.Uint64(old); oldInode != inode {
+			batch.Delete(inodeKey(oldInode))
+		}
+	}
+	batch.Put(inodeKey(inode), []byte(p))
+	batch.Put(pathKey(p), value[:])
+	return t.db.Write(batch, nil)
+}
+
+// rename moves the handles of the path and everything under it
+func (t *handleTable) rename(oldPath, newPath util.FullPath) error {
+	batch := new(leveldb.Batch)
+	move := func(key, value []byte) {
+		p := util.FullPath(key[1:])
+		moved := newPath + p[len(oldPath):]
+		batch.Delete(key)
+		batch.Put(pathKey(moved), value)
+		if len(value) == 8 {
+			batch.Put(inodeKey(binary.BigEndian.Uint64(value)), []byte(moved))
+		}
+	}
+	if value, err := t.db.Get(pathKey(oldPath), nil); err == nil {
+		move(pathKey(oldPath), value)
+	}
+	iter := t.db.NewIterator(leveldb_util.BytesPrefix(pathKey(oldPath+"/")), nil)
+	for iter.Next() {
+		move(append([]byte(nil), iter.Key()...), append([]byte(nil), iter.Value()...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return t.db.Write(batch, nil)
+}
+
+// remove forgets the handle of a deleted entry
+func (t *handleTable) remove(p util.FullPath) error {
+	value, err := t.db.Get(pathKey(p), nil)
+	if err != nil {
+		return nil
+	}
+	batch := new(leveldb.Batch)
+	batch.Delete(pathKey(p))
+	if len(value) == 8 {
+		batch.Delete(inodeKey(binary.BigEndian.Uint64(value)))
+	}
+	return t.db.Write(batch, nil)
+}
@@ -0,0 +1,100 @@
+package nfs
+
+import (
+	"path"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// The MOUNT protocol version 3, RFC 1813 appendix I, hands out the root file handle of an export.
+const (
+	mountProgram = 100005
+
+	mountProcNull    = 0
+	mountProcMnt     = 1
+	mountProcDump    = 2
+	mountProcUmnt    = 3
+	mountProcUmntAll = 4
+	mountProcExport  = 5
+
+	mnt3Ok       = 0
+	mnt3ErrNoEnt = 2
+	mnt3ErrAcces = 13
+	mnt3ErrNoDir = 20
+	mnt3ErrIO    = 5
+
+	mountPathLen = 1024
+)
+
+func (s *NfsServer) handleMount(req *rpcRequest, res *xdrWriter) acceptStat {
+	switch req.proc {
+	case mountProcNull:
+	case mountProcMnt:
+		dirPath := req.args.string(mountPathLen)
+		if req.args.err != nil {
+			return rpcGarbageArgs
+		}
+		s.mount(req, dirPath, res)
+	case mountProcDump:
+		// mounts are not tracked
+		res.bool(false)
+	case mountProcUmnt:
+		req.args.string(mountPathLen)
+	case mountProcUmntAll:
+	case mountProcExport:
+		for _, export := range s.option.Exports {
+			res.bool(true)
+			res.string(string(export.Path))
+			for _, rule := range export.Rules {
+				res.bool(true)
+				if rule.Network == nil {
+					res.string("*")
+				} else {
+					res.string(rule.Network.String())
+				}
+			}
+			res.bool(false)
+		}
+		res.bool(false)
+	default:
+		return rpcProcUnavail
+	}
+	return rpcSuccess
+}
+
+func (s *NfsServer) mount(req *rpcRequest, dirPath string, res *xdrWriter) {
+	p := util.FullPath(path.Clean("/" + dirPath))
+	export, _ := s.exports.lookup(p, req.clientIP)
+	if export == nil {
+		glog.V(0).Infof("nfs client %s may not mount %s", req.clientIP, p)
+		res.uint32(mnt3ErrAcces)
+		return
+	}
+	entry, err := s.lookupEntry(p)
+	if err != nil {
+		if toStatus(err) == nfs3ErrNoEnt {
+			res.uint32(mnt3ErrNoEnt)
+		} else {
+			res.uint32(mnt3ErrIO)
+		}
+		return
+	}
+	if !entry.IsDirectory {
+		res.uint32(mnt3ErrNoDir)
+		return
+	}
+	inode, err := s.handles.inode(p, entry)
+	if err != nil {
+		glog.Errorf("nfs mount %s: %v", p, err)
+		res.uint32(mnt3ErrIO)
+		return
+	}
+	glog.V(0).Infof("nfs client %s mounts %s", req.clientIP, p)
+	res.uint32(mnt3Ok)
+	res.opaque(toHandle(inode))
+	// auth flavors
+	res.uint32(2)
+	res.uint32(authUnix)
+	res.uint32(authNone)
+}
@@ -0,0 +1,163 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+type NfsServerOption struct {
+	Filer          pb.ServerAddress
+	GrpcDialOption grpc.DialOption
+	Exports        []*Export
+	HandleDir      string
+	Collection     string
+	Replication    string
+	DiskType       string
+	DataCenter     string
+	TtlSec         int32
+	// unstable writes to a file are buffered up to this size before they are uploaded as one chunk
+	ChunkSizeLimit int64
+}
+
+// NfsServer is an NFSv3 server, RFC 1813, that keeps files in the filer
+type NfsServer struct {
+	option    *NfsServerOption
+	signature int32
+	exports   *exportTable
+	handles   *handleTable
+	writers   *fileWriters
+	cookies   *cookieCache
+	// the write verifier changes on restart, so clients resend writes that were not committed
+	writeVerifier [8]byte
+}
+
+func NewNfsServer(option *NfsServerOption) (*NfsServer, error) {
+	handles, err := newHandleTable(option.HandleDir)
+	if err != nil {
+		return nil, err
+	}
+	s := &NfsServer{
+		option:    option,
+		signature: util.RandomInt32(),
+		exports:   newExportTable(option.Exports),
+		handles:   handles,
+		cookies:   newCookieCache(64 * 1024),
+	}
+	s.writers = newFileWriters(s)
+	binary.BigEndian.PutUint64(s.writeVerifier[:], uint64(time.Now().UnixNano()))
+	for _, export := range option.Exports {
+		glog.V(0).Infof("nfs export %s", export.Path)
+	}
+	return s, nil
+}
+
+// Serve answers the MOUNT and NFS programs on the listener, so clients mount with
+// -o vers=3,tcp,port=<port>,mountport=<port>,nolock
+func (s *NfsServer) Serve(listener net.Listener) error {
+	return newRpcServer(
+		&rpcProgram{prog: mountProgram, lowVers: 3, hiVers: 3, handler: s.handleMount},
+		&rpcProgram{prog: nfsProgram, lowVers: 3, hiVers: 3, handler: s.handleNfs},
+	).serve(listener)
+}
+
+// Shutdown uploads the buffered writes and closes the handle table
+func (s *NfsServer) Shutdown() {
+	s.writers.flushAll()
+	if err := s.handles.close(); err != nil {
+		glog.Warningf("close nfs file handles: %v", err)
+	}
+}
+
+func (s *NfsServer) AdjustedUrl(location *filer_pb.Location) string { return location.Url }
+func (s *NfsServer) GetDataCenter() string                          { return s.option.DataCenter }
+func (s *NfsServer) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithGrpcFilerClient(streamingMode, s.signature, s.option.Filer, s.option.GrpcDialOption, fn)
+}
+
+var _ = filer_pb.FilerClient(&NfsServer{})
+
+// lookupEntry reads the entry at the path, or returns filer_pb.ErrNotFound
+func (s *NfsServer) lookupEntry(p util.FullPath) (*filer_pb.Entry, error) {
+	if p == "/" {
+		return &filer_pb.Entry{
+			Name:        "/",
+			IsDirectory: true,
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(os.ModeDir | 0777),
+			},
+		}, nil
+	}
+	dir, name := p.DirAndName()
+	var entry *filer_pb.Entry
+	err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			return err
+		}
+		entry = resp.Entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	return entry, nil
+}
+
+func (s *NfsServer) createEntry(dir util.FullPath, entry *filer_pb.Entry, exclusive bool) error {
+	return s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory:  string(dir),
+			Entry:      entry,
+			OExcl:      exclusive,
+			Signatures: []int32{s.signature},
+		})
+	})
+}
+
+func (s *NfsServer) updateEntry(dir util.FullPath, entry *filer_pb.Entry) error {
+	return s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(context.Background(), client, &filer_pb.UpdateEntryRequest{
+			Directory:  string(dir),
+			Entry:      entry,
+			Signatures: []int32{s.signature},
+		})
+	})
+}
+
+// toStatus maps a filer error to an NFS status
+func toStatus(err error) nfsStatus {
+	switch {
+	case err == nil:
+		return nfs3Ok
+	case err == filer_pb.ErrNotFound || strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()):
+		return nfs3ErrNoEnt
+	case strings.Contains(err.Error(), "EEXIST") || strings.Contains(err.Error(), "already exists"):
+		return nfs3ErrExist
+	case strings.Contains(err.Error(), filer.MsgFailDelNonEmptyFolder):
+		return nfs3ErrNotEmpty
+	case filer.IsQuotaExceeded(err):
+		return nfs3ErrDQuot
+	case strings.Contains(err.Error(), "is a file") || strings.Contains(err.Error(), "not a directory"):
+		return nfs3ErrNotDir
+	}
+	glog.V(1).Infof("nfs filer error: %v", err)
+	return nfs3ErrIO
+}
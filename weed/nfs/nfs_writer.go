@@ -0,0 +1,254 @@
+package nfs
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Unstable writes are kept in memory until the client commits them, the buffer of the
+// file is full, or the file is idle. The write verifier tells clients to resend
+// uncommitted writes if the server restarts.
+const writerIdleFlush = 10 * time.Second
+
+type dirtyPage struct {
+	offset int64
+	data   []byte
+}
+
+type fileWriter struct {
+	sync.Mutex
+	inode     uint64
+	pages     []*dirtyPage
+	size      int64
+	end       int64
+	lastWrite time.Time
+}
+
+type fileWriters struct {
+	s *NfsServer
+	sync.Mutex
+	writers map[uint64]*fileWriter // by inode
+}
+
+func newFileWriters(s *NfsServer) *fileWriters {
+	w := &fileWriters{
+		s:       s,
+		writers: make(map[uint64]*fileWriter),
+	}
+	go w.loopFlushIdle()
+	return w
+}
+
+func (w *fileWriters) get(inode uint64, create bool) *fileWriter {
+	w.Lock()
+	defer w.Unlock()
+	fw, found := w.writers[inode]
+	if !found && create {
+		fw = &fileWriter{inode: inode}
+		w.writers[inode] = fw
+	}
+	return fw
+}
+
+// lockWriter locks the writer of the file, which stays in use until it is unlocked
+func (w *fileWriters) lockWriter(inode uint64) *fileWriter {
+	for {
+		fw := w.get(inode, true)
+		fw.Lock()
+		w.Lock()
+		current := w.writers[inode] == fw
+		w.Unlock()
+		if current {
+			return fw
+		}
+		// the writer was flushed and removed while waiting for it
+		fw.Unlock()
+	}
+}
+
+// write buffers the data, and uploads the buffer if it is full or the write must be stable
+func (w *fileWriters) write(inode uint64, offset int64, data []byte, stable bool) error {
+	fw := w.lockWriter(inode)
+	defer fw.Unlock()
+
+	if n := len(fw.pages); n > 0 && fw.pages[n-1].offset+int64(len(fw.pages[n-1].data)) == offset {
+		fw.pages[n-1].data = append(fw.pages[n-1].data, data...)
+	} else {
+		fw.pages = append(fw.pages, &dirtyPage{offset: offset, data: append([]byte(nil), data...)})
+	}
+	fw.size += int64(len(data))
+	if end := offset + int64(len(data)); end > fw.end {
+		fw.end = end
+	}
+	fw.lastWrite = time.Now()
+
+	if stable || fw.size >= w.s.option.ChunkSizeLimit {
+		return w.flushLocked(fw)
+	}
+	return nil
+}
+
+// bufferedEnd returns the end of the buffered writes of the file, or 0
+func (w *fileWriters) bufferedEnd(inode uint64) int64 {
+	fw := w.get(inode, false)
+	if fw == nil {
+		return 0
+	}
+	fw.Lock()
+	defer fw.Unlock()
+	return fw.end
+}
+
+func (w *fileWriters) flush(inode uint64) error {
+	fw := w.get(inode, false)
+	if fw == nil {
+		return nil
+	}
+	fw.Lock()
+	defer fw.Unlock()
+	return w.flushLocked(fw)
+}
+
+// discard drops the buffered writes of a deleted file
+func (w *fileWriters) discard(inode uint64) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.writers, inode)
+}
+
+func (w *fileWriters) flushAll() {
+	w.Lock()
+	inodes := make([]uint64, 0, len(w.writers))
+	for inode := range w.writers {
+		inodes = append(inodes, inode)
+	}
+	w.Unlock()
+	for _, inode := range inodes {
+		if err := w.flush(inode); err != nil {
+			glog.Errorf("nfs flush inode %d: %v", inode, err)
+		}
+	}
+}
+
+func (w *fileWriters) loopFlushIdle() {
+	for {
+		time.Sleep(writerIdleFlush / 2)
+		w.Lock()
+		var idle []uint64
+		for inode, fw := range w.writers {
+			if fw.TryLock() {
+				if time.Since(fw.lastWrite) > writerIdleFlush {
+					idle = append(idle, inode)
+				}
+				fw.Unlock()
+			}
+		}
+		w.Unlock()
+		for _, inode := range idle {
+			if err := w.flush(inode); err != nil {
+				glog.Errorf("nfs flush idle inode %d: %v", inode, err)
+			}
+		}
+	}
+}
+
+// flushLocked uploads the buffered pages as chunks, and adds them to the entry
+func (w *fileWriters) flushLocked(fw *fileWriter) error {
+	if len(fw.pages) == 0 {
+		w.removeIfIdle(fw)
+		return nil
+	}
+	fullPath, found := w.s.handles.path(fw.inode)
+	if !found {
+		return fmt.Errorf("inode %d is not known", fw.inode)
+	}
+	entry, err := w.s.lookupEntry(fullPath)
+	if err != nil {
+		return err
+	}
+
+	var chunks []*filer_pb.FileChunk
+	tsNs := time.Now().UnixNano()
+	if len(entry.Content) > 0 {
+		// move the inline content to a chunk, so the new chunks can overlay it
+		chunk, err := w.s.saveDataAsChunk(fullPath, entry.Content, 0, tsNs)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+		entry.Content = nil
+	}
+	for _, page := range fw.pages {
+		tsNs++
+		chunk, err := w.s.saveDataAsChunk(fullPath, page.data, page.offset, tsNs)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	entry.Chunks = append(entry.GetChunks(), chunks...)
+	if fileSize := uint64(fw.end); fileSize > entry.Attributes.FileSize {
+		entry.Attributes.FileSize = fileSize
+	}
+	if fileSize := filer.FileSize(entry); fileSize > entry.Attributes.FileSize {
+		entry.Attributes.FileSize = fileSize
+	}
+	entry.Attributes.Mtime = time.Now().Unix()
+	dir, _ := fullPath.DirAndName()
+	if err = w.s.updateEntry(util.FullPath(dir), entry); err != nil {
+		return err
+	}
+	fw.pages, fw.size, fw.end = nil, 0, 0
+	w.removeIfIdle(fw)
+	return nil
+}
+
+func (w *fileWriters) removeIfIdle(fw *fileWriter) {
+	w.Lock()
+	defer w.Unlock()
+	if len(fw.pages) == 0 && w.writers[fw.inode] == fw {
+		delete(w.writers, fw.inode)
+	}
+}
+
+func (s *NfsServer) saveDataAsChunk(fullPath util.FullPath, data []byte, offset int64, tsNs int64) (*filer_pb.FileChunk, error) {
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return nil, err
+	}
+	fileId, uploadResult, err, _ := uploader.UploadWithRetry(
+		s,
+		&filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: s.option.Replication,
+			Collection:  s.option.Collection,
+			TtlSec:      s.option.TtlSec,
+			DiskType:    s.option.DiskType,
+			DataCenter:  s.option.DataCenter,
+			Path:        string(fullPath),
+		},
+		&operation.UploadOption{
+			Filename: fullPath.Name(),
+		},
+		func(host, fileId string) string {
+			return fmt.Sprintf("http://%s/%s", host, fileId)
+		},
+		bytes.NewReader(data),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upload data: %w", err)
+	}
+	if uploadResult.Error != "" {
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+	return uploadResult.ToPbFileChunk(fileId, offset, tsNs), nil
+}
@@ -0,0 +1,234 @@
+package nfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// ONC RPC version 2 over TCP, RFC 5531.
+
+const (
+	rpcCall  = 0
+	rpcReply = 1
+
+	rpcMsgAccepted = 0
+	rpcMsgDenied   = 1
+	rpcMismatch    = 0
+
+	authNone = 0
+	authUnix = 1
+
+	maxRecordSize          = 4 * 1024 * 1024
+	maxConcurrentCallsConn = 16
+)
+
+type acceptStat uint32
+
+const (
+	rpcSuccess      acceptStat = 0
+	rpcProgUnavail  acceptStat = 1
+	rpcProgMismatch acceptStat = 2
+	rpcProcUnavail  acceptStat = 3
+	rpcGarbageArgs  acceptStat = 4
+	rpcSystemErr    acceptStat = 5
+)
+
+// rpcCredential is the AUTH_UNIX credential of a call, or uid and gid nobody without one
+type rpcCredential struct {
+	uid  uint32
+	gid  uint32
+	gids []uint32
+}
+
+type rpcRequest struct {
+	xid      uint32
+	prog     uint32
+	vers     uint32
+	proc     uint32
+	cred     rpcCredential
+	args     *xdrReader
+	clientIP net.IP
+}
+
+// rpcProgram answers the calls of one RPC program. The handler writes the
+// result of a successful call, and returns another status on failure.
+type rpcProgram struct {
+	prog    uint32
+	lowVers uint32
+	hiVers  uint32
+	handler func(req *rpcRequest, res *xdrWriter) acceptStat
+}
+
+type rpcServer struct {
+	programs map[uint32]*rpcProgram
+}
+
+func newRpcServer(programs ...*rpcProgram) *rpcServer {
+	s := &rpcServer{programs: make(map[uint32]*rpcProgram)}
+	for _, p := range programs {
+		s.programs[p.prog] = p
+	}
+	return s
+}
+
+func (s *rpcServer) serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *rpcServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	var clientIP net.IP
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		clientIP = addr.IP
+	}
+	reader := bufio.NewReader(conn)
+
+	var writeLock sync.Mutex
+	writeReply := func(reply []byte) {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		var header [4]byte
+		binary.BigEndian.PutUint32(header[:], uint32(len(reply))|0x80000000)
+		if _, err := conn.Write(append(header[:], reply...)); err != nil {
+			glog.V(1).Infof("nfs reply to %s: %v", conn.RemoteAddr(), err)
+		}
+	}
+
+	// clients send many calls without waiting for the replies
+	limiter := make(chan struct{}, maxConcurrentCallsConn)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		record, err := readRecord(reader)
+		if err != nil {
+			if err != io.EOF {
+				glog.V(1).Infof("nfs read from %s: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			if reply := s.handleRecord(record, clientIP); reply != nil {
+				writeReply(reply)
+			}
+		}()
+	}
+}
+
+// readRecord joins the fragments of one record
+func readRecord(reader io.Reader) ([]byte, error) {
+	var record []byte
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return nil, err
+		}
+		h := binary.BigEndian.Uint32(header[:])
+		size := int(h & 0x7fffffff)
+		if len(record)+size > maxRecordSize {
+			return nil, fmt.Errorf("record larger than %d bytes", maxRecordSize)
+		}
+		fragment := make([]byte, size)
+		if _, err := io.ReadFull(reader, fragment); err != nil {
+			return nil, err
+		}
+		record = append(record, fragment...)
+		if h&0x80000000 != 0 {
+			return record, nil
+		}
+	}
+}
+
+func (s *rpcServer) handleRecord(record []byte, clientIP net.IP) []byte {
+	r := newXdrReader(record)
+	req := &rpcRequest{
+		xid:      r.uint32(),
+		clientIP: clientIP,
+		cred:     rpcCredential{uid: nobody, gid: nobody},
+	}
+	msgType := r.uint32()
+	rpcVersion := r.uint32()
+	req.prog, req.vers, req.proc = r.uint32(), r.uint32(), r.uint32()
+	credFlavor := r.uint32()
+	credBody := r.opaque(400)
+	r.uint32() // verifier flavor
+	r.opaque(400)
+	if r.err != nil || msgType != rpcCall {
+		return nil
+	}
+
+	res := &xdrWriter{}
+	res.uint32(req.xid)
+	res.uint32(rpcReply)
+	if rpcVersion != 2 {
+		res.uint32(rpcMsgDenied)
+		res.uint32(rpcMismatch)
+		res.uint32(2)
+		res.uint32(2)
+		return res.Bytes()
+	}
+	if credFlavor == authUnix {
+		req.cred = parseAuthUnix(credBody)
+	}
+	req.args = r
+
+	res.uint32(rpcMsgAccepted)
+	res.uint32(authNone)
+	res.uint32(0)
+
+	program, found := s.programs[req.prog]
+	if !found {
+		res.uint32(uint32(rpcProgUnavail))
+		return res.Bytes()
+	}
+	if req.vers < program.lowVers || req.vers > program.hiVers {
+		res.uint32(uint32(rpcProgMismatch))
+		res.uint32(program.lowVers)
+		res.uint32(program.hiVers)
+		return res.Bytes()
+	}
+
+	result := &xdrWriter{}
+	status := program.handler(req, result)
+	if status == rpcSuccess && req.args.err != nil {
+		status = rpcGarbageArgs
+	}
+	res.uint32(uint32(status))
+	if status == rpcSuccess {
+		res.Write(result.Bytes())
+	}
+	return res.Bytes()
+}
+
+func parseAuthUnix(body []byte) rpcCredential {
+	r := newXdrReader(body)
+	r.uint32()    // stamp
+	r.string(255) // machine name
+	cred := rpcCredential{uid: r.uint32(), gid: r.uint32()}
+	count := r.uint32()
+	for i := uint32(0); i < count && i < 16 && r.err == nil; i++ {
+		cred.gids = append(cred.gids, r.uint32())
+	}
+	if r.err != nil {
+		return rpcCredential{uid: nobody, gid: nobody}
+	}
+	return cred
+}
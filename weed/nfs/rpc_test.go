@@ -0,0 +1,117 @@
+package nfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+func TestXdrRoundTrip(t *testing.T) {
+	w := &xdrWriter{}
+	w.uint32(7)
+	w.uint64(1 << 40)
+	w.bool(true)
+	w.string("hello")
+	w.opaque([]byte{1, 2, 3, 4})
+	if w.Len()%4 != 0 {
+		t.Fatalf("xdr data should be padded to 4 bytes, got %d", w.Len())
+	}
+
+	r := newXdrReader(w.Bytes())
+	if r.uint32() != 7 || r.uint64() != 1<<40 || !r.bool() || r.string(10) != "hello" || len(r.opaque(10)) != 4 || r.err != nil {
+		t.Errorf("round trip failed: %v", r.err)
+	}
+	r.uint32()
+	if r.err != errGarbageArgs {
+		t.Errorf("reading past the end should fail, got %v", r.err)
+	}
+	if r = newXdrReader(w.Bytes()[16:]); r.string(3) != "" || r.err == nil {
+		t.Errorf("a string longer than the limit should fail")
+	}
+}
+
+func TestRpcServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	server := newRpcServer(&rpcProgram{prog: 400000, lowVers: 1, hiVers: 1, handler: func(req *rpcRequest, res *xdrWriter) acceptStat {
+		if req.proc != 1 {
+			return rpcProcUnavail
+		}
+		res.uint32(req.cred.uid)
+		res.uint32(req.args.uint32() + 1)
+		return rpcSuccess
+	}})
+	go server.serve(listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	call := func(xid, prog, vers, proc uint32, arg uint32) *xdrReader {
+		w := &xdrWriter{}
+		w.uint32(xid)
+		w.uint32(rpcCall)
+		w.uint32(2)
+		w.uint32(prog)
+		w.uint32(vers)
+		w.uint32(proc)
+		cred := &xdrWriter{}
+		cred.uint32(0)
+		cred.string("client")
+		cred.uint32(1000)
+		cred.uint32(1000)
+		cred.uint32(0)
+		w.uint32(authUnix)
+		w.opaque(cred.Bytes())
+		w.uint32(authNone)
+		w.opaque(nil)
+		w.uint32(arg)
+
+		// send the call in two fragments
+		body := w.Bytes()
+		var header [4]byte
+		binary.BigEndian.PutUint32(header[:], 8)
+		conn.Write(append(header[:], body[:8]...))
+		binary.BigEndian.PutUint32(header[:], uint32(len(body)-8)|0x80000000)
+		conn.Write(append(header[:], body[8:]...))
+
+		record, err := readRecord(reader)
+		if err != nil {
+			t.Fatalf("read reply: %v", err)
+		}
+		r := newXdrReader(record)
+		if r.uint32() != xid || r.uint32() != rpcReply || r.uint32() != rpcMsgAccepted {
+			t.Fatalf("unexpected reply header")
+		}
+		r.uint32()
+		r.opaque(400)
+		return r
+	}
+
+	r := call(1, 400000, 1, 1, 41)
+	if status := acceptStat(r.uint32()); status != rpcSuccess {
+		t.Fatalf("call failed with %d", status)
+	}
+	if uid, result := r.uint32(), r.uint32(); uid != 1000 || result != 42 {
+		t.Errorf("unexpected result uid %d value %d", uid, result)
+	}
+
+	if status := acceptStat(call(2, 400000, 1, 9, 0).uint32()); status != rpcProcUnavail {
+		t.Errorf("expected PROC_UNAVAIL, got %d", status)
+	}
+	if status := acceptStat(call(3, 400001, 1, 1, 0).uint32()); status != rpcProgUnavail {
+		t.Errorf("expected PROG_UNAVAIL, got %d", status)
+	}
+	r = call(4, 400000, 3, 1, 0)
+	if status := acceptStat(r.uint32()); status != rpcProgMismatch || r.uint32() != 1 || r.uint32() != 1 {
+		t.Errorf("expected PROG_MISMATCH for versions 1 to 1")
+	}
+}
@@ -0,0 +1,115 @@
+package nfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// XDR encoding, RFC 4506. Everything is big endian and padded to 4 bytes.
+
+var errGarbageArgs = errors.New("malformed xdr arguments")
+
+type xdrReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func newXdrReader(buf []byte) *xdrReader {
+	return &xdrReader{buf: buf}
+}
+
+func (r *xdrReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.buf) {
+		r.err = errGarbageArgs
+		return nil
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *xdrReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *xdrReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *xdrReader) bool() bool {
+	return r.uint32() != 0
+}
+
+func (r *xdrReader) fixedOpaque(n int) []byte {
+	b := r.next(n)
+	r.next(pad(n))
+	return b
+}
+
+func (r *xdrReader) opaque(maxSize int) []byte {
+	n := r.uint32()
+	if r.err == nil && n > uint32(maxSize) {
+		r.err = errGarbageArgs
+	}
+	return r.fixedOpaque(int(n))
+}
+
+func (r *xdrReader) string(maxSize int) string {
+	return string(r.opaque(maxSize))
+}
+
+type xdrWriter struct {
+	bytes.Buffer
+}
+
+func (w *xdrWriter) uint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	w.Write(b[:])
+}
+
+func (w *xdrWriter) uint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	w.Write(b[:])
+}
+
+func (w *xdrWriter) bool(v bool) {
+	if v {
+		w.uint32(1)
+	} else {
+		w.uint32(0)
+	}
+}
+
+func (w *xdrWriter) fixedOpaque(b []byte) {
+	w.Write(b)
+	var zeros [4]byte
+	w.Write(zeros[:pad(len(b))])
+}
+
+func (w *xdrWriter) opaque(b []byte) {
+	w.uint32(uint32(len(b)))
+	w.fixedOpaque(b)
+}
+
+func (w *xdrWriter) string(s string) {
+	w.opaque([]byte(s))
+}
+
+func pad(n int) int {
+	return (4 - n%4) % 4
+}
@@ -245,6 +245,7 @@ type StorageOption struct {
 	MaxFileNameLength uint32
 	Fsync             bool
 	SaveInside        bool
+	Cipher            bool // encrypt the chunks, required by locations with a kms key
 }
 
 func (so *StorageOption) TtlString() string {
@@ -147,6 +147,9 @@ func (uploader *Uploader) UploadWithRetry(filerClient filer_pb.FilerClient, assi
 			}
 
 			fileId, auth = resp.FileId, security.EncodedJwt(resp.Auth)
+			if resp.Cipher {
+				uploadOption.Cipher = true
+			}
 			loc := resp.Location
 			host = filerClient.AdjustedUrl(loc)
 
@@ -25,6 +25,10 @@ type S3ApiConfiguration struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Identities    []*Identity            `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
 	Accounts      []*Account             `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Groups        []*Group               `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Policies      []*ManagedPolicy       `protobuf:"bytes,5,rep,name=policies,proto3" json:"policies,omitempty"`
+	Tenants       []*Tenant              `protobuf:"bytes,6,rep,name=tenants,proto3" json:"tenants,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,14 +77,47 @@ func (x *S3ApiConfiguration) GetAccounts() []*Account {
 	return nil
 }
 
+func (x *S3ApiConfiguration) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *S3ApiConfiguration) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *S3ApiConfiguration) GetPolicies() []*ManagedPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *S3ApiConfiguration) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
 type Identity struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Credentials   []*Credential          `protobuf:"bytes,2,rep,name=credentials,proto3" json:"credentials,omitempty"`
-	Actions       []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
-	Account       *Account               `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Credentials        []*Credential          `protobuf:"bytes,2,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	Actions            []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	Account            *Account               `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	PolicyNames        []string               `protobuf:"bytes,5,rep,name=policy_names,json=policyNames,proto3" json:"policy_names,omitempty"` // attached managed policies
+	Tags               map[string]string      `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Sftp               *SftpConfig            `protobuf:"bytes,7,opt,name=sftp,proto3" json:"sftp,omitempty"`
+	ClientCertificates []string               `protobuf:"bytes,8,rep,name=client_certificates,json=clientCertificates,proto3" json:"client_certificates,omitempty"` // names of verified TLS client certificates authenticating as this identity
+	Tenant             string                 `protobuf:"bytes,9,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                   // the identity only sees the buckets of its tenant, and manages its users with the Admin action
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Identity) Reset() {
@@ -141,6 +178,126 @@ func (x *Identity) GetAccount() *Account {
 	return nil
 }
 
+func (x *Identity) GetPolicyNames() []string {
+	if x != nil {
+		return x.PolicyNames
+	}
+	return nil
+}
+
+func (x *Identity) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Identity) GetSftp() *SftpConfig {
+	if x != nil {
+		return x.Sftp
+	}
+	return nil
+}
+
+func (x *Identity) GetClientCertificates() []string {
+	if x != nil {
+		return x.ClientCertificates
+	}
+	return nil
+}
+
+func (x *Identity) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// SftpConfig holds the SSH login of an identity for the SFTP server
+type SftpConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PasswordHash  string                 `protobuf:"bytes,1,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"` // bcrypt
+	PublicKeys    []string               `protobuf:"bytes,2,rep,name=public_keys,json=publicKeys,proto3" json:"public_keys,omitempty"`       // authorized_keys format
+	HomeDir       string                 `protobuf:"bytes,3,opt,name=home_dir,json=homeDir,proto3" json:"home_dir,omitempty"`
+	Chroot        bool                   `protobuf:"varint,4,opt,name=chroot,proto3" json:"chroot,omitempty"` // confine the user to the home directory
+	Uid           uint32                 `protobuf:"varint,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid           uint32                 `protobuf:"varint,6,opt,name=gid,proto3" json:"gid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SftpConfig) Reset() {
+	*x = SftpConfig{}
+	mi := &file_iam_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SftpConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SftpConfig) ProtoMessage() {}
+
+func (x *SftpConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SftpConfig.ProtoReflect.Descriptor instead.
+func (*SftpConfig) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SftpConfig) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *SftpConfig) GetPublicKeys() []string {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+func (x *SftpConfig) GetHomeDir() string {
+	if x != nil {
+		return x.HomeDir
+	}
+	return ""
+}
+
+func (x *SftpConfig) GetChroot() bool {
+	if x != nil {
+		return x.Chroot
+	}
+	return false
+}
+
+func (x *SftpConfig) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *SftpConfig) GetGid() uint32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
 type Credential struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccessKey     string                 `protobuf:"bytes,1,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
@@ -151,7 +308,7 @@ type Credential struct {
 
 func (x *Credential) Reset() {
 	*x = Credential{}
-	mi := &file_iam_proto_msgTypes[2]
+	mi := &file_iam_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -163,7 +320,7 @@ func (x *Credential) String() string {
 func (*Credential) ProtoMessage() {}
 
 func (x *Credential) ProtoReflect() protoreflect.Message {
-	mi := &file_iam_proto_msgTypes[2]
+	mi := &file_iam_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +333,7 @@ func (x *Credential) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Credential.ProtoReflect.Descriptor instead.
 func (*Credential) Descriptor() ([]byte, []int) {
-	return file_iam_proto_rawDescGZIP(), []int{2}
+	return file_iam_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Credential) GetAccessKey() string {
@@ -193,6 +350,92 @@ func (x *Credential) GetSecretKey() string {
 	return ""
 }
 
+// Tenant is an account with its own bucket namespace and resource limits.
+// Its buckets are stored as "<tenant>_<bucket>" in the buckets folder.
+type Tenant struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxBuckets           int64                  `protobuf:"varint,2,opt,name=max_buckets,json=maxBuckets,proto3" json:"max_buckets,omitempty"`
+	QuotaBytes           uint64                 `protobuf:"varint,3,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`                                   // enforced by the filer on the tenant's buckets
+	QuotaObjects         uint64                 `protobuf:"varint,4,opt,name=quota_objects,json=quotaObjects,proto3" json:"quota_objects,omitempty"`                             // files and folders, enforced by the filer
+	MaxRequestsPerSecond int64                  `protobuf:"varint,5,opt,name=max_requests_per_second,json=maxRequestsPerSecond,proto3" json:"max_requests_per_second,omitempty"` // enforced by the S3 circuit breaker
+	CreateDate           int64                  `protobuf:"varint,6,opt,name=create_date,json=createDate,proto3" json:"create_date,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_iam_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetMaxBuckets() int64 {
+	if x != nil {
+		return x.MaxBuckets
+	}
+	return 0
+}
+
+func (x *Tenant) GetQuotaBytes() uint64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *Tenant) GetQuotaObjects() uint64 {
+	if x != nil {
+		return x.QuotaObjects
+	}
+	return 0
+}
+
+func (x *Tenant) GetMaxRequestsPerSecond() int64 {
+	if x != nil {
+		return x.MaxRequestsPerSecond
+	}
+	return 0
+}
+
+func (x *Tenant) GetCreateDate() int64 {
+	if x != nil {
+		return x.CreateDate
+	}
+	return 0
+}
+
 type Account struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -204,7 +447,7 @@ type Account struct {
 
 func (x *Account) Reset() {
 	*x = Account{}
-	mi := &file_iam_proto_msgTypes[3]
+	mi := &file_iam_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +459,7 @@ func (x *Account) String() string {
 func (*Account) ProtoMessage() {}
 
 func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_iam_proto_msgTypes[3]
+	mi := &file_iam_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +472,7 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Account.ProtoReflect.Descriptor instead.
 func (*Account) Descriptor() ([]byte, []int) {
-	return file_iam_proto_rawDescGZIP(), []int{3}
+	return file_iam_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Account) GetId() string {
@@ -253,31 +496,380 @@ func (x *Account) GetEmailAddress() string {
 	return ""
 }
 
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	PolicyNames   []string               `protobuf:"bytes,3,rep,name=policy_names,json=policyNames,proto3" json:"policy_names,omitempty"`
+	CreateDate    int64                  `protobuf:"varint,4,opt,name=create_date,json=createDate,proto3" json:"create_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_iam_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Group) GetPolicyNames() []string {
+	if x != nil {
+		return x.PolicyNames
+	}
+	return nil
+}
+
+func (x *Group) GetCreateDate() int64 {
+	if x != nil {
+		return x.CreateDate
+	}
+	return 0
+}
+
+type Role struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Name                     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description              string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	AssumeRolePolicyDocument string                 `protobuf:"bytes,3,opt,name=assume_role_policy_document,json=assumeRolePolicyDocument,proto3" json:"assume_role_policy_document,omitempty"`
+	PolicyNames              []string               `protobuf:"bytes,4,rep,name=policy_names,json=policyNames,proto3" json:"policy_names,omitempty"`
+	Tags                     map[string]string      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreateDate               int64                  `protobuf:"varint,6,opt,name=create_date,json=createDate,proto3" json:"create_date,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_iam_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Role) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Role) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Role) GetAssumeRolePolicyDocument() string {
+	if x != nil {
+		return x.AssumeRolePolicyDocument
+	}
+	return ""
+}
+
+func (x *Role) GetPolicyNames() []string {
+	if x != nil {
+		return x.PolicyNames
+	}
+	return nil
+}
+
+func (x *Role) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Role) GetCreateDate() int64 {
+	if x != nil {
+		return x.CreateDate
+	}
+	return 0
+}
+
+type ManagedPolicy struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DefaultVersionId string                 `protobuf:"bytes,3,opt,name=default_version_id,json=defaultVersionId,proto3" json:"default_version_id,omitempty"`
+	Versions         []*PolicyVersion       `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`
+	CreateDate       int64                  `protobuf:"varint,5,opt,name=create_date,json=createDate,proto3" json:"create_date,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ManagedPolicy) Reset() {
+	*x = ManagedPolicy{}
+	mi := &file_iam_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManagedPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManagedPolicy) ProtoMessage() {}
+
+func (x *ManagedPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManagedPolicy.ProtoReflect.Descriptor instead.
+func (*ManagedPolicy) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ManagedPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManagedPolicy) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ManagedPolicy) GetDefaultVersionId() string {
+	if x != nil {
+		return x.DefaultVersionId
+	}
+	return ""
+}
+
+func (x *ManagedPolicy) GetVersions() []*PolicyVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ManagedPolicy) GetCreateDate() int64 {
+	if x != nil {
+		return x.CreateDate
+	}
+	return 0
+}
+
+type PolicyVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	CreateDate    int64                  `protobuf:"varint,3,opt,name=create_date,json=createDate,proto3" json:"create_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyVersion) Reset() {
+	*x = PolicyVersion{}
+	mi := &file_iam_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyVersion) ProtoMessage() {}
+
+func (x *PolicyVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_iam_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyVersion.ProtoReflect.Descriptor instead.
+func (*PolicyVersion) Descriptor() ([]byte, []int) {
+	return file_iam_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PolicyVersion) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *PolicyVersion) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *PolicyVersion) GetCreateDate() int64 {
+	if x != nil {
+		return x.CreateDate
+	}
+	return 0
+}
+
 var File_iam_proto protoreflect.FileDescriptor
 
 const file_iam_proto_rawDesc = "" +
 	"\n" +
-	"\tiam.proto\x12\x06iam_pb\"s\n" +
+	"\tiam.proto\x12\x06iam_pb\"\x9b\x02\n" +
 	"\x12S3ApiConfiguration\x120\n" +
 	"\n" +
 	"identities\x18\x01 \x03(\v2\x10.iam_pb.IdentityR\n" +
 	"identities\x12+\n" +
-	"\baccounts\x18\x02 \x03(\v2\x0f.iam_pb.AccountR\baccounts\"\x99\x01\n" +
+	"\baccounts\x18\x02 \x03(\v2\x0f.iam_pb.AccountR\baccounts\x12%\n" +
+	"\x06groups\x18\x03 \x03(\v2\r.iam_pb.GroupR\x06groups\x12\"\n" +
+	"\x05roles\x18\x04 \x03(\v2\f.iam_pb.RoleR\x05roles\x121\n" +
+	"\bpolicies\x18\x05 \x03(\v2\x15.iam_pb.ManagedPolicyR\bpolicies\x12(\n" +
+	"\atenants\x18\x06 \x03(\v2\x0e.iam_pb.TenantR\atenants\"\x96\x03\n" +
 	"\bIdentity\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x124\n" +
 	"\vcredentials\x18\x02 \x03(\v2\x12.iam_pb.CredentialR\vcredentials\x12\x18\n" +
 	"\aactions\x18\x03 \x03(\tR\aactions\x12)\n" +
-	"\aaccount\x18\x04 \x01(\v2\x0f.iam_pb.AccountR\aaccount\"J\n" +
+	"\aaccount\x18\x04 \x01(\v2\x0f.iam_pb.AccountR\aaccount\x12!\n" +
+	"\fpolicy_names\x18\x05 \x03(\tR\vpolicyNames\x12.\n" +
+	"\x04tags\x18\x06 \x03(\v2\x1a.iam_pb.Identity.TagsEntryR\x04tags\x12&\n" +
+	"\x04sftp\x18\a \x01(\v2\x12.iam_pb.SftpConfigR\x04sftp\x12/\n" +
+	"\x13client_certificates\x18\b \x03(\tR\x12clientCertificates\x12\x16\n" +
+	"\x06tenant\x18\t \x01(\tR\x06tenant\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\n" +
+	"SftpConfig\x12#\n" +
+	"\rpassword_hash\x18\x01 \x01(\tR\fpasswordHash\x12\x1f\n" +
+	"\vpublic_keys\x18\x02 \x03(\tR\n" +
+	"publicKeys\x12\x19\n" +
+	"\bhome_dir\x18\x03 \x01(\tR\ahomeDir\x12\x16\n" +
+	"\x06chroot\x18\x04 \x01(\bR\x06chroot\x12\x10\n" +
+	"\x03uid\x18\x05 \x01(\rR\x03uid\x12\x10\n" +
+	"\x03gid\x18\x06 \x01(\rR\x03gid\"J\n" +
 	"\n" +
 	"Credential\x12\x1d\n" +
 	"\n" +
 	"access_key\x18\x01 \x01(\tR\taccessKey\x12\x1d\n" +
 	"\n" +
-	"secret_key\x18\x02 \x01(\tR\tsecretKey\"a\n" +
+	"secret_key\x18\x02 \x01(\tR\tsecretKey\"\xdb\x01\n" +
+	"\x06Tenant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vmax_buckets\x18\x02 \x01(\x03R\n" +
+	"maxBuckets\x12\x1f\n" +
+	"\vquota_bytes\x18\x03 \x01(\x04R\n" +
+	"quotaBytes\x12#\n" +
+	"\rquota_objects\x18\x04 \x01(\x04R\fquotaObjects\x125\n" +
+	"\x17max_requests_per_second\x18\x05 \x01(\x03R\x14maxRequestsPerSecond\x12\x1f\n" +
+	"\vcreate_date\x18\x06 \x01(\x03R\n" +
+	"createDate\"a\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12#\n" +
-	"\remail_address\x18\x03 \x01(\tR\femailAddress2!\n" +
+	"\remail_address\x18\x03 \x01(\tR\femailAddress\"y\n" +
+	"\x05Group\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\x12!\n" +
+	"\fpolicy_names\x18\x03 \x03(\tR\vpolicyNames\x12\x1f\n" +
+	"\vcreate_date\x18\x04 \x01(\x03R\n" +
+	"createDate\"\xa4\x02\n" +
+	"\x04Role\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12=\n" +
+	"\x1bassume_role_policy_document\x18\x03 \x01(\tR\x18assumeRolePolicyDocument\x12!\n" +
+	"\fpolicy_names\x18\x04 \x03(\tR\vpolicyNames\x12*\n" +
+	"\x04tags\x18\x05 \x03(\v2\x16.iam_pb.Role.TagsEntryR\x04tags\x12\x1f\n" +
+	"\vcreate_date\x18\x06 \x01(\x03R\n" +
+	"createDate\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc7\x01\n" +
+	"\rManagedPolicy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12,\n" +
+	"\x12default_version_id\x18\x03 \x01(\tR\x10defaultVersionId\x121\n" +
+	"\bversions\x18\x04 \x03(\v2\x15.iam_pb.PolicyVersionR\bversions\x12\x1f\n" +
+	"\vcreate_date\x18\x05 \x01(\x03R\n" +
+	"createDate\"k\n" +
+	"\rPolicyVersion\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12\x1f\n" +
+	"\vcreate_date\x18\x03 \x01(\x03R\n" +
+	"createDate2!\n" +
 	"\x1fSeaweedIdentityAccessManagementBK\n" +
 	"\x10seaweedfs.clientB\bIamProtoZ-github.com/seaweedfs/seaweedfs/weed/pb/iam_pbb\x06proto3"
 
@@ -293,23 +885,39 @@ func file_iam_proto_rawDescGZIP() []byte {
 	return file_iam_proto_rawDescData
 }
 
-var file_iam_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_iam_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_iam_proto_goTypes = []any{
 	(*S3ApiConfiguration)(nil), // 0: iam_pb.S3ApiConfiguration
 	(*Identity)(nil),           // 1: iam_pb.Identity
-	(*Credential)(nil),         // 2: iam_pb.Credential
-	(*Account)(nil),            // 3: iam_pb.Account
+	(*SftpConfig)(nil),         // 2: iam_pb.SftpConfig
+	(*Credential)(nil),         // 3: iam_pb.Credential
+	(*Tenant)(nil),             // 4: iam_pb.Tenant
+	(*Account)(nil),            // 5: iam_pb.Account
+	(*Group)(nil),              // 6: iam_pb.Group
+	(*Role)(nil),               // 7: iam_pb.Role
+	(*ManagedPolicy)(nil),      // 8: iam_pb.ManagedPolicy
+	(*PolicyVersion)(nil),      // 9: iam_pb.PolicyVersion
+	nil,                        // 10: iam_pb.Identity.TagsEntry
+	nil,                        // 11: iam_pb.Role.TagsEntry
 }
 var file_iam_proto_depIdxs = []int32{
-	1, // 0: iam_pb.S3ApiConfiguration.identities:type_name -> iam_pb.Identity
-	3, // 1: iam_pb.S3ApiConfiguration.accounts:type_name -> iam_pb.Account
-	2, // 2: iam_pb.Identity.credentials:type_name -> iam_pb.Credential
-	3, // 3: iam_pb.Identity.account:type_name -> iam_pb.Account
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1,  // 0: iam_pb.S3ApiConfiguration.identities:type_name -> iam_pb.Identity
+	5,  // 1: iam_pb.S3ApiConfiguration.accounts:type_name -> iam_pb.Account
+	6,  // 2: iam_pb.S3ApiConfiguration.groups:type_name -> iam_pb.Group
+	7,  // 3: iam_pb.S3ApiConfiguration.roles:type_name -> iam_pb.Role
+	8,  // 4: iam_pb.S3ApiConfiguration.policies:type_name -> iam_pb.ManagedPolicy
+	4,  // 5: iam_pb.S3ApiConfiguration.tenants:type_name -> iam_pb.Tenant
+	3,  // 6: iam_pb.Identity.credentials:type_name -> iam_pb.Credential
+	5,  // 7: iam_pb.Identity.account:type_name -> iam_pb.Account
+	10, // 8: iam_pb.Identity.tags:type_name -> iam_pb.Identity.TagsEntry
+	2,  // 9: iam_pb.Identity.sftp:type_name -> iam_pb.SftpConfig
+	11, // 10: iam_pb.Role.tags:type_name -> iam_pb.Role.TagsEntry
+	9,  // 11: iam_pb.ManagedPolicy.versions:type_name -> iam_pb.PolicyVersion
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_iam_proto_init() }
@@ -323,7 +931,7 @@ func file_iam_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_proto_rawDesc), len(file_iam_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
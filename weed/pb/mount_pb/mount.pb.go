@@ -101,6 +101,178 @@ func (*ConfigureResponse) Descriptor() ([]byte, []int) {
 	return file_mount_proto_rawDescGZIP(), []int{1}
 }
 
+type PinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // empty to only list the pinned directories
+	Unpin         bool                   `protobuf:"varint,2,opt,name=unpin,proto3" json:"unpin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinRequest) Reset() {
+	*x = PinRequest{}
+	mi := &file_mount_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinRequest) ProtoMessage() {}
+
+func (x *PinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mount_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinRequest.ProtoReflect.Descriptor instead.
+func (*PinRequest) Descriptor() ([]byte, []int) {
+	return file_mount_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PinRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PinRequest) GetUnpin() bool {
+	if x != nil {
+		return x.Unpin
+	}
+	return false
+}
+
+type PinStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FileCount     int64                  `protobuf:"varint,2,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	ByteCount     int64                  `protobuf:"varint,3,opt,name=byte_count,json=byteCount,proto3" json:"byte_count,omitempty"`
+	SyncedAtNs    int64                  `protobuf:"varint,4,opt,name=synced_at_ns,json=syncedAtNs,proto3" json:"synced_at_ns,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinStatus) Reset() {
+	*x = PinStatus{}
+	mi := &file_mount_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinStatus) ProtoMessage() {}
+
+func (x *PinStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mount_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinStatus.ProtoReflect.Descriptor instead.
+func (*PinStatus) Descriptor() ([]byte, []int) {
+	return file_mount_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PinStatus) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PinStatus) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *PinStatus) GetByteCount() int64 {
+	if x != nil {
+		return x.ByteCount
+	}
+	return 0
+}
+
+func (x *PinStatus) GetSyncedAtNs() int64 {
+	if x != nil {
+		return x.SyncedAtNs
+	}
+	return 0
+}
+
+func (x *PinStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*PinStatus           `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinResponse) Reset() {
+	*x = PinResponse{}
+	mi := &file_mount_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinResponse) ProtoMessage() {}
+
+func (x *PinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mount_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinResponse.ProtoReflect.Descriptor instead.
+func (*PinResponse) Descriptor() ([]byte, []int) {
+	return file_mount_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PinResponse) GetPins() []*PinStatus {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
 var File_mount_proto protoreflect.FileDescriptor
 
 const file_mount_proto_rawDesc = "" +
@@ -108,9 +280,25 @@ const file_mount_proto_rawDesc = "" +
 	"\vmount.proto\x12\fmessaging_pb\"C\n" +
 	"\x10ConfigureRequest\x12/\n" +
 	"\x13collection_capacity\x18\x01 \x01(\x03R\x12collectionCapacity\"\x13\n" +
-	"\x11ConfigureResponse2^\n" +
+	"\x11ConfigureResponse\"6\n" +
+	"\n" +
+	"PinRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05unpin\x18\x02 \x01(\bR\x05unpin\"\x95\x01\n" +
+	"\tPinStatus\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x02 \x01(\x03R\tfileCount\x12\x1d\n" +
+	"\n" +
+	"byte_count\x18\x03 \x01(\x03R\tbyteCount\x12 \n" +
+	"\fsynced_at_ns\x18\x04 \x01(\x03R\n" +
+	"syncedAtNs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\":\n" +
+	"\vPinResponse\x12+\n" +
+	"\x04pins\x18\x01 \x03(\v2\x17.messaging_pb.PinStatusR\x04pins2\x9c\x01\n" +
 	"\fSeaweedMount\x12N\n" +
-	"\tConfigure\x12\x1e.messaging_pb.ConfigureRequest\x1a\x1f.messaging_pb.ConfigureResponse\"\x00BO\n" +
+	"\tConfigure\x12\x1e.messaging_pb.ConfigureRequest\x1a\x1f.messaging_pb.ConfigureResponse\"\x00\x12<\n" +
+	"\x03Pin\x12\x18.messaging_pb.PinRequest\x1a\x19.messaging_pb.PinResponse\"\x00BO\n" +
 	"\x10seaweedfs.clientB\n" +
 	"MountProtoZ/github.com/seaweedfs/seaweedfs/weed/pb/mount_pbb\x06proto3"
 
@@ -126,19 +314,25 @@ func file_mount_proto_rawDescGZIP() []byte {
 	return file_mount_proto_rawDescData
 }
 
-var file_mount_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_mount_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_mount_proto_goTypes = []any{
 	(*ConfigureRequest)(nil),  // 0: messaging_pb.ConfigureRequest
 	(*ConfigureResponse)(nil), // 1: messaging_pb.ConfigureResponse
+	(*PinRequest)(nil),        // 2: messaging_pb.PinRequest
+	(*PinStatus)(nil),         // 3: messaging_pb.PinStatus
+	(*PinResponse)(nil),       // 4: messaging_pb.PinResponse
 }
 var file_mount_proto_depIdxs = []int32{
-	0, // 0: messaging_pb.SeaweedMount.Configure:input_type -> messaging_pb.ConfigureRequest
-	1, // 1: messaging_pb.SeaweedMount.Configure:output_type -> messaging_pb.ConfigureResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: messaging_pb.PinResponse.pins:type_name -> messaging_pb.PinStatus
+	0, // 1: messaging_pb.SeaweedMount.Configure:input_type -> messaging_pb.ConfigureRequest
+	2, // 2: messaging_pb.SeaweedMount.Pin:input_type -> messaging_pb.PinRequest
+	1, // 3: messaging_pb.SeaweedMount.Configure:output_type -> messaging_pb.ConfigureResponse
+	4, // 4: messaging_pb.SeaweedMount.Pin:output_type -> messaging_pb.PinResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_mount_proto_init() }
@@ -152,7 +346,7 @@ func file_mount_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mount_proto_rawDesc), len(file_mount_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
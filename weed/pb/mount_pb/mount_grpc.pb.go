@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	SeaweedMount_Configure_FullMethodName = "/messaging_pb.SeaweedMount/Configure"
+	SeaweedMount_Pin_FullMethodName       = "/messaging_pb.SeaweedMount/Pin"
 )
 
 // SeaweedMountClient is the client API for SeaweedMount service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SeaweedMountClient interface {
 	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+	Pin(ctx context.Context, in *PinRequest, opts ...grpc.CallOption) (*PinResponse, error)
 }
 
 type seaweedMountClient struct {
@@ -47,11 +49,22 @@ func (c *seaweedMountClient) Configure(ctx context.Context, in *ConfigureRequest
 	return out, nil
 }
 
+func (c *seaweedMountClient) Pin(ctx context.Context, in *PinRequest, opts ...grpc.CallOption) (*PinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinResponse)
+	err := c.cc.Invoke(ctx, SeaweedMount_Pin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SeaweedMountServer is the server API for SeaweedMount service.
 // All implementations must embed UnimplementedSeaweedMountServer
 // for forward compatibility.
 type SeaweedMountServer interface {
 	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	Pin(context.Context, *PinRequest) (*PinResponse, error)
 	mustEmbedUnimplementedSeaweedMountServer()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedSeaweedMountServer struct{}
 func (UnimplementedSeaweedMountServer) Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (UnimplementedSeaweedMountServer) Pin(context.Context, *PinRequest) (*PinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pin not implemented")
+}
 func (UnimplementedSeaweedMountServer) mustEmbedUnimplementedSeaweedMountServer() {}
 func (UnimplementedSeaweedMountServer) testEmbeddedByValue()                      {}
 
@@ -104,6 +120,24 @@ func _SeaweedMount_Configure_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedMount_Pin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedMountServer).Pin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeaweedMount_Pin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedMountServer).Pin(ctx, req.(*PinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SeaweedMount_ServiceDesc is the grpc.ServiceDesc for SeaweedMount service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,10 @@ var SeaweedMount_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _SeaweedMount_Configure_Handler,
 		},
+		{
+			MethodName: "Pin",
+			Handler:    _SeaweedMount_Pin_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mount.proto",
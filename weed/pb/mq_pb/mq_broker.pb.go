@@ -22,6 +22,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ////////////////////////////////////////////////
+type TopicCleanupPolicy int32
+
+const (
+	TopicCleanupPolicy_CLEANUP_DELETE         TopicCleanupPolicy = 0 // delete segments older than retention_seconds or beyond retention_bytes
+	TopicCleanupPolicy_CLEANUP_COMPACT        TopicCleanupPolicy = 1 // keep only the latest value per key, in the log files but not in parquet files
+	TopicCleanupPolicy_CLEANUP_COMPACT_DELETE TopicCleanupPolicy = 2 // compact by key, then apply the delete rules
+)
+
+// Enum value maps for TopicCleanupPolicy.
+var (
+	TopicCleanupPolicy_name = map[int32]string{
+		0: "CLEANUP_DELETE",
+		1: "CLEANUP_COMPACT",
+		2: "CLEANUP_COMPACT_DELETE",
+	}
+	TopicCleanupPolicy_value = map[string]int32{
+		"CLEANUP_DELETE":         0,
+		"CLEANUP_COMPACT":        1,
+		"CLEANUP_COMPACT_DELETE": 2,
+	}
+)
+
+func (x TopicCleanupPolicy) Enum() *TopicCleanupPolicy {
+	p := new(TopicCleanupPolicy)
+	*p = x
+	return p
+}
+
+func (x TopicCleanupPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopicCleanupPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_mq_broker_proto_enumTypes[0].Descriptor()
+}
+
+func (TopicCleanupPolicy) Type() protoreflect.EnumType {
+	return &file_mq_broker_proto_enumTypes[0]
+}
+
+func (x TopicCleanupPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopicCleanupPolicy.Descriptor instead.
+func (TopicCleanupPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{0}
+}
+
 type FindBrokerLeaderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FilerGroup    string                 `protobuf:"bytes,1,opt,name=filer_group,json=filerGroup,proto3" json:"filer_group,omitempty"`
@@ -429,18 +479,100 @@ func (*BalanceTopicsResponse) Descriptor() ([]byte, []int) {
 	return file_mq_broker_proto_rawDescGZIP(), []int{7}
 }
 
-// ////////////////////////////////////////////////
+type EnforceTopicRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         *schema_pb.Topic       `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"` // all topics if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnforceTopicRetentionRequest) Reset() {
+	*x = EnforceTopicRetentionRequest{}
+	mi := &file_mq_broker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnforceTopicRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnforceTopicRetentionRequest) ProtoMessage() {}
+
+func (x *EnforceTopicRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mq_broker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnforceTopicRetentionRequest.ProtoReflect.Descriptor instead.
+func (*EnforceTopicRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EnforceTopicRetentionRequest) GetTopic() *schema_pb.Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+type EnforceTopicRetentionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnforceTopicRetentionResponse) Reset() {
+	*x = EnforceTopicRetentionResponse{}
+	mi := &file_mq_broker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnforceTopicRetentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnforceTopicRetentionResponse) ProtoMessage() {}
+
+func (x *EnforceTopicRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mq_broker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnforceTopicRetentionResponse.ProtoReflect.Descriptor instead.
+func (*EnforceTopicRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{9}
+}
+
 type TopicRetention struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	RetentionSeconds int64                  `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"` // retention duration in seconds
-	Enabled          bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`                                           // whether retention is enabled
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	RetentionSeconds          int64                  `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"` // retention duration in seconds
+	Enabled                   bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`                                           // whether retention is enabled
+	RetentionBytes            int64                  `protobuf:"varint,3,opt,name=retention_bytes,json=retentionBytes,proto3" json:"retention_bytes,omitempty"`       // max bytes kept per partition, 0 means unlimited
+	CleanupPolicy             TopicCleanupPolicy     `protobuf:"varint,4,opt,name=cleanup_policy,json=cleanupPolicy,proto3,enum=messaging_pb.TopicCleanupPolicy" json:"cleanup_policy,omitempty"`
+	TombstoneRetentionSeconds int64                  `protobuf:"varint,5,opt,name=tombstone_retention_seconds,json=tombstoneRetentionSeconds,proto3" json:"tombstone_retention_seconds,omitempty"` // how long compaction keeps tombstones (keyed messages with empty value, so an empty value deletes the key)
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *TopicRetention) Reset() {
 	*x = TopicRetention{}
-	mi := &file_mq_broker_proto_msgTypes[8]
+	mi := &file_mq_broker_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -452,7 +584,7 @@ func (x *TopicRetention) String() string {
 func (*TopicRetention) ProtoMessage() {}
 
 func (x *TopicRetention) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[8]
+	mi := &file_mq_broker_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +597,7 @@ func (x *TopicRetention) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicRetention.ProtoReflect.Descriptor instead.
 func (*TopicRetention) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{8}
+	return file_mq_broker_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TopicRetention) GetRetentionSeconds() int64 {
@@ -482,6 +614,27 @@ func (x *TopicRetention) GetEnabled() bool {
 	return false
 }
 
+func (x *TopicRetention) GetRetentionBytes() int64 {
+	if x != nil {
+		return x.RetentionBytes
+	}
+	return 0
+}
+
+func (x *TopicRetention) GetCleanupPolicy() TopicCleanupPolicy {
+	if x != nil {
+		return x.CleanupPolicy
+	}
+	return TopicCleanupPolicy_CLEANUP_DELETE
+}
+
+func (x *TopicRetention) GetTombstoneRetentionSeconds() int64 {
+	if x != nil {
+		return x.TombstoneRetentionSeconds
+	}
+	return 0
+}
+
 type ConfigureTopicRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Topic          *schema_pb.Topic       `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
@@ -494,7 +647,7 @@ type ConfigureTopicRequest struct {
 
 func (x *ConfigureTopicRequest) Reset() {
 	*x = ConfigureTopicRequest{}
-	mi := &file_mq_broker_proto_msgTypes[9]
+	mi := &file_mq_broker_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -506,7 +659,7 @@ func (x *ConfigureTopicRequest) String() string {
 func (*ConfigureTopicRequest) ProtoMessage() {}
 
 func (x *ConfigureTopicRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[9]
+	mi := &file_mq_broker_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +672,7 @@ func (x *ConfigureTopicRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureTopicRequest.ProtoReflect.Descriptor instead.
 func (*ConfigureTopicRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{9}
+	return file_mq_broker_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ConfigureTopicRequest) GetTopic() *schema_pb.Topic {
@@ -561,7 +714,7 @@ type ConfigureTopicResponse struct {
 
 func (x *ConfigureTopicResponse) Reset() {
 	*x = ConfigureTopicResponse{}
-	mi := &file_mq_broker_proto_msgTypes[10]
+	mi := &file_mq_broker_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -573,7 +726,7 @@ func (x *ConfigureTopicResponse) String() string {
 func (*ConfigureTopicResponse) ProtoMessage() {}
 
 func (x *ConfigureTopicResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[10]
+	mi := &file_mq_broker_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +739,7 @@ func (x *ConfigureTopicResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureTopicResponse.ProtoReflect.Descriptor instead.
 func (*ConfigureTopicResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{10}
+	return file_mq_broker_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ConfigureTopicResponse) GetBrokerPartitionAssignments() []*BrokerPartitionAssignment {
@@ -618,7 +771,7 @@ type ListTopicsRequest struct {
 
 func (x *ListTopicsRequest) Reset() {
 	*x = ListTopicsRequest{}
-	mi := &file_mq_broker_proto_msgTypes[11]
+	mi := &file_mq_broker_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -630,7 +783,7 @@ func (x *ListTopicsRequest) String() string {
 func (*ListTopicsRequest) ProtoMessage() {}
 
 func (x *ListTopicsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[11]
+	mi := &file_mq_broker_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,7 +796,7 @@ func (x *ListTopicsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTopicsRequest.ProtoReflect.Descriptor instead.
 func (*ListTopicsRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{11}
+	return file_mq_broker_proto_rawDescGZIP(), []int{13}
 }
 
 type ListTopicsResponse struct {
@@ -655,7 +808,7 @@ type ListTopicsResponse struct {
 
 func (x *ListTopicsResponse) Reset() {
 	*x = ListTopicsResponse{}
-	mi := &file_mq_broker_proto_msgTypes[12]
+	mi := &file_mq_broker_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -667,7 +820,7 @@ func (x *ListTopicsResponse) String() string {
 func (*ListTopicsResponse) ProtoMessage() {}
 
 func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[12]
+	mi := &file_mq_broker_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,7 +833,7 @@ func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
 func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{12}
+	return file_mq_broker_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListTopicsResponse) GetTopics() []*schema_pb.Topic {
@@ -699,7 +852,7 @@ type LookupTopicBrokersRequest struct {
 
 func (x *LookupTopicBrokersRequest) Reset() {
 	*x = LookupTopicBrokersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[13]
+	mi := &file_mq_broker_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -711,7 +864,7 @@ func (x *LookupTopicBrokersRequest) String() string {
 func (*LookupTopicBrokersRequest) ProtoMessage() {}
 
 func (x *LookupTopicBrokersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[13]
+	mi := &file_mq_broker_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +877,7 @@ func (x *LookupTopicBrokersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupTopicBrokersRequest.ProtoReflect.Descriptor instead.
 func (*LookupTopicBrokersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{13}
+	return file_mq_broker_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *LookupTopicBrokersRequest) GetTopic() *schema_pb.Topic {
@@ -744,7 +897,7 @@ type LookupTopicBrokersResponse struct {
 
 func (x *LookupTopicBrokersResponse) Reset() {
 	*x = LookupTopicBrokersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[14]
+	mi := &file_mq_broker_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -756,7 +909,7 @@ func (x *LookupTopicBrokersResponse) String() string {
 func (*LookupTopicBrokersResponse) ProtoMessage() {}
 
 func (x *LookupTopicBrokersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[14]
+	mi := &file_mq_broker_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,7 +922,7 @@ func (x *LookupTopicBrokersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupTopicBrokersResponse.ProtoReflect.Descriptor instead.
 func (*LookupTopicBrokersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{14}
+	return file_mq_broker_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LookupTopicBrokersResponse) GetTopic() *schema_pb.Topic {
@@ -797,7 +950,7 @@ type BrokerPartitionAssignment struct {
 
 func (x *BrokerPartitionAssignment) Reset() {
 	*x = BrokerPartitionAssignment{}
-	mi := &file_mq_broker_proto_msgTypes[15]
+	mi := &file_mq_broker_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -809,7 +962,7 @@ func (x *BrokerPartitionAssignment) String() string {
 func (*BrokerPartitionAssignment) ProtoMessage() {}
 
 func (x *BrokerPartitionAssignment) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[15]
+	mi := &file_mq_broker_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,7 +975,7 @@ func (x *BrokerPartitionAssignment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BrokerPartitionAssignment.ProtoReflect.Descriptor instead.
 func (*BrokerPartitionAssignment) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{15}
+	return file_mq_broker_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BrokerPartitionAssignment) GetPartition() *schema_pb.Partition {
@@ -855,7 +1008,7 @@ type GetTopicConfigurationRequest struct {
 
 func (x *GetTopicConfigurationRequest) Reset() {
 	*x = GetTopicConfigurationRequest{}
-	mi := &file_mq_broker_proto_msgTypes[16]
+	mi := &file_mq_broker_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -867,7 +1020,7 @@ func (x *GetTopicConfigurationRequest) String() string {
 func (*GetTopicConfigurationRequest) ProtoMessage() {}
 
 func (x *GetTopicConfigurationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[16]
+	mi := &file_mq_broker_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,7 +1033,7 @@ func (x *GetTopicConfigurationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicConfigurationRequest.ProtoReflect.Descriptor instead.
 func (*GetTopicConfigurationRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{16}
+	return file_mq_broker_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetTopicConfigurationRequest) GetTopic() *schema_pb.Topic {
@@ -905,7 +1058,7 @@ type GetTopicConfigurationResponse struct {
 
 func (x *GetTopicConfigurationResponse) Reset() {
 	*x = GetTopicConfigurationResponse{}
-	mi := &file_mq_broker_proto_msgTypes[17]
+	mi := &file_mq_broker_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -917,7 +1070,7 @@ func (x *GetTopicConfigurationResponse) String() string {
 func (*GetTopicConfigurationResponse) ProtoMessage() {}
 
 func (x *GetTopicConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[17]
+	mi := &file_mq_broker_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,7 +1083,7 @@ func (x *GetTopicConfigurationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicConfigurationResponse.ProtoReflect.Descriptor instead.
 func (*GetTopicConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{17}
+	return file_mq_broker_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetTopicConfigurationResponse) GetTopic() *schema_pb.Topic {
@@ -991,7 +1144,7 @@ type GetTopicPublishersRequest struct {
 
 func (x *GetTopicPublishersRequest) Reset() {
 	*x = GetTopicPublishersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[18]
+	mi := &file_mq_broker_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +1156,7 @@ func (x *GetTopicPublishersRequest) String() string {
 func (*GetTopicPublishersRequest) ProtoMessage() {}
 
 func (x *GetTopicPublishersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[18]
+	mi := &file_mq_broker_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1169,7 @@ func (x *GetTopicPublishersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicPublishersRequest.ProtoReflect.Descriptor instead.
 func (*GetTopicPublishersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{18}
+	return file_mq_broker_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetTopicPublishersRequest) GetTopic() *schema_pb.Topic {
@@ -1035,7 +1188,7 @@ type GetTopicPublishersResponse struct {
 
 func (x *GetTopicPublishersResponse) Reset() {
 	*x = GetTopicPublishersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[19]
+	mi := &file_mq_broker_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1047,7 +1200,7 @@ func (x *GetTopicPublishersResponse) String() string {
 func (*GetTopicPublishersResponse) ProtoMessage() {}
 
 func (x *GetTopicPublishersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[19]
+	mi := &file_mq_broker_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1060,7 +1213,7 @@ func (x *GetTopicPublishersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicPublishersResponse.ProtoReflect.Descriptor instead.
 func (*GetTopicPublishersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{19}
+	return file_mq_broker_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetTopicPublishersResponse) GetPublishers() []*TopicPublisher {
@@ -1079,7 +1232,7 @@ type GetTopicSubscribersRequest struct {
 
 func (x *GetTopicSubscribersRequest) Reset() {
 	*x = GetTopicSubscribersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[20]
+	mi := &file_mq_broker_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1091,7 +1244,7 @@ func (x *GetTopicSubscribersRequest) String() string {
 func (*GetTopicSubscribersRequest) ProtoMessage() {}
 
 func (x *GetTopicSubscribersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[20]
+	mi := &file_mq_broker_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,7 +1257,7 @@ func (x *GetTopicSubscribersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicSubscribersRequest.ProtoReflect.Descriptor instead.
 func (*GetTopicSubscribersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{20}
+	return file_mq_broker_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetTopicSubscribersRequest) GetTopic() *schema_pb.Topic {
@@ -1123,7 +1276,7 @@ type GetTopicSubscribersResponse struct {
 
 func (x *GetTopicSubscribersResponse) Reset() {
 	*x = GetTopicSubscribersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[21]
+	mi := &file_mq_broker_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1135,7 +1288,7 @@ func (x *GetTopicSubscribersResponse) String() string {
 func (*GetTopicSubscribersResponse) ProtoMessage() {}
 
 func (x *GetTopicSubscribersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[21]
+	mi := &file_mq_broker_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1148,7 +1301,7 @@ func (x *GetTopicSubscribersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopicSubscribersResponse.ProtoReflect.Descriptor instead.
 func (*GetTopicSubscribersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{21}
+	return file_mq_broker_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetTopicSubscribersResponse) GetSubscribers() []*TopicSubscriber {
@@ -1175,7 +1328,7 @@ type TopicPublisher struct {
 
 func (x *TopicPublisher) Reset() {
 	*x = TopicPublisher{}
-	mi := &file_mq_broker_proto_msgTypes[22]
+	mi := &file_mq_broker_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1187,7 +1340,7 @@ func (x *TopicPublisher) String() string {
 func (*TopicPublisher) ProtoMessage() {}
 
 func (x *TopicPublisher) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[22]
+	mi := &file_mq_broker_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,7 +1353,7 @@ func (x *TopicPublisher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicPublisher.ProtoReflect.Descriptor instead.
 func (*TopicPublisher) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{22}
+	return file_mq_broker_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *TopicPublisher) GetPublisherName() string {
@@ -1284,7 +1437,7 @@ type TopicSubscriber struct {
 
 func (x *TopicSubscriber) Reset() {
 	*x = TopicSubscriber{}
-	mi := &file_mq_broker_proto_msgTypes[23]
+	mi := &file_mq_broker_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1296,7 +1449,7 @@ func (x *TopicSubscriber) String() string {
 func (*TopicSubscriber) ProtoMessage() {}
 
 func (x *TopicSubscriber) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[23]
+	mi := &file_mq_broker_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,7 +1462,7 @@ func (x *TopicSubscriber) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicSubscriber.ProtoReflect.Descriptor instead.
 func (*TopicSubscriber) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{23}
+	return file_mq_broker_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *TopicSubscriber) GetConsumerGroup() string {
@@ -1394,7 +1547,7 @@ type AssignTopicPartitionsRequest struct {
 
 func (x *AssignTopicPartitionsRequest) Reset() {
 	*x = AssignTopicPartitionsRequest{}
-	mi := &file_mq_broker_proto_msgTypes[24]
+	mi := &file_mq_broker_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1406,7 +1559,7 @@ func (x *AssignTopicPartitionsRequest) String() string {
 func (*AssignTopicPartitionsRequest) ProtoMessage() {}
 
 func (x *AssignTopicPartitionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[24]
+	mi := &file_mq_broker_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1419,7 +1572,7 @@ func (x *AssignTopicPartitionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignTopicPartitionsRequest.ProtoReflect.Descriptor instead.
 func (*AssignTopicPartitionsRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{24}
+	return file_mq_broker_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *AssignTopicPartitionsRequest) GetTopic() *schema_pb.Topic {
@@ -1458,7 +1611,7 @@ type AssignTopicPartitionsResponse struct {
 
 func (x *AssignTopicPartitionsResponse) Reset() {
 	*x = AssignTopicPartitionsResponse{}
-	mi := &file_mq_broker_proto_msgTypes[25]
+	mi := &file_mq_broker_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1470,7 +1623,7 @@ func (x *AssignTopicPartitionsResponse) String() string {
 func (*AssignTopicPartitionsResponse) ProtoMessage() {}
 
 func (x *AssignTopicPartitionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[25]
+	mi := &file_mq_broker_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1483,7 +1636,7 @@ func (x *AssignTopicPartitionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignTopicPartitionsResponse.ProtoReflect.Descriptor instead.
 func (*AssignTopicPartitionsResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{25}
+	return file_mq_broker_proto_rawDescGZIP(), []int{27}
 }
 
 type SubscriberToSubCoordinatorRequest struct {
@@ -1500,7 +1653,7 @@ type SubscriberToSubCoordinatorRequest struct {
 
 func (x *SubscriberToSubCoordinatorRequest) Reset() {
 	*x = SubscriberToSubCoordinatorRequest{}
-	mi := &file_mq_broker_proto_msgTypes[26]
+	mi := &file_mq_broker_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1512,7 +1665,7 @@ func (x *SubscriberToSubCoordinatorRequest) String() string {
 func (*SubscriberToSubCoordinatorRequest) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[26]
+	mi := &file_mq_broker_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,7 +1678,7 @@ func (x *SubscriberToSubCoordinatorRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SubscriberToSubCoordinatorRequest.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{26}
+	return file_mq_broker_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SubscriberToSubCoordinatorRequest) GetMessage() isSubscriberToSubCoordinatorRequest_Message {
@@ -1599,7 +1752,7 @@ type SubscriberToSubCoordinatorResponse struct {
 
 func (x *SubscriberToSubCoordinatorResponse) Reset() {
 	*x = SubscriberToSubCoordinatorResponse{}
-	mi := &file_mq_broker_proto_msgTypes[27]
+	mi := &file_mq_broker_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1611,7 +1764,7 @@ func (x *SubscriberToSubCoordinatorResponse) String() string {
 func (*SubscriberToSubCoordinatorResponse) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[27]
+	mi := &file_mq_broker_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1624,7 +1777,7 @@ func (x *SubscriberToSubCoordinatorResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SubscriberToSubCoordinatorResponse.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{27}
+	return file_mq_broker_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SubscriberToSubCoordinatorResponse) GetMessage() isSubscriberToSubCoordinatorResponse_Message {
@@ -1681,7 +1834,7 @@ type ControlMessage struct {
 
 func (x *ControlMessage) Reset() {
 	*x = ControlMessage{}
-	mi := &file_mq_broker_proto_msgTypes[28]
+	mi := &file_mq_broker_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1693,7 +1846,7 @@ func (x *ControlMessage) String() string {
 func (*ControlMessage) ProtoMessage() {}
 
 func (x *ControlMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[28]
+	mi := &file_mq_broker_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1706,7 +1859,7 @@ func (x *ControlMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControlMessage.ProtoReflect.Descriptor instead.
 func (*ControlMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{28}
+	return file_mq_broker_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ControlMessage) GetIsClose() bool {
@@ -1735,7 +1888,7 @@ type DataMessage struct {
 
 func (x *DataMessage) Reset() {
 	*x = DataMessage{}
-	mi := &file_mq_broker_proto_msgTypes[29]
+	mi := &file_mq_broker_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1747,7 +1900,7 @@ func (x *DataMessage) String() string {
 func (*DataMessage) ProtoMessage() {}
 
 func (x *DataMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[29]
+	mi := &file_mq_broker_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1760,7 +1913,7 @@ func (x *DataMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataMessage.ProtoReflect.Descriptor instead.
 func (*DataMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{29}
+	return file_mq_broker_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *DataMessage) GetKey() []byte {
@@ -1804,7 +1957,7 @@ type PublishMessageRequest struct {
 
 func (x *PublishMessageRequest) Reset() {
 	*x = PublishMessageRequest{}
-	mi := &file_mq_broker_proto_msgTypes[30]
+	mi := &file_mq_broker_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1816,7 +1969,7 @@ func (x *PublishMessageRequest) String() string {
 func (*PublishMessageRequest) ProtoMessage() {}
 
 func (x *PublishMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[30]
+	mi := &file_mq_broker_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1829,7 +1982,7 @@ func (x *PublishMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishMessageRequest.ProtoReflect.Descriptor instead.
 func (*PublishMessageRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{30}
+	return file_mq_broker_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PublishMessageRequest) GetMessage() isPublishMessageRequest_Message {
@@ -1884,7 +2037,7 @@ type PublishMessageResponse struct {
 
 func (x *PublishMessageResponse) Reset() {
 	*x = PublishMessageResponse{}
-	mi := &file_mq_broker_proto_msgTypes[31]
+	mi := &file_mq_broker_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1896,7 +2049,7 @@ func (x *PublishMessageResponse) String() string {
 func (*PublishMessageResponse) ProtoMessage() {}
 
 func (x *PublishMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[31]
+	mi := &file_mq_broker_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1909,7 +2062,7 @@ func (x *PublishMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishMessageResponse.ProtoReflect.Descriptor instead.
 func (*PublishMessageResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{31}
+	return file_mq_broker_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *PublishMessageResponse) GetAckSequence() int64 {
@@ -1948,7 +2101,7 @@ type PublishFollowMeRequest struct {
 
 func (x *PublishFollowMeRequest) Reset() {
 	*x = PublishFollowMeRequest{}
-	mi := &file_mq_broker_proto_msgTypes[32]
+	mi := &file_mq_broker_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1960,7 +2113,7 @@ func (x *PublishFollowMeRequest) String() string {
 func (*PublishFollowMeRequest) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[32]
+	mi := &file_mq_broker_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1973,7 +2126,7 @@ func (x *PublishFollowMeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishFollowMeRequest.ProtoReflect.Descriptor instead.
 func (*PublishFollowMeRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{32}
+	return file_mq_broker_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *PublishFollowMeRequest) GetMessage() isPublishFollowMeRequest_Message {
@@ -2056,7 +2209,7 @@ type PublishFollowMeResponse struct {
 
 func (x *PublishFollowMeResponse) Reset() {
 	*x = PublishFollowMeResponse{}
-	mi := &file_mq_broker_proto_msgTypes[33]
+	mi := &file_mq_broker_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2068,7 +2221,7 @@ func (x *PublishFollowMeResponse) String() string {
 func (*PublishFollowMeResponse) ProtoMessage() {}
 
 func (x *PublishFollowMeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[33]
+	mi := &file_mq_broker_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2081,7 +2234,7 @@ func (x *PublishFollowMeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishFollowMeResponse.ProtoReflect.Descriptor instead.
 func (*PublishFollowMeResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{33}
+	return file_mq_broker_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *PublishFollowMeResponse) GetAckTsNs() int64 {
@@ -2104,7 +2257,7 @@ type SubscribeMessageRequest struct {
 
 func (x *SubscribeMessageRequest) Reset() {
 	*x = SubscribeMessageRequest{}
-	mi := &file_mq_broker_proto_msgTypes[34]
+	mi := &file_mq_broker_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2116,7 +2269,7 @@ func (x *SubscribeMessageRequest) String() string {
 func (*SubscribeMessageRequest) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[34]
+	mi := &file_mq_broker_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2129,7 +2282,7 @@ func (x *SubscribeMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeMessageRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{34}
+	return file_mq_broker_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *SubscribeMessageRequest) GetMessage() isSubscribeMessageRequest_Message {
@@ -2186,7 +2339,7 @@ type SubscribeMessageResponse struct {
 
 func (x *SubscribeMessageResponse) Reset() {
 	*x = SubscribeMessageResponse{}
-	mi := &file_mq_broker_proto_msgTypes[35]
+	mi := &file_mq_broker_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2198,7 +2351,7 @@ func (x *SubscribeMessageResponse) String() string {
 func (*SubscribeMessageResponse) ProtoMessage() {}
 
 func (x *SubscribeMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[35]
+	mi := &file_mq_broker_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2211,7 +2364,7 @@ func (x *SubscribeMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeMessageResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{35}
+	return file_mq_broker_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *SubscribeMessageResponse) GetMessage() isSubscribeMessageResponse_Message {
@@ -2269,7 +2422,7 @@ type SubscribeFollowMeRequest struct {
 
 func (x *SubscribeFollowMeRequest) Reset() {
 	*x = SubscribeFollowMeRequest{}
-	mi := &file_mq_broker_proto_msgTypes[36]
+	mi := &file_mq_broker_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2281,7 +2434,7 @@ func (x *SubscribeFollowMeRequest) String() string {
 func (*SubscribeFollowMeRequest) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[36]
+	mi := &file_mq_broker_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2294,7 +2447,7 @@ func (x *SubscribeFollowMeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeFollowMeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{36}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SubscribeFollowMeRequest) GetMessage() isSubscribeFollowMeRequest_Message {
@@ -2362,7 +2515,7 @@ type SubscribeFollowMeResponse struct {
 
 func (x *SubscribeFollowMeResponse) Reset() {
 	*x = SubscribeFollowMeResponse{}
-	mi := &file_mq_broker_proto_msgTypes[37]
+	mi := &file_mq_broker_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2374,7 +2527,7 @@ func (x *SubscribeFollowMeResponse) String() string {
 func (*SubscribeFollowMeResponse) ProtoMessage() {}
 
 func (x *SubscribeFollowMeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[37]
+	mi := &file_mq_broker_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2387,7 +2540,7 @@ func (x *SubscribeFollowMeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeFollowMeResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{37}
+	return file_mq_broker_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SubscribeFollowMeResponse) GetAckTsNs() int64 {
@@ -2407,7 +2560,7 @@ type ClosePublishersRequest struct {
 
 func (x *ClosePublishersRequest) Reset() {
 	*x = ClosePublishersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[38]
+	mi := &file_mq_broker_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2419,7 +2572,7 @@ func (x *ClosePublishersRequest) String() string {
 func (*ClosePublishersRequest) ProtoMessage() {}
 
 func (x *ClosePublishersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[38]
+	mi := &file_mq_broker_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2432,7 +2585,7 @@ func (x *ClosePublishersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePublishersRequest.ProtoReflect.Descriptor instead.
 func (*ClosePublishersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{38}
+	return file_mq_broker_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ClosePublishersRequest) GetTopic() *schema_pb.Topic {
@@ -2457,7 +2610,7 @@ type ClosePublishersResponse struct {
 
 func (x *ClosePublishersResponse) Reset() {
 	*x = ClosePublishersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[39]
+	mi := &file_mq_broker_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2469,7 +2622,7 @@ func (x *ClosePublishersResponse) String() string {
 func (*ClosePublishersResponse) ProtoMessage() {}
 
 func (x *ClosePublishersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[39]
+	mi := &file_mq_broker_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2482,7 +2635,7 @@ func (x *ClosePublishersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePublishersResponse.ProtoReflect.Descriptor instead.
 func (*ClosePublishersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{39}
+	return file_mq_broker_proto_rawDescGZIP(), []int{41}
 }
 
 type CloseSubscribersRequest struct {
@@ -2495,7 +2648,7 @@ type CloseSubscribersRequest struct {
 
 func (x *CloseSubscribersRequest) Reset() {
 	*x = CloseSubscribersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[40]
+	mi := &file_mq_broker_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2507,7 +2660,7 @@ func (x *CloseSubscribersRequest) String() string {
 func (*CloseSubscribersRequest) ProtoMessage() {}
 
 func (x *CloseSubscribersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[40]
+	mi := &file_mq_broker_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2520,7 +2673,7 @@ func (x *CloseSubscribersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseSubscribersRequest.ProtoReflect.Descriptor instead.
 func (*CloseSubscribersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{40}
+	return file_mq_broker_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *CloseSubscribersRequest) GetTopic() *schema_pb.Topic {
@@ -2545,7 +2698,7 @@ type CloseSubscribersResponse struct {
 
 func (x *CloseSubscribersResponse) Reset() {
 	*x = CloseSubscribersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[41]
+	mi := &file_mq_broker_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2557,7 +2710,7 @@ func (x *CloseSubscribersResponse) String() string {
 func (*CloseSubscribersResponse) ProtoMessage() {}
 
 func (x *CloseSubscribersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[41]
+	mi := &file_mq_broker_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2570,7 +2723,7 @@ func (x *CloseSubscribersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseSubscribersResponse.ProtoReflect.Descriptor instead.
 func (*CloseSubscribersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{41}
+	return file_mq_broker_proto_rawDescGZIP(), []int{43}
 }
 
 type PublisherToPubBalancerRequest_InitMessage struct {
@@ -2582,7 +2735,7 @@ type PublisherToPubBalancerRequest_InitMessage struct {
 
 func (x *PublisherToPubBalancerRequest_InitMessage) Reset() {
 	*x = PublisherToPubBalancerRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[43]
+	mi := &file_mq_broker_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2594,7 +2747,7 @@ func (x *PublisherToPubBalancerRequest_InitMessage) String() string {
 func (*PublisherToPubBalancerRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublisherToPubBalancerRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[43]
+	mi := &file_mq_broker_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2638,7 +2791,7 @@ type SubscriberToSubCoordinatorRequest_InitMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_InitMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[44]
+	mi := &file_mq_broker_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2650,7 +2803,7 @@ func (x *SubscriberToSubCoordinatorRequest_InitMessage) String() string {
 func (*SubscriberToSubCoordinatorRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[44]
+	mi := &file_mq_broker_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2663,7 +2816,7 @@ func (x *SubscriberToSubCoordinatorRequest_InitMessage) ProtoReflect() protorefl
 
 // Deprecated: Use SubscriberToSubCoordinatorRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{26, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{28, 0}
 }
 
 func (x *SubscriberToSubCoordinatorRequest_InitMessage) GetConsumerGroup() string {
@@ -2710,7 +2863,7 @@ type SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage{}
-	mi := &file_mq_broker_proto_msgTypes[45]
+	mi := &file_mq_broker_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2722,7 +2875,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) String() stri
 func (*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[45]
+	mi := &file_mq_broker_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2735,7 +2888,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) ProtoReflect(
 
 // Deprecated: Use SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{26, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{28, 1}
 }
 
 func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) GetPartition() *schema_pb.Partition {
@@ -2754,7 +2907,7 @@ type SubscriberToSubCoordinatorRequest_AckAssignmentMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_AckAssignmentMessage{}
-	mi := &file_mq_broker_proto_msgTypes[46]
+	mi := &file_mq_broker_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2766,7 +2919,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) String() string
 func (*SubscriberToSubCoordinatorRequest_AckAssignmentMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[46]
+	mi := &file_mq_broker_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2779,7 +2932,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) ProtoReflect()
 
 // Deprecated: Use SubscriberToSubCoordinatorRequest_AckAssignmentMessage.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorRequest_AckAssignmentMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{26, 2}
+	return file_mq_broker_proto_rawDescGZIP(), []int{28, 2}
 }
 
 func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) GetPartition() *schema_pb.Partition {
@@ -2798,7 +2951,7 @@ type SubscriberToSubCoordinatorResponse_Assignment struct {
 
 func (x *SubscriberToSubCoordinatorResponse_Assignment) Reset() {
 	*x = SubscriberToSubCoordinatorResponse_Assignment{}
-	mi := &file_mq_broker_proto_msgTypes[47]
+	mi := &file_mq_broker_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2810,7 +2963,7 @@ func (x *SubscriberToSubCoordinatorResponse_Assignment) String() string {
 func (*SubscriberToSubCoordinatorResponse_Assignment) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorResponse_Assignment) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[47]
+	mi := &file_mq_broker_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2823,7 +2976,7 @@ func (x *SubscriberToSubCoordinatorResponse_Assignment) ProtoReflect() protorefl
 
 // Deprecated: Use SubscriberToSubCoordinatorResponse_Assignment.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorResponse_Assignment) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{27, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *SubscriberToSubCoordinatorResponse_Assignment) GetPartitionAssignment() *BrokerPartitionAssignment {
@@ -2842,7 +2995,7 @@ type SubscriberToSubCoordinatorResponse_UnAssignment struct {
 
 func (x *SubscriberToSubCoordinatorResponse_UnAssignment) Reset() {
 	*x = SubscriberToSubCoordinatorResponse_UnAssignment{}
-	mi := &file_mq_broker_proto_msgTypes[48]
+	mi := &file_mq_broker_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2854,7 +3007,7 @@ func (x *SubscriberToSubCoordinatorResponse_UnAssignment) String() string {
 func (*SubscriberToSubCoordinatorResponse_UnAssignment) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorResponse_UnAssignment) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[48]
+	mi := &file_mq_broker_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2867,7 +3020,7 @@ func (x *SubscriberToSubCoordinatorResponse_UnAssignment) ProtoReflect() protore
 
 // Deprecated: Use SubscriberToSubCoordinatorResponse_UnAssignment.ProtoReflect.Descriptor instead.
 func (*SubscriberToSubCoordinatorResponse_UnAssignment) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{27, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{29, 1}
 }
 
 func (x *SubscriberToSubCoordinatorResponse_UnAssignment) GetPartition() *schema_pb.Partition {
@@ -2890,7 +3043,7 @@ type PublishMessageRequest_InitMessage struct {
 
 func (x *PublishMessageRequest_InitMessage) Reset() {
 	*x = PublishMessageRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[49]
+	mi := &file_mq_broker_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2902,7 +3055,7 @@ func (x *PublishMessageRequest_InitMessage) String() string {
 func (*PublishMessageRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublishMessageRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[49]
+	mi := &file_mq_broker_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2915,7 +3068,7 @@ func (x *PublishMessageRequest_InitMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PublishMessageRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*PublishMessageRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{30, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{32, 0}
 }
 
 func (x *PublishMessageRequest_InitMessage) GetTopic() *schema_pb.Topic {
@@ -2963,7 +3116,7 @@ type PublishFollowMeRequest_InitMessage struct {
 
 func (x *PublishFollowMeRequest_InitMessage) Reset() {
 	*x = PublishFollowMeRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[50]
+	mi := &file_mq_broker_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2975,7 +3128,7 @@ func (x *PublishFollowMeRequest_InitMessage) String() string {
 func (*PublishFollowMeRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[50]
+	mi := &file_mq_broker_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2988,7 +3141,7 @@ func (x *PublishFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PublishFollowMeRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*PublishFollowMeRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{32, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{34, 0}
 }
 
 func (x *PublishFollowMeRequest_InitMessage) GetTopic() *schema_pb.Topic {
@@ -3014,7 +3167,7 @@ type PublishFollowMeRequest_FlushMessage struct {
 
 func (x *PublishFollowMeRequest_FlushMessage) Reset() {
 	*x = PublishFollowMeRequest_FlushMessage{}
-	mi := &file_mq_broker_proto_msgTypes[51]
+	mi := &file_mq_broker_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3026,7 +3179,7 @@ func (x *PublishFollowMeRequest_FlushMessage) String() string {
 func (*PublishFollowMeRequest_FlushMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_FlushMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[51]
+	mi := &file_mq_broker_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3039,7 +3192,7 @@ func (x *PublishFollowMeRequest_FlushMessage) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use PublishFollowMeRequest_FlushMessage.ProtoReflect.Descriptor instead.
 func (*PublishFollowMeRequest_FlushMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{32, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{34, 1}
 }
 
 func (x *PublishFollowMeRequest_FlushMessage) GetTsNs() int64 {
@@ -3057,7 +3210,7 @@ type PublishFollowMeRequest_CloseMessage struct {
 
 func (x *PublishFollowMeRequest_CloseMessage) Reset() {
 	*x = PublishFollowMeRequest_CloseMessage{}
-	mi := &file_mq_broker_proto_msgTypes[52]
+	mi := &file_mq_broker_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3069,7 +3222,7 @@ func (x *PublishFollowMeRequest_CloseMessage) String() string {
 func (*PublishFollowMeRequest_CloseMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[52]
+	mi := &file_mq_broker_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3082,7 +3235,7 @@ func (x *PublishFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use PublishFollowMeRequest_CloseMessage.ProtoReflect.Descriptor instead.
 func (*PublishFollowMeRequest_CloseMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{32, 2}
+	return file_mq_broker_proto_rawDescGZIP(), []int{34, 2}
 }
 
 type SubscribeMessageRequest_InitMessage struct {
@@ -3102,7 +3255,7 @@ type SubscribeMessageRequest_InitMessage struct {
 
 func (x *SubscribeMessageRequest_InitMessage) Reset() {
 	*x = SubscribeMessageRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[53]
+	mi := &file_mq_broker_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3114,7 +3267,7 @@ func (x *SubscribeMessageRequest_InitMessage) String() string {
 func (*SubscribeMessageRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[53]
+	mi := &file_mq_broker_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3127,7 +3280,7 @@ func (x *SubscribeMessageRequest_InitMessage) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use SubscribeMessageRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{34, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{36, 0}
 }
 
 func (x *SubscribeMessageRequest_InitMessage) GetConsumerGroup() string {
@@ -3203,7 +3356,7 @@ type SubscribeMessageRequest_AckMessage struct {
 
 func (x *SubscribeMessageRequest_AckMessage) Reset() {
 	*x = SubscribeMessageRequest_AckMessage{}
-	mi := &file_mq_broker_proto_msgTypes[54]
+	mi := &file_mq_broker_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3215,7 +3368,7 @@ func (x *SubscribeMessageRequest_AckMessage) String() string {
 func (*SubscribeMessageRequest_AckMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest_AckMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[54]
+	mi := &file_mq_broker_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3228,7 +3381,7 @@ func (x *SubscribeMessageRequest_AckMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SubscribeMessageRequest_AckMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageRequest_AckMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{34, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{36, 1}
 }
 
 func (x *SubscribeMessageRequest_AckMessage) GetSequence() int64 {
@@ -3256,7 +3409,7 @@ type SubscribeMessageResponse_SubscribeCtrlMessage struct {
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) Reset() {
 	*x = SubscribeMessageResponse_SubscribeCtrlMessage{}
-	mi := &file_mq_broker_proto_msgTypes[55]
+	mi := &file_mq_broker_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3268,7 +3421,7 @@ func (x *SubscribeMessageResponse_SubscribeCtrlMessage) String() string {
 func (*SubscribeMessageResponse_SubscribeCtrlMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[55]
+	mi := &file_mq_broker_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3281,7 +3434,7 @@ func (x *SubscribeMessageResponse_SubscribeCtrlMessage) ProtoReflect() protorefl
 
 // Deprecated: Use SubscribeMessageResponse_SubscribeCtrlMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageResponse_SubscribeCtrlMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{35, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{37, 0}
 }
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) GetError() string {
@@ -3316,7 +3469,7 @@ type SubscribeFollowMeRequest_InitMessage struct {
 
 func (x *SubscribeFollowMeRequest_InitMessage) Reset() {
 	*x = SubscribeFollowMeRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[56]
+	mi := &file_mq_broker_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3328,7 +3481,7 @@ func (x *SubscribeFollowMeRequest_InitMessage) String() string {
 func (*SubscribeFollowMeRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[56]
+	mi := &file_mq_broker_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3341,7 +3494,7 @@ func (x *SubscribeFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SubscribeFollowMeRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{36, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38, 0}
 }
 
 func (x *SubscribeFollowMeRequest_InitMessage) GetTopic() *schema_pb.Topic {
@@ -3374,7 +3527,7 @@ type SubscribeFollowMeRequest_AckMessage struct {
 
 func (x *SubscribeFollowMeRequest_AckMessage) Reset() {
 	*x = SubscribeFollowMeRequest_AckMessage{}
-	mi := &file_mq_broker_proto_msgTypes[57]
+	mi := &file_mq_broker_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3386,7 +3539,7 @@ func (x *SubscribeFollowMeRequest_AckMessage) String() string {
 func (*SubscribeFollowMeRequest_AckMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_AckMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[57]
+	mi := &file_mq_broker_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3399,7 +3552,7 @@ func (x *SubscribeFollowMeRequest_AckMessage) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use SubscribeFollowMeRequest_AckMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_AckMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{36, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38, 1}
 }
 
 func (x *SubscribeFollowMeRequest_AckMessage) GetTsNs() int64 {
@@ -3417,7 +3570,7 @@ type SubscribeFollowMeRequest_CloseMessage struct {
 
 func (x *SubscribeFollowMeRequest_CloseMessage) Reset() {
 	*x = SubscribeFollowMeRequest_CloseMessage{}
-	mi := &file_mq_broker_proto_msgTypes[58]
+	mi := &file_mq_broker_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3429,7 +3582,7 @@ func (x *SubscribeFollowMeRequest_CloseMessage) String() string {
 func (*SubscribeFollowMeRequest_CloseMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[58]
+	mi := &file_mq_broker_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3442,7 +3595,7 @@ func (x *SubscribeFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use SubscribeFollowMeRequest_CloseMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_CloseMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{36, 2}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38, 2}
 }
 
 var File_mq_broker_proto protoreflect.FileDescriptor
@@ -3476,10 +3629,16 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\amessage\" \n" +
 	"\x1ePublisherToPubBalancerResponse\"\x16\n" +
 	"\x14BalanceTopicsRequest\"\x17\n" +
-	"\x15BalanceTopicsResponse\"W\n" +
+	"\x15BalanceTopicsResponse\"F\n" +
+	"\x1cEnforceTopicRetentionRequest\x12&\n" +
+	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\"\x1f\n" +
+	"\x1dEnforceTopicRetentionResponse\"\x89\x02\n" +
 	"\x0eTopicRetention\x12+\n" +
 	"\x11retention_seconds\x18\x01 \x01(\x03R\x10retentionSeconds\x12\x18\n" +
-	"\aenabled\x18\x02 \x01(\bR\aenabled\"\xdc\x01\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12'\n" +
+	"\x0fretention_bytes\x18\x03 \x01(\x03R\x0eretentionBytes\x12G\n" +
+	"\x0ecleanup_policy\x18\x04 \x01(\x0e2 .messaging_pb.TopicCleanupPolicyR\rcleanupPolicy\x12>\n" +
+	"\x1btombstone_retention_seconds\x18\x05 \x01(\x03R\x19tombstoneRetentionSeconds\"\xdc\x01\n" +
 	"\x15ConfigureTopicRequest\x12&\n" +
 	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\x12'\n" +
 	"\x0fpartition_count\x18\x02 \x01(\x05R\x0epartitionCount\x126\n" +
@@ -3669,11 +3828,16 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\x12 \n" +
 	"\funix_time_ns\x18\x02 \x01(\x03R\n" +
 	"unixTimeNs\"\x1a\n" +
-	"\x18CloseSubscribersResponse2\x97\x0e\n" +
+	"\x18CloseSubscribersResponse*Y\n" +
+	"\x12TopicCleanupPolicy\x12\x12\n" +
+	"\x0eCLEANUP_DELETE\x10\x00\x12\x13\n" +
+	"\x0fCLEANUP_COMPACT\x10\x01\x12\x1a\n" +
+	"\x16CLEANUP_COMPACT_DELETE\x10\x022\x8b\x0f\n" +
 	"\x10SeaweedMessaging\x12c\n" +
 	"\x10FindBrokerLeader\x12%.messaging_pb.FindBrokerLeaderRequest\x1a&.messaging_pb.FindBrokerLeaderResponse\"\x00\x12y\n" +
 	"\x16PublisherToPubBalancer\x12+.messaging_pb.PublisherToPubBalancerRequest\x1a,.messaging_pb.PublisherToPubBalancerResponse\"\x00(\x010\x01\x12Z\n" +
-	"\rBalanceTopics\x12\".messaging_pb.BalanceTopicsRequest\x1a#.messaging_pb.BalanceTopicsResponse\"\x00\x12Q\n" +
+	"\rBalanceTopics\x12\".messaging_pb.BalanceTopicsRequest\x1a#.messaging_pb.BalanceTopicsResponse\"\x00\x12r\n" +
+	"\x15EnforceTopicRetention\x12*.messaging_pb.EnforceTopicRetentionRequest\x1a+.messaging_pb.EnforceTopicRetentionResponse\"\x00\x12Q\n" +
 	"\n" +
 	"ListTopics\x12\x1f.messaging_pb.ListTopicsRequest\x1a .messaging_pb.ListTopicsResponse\"\x00\x12]\n" +
 	"\x0eConfigureTopic\x12#.messaging_pb.ConfigureTopicRequest\x1a$.messaging_pb.ConfigureTopicResponse\"\x00\x12i\n" +
@@ -3703,178 +3867,186 @@ func file_mq_broker_proto_rawDescGZIP() []byte {
 	return file_mq_broker_proto_rawDescData
 }
 
-var file_mq_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
+var file_mq_broker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mq_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_mq_broker_proto_goTypes = []any{
-	(*FindBrokerLeaderRequest)(nil),                                  // 0: messaging_pb.FindBrokerLeaderRequest
-	(*FindBrokerLeaderResponse)(nil),                                 // 1: messaging_pb.FindBrokerLeaderResponse
-	(*BrokerStats)(nil),                                              // 2: messaging_pb.BrokerStats
-	(*TopicPartitionStats)(nil),                                      // 3: messaging_pb.TopicPartitionStats
-	(*PublisherToPubBalancerRequest)(nil),                            // 4: messaging_pb.PublisherToPubBalancerRequest
-	(*PublisherToPubBalancerResponse)(nil),                           // 5: messaging_pb.PublisherToPubBalancerResponse
-	(*BalanceTopicsRequest)(nil),                                     // 6: messaging_pb.BalanceTopicsRequest
-	(*BalanceTopicsResponse)(nil),                                    // 7: messaging_pb.BalanceTopicsResponse
-	(*TopicRetention)(nil),                                           // 8: messaging_pb.TopicRetention
-	(*ConfigureTopicRequest)(nil),                                    // 9: messaging_pb.ConfigureTopicRequest
-	(*ConfigureTopicResponse)(nil),                                   // 10: messaging_pb.ConfigureTopicResponse
-	(*ListTopicsRequest)(nil),                                        // 11: messaging_pb.ListTopicsRequest
-	(*ListTopicsResponse)(nil),                                       // 12: messaging_pb.ListTopicsResponse
-	(*LookupTopicBrokersRequest)(nil),                                // 13: messaging_pb.LookupTopicBrokersRequest
-	(*LookupTopicBrokersResponse)(nil),                               // 14: messaging_pb.LookupTopicBrokersResponse
-	(*BrokerPartitionAssignment)(nil),                                // 15: messaging_pb.BrokerPartitionAssignment
-	(*GetTopicConfigurationRequest)(nil),                             // 16: messaging_pb.GetTopicConfigurationRequest
-	(*GetTopicConfigurationResponse)(nil),                            // 17: messaging_pb.GetTopicConfigurationResponse
-	(*GetTopicPublishersRequest)(nil),                                // 18: messaging_pb.GetTopicPublishersRequest
-	(*GetTopicPublishersResponse)(nil),                               // 19: messaging_pb.GetTopicPublishersResponse
-	(*GetTopicSubscribersRequest)(nil),                               // 20: messaging_pb.GetTopicSubscribersRequest
-	(*GetTopicSubscribersResponse)(nil),                              // 21: messaging_pb.GetTopicSubscribersResponse
-	(*TopicPublisher)(nil),                                           // 22: messaging_pb.TopicPublisher
-	(*TopicSubscriber)(nil),                                          // 23: messaging_pb.TopicSubscriber
-	(*AssignTopicPartitionsRequest)(nil),                             // 24: messaging_pb.AssignTopicPartitionsRequest
-	(*AssignTopicPartitionsResponse)(nil),                            // 25: messaging_pb.AssignTopicPartitionsResponse
-	(*SubscriberToSubCoordinatorRequest)(nil),                        // 26: messaging_pb.SubscriberToSubCoordinatorRequest
-	(*SubscriberToSubCoordinatorResponse)(nil),                       // 27: messaging_pb.SubscriberToSubCoordinatorResponse
-	(*ControlMessage)(nil),                                           // 28: messaging_pb.ControlMessage
-	(*DataMessage)(nil),                                              // 29: messaging_pb.DataMessage
-	(*PublishMessageRequest)(nil),                                    // 30: messaging_pb.PublishMessageRequest
-	(*PublishMessageResponse)(nil),                                   // 31: messaging_pb.PublishMessageResponse
-	(*PublishFollowMeRequest)(nil),                                   // 32: messaging_pb.PublishFollowMeRequest
-	(*PublishFollowMeResponse)(nil),                                  // 33: messaging_pb.PublishFollowMeResponse
-	(*SubscribeMessageRequest)(nil),                                  // 34: messaging_pb.SubscribeMessageRequest
-	(*SubscribeMessageResponse)(nil),                                 // 35: messaging_pb.SubscribeMessageResponse
-	(*SubscribeFollowMeRequest)(nil),                                 // 36: messaging_pb.SubscribeFollowMeRequest
-	(*SubscribeFollowMeResponse)(nil),                                // 37: messaging_pb.SubscribeFollowMeResponse
-	(*ClosePublishersRequest)(nil),                                   // 38: messaging_pb.ClosePublishersRequest
-	(*ClosePublishersResponse)(nil),                                  // 39: messaging_pb.ClosePublishersResponse
-	(*CloseSubscribersRequest)(nil),                                  // 40: messaging_pb.CloseSubscribersRequest
-	(*CloseSubscribersResponse)(nil),                                 // 41: messaging_pb.CloseSubscribersResponse
-	nil,                                                              // 42: messaging_pb.BrokerStats.StatsEntry
-	(*PublisherToPubBalancerRequest_InitMessage)(nil),                // 43: messaging_pb.PublisherToPubBalancerRequest.InitMessage
-	(*SubscriberToSubCoordinatorRequest_InitMessage)(nil),            // 44: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
-	(*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage)(nil), // 45: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
-	(*SubscriberToSubCoordinatorRequest_AckAssignmentMessage)(nil),   // 46: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
-	(*SubscriberToSubCoordinatorResponse_Assignment)(nil),            // 47: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
-	(*SubscriberToSubCoordinatorResponse_UnAssignment)(nil),          // 48: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
-	(*PublishMessageRequest_InitMessage)(nil),                        // 49: messaging_pb.PublishMessageRequest.InitMessage
-	(*PublishFollowMeRequest_InitMessage)(nil),                       // 50: messaging_pb.PublishFollowMeRequest.InitMessage
-	(*PublishFollowMeRequest_FlushMessage)(nil),                      // 51: messaging_pb.PublishFollowMeRequest.FlushMessage
-	(*PublishFollowMeRequest_CloseMessage)(nil),                      // 52: messaging_pb.PublishFollowMeRequest.CloseMessage
-	(*SubscribeMessageRequest_InitMessage)(nil),                      // 53: messaging_pb.SubscribeMessageRequest.InitMessage
-	(*SubscribeMessageRequest_AckMessage)(nil),                       // 54: messaging_pb.SubscribeMessageRequest.AckMessage
-	(*SubscribeMessageResponse_SubscribeCtrlMessage)(nil),            // 55: messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
-	(*SubscribeFollowMeRequest_InitMessage)(nil),                     // 56: messaging_pb.SubscribeFollowMeRequest.InitMessage
-	(*SubscribeFollowMeRequest_AckMessage)(nil),                      // 57: messaging_pb.SubscribeFollowMeRequest.AckMessage
-	(*SubscribeFollowMeRequest_CloseMessage)(nil),                    // 58: messaging_pb.SubscribeFollowMeRequest.CloseMessage
-	(*schema_pb.Topic)(nil),                                          // 59: schema_pb.Topic
-	(*schema_pb.Partition)(nil),                                      // 60: schema_pb.Partition
-	(*schema_pb.RecordType)(nil),                                     // 61: schema_pb.RecordType
-	(*schema_pb.PartitionOffset)(nil),                                // 62: schema_pb.PartitionOffset
-	(schema_pb.OffsetType)(0),                                        // 63: schema_pb.OffsetType
+	(TopicCleanupPolicy)(0),                                          // 0: messaging_pb.TopicCleanupPolicy
+	(*FindBrokerLeaderRequest)(nil),                                  // 1: messaging_pb.FindBrokerLeaderRequest
+	(*FindBrokerLeaderResponse)(nil),                                 // 2: messaging_pb.FindBrokerLeaderResponse
+	(*BrokerStats)(nil),                                              // 3: messaging_pb.BrokerStats
+	(*TopicPartitionStats)(nil),                                      // 4: messaging_pb.TopicPartitionStats
+	(*PublisherToPubBalancerRequest)(nil),                            // 5: messaging_pb.PublisherToPubBalancerRequest
+	(*PublisherToPubBalancerResponse)(nil),                           // 6: messaging_pb.PublisherToPubBalancerResponse
+	(*BalanceTopicsRequest)(nil),                                     // 7: messaging_pb.BalanceTopicsRequest
+	(*BalanceTopicsResponse)(nil),                                    // 8: messaging_pb.BalanceTopicsResponse
+	(*EnforceTopicRetentionRequest)(nil),                             // 9: messaging_pb.EnforceTopicRetentionRequest
+	(*EnforceTopicRetentionResponse)(nil),                            // 10: messaging_pb.EnforceTopicRetentionResponse
+	(*TopicRetention)(nil),                                           // 11: messaging_pb.TopicRetention
+	(*ConfigureTopicRequest)(nil),                                    // 12: messaging_pb.ConfigureTopicRequest
+	(*ConfigureTopicResponse)(nil),                                   // 13: messaging_pb.ConfigureTopicResponse
+	(*ListTopicsRequest)(nil),                                        // 14: messaging_pb.ListTopicsRequest
+	(*ListTopicsResponse)(nil),                                       // 15: messaging_pb.ListTopicsResponse
+	(*LookupTopicBrokersRequest)(nil),                                // 16: messaging_pb.LookupTopicBrokersRequest
+	(*LookupTopicBrokersResponse)(nil),                               // 17: messaging_pb.LookupTopicBrokersResponse
+	(*BrokerPartitionAssignment)(nil),                                // 18: messaging_pb.BrokerPartitionAssignment
+	(*GetTopicConfigurationRequest)(nil),                             // 19: messaging_pb.GetTopicConfigurationRequest
+	(*GetTopicConfigurationResponse)(nil),                            // 20: messaging_pb.GetTopicConfigurationResponse
+	(*GetTopicPublishersRequest)(nil),                                // 21: messaging_pb.GetTopicPublishersRequest
+	(*GetTopicPublishersResponse)(nil),                               // 22: messaging_pb.GetTopicPublishersResponse
+	(*GetTopicSubscribersRequest)(nil),                               // 23: messaging_pb.GetTopicSubscribersRequest
+	(*GetTopicSubscribersResponse)(nil),                              // 24: messaging_pb.GetTopicSubscribersResponse
+	(*TopicPublisher)(nil),                                           // 25: messaging_pb.TopicPublisher
+	(*TopicSubscriber)(nil),                                          // 26: messaging_pb.TopicSubscriber
+	(*AssignTopicPartitionsRequest)(nil),                             // 27: messaging_pb.AssignTopicPartitionsRequest
+	(*AssignTopicPartitionsResponse)(nil),                            // 28: messaging_pb.AssignTopicPartitionsResponse
+	(*SubscriberToSubCoordinatorRequest)(nil),                        // 29: messaging_pb.SubscriberToSubCoordinatorRequest
+	(*SubscriberToSubCoordinatorResponse)(nil),                       // 30: messaging_pb.SubscriberToSubCoordinatorResponse
+	(*ControlMessage)(nil),                                           // 31: messaging_pb.ControlMessage
+	(*DataMessage)(nil),                                              // 32: messaging_pb.DataMessage
+	(*PublishMessageRequest)(nil),                                    // 33: messaging_pb.PublishMessageRequest
+	(*PublishMessageResponse)(nil),                                   // 34: messaging_pb.PublishMessageResponse
+	(*PublishFollowMeRequest)(nil),                                   // 35: messaging_pb.PublishFollowMeRequest
+	(*PublishFollowMeResponse)(nil),                                  // 36: messaging_pb.PublishFollowMeResponse
+	(*SubscribeMessageRequest)(nil),                                  // 37: messaging_pb.SubscribeMessageRequest
+	(*SubscribeMessageResponse)(nil),                                 // 38: messaging_pb.SubscribeMessageResponse
+	(*SubscribeFollowMeRequest)(nil),                                 // 39: messaging_pb.SubscribeFollowMeRequest
+	(*SubscribeFollowMeResponse)(nil),                                // 40: messaging_pb.SubscribeFollowMeResponse
+	(*ClosePublishersRequest)(nil),                                   // 41: messaging_pb.ClosePublishersRequest
+	(*ClosePublishersResponse)(nil),                                  // 42: messaging_pb.ClosePublishersResponse
+	(*CloseSubscribersRequest)(nil),                                  // 43: messaging_pb.CloseSubscribersRequest
+	(*CloseSubscribersResponse)(nil),                                 // 44: messaging_pb.CloseSubscribersResponse
+	nil,                                                              // 45: messaging_pb.BrokerStats.StatsEntry
+	(*PublisherToPubBalancerRequest_InitMessage)(nil),                // 46: messaging_pb.PublisherToPubBalancerRequest.InitMessage
+	(*SubscriberToSubCoordinatorRequest_InitMessage)(nil),            // 47: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
+	(*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage)(nil), // 48: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
+	(*SubscriberToSubCoordinatorRequest_AckAssignmentMessage)(nil),   // 49: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
+	(*SubscriberToSubCoordinatorResponse_Assignment)(nil),            // 50: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
+	(*SubscriberToSubCoordinatorResponse_UnAssignment)(nil),          // 51: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
+	(*PublishMessageRequest_InitMessage)(nil),                        // 52: messaging_pb.PublishMessageRequest.InitMessage
+	(*PublishFollowMeRequest_InitMessage)(nil),                       // 53: messaging_pb.PublishFollowMeRequest.InitMessage
+	(*PublishFollowMeRequest_FlushMessage)(nil),                      // 54: messaging_pb.PublishFollowMeRequest.FlushMessage
+	(*PublishFollowMeRequest_CloseMessage)(nil),                      // 55: messaging_pb.PublishFollowMeRequest.CloseMessage
+	(*SubscribeMessageRequest_InitMessage)(nil),                      // 56: messaging_pb.SubscribeMessageRequest.InitMessage
+	(*SubscribeMessageRequest_AckMessage)(nil),                       // 57: messaging_pb.SubscribeMessageRequest.AckMessage
+	(*SubscribeMessageResponse_SubscribeCtrlMessage)(nil),            // 58: messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
+	(*SubscribeFollowMeRequest_InitMessage)(nil),                     // 59: messaging_pb.SubscribeFollowMeRequest.InitMessage
+	(*SubscribeFollowMeRequest_AckMessage)(nil),                      // 60: messaging_pb.SubscribeFollowMeRequest.AckMessage
+	(*SubscribeFollowMeRequest_CloseMessage)(nil),                    // 61: messaging_pb.SubscribeFollowMeRequest.CloseMessage
+	(*schema_pb.Topic)(nil),                                          // 62: schema_pb.Topic
+	(*schema_pb.Partition)(nil),                                      // 63: schema_pb.Partition
+	(*schema_pb.RecordType)(nil),                                     // 64: schema_pb.RecordType
+	(*schema_pb.PartitionOffset)(nil),                                // 65: schema_pb.PartitionOffset
+	(schema_pb.OffsetType)(0),                                        // 66: schema_pb.OffsetType
 }
 var file_mq_broker_proto_depIdxs = []int32{
-	42, // 0: messaging_pb.BrokerStats.stats:type_name -> messaging_pb.BrokerStats.StatsEntry
-	59, // 1: messaging_pb.TopicPartitionStats.topic:type_name -> schema_pb.Topic
-	60, // 2: messaging_pb.TopicPartitionStats.partition:type_name -> schema_pb.Partition
-	43, // 3: messaging_pb.PublisherToPubBalancerRequest.init:type_name -> messaging_pb.PublisherToPubBalancerRequest.InitMessage
-	2,  // 4: messaging_pb.PublisherToPubBalancerRequest.stats:type_name -> messaging_pb.BrokerStats
-	59, // 5: messaging_pb.ConfigureTopicRequest.topic:type_name -> schema_pb.Topic
-	61, // 6: messaging_pb.ConfigureTopicRequest.record_type:type_name -> schema_pb.RecordType
-	8,  // 7: messaging_pb.ConfigureTopicRequest.retention:type_name -> messaging_pb.TopicRetention
-	15, // 8: messaging_pb.ConfigureTopicResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	61, // 9: messaging_pb.ConfigureTopicResponse.record_type:type_name -> schema_pb.RecordType
-	8,  // 10: messaging_pb.ConfigureTopicResponse.retention:type_name -> messaging_pb.TopicRetention
-	59, // 11: messaging_pb.ListTopicsResponse.topics:type_name -> schema_pb.Topic
-	59, // 12: messaging_pb.LookupTopicBrokersRequest.topic:type_name -> schema_pb.Topic
-	59, // 13: messaging_pb.LookupTopicBrokersResponse.topic:type_name -> schema_pb.Topic
-	15, // 14: messaging_pb.LookupTopicBrokersResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	60, // 15: messaging_pb.BrokerPartitionAssignment.partition:type_name -> schema_pb.Partition
-	59, // 16: messaging_pb.GetTopicConfigurationRequest.topic:type_name -> schema_pb.Topic
-	59, // 17: messaging_pb.GetTopicConfigurationResponse.topic:type_name -> schema_pb.Topic
-	61, // 18: messaging_pb.GetTopicConfigurationResponse.record_type:type_name -> schema_pb.RecordType
-	15, // 19: messaging_pb.GetTopicConfigurationResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	8,  // 20: messaging_pb.GetTopicConfigurationResponse.retention:type_name -> messaging_pb.TopicRetention
-	59, // 21: messaging_pb.GetTopicPublishersRequest.topic:type_name -> schema_pb.Topic
-	22, // 22: messaging_pb.GetTopicPublishersResponse.publishers:type_name -> messaging_pb.TopicPublisher
-	59, // 23: messaging_pb.GetTopicSubscribersRequest.topic:type_name -> schema_pb.Topic
-	23, // 24: messaging_pb.GetTopicSubscribersResponse.subscribers:type_name -> messaging_pb.TopicSubscriber
-	60, // 25: messaging_pb.TopicPublisher.partition:type_name -> schema_pb.Partition
-	60, // 26: messaging_pb.TopicSubscriber.partition:type_name -> schema_pb.Partition
-	59, // 27: messaging_pb.AssignTopicPartitionsRequest.topic:type_name -> schema_pb.Topic
-	15, // 28: messaging_pb.AssignTopicPartitionsRequest.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	44, // 29: messaging_pb.SubscriberToSubCoordinatorRequest.init:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
-	46, // 30: messaging_pb.SubscriberToSubCoordinatorRequest.ack_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
-	45, // 31: messaging_pb.SubscriberToSubCoordinatorRequest.ack_un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
-	47, // 32: messaging_pb.SubscriberToSubCoordinatorResponse.assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
-	48, // 33: messaging_pb.SubscriberToSubCoordinatorResponse.un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
-	28, // 34: messaging_pb.DataMessage.ctrl:type_name -> messaging_pb.ControlMessage
-	49, // 35: messaging_pb.PublishMessageRequest.init:type_name -> messaging_pb.PublishMessageRequest.InitMessage
-	29, // 36: messaging_pb.PublishMessageRequest.data:type_name -> messaging_pb.DataMessage
-	50, // 37: messaging_pb.PublishFollowMeRequest.init:type_name -> messaging_pb.PublishFollowMeRequest.InitMessage
-	29, // 38: messaging_pb.PublishFollowMeRequest.data:type_name -> messaging_pb.DataMessage
-	51, // 39: messaging_pb.PublishFollowMeRequest.flush:type_name -> messaging_pb.PublishFollowMeRequest.FlushMessage
-	52, // 40: messaging_pb.PublishFollowMeRequest.close:type_name -> messaging_pb.PublishFollowMeRequest.CloseMessage
-	53, // 41: messaging_pb.SubscribeMessageRequest.init:type_name -> messaging_pb.SubscribeMessageRequest.InitMessage
-	54, // 42: messaging_pb.SubscribeMessageRequest.ack:type_name -> messaging_pb.SubscribeMessageRequest.AckMessage
-	55, // 43: messaging_pb.SubscribeMessageResponse.ctrl:type_name -> messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
-	29, // 44: messaging_pb.SubscribeMessageResponse.data:type_name -> messaging_pb.DataMessage
-	56, // 45: messaging_pb.SubscribeFollowMeRequest.init:type_name -> messaging_pb.SubscribeFollowMeRequest.InitMessage
-	57, // 46: messaging_pb.SubscribeFollowMeRequest.ack:type_name -> messaging_pb.SubscribeFollowMeRequest.AckMessage
-	58, // 47: messaging_pb.SubscribeFollowMeRequest.close:type_name -> messaging_pb.SubscribeFollowMeRequest.CloseMessage
-	59, // 48: messaging_pb.ClosePublishersRequest.topic:type_name -> schema_pb.Topic
-	59, // 49: messaging_pb.CloseSubscribersRequest.topic:type_name -> schema_pb.Topic
-	3,  // 50: messaging_pb.BrokerStats.StatsEntry.value:type_name -> messaging_pb.TopicPartitionStats
-	59, // 51: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	60, // 52: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage.partition:type_name -> schema_pb.Partition
-	60, // 53: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage.partition:type_name -> schema_pb.Partition
-	15, // 54: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment.partition_assignment:type_name -> messaging_pb.BrokerPartitionAssignment
-	60, // 55: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment.partition:type_name -> schema_pb.Partition
-	59, // 56: messaging_pb.PublishMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	60, // 57: messaging_pb.PublishMessageRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	59, // 58: messaging_pb.PublishFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	60, // 59: messaging_pb.PublishFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	59, // 60: messaging_pb.SubscribeMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	62, // 61: messaging_pb.SubscribeMessageRequest.InitMessage.partition_offset:type_name -> schema_pb.PartitionOffset
-	63, // 62: messaging_pb.SubscribeMessageRequest.InitMessage.offset_type:type_name -> schema_pb.OffsetType
-	59, // 63: messaging_pb.SubscribeFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	60, // 64: messaging_pb.SubscribeFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	0,  // 65: messaging_pb.SeaweedMessaging.FindBrokerLeader:input_type -> messaging_pb.FindBrokerLeaderRequest
-	4,  // 66: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:input_type -> messaging_pb.PublisherToPubBalancerRequest
-	6,  // 67: messaging_pb.SeaweedMessaging.BalanceTopics:input_type -> messaging_pb.BalanceTopicsRequest
-	11, // 68: messaging_pb.SeaweedMessaging.ListTopics:input_type -> messaging_pb.ListTopicsRequest
-	9,  // 69: messaging_pb.SeaweedMessaging.ConfigureTopic:input_type -> messaging_pb.ConfigureTopicRequest
-	13, // 70: messaging_pb.SeaweedMessaging.LookupTopicBrokers:input_type -> messaging_pb.LookupTopicBrokersRequest
-	16, // 71: messaging_pb.SeaweedMessaging.GetTopicConfiguration:input_type -> messaging_pb.GetTopicConfigurationRequest
-	18, // 72: messaging_pb.SeaweedMessaging.GetTopicPublishers:input_type -> messaging_pb.GetTopicPublishersRequest
-	20, // 73: messaging_pb.SeaweedMessaging.GetTopicSubscribers:input_type -> messaging_pb.GetTopicSubscribersRequest
-	24, // 74: messaging_pb.SeaweedMessaging.AssignTopicPartitions:input_type -> messaging_pb.AssignTopicPartitionsRequest
-	38, // 75: messaging_pb.SeaweedMessaging.ClosePublishers:input_type -> messaging_pb.ClosePublishersRequest
-	40, // 76: messaging_pb.SeaweedMessaging.CloseSubscribers:input_type -> messaging_pb.CloseSubscribersRequest
-	26, // 77: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:input_type -> messaging_pb.SubscriberToSubCoordinatorRequest
-	30, // 78: messaging_pb.SeaweedMessaging.PublishMessage:input_type -> messaging_pb.PublishMessageRequest
-	34, // 79: messaging_pb.SeaweedMessaging.SubscribeMessage:input_type -> messaging_pb.SubscribeMessageRequest
-	32, // 80: messaging_pb.SeaweedMessaging.PublishFollowMe:input_type -> messaging_pb.PublishFollowMeRequest
-	36, // 81: messaging_pb.SeaweedMessaging.SubscribeFollowMe:input_type -> messaging_pb.SubscribeFollowMeRequest
-	1,  // 82: messaging_pb.SeaweedMessaging.FindBrokerLeader:output_type -> messaging_pb.FindBrokerLeaderResponse
-	5,  // 83: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:output_type -> messaging_pb.PublisherToPubBalancerResponse
-	7,  // 84: messaging_pb.SeaweedMessaging.BalanceTopics:output_type -> messaging_pb.BalanceTopicsResponse
-	12, // 85: messaging_pb.SeaweedMessaging.ListTopics:output_type -> messaging_pb.ListTopicsResponse
-	10, // 86: messaging_pb.SeaweedMessaging.ConfigureTopic:output_type -> messaging_pb.ConfigureTopicResponse
-	14, // 87: messaging_pb.SeaweedMessaging.LookupTopicBrokers:output_type -> messaging_pb.LookupTopicBrokersResponse
-	17, // 88: messaging_pb.SeaweedMessaging.GetTopicConfiguration:output_type -> messaging_pb.GetTopicConfigurationResponse
-	19, // 89: messaging_pb.SeaweedMessaging.GetTopicPublishers:output_type -> messaging_pb.GetTopicPublishersResponse
-	21, // 90: messaging_pb.SeaweedMessaging.GetTopicSubscribers:output_type -> messaging_pb.GetTopicSubscribersResponse
-	25, // 91: messaging_pb.SeaweedMessaging.AssignTopicPartitions:output_type -> messaging_pb.AssignTopicPartitionsResponse
-	39, // 92: messaging_pb.SeaweedMessaging.ClosePublishers:output_type -> messaging_pb.ClosePublishersResponse
-	41, // 93: messaging_pb.SeaweedMessaging.CloseSubscribers:output_type -> messaging_pb.CloseSubscribersResponse
-	27, // 94: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:output_type -> messaging_pb.SubscriberToSubCoordinatorResponse
-	31, // 95: messaging_pb.SeaweedMessaging.PublishMessage:output_type -> messaging_pb.PublishMessageResponse
-	35, // 96: messaging_pb.SeaweedMessaging.SubscribeMessage:output_type -> messaging_pb.SubscribeMessageResponse
-	33, // 97: messaging_pb.SeaweedMessaging.PublishFollowMe:output_type -> messaging_pb.PublishFollowMeResponse
-	37, // 98: messaging_pb.SeaweedMessaging.SubscribeFollowMe:output_type -> messaging_pb.SubscribeFollowMeResponse
-	82, // [82:99] is the sub-list for method output_type
-	65, // [65:82] is the sub-list for method input_type
-	65, // [65:65] is the sub-list for extension type_name
-	65, // [65:65] is the sub-list for extension extendee
-	0,  // [0:65] is the sub-list for field type_name
+	45, // 0: messaging_pb.BrokerStats.stats:type_name -> messaging_pb.BrokerStats.StatsEntry
+	62, // 1: messaging_pb.TopicPartitionStats.topic:type_name -> schema_pb.Topic
+	63, // 2: messaging_pb.TopicPartitionStats.partition:type_name -> schema_pb.Partition
+	46, // 3: messaging_pb.PublisherToPubBalancerRequest.init:type_name -> messaging_pb.PublisherToPubBalancerRequest.InitMessage
+	3,  // 4: messaging_pb.PublisherToPubBalancerRequest.stats:type_name -> messaging_pb.BrokerStats
+	62, // 5: messaging_pb.EnforceTopicRetentionRequest.topic:type_name -> schema_pb.Topic
+	0,  // 6: messaging_pb.TopicRetention.cleanup_policy:type_name -> messaging_pb.TopicCleanupPolicy
+	62, // 7: messaging_pb.ConfigureTopicRequest.topic:type_name -> schema_pb.Topic
+	64, // 8: messaging_pb.ConfigureTopicRequest.record_type:type_name -> schema_pb.RecordType
+	11, // 9: messaging_pb.ConfigureTopicRequest.retention:type_name -> messaging_pb.TopicRetention
+	18, // 10: messaging_pb.ConfigureTopicResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	64, // 11: messaging_pb.ConfigureTopicResponse.record_type:type_name -> schema_pb.RecordType
+	11, // 12: messaging_pb.ConfigureTopicResponse.retention:type_name -> messaging_pb.TopicRetention
+	62, // 13: messaging_pb.ListTopicsResponse.topics:type_name -> schema_pb.Topic
+	62, // 14: messaging_pb.LookupTopicBrokersRequest.topic:type_name -> schema_pb.Topic
+	62, // 15: messaging_pb.LookupTopicBrokersResponse.topic:type_name -> schema_pb.Topic
+	18, // 16: messaging_pb.LookupTopicBrokersResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	63, // 17: messaging_pb.BrokerPartitionAssignment.partition:type_name -> schema_pb.Partition
+	62, // 18: messaging_pb.GetTopicConfigurationRequest.topic:type_name -> schema_pb.Topic
+	62, // 19: messaging_pb.GetTopicConfigurationResponse.topic:type_name -> schema_pb.Topic
+	64, // 20: messaging_pb.GetTopicConfigurationResponse.record_type:type_name -> schema_pb.RecordType
+	18, // 21: messaging_pb.GetTopicConfigurationResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	11, // 22: messaging_pb.GetTopicConfigurationResponse.retention:type_name -> messaging_pb.TopicRetention
+	62, // 23: messaging_pb.GetTopicPublishersRequest.topic:type_name -> schema_pb.Topic
+	25, // 24: messaging_pb.GetTopicPublishersResponse.publishers:type_name -> messaging_pb.TopicPublisher
+	62, // 25: messaging_pb.GetTopicSubscribersRequest.topic:type_name -> schema_pb.Topic
+	26, // 26: messaging_pb.GetTopicSubscribersResponse.subscribers:type_name -> messaging_pb.TopicSubscriber
+	63, // 27: messaging_pb.TopicPublisher.partition:type_name -> schema_pb.Partition
+	63, // 28: messaging_pb.TopicSubscriber.partition:type_name -> schema_pb.Partition
+	62, // 29: messaging_pb.AssignTopicPartitionsRequest.topic:type_name -> schema_pb.Topic
+	18, // 30: messaging_pb.AssignTopicPartitionsRequest.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	47, // 31: messaging_pb.SubscriberToSubCoordinatorRequest.init:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
+	49, // 32: messaging_pb.SubscriberToSubCoordinatorRequest.ack_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
+	48, // 33: messaging_pb.SubscriberToSubCoordinatorRequest.ack_un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
+	50, // 34: messaging_pb.SubscriberToSubCoordinatorResponse.assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
+	51, // 35: messaging_pb.SubscriberToSubCoordinatorResponse.un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
+	31, // 36: messaging_pb.DataMessage.ctrl:type_name -> messaging_pb.ControlMessage
+	52, // 37: messaging_pb.PublishMessageRequest.init:type_name -> messaging_pb.PublishMessageRequest.InitMessage
+	32, // 38: messaging_pb.PublishMessageRequest.data:type_name -> messaging_pb.DataMessage
+	53, // 39: messaging_pb.PublishFollowMeRequest.init:type_name -> messaging_pb.PublishFollowMeRequest.InitMessage
+	32, // 40: messaging_pb.PublishFollowMeRequest.data:type_name -> messaging_pb.DataMessage
+	54, // 41: messaging_pb.PublishFollowMeRequest.flush:type_name -> messaging_pb.PublishFollowMeRequest.FlushMessage
+	55, // 42: messaging_pb.PublishFollowMeRequest.close:type_name -> messaging_pb.PublishFollowMeRequest.CloseMessage
+	56, // 43: messaging_pb.SubscribeMessageRequest.init:type_name -> messaging_pb.SubscribeMessageRequest.InitMessage
+	57, // 44: messaging_pb.SubscribeMessageRequest.ack:type_name -> messaging_pb.SubscribeMessageRequest.AckMessage
+	58, // 45: messaging_pb.SubscribeMessageResponse.ctrl:type_name -> messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
+	32, // 46: messaging_pb.SubscribeMessageResponse.data:type_name -> messaging_pb.DataMessage
+	59, // 47: messaging_pb.SubscribeFollowMeRequest.init:type_name -> messaging_pb.SubscribeFollowMeRequest.InitMessage
+	60, // 48: messaging_pb.SubscribeFollowMeRequest.ack:type_name -> messaging_pb.SubscribeFollowMeRequest.AckMessage
+	61, // 49: messaging_pb.SubscribeFollowMeRequest.close:type_name -> messaging_pb.SubscribeFollowMeRequest.CloseMessage
+	62, // 50: messaging_pb.ClosePublishersRequest.topic:type_name -> schema_pb.Topic
+	62, // 51: messaging_pb.CloseSubscribersRequest.topic:type_name -> schema_pb.Topic
+	4,  // 52: messaging_pb.BrokerStats.StatsEntry.value:type_name -> messaging_pb.TopicPartitionStats
+	62, // 53: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	63, // 54: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage.partition:type_name -> schema_pb.Partition
+	63, // 55: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage.partition:type_name -> schema_pb.Partition
+	18, // 56: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment.partition_assignment:type_name -> messaging_pb.BrokerPartitionAssignment
+	63, // 57: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment.partition:type_name -> schema_pb.Partition
+	62, // 58: messaging_pb.PublishMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	63, // 59: messaging_pb.PublishMessageRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	62, // 60: messaging_pb.PublishFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	63, // 61: messaging_pb.PublishFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	62, // 62: messaging_pb.SubscribeMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	65, // 63: messaging_pb.SubscribeMessageRequest.InitMessage.partition_offset:type_name -> schema_pb.PartitionOffset
+	66, // 64: messaging_pb.SubscribeMessageRequest.InitMessage.offset_type:type_name -> schema_pb.OffsetType
+	62, // 65: messaging_pb.SubscribeFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	63, // 66: messaging_pb.SubscribeFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	1,  // 67: messaging_pb.SeaweedMessaging.FindBrokerLeader:input_type -> messaging_pb.FindBrokerLeaderRequest
+	5,  // 68: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:input_type -> messaging_pb.PublisherToPubBalancerRequest
+	7,  // 69: messaging_pb.SeaweedMessaging.BalanceTopics:input_type -> messaging_pb.BalanceTopicsRequest
+	9,  // 70: messaging_pb.SeaweedMessaging.EnforceTopicRetention:input_type -> messaging_pb.EnforceTopicRetentionRequest
+	14, // 71: messaging_pb.SeaweedMessaging.ListTopics:input_type -> messaging_pb.ListTopicsRequest
+	12, // 72: messaging_pb.SeaweedMessaging.ConfigureTopic:input_type -> messaging_pb.ConfigureTopicRequest
+	16, // 73: messaging_pb.SeaweedMessaging.LookupTopicBrokers:input_type -> messaging_pb.LookupTopicBrokersRequest
+	19, // 74: messaging_pb.SeaweedMessaging.GetTopicConfiguration:input_type -> messaging_pb.GetTopicConfigurationRequest
+	21, // 75: messaging_pb.SeaweedMessaging.GetTopicPublishers:input_type -> messaging_pb.GetTopicPublishersRequest
+	23, // 76: messaging_pb.SeaweedMessaging.GetTopicSubscribers:input_type -> messaging_pb.GetTopicSubscribersRequest
+	27, // 77: messaging_pb.SeaweedMessaging.AssignTopicPartitions:input_type -> messaging_pb.AssignTopicPartitionsRequest
+	41, // 78: messaging_pb.SeaweedMessaging.ClosePublishers:input_type -> messaging_pb.ClosePublishersRequest
+	43, // 79: messaging_pb.SeaweedMessaging.CloseSubscribers:input_type -> messaging_pb.CloseSubscribersRequest
+	29, // 80: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:input_type -> messaging_pb.SubscriberToSubCoordinatorRequest
+	33, // 81: messaging_pb.SeaweedMessaging.PublishMessage:input_type -> messaging_pb.PublishMessageRequest
+	37, // 82: messaging_pb.SeaweedMessaging.SubscribeMessage:input_type -> messaging_pb.SubscribeMessageRequest
+	35, // 83: messaging_pb.SeaweedMessaging.PublishFollowMe:input_type -> messaging_pb.PublishFollowMeRequest
+	39, // 84: messaging_pb.SeaweedMessaging.SubscribeFollowMe:input_type -> messaging_pb.SubscribeFollowMeRequest
+	2,  // 85: messaging_pb.SeaweedMessaging.FindBrokerLeader:output_type -> messaging_pb.FindBrokerLeaderResponse
+	6,  // 86: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:output_type -> messaging_pb.PublisherToPubBalancerResponse
+	8,  // 87: messaging_pb.SeaweedMessaging.BalanceTopics:output_type -> messaging_pb.BalanceTopicsResponse
+	10, // 88: messaging_pb.SeaweedMessaging.EnforceTopicRetention:output_type -> messaging_pb.EnforceTopicRetentionResponse
+	15, // 89: messaging_pb.SeaweedMessaging.ListTopics:output_type -> messaging_pb.ListTopicsResponse
+	13, // 90: messaging_pb.SeaweedMessaging.ConfigureTopic:output_type -> messaging_pb.ConfigureTopicResponse
+	17, // 91: messaging_pb.SeaweedMessaging.LookupTopicBrokers:output_type -> messaging_pb.LookupTopicBrokersResponse
+	20, // 92: messaging_pb.SeaweedMessaging.GetTopicConfiguration:output_type -> messaging_pb.GetTopicConfigurationResponse
+	22, // 93: messaging_pb.SeaweedMessaging.GetTopicPublishers:output_type -> messaging_pb.GetTopicPublishersResponse
+	24, // 94: messaging_pb.SeaweedMessaging.GetTopicSubscribers:output_type -> messaging_pb.GetTopicSubscribersResponse
+	28, // 95: messaging_pb.SeaweedMessaging.AssignTopicPartitions:output_type -> messaging_pb.AssignTopicPartitionsResponse
+	42, // 96: messaging_pb.SeaweedMessaging.ClosePublishers:output_type -> messaging_pb.ClosePublishersResponse
+	44, // 97: messaging_pb.SeaweedMessaging.CloseSubscribers:output_type -> messaging_pb.CloseSubscribersResponse
+	30, // 98: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:output_type -> messaging_pb.SubscriberToSubCoordinatorResponse
+	34, // 99: messaging_pb.SeaweedMessaging.PublishMessage:output_type -> messaging_pb.PublishMessageResponse
+	38, // 100: messaging_pb.SeaweedMessaging.SubscribeMessage:output_type -> messaging_pb.SubscribeMessageResponse
+	36, // 101: messaging_pb.SeaweedMessaging.PublishFollowMe:output_type -> messaging_pb.PublishFollowMeResponse
+	40, // 102: messaging_pb.SeaweedMessaging.SubscribeFollowMe:output_type -> messaging_pb.SubscribeFollowMeResponse
+	85, // [85:103] is the sub-list for method output_type
+	67, // [67:85] is the sub-list for method input_type
+	67, // [67:67] is the sub-list for extension type_name
+	67, // [67:67] is the sub-list for extension extendee
+	0,  // [0:67] is the sub-list for field type_name
 }
 
 func init() { file_mq_broker_proto_init() }
@@ -3886,34 +4058,34 @@ func file_mq_broker_proto_init() {
 		(*PublisherToPubBalancerRequest_Init)(nil),
 		(*PublisherToPubBalancerRequest_Stats)(nil),
 	}
-	file_mq_broker_proto_msgTypes[26].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[28].OneofWrappers = []any{
 		(*SubscriberToSubCoordinatorRequest_Init)(nil),
 		(*SubscriberToSubCoordinatorRequest_AckAssignment)(nil),
 		(*SubscriberToSubCoordinatorRequest_AckUnAssignment)(nil),
 	}
-	file_mq_broker_proto_msgTypes[27].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[29].OneofWrappers = []any{
 		(*SubscriberToSubCoordinatorResponse_Assignment_)(nil),
 		(*SubscriberToSubCoordinatorResponse_UnAssignment_)(nil),
 	}
-	file_mq_broker_proto_msgTypes[30].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[32].OneofWrappers = []any{
 		(*PublishMessageRequest_Init)(nil),
 		(*PublishMessageRequest_Data)(nil),
 	}
-	file_mq_broker_proto_msgTypes[32].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[34].OneofWrappers = []any{
 		(*PublishFollowMeRequest_Init)(nil),
 		(*PublishFollowMeRequest_Data)(nil),
 		(*PublishFollowMeRequest_Flush)(nil),
 		(*PublishFollowMeRequest_Close)(nil),
 	}
-	file_mq_broker_proto_msgTypes[34].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[36].OneofWrappers = []any{
 		(*SubscribeMessageRequest_Init)(nil),
 		(*SubscribeMessageRequest_Ack)(nil),
 	}
-	file_mq_broker_proto_msgTypes[35].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[37].OneofWrappers = []any{
 		(*SubscribeMessageResponse_Ctrl)(nil),
 		(*SubscribeMessageResponse_Data)(nil),
 	}
-	file_mq_broker_proto_msgTypes[36].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[38].OneofWrappers = []any{
 		(*SubscribeFollowMeRequest_Init)(nil),
 		(*SubscribeFollowMeRequest_Ack)(nil),
 		(*SubscribeFollowMeRequest_Close)(nil),
@@ -3923,13 +4095,14 @@ func file_mq_broker_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mq_broker_proto_rawDesc), len(file_mq_broker_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   59,
+			NumEnums:      1,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_mq_broker_proto_goTypes,
 		DependencyIndexes: file_mq_broker_proto_depIdxs,
+		EnumInfos:         file_mq_broker_proto_enumTypes,
 		MessageInfos:      file_mq_broker_proto_msgTypes,
 	}.Build()
 	File_mq_broker_proto = out.File
@@ -22,6 +22,7 @@ const (
 	SeaweedMessaging_FindBrokerLeader_FullMethodName           = "/messaging_pb.SeaweedMessaging/FindBrokerLeader"
 	SeaweedMessaging_PublisherToPubBalancer_FullMethodName     = "/messaging_pb.SeaweedMessaging/PublisherToPubBalancer"
 	SeaweedMessaging_BalanceTopics_FullMethodName              = "/messaging_pb.SeaweedMessaging/BalanceTopics"
+	SeaweedMessaging_EnforceTopicRetention_FullMethodName      = "/messaging_pb.SeaweedMessaging/EnforceTopicRetention"
 	SeaweedMessaging_ListTopics_FullMethodName                 = "/messaging_pb.SeaweedMessaging/ListTopics"
 	SeaweedMessaging_ConfigureTopic_FullMethodName             = "/messaging_pb.SeaweedMessaging/ConfigureTopic"
 	SeaweedMessaging_LookupTopicBrokers_FullMethodName         = "/messaging_pb.SeaweedMessaging/LookupTopicBrokers"
@@ -47,6 +48,7 @@ type SeaweedMessagingClient interface {
 	// control plane for balancer
 	PublisherToPubBalancer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PublisherToPubBalancerRequest, PublisherToPubBalancerResponse], error)
 	BalanceTopics(ctx context.Context, in *BalanceTopicsRequest, opts ...grpc.CallOption) (*BalanceTopicsResponse, error)
+	EnforceTopicRetention(ctx context.Context, in *EnforceTopicRetentionRequest, opts ...grpc.CallOption) (*EnforceTopicRetentionResponse, error)
 	// control plane for topic partitions
 	ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error)
 	ConfigureTopic(ctx context.Context, in *ConfigureTopicRequest, opts ...grpc.CallOption) (*ConfigureTopicResponse, error)
@@ -109,6 +111,16 @@ func (c *seaweedMessagingClient) BalanceTopics(ctx context.Context, in *BalanceT
 	return out, nil
 }
 
+func (c *seaweedMessagingClient) EnforceTopicRetention(ctx context.Context, in *EnforceTopicRetentionRequest, opts ...grpc.CallOption) (*EnforceTopicRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnforceTopicRetentionResponse)
+	err := c.cc.Invoke(ctx, SeaweedMessaging_EnforceTopicRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *seaweedMessagingClient) ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListTopicsResponse)
@@ -273,6 +285,7 @@ type SeaweedMessagingServer interface {
 	// control plane for balancer
 	PublisherToPubBalancer(grpc.BidiStreamingServer[PublisherToPubBalancerRequest, PublisherToPubBalancerResponse]) error
 	BalanceTopics(context.Context, *BalanceTopicsRequest) (*BalanceTopicsResponse, error)
+	EnforceTopicRetention(context.Context, *EnforceTopicRetentionRequest) (*EnforceTopicRetentionResponse, error)
 	// control plane for topic partitions
 	ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error)
 	ConfigureTopic(context.Context, *ConfigureTopicRequest) (*ConfigureTopicResponse, error)
@@ -311,6 +324,9 @@ func (UnimplementedSeaweedMessagingServer) PublisherToPubBalancer(grpc.BidiStrea
 func (UnimplementedSeaweedMessagingServer) BalanceTopics(context.Context, *BalanceTopicsRequest) (*BalanceTopicsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BalanceTopics not implemented")
 }
+func (UnimplementedSeaweedMessagingServer) EnforceTopicRetention(context.Context, *EnforceTopicRetentionRequest) (*EnforceTopicRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnforceTopicRetention not implemented")
+}
 func (UnimplementedSeaweedMessagingServer) ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTopics not implemented")
 }
@@ -417,6 +433,24 @@ func _SeaweedMessaging_BalanceTopics_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedMessaging_EnforceTopicRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnforceTopicRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedMessagingServer).EnforceTopicRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeaweedMessaging_EnforceTopicRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedMessagingServer).EnforceTopicRetention(ctx, req.(*EnforceTopicRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SeaweedMessaging_ListTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListTopicsRequest)
 	if err := dec(in); err != nil {
@@ -629,6 +663,10 @@ var SeaweedMessaging_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BalanceTopics",
 			Handler:    _SeaweedMessaging_BalanceTopics_Handler,
 		},
+		{
+			MethodName: "EnforceTopicRetention",
+			Handler:    _SeaweedMessaging_EnforceTopicRetention_Handler,
+		},
 		{
 			MethodName: "ListTopics",
 			Handler:    _SeaweedMessaging_ListTopics_Handler,
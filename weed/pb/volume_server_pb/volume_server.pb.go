@@ -962,6 +962,8 @@ type VolumeIncrementalCopyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VolumeId      uint32                 `protobuf:"varint,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
 	SinceNs       uint64                 `protobuf:"varint,2,opt,name=since_ns,json=sinceNs,proto3" json:"since_ns,omitempty"`
+	SinceOffset   uint64                 `protobuf:"varint,3,opt,name=since_offset,json=sinceOffset,proto3" json:"since_offset,omitempty"` // if set, copy from this .dat offset instead of since_ns
+	StopOffset    uint64                 `protobuf:"varint,4,opt,name=stop_offset,json=stopOffset,proto3" json:"stop_offset,omitempty"`    // if set, copy up to this .dat offset instead of the end
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1010,6 +1012,20 @@ func (x *VolumeIncrementalCopyRequest) GetSinceNs() uint64 {
 	return 0
 }
 
+func (x *VolumeIncrementalCopyRequest) GetSinceOffset() uint64 {
+	if x != nil {
+		return x.SinceOffset
+	}
+	return 0
+}
+
+func (x *VolumeIncrementalCopyRequest) GetStopOffset() uint64 {
+	if x != nil {
+		return x.StopOffset
+	}
+	return 0
+}
+
 type VolumeIncrementalCopyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
@@ -6158,10 +6174,13 @@ const file_volume_server_proto_rawDesc = "" +
 	"tailOffset\x12)\n" +
 	"\x10compact_revision\x18\a \x01(\rR\x0fcompactRevision\x12\"\n" +
 	"\ridx_file_size\x18\b \x01(\x04R\vidxFileSize\x12\x18\n" +
-	"\aversion\x18\t \x01(\rR\aversion\"V\n" +
+	"\aversion\x18\t \x01(\rR\aversion\"\x9a\x01\n" +
 	"\x1cVolumeIncrementalCopyRequest\x12\x1b\n" +
 	"\tvolume_id\x18\x01 \x01(\rR\bvolumeId\x12\x19\n" +
-	"\bsince_ns\x18\x02 \x01(\x04R\asinceNs\"B\n" +
+	"\bsince_ns\x18\x02 \x01(\x04R\asinceNs\x12!\n" +
+	"\fsince_offset\x18\x03 \x01(\x04R\vsinceOffset\x12\x1f\n" +
+	"\vstop_offset\x18\x04 \x01(\x04R\n" +
+	"stopOffset\"B\n" +
 	"\x1dVolumeIncrementalCopyResponse\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\"1\n" +
 	"\x12VolumeMountRequest\x12\x1b\n" +
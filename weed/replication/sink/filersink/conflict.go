@@ -0,0 +1,65 @@
+package filersink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// the content tag of each replicated file is kept in the target filer, to tell later
+// whether the file has been changed locally since it was replicated
+const replicatedTagKeyPrefix = "sync.replicated:"
+
+// ContentTag identifies the content of a file, also for the small files kept inside the entry
+func ContentTag(entry *filer_pb.Entry) string {
+	if entry == nil {
+		return ""
+	}
+	if len(entry.GetChunks()) == 0 && len(entry.Content) > 0 {
+		return fmt.Sprintf("%x", util.Md5(entry.Content))
+	}
+	return filer.ETag(entry)
+}
+
+// TrackReplicated keeps the content tag of the replicated files, see ReplicatedTag
+func (fs *FilerSink) TrackReplicated() {
+	fs.trackReplicated = true
+}
+
+// ReplicatedTag is the content tag of the file as it was last replicated to the key, empty if unknown
+func (fs *FilerSink) ReplicatedTag(key string) (tag string, err error) {
+	err = fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: []byte(replicatedTagKeyPrefix + key)})
+		if err != nil {
+			return err
+		}
+		if len(resp.Error) != 0 {
+			return errors.New(resp.Error)
+		}
+		tag = string(resp.Value)
+		return nil
+	})
+	return
+}
+
+func (fs *FilerSink) setReplicatedTag(client filer_pb.SeaweedFilerClient, key string, entry *filer_pb.Entry) {
+	if !fs.trackReplicated || entry != nil && entry.IsDirectory {
+		return
+	}
+	// an empty value removes the tag
+	resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{
+		Key:   []byte(replicatedTagKeyPrefix + key),
+		Value: []byte(ContentTag(entry)),
+	})
+	if err == nil && len(resp.Error) != 0 {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		glog.Warningf("keep replicated tag of %s: %v", key, err)
+	}
+}
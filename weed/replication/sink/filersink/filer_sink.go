@@ -34,6 +34,7 @@ type FilerSink struct {
 	isIncremental     bool
 	executor          *util.LimitedConcurrentExecutor
 	signature         int32
+	trackReplicated   bool
 }
 
 func init() {
@@ -95,12 +96,16 @@ func (fs *FilerSink) DeleteEntry(key string, isDirectory, deleteIncludeChunks bo
 	dir, name := util.FullPath(key).DirAndName()
 
 	glog.V(4).Infof("delete entry: %v", key)
-	err := filer_pb.Remove(context.Background(), fs, dir, name, deleteIncludeChunks, true, true, true, signatures)
-	if err != nil {
-		glog.V(0).Infof("delete entry %s: %v", key, err)
-		return fmt.Errorf("delete entry %s: %v", key, err)
-	}
-	return nil
+	return fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		if err := filer_pb.DoRemove(context.Background(), client, dir, name, deleteIncludeChunks, true, true, true, signatures); err != nil {
+			glog.V(0).Infof("delete entry %s: %v", key, err)
+			return fmt.Errorf("delete entry %s: %v", key, err)
+		}
+		if !isDirectory {
+			fs.setReplicatedTag(client, key, nil)
+		}
+		return nil
+	})
 }
 
 func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures []int32) error {
@@ -118,6 +123,7 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures [
 		if resp, err := filer_pb.LookupEntry(context.Background(), client, lookupRequest); err == nil {
 			if filer.ETag(resp.Entry) == filer.ETag(entry) {
 				glog.V(3).Infof("already replicated %s", key)
+				fs.setReplicatedTag(client, key, entry)
 				return nil
 			}
 			if resp.Entry.Attributes != nil && resp.Entry.Attributes.Mtime >= entry.Attributes.Mtime {
@@ -126,39 +132,54 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures [
 			}
 		}
 
-		replicatedChunks, err := fs.replicateChunks(entry.GetChunks(), key)
+		return fs.createEntry(client, key, entry, signatures)
+	})
+}
 
-		if err != nil {
-			// only warning here since the source chunk may have been deleted already
-			glog.Warningf("replicate entry chunks %s: %v", key, err)
-			return nil
-		}
+// ReplaceEntry writes the entry over the existing one, even if the existing one is newer
+func (fs *FilerSink) ReplaceEntry(key string, entry *filer_pb.Entry, signatures []int32) error {
+	return fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return fs.createEntry(client, key, entry, signatures)
+	})
+}
 
-		// glog.V(4).Infof("replicated %s %+v ===> %+v", key, entry.GetChunks(), replicatedChunks)
+func (fs *FilerSink) createEntry(client filer_pb.SeaweedFilerClient, key string, entry *filer_pb.Entry, signatures []int32) error {
 
-		request := &filer_pb.CreateEntryRequest{
-			Directory: dir,
-			Entry: &filer_pb.Entry{
-				Name:        name,
-				IsDirectory: entry.IsDirectory,
-				Attributes:  entry.Attributes,
-				Extended:    entry.Extended,
-				Chunks:      replicatedChunks,
-				Content:     entry.Content,
-				RemoteEntry: entry.RemoteEntry,
-			},
-			IsFromOtherCluster: true,
-			Signatures:         signatures,
-		}
+	dir, name := util.FullPath(key).DirAndName()
 
-		glog.V(3).Infof("create: %v", request)
-		if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
-			glog.V(0).Infof("create entry %s: %v", key, err)
-			return fmt.Errorf("create entry %s: %v", key, err)
-		}
+	replicatedChunks, err := fs.replicateChunks(entry.GetChunks(), key)
 
+	if err != nil {
+		// only warning here since the source chunk may have been deleted already
+		glog.Warningf("replicate entry chunks %s: %v", key, err)
 		return nil
-	})
+	}
+
+	// glog.V(4).Infof("replicated %s %+v ===> %+v", key, entry.GetChunks(), replicatedChunks)
+
+	request := &filer_pb.CreateEntryRequest{
+		Directory: dir,
+		Entry: &filer_pb.Entry{
+			Name:        name,
+			IsDirectory: entry.IsDirectory,
+			Attributes:  entry.Attributes,
+			Extended:    entry.Extended,
+			Chunks:      replicatedChunks,
+			Content:     entry.Content,
+			RemoteEntry: entry.RemoteEntry,
+		},
+		IsFromOtherCluster: true,
+		Signatures:         signatures,
+	}
+
+	glog.V(3).Infof("create: %v", request)
+	if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
+		glog.V(0).Infof("create entry %s: %v", key, err)
+		return fmt.Errorf("create entry %s: %v", key, err)
+	}
+	fs.setReplicatedTag(client, key, entry)
+
+	return nil
 }
 
 func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParentPath string, newEntry *filer_pb.Entry, deleteIncludeChunks bool, signatures []int32) (foundExistingEntry bool, err error) {
@@ -237,6 +258,7 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParent
 		if _, err := client.UpdateEntry(context.Background(), request); err != nil {
 			return fmt.Errorf("update existingEntry %s: %v", key, err)
 		}
+		fs.setReplicatedTag(client, key, existingEntry)
 
 		return nil
 	})
@@ -13,11 +13,13 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/sts"
 	"github.com/seaweedfs/seaweedfs/weed/kms"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 
 	// Import KMS providers to register them
 	_ "github.com/seaweedfs/seaweedfs/weed/kms/aws"
@@ -44,6 +46,7 @@ type IdentityAccessManagement struct {
 	hashes            map[string]*sync.Pool
 	hashCounters      map[string]*int32
 	identityAnonymous *Identity
+	tenants           map[string]*iam_pb.Tenant
 	hashMu            sync.RWMutex
 	domain            string
 	isAuthEnabled     bool
@@ -56,11 +59,20 @@ type IdentityAccessManagement struct {
 }
 
 type Identity struct {
-	Name        string
-	Account     *Account
-	Credentials []*Credential
-	Actions     []Action
+	Name         string
+	Account      *Account
+	Credentials  []*Credential
+	Actions      []Action
 	PrincipalArn string // ARN for IAM authorization (e.g., "arn:seaweed:iam::user/username")
+
+	// ClientCertificates are the names of verified TLS client certificates authenticating as this identity
+	ClientCertificates []string
+
+	// Tenant scopes the identity to the buckets of its tenant, see scopeToTenant
+	Tenant string
+
+	// session is set for the temporary credentials of an assumed role, see lookupSigningCredential
+	session *sts.SessionInfo
 }
 
 // Account represents a system user, a system user can
@@ -300,6 +312,7 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 		accounts[AccountAnonymous.Id] = &AccountAnonymous
 		emailAccount[AccountAnonymous.EmailAddress] = &AccountAnonymous
 	}
+	identityActions := credential.NewIdentityActions(config)
 	for _, ident := range config.Identities {
 		glog.V(3).Infof("loading identity %s", ident.Name)
 		t := &Identity{
@@ -307,6 +320,9 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 			Credentials:  nil,
 			Actions:      nil,
 			PrincipalArn: generatePrincipalArn(ident.Name),
+
+			ClientCertificates: ident.ClientCertificates,
+			Tenant:             ident.Tenant,
 		}
 		switch {
 		case ident.Name == AccountAnonymous.Id:
@@ -323,7 +339,8 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 			}
 		}
 
-		for _, action := range ident.Actions {
+		// including permissions from attached managed policies, directly or through groups
+		for _, action := range identityActions.Actions(ident) {
 			t.Actions = append(t.Actions, Action(action))
 		}
 		for _, cred := range ident.Credentials {
@@ -336,10 +353,16 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 		identities = append(identities, t)
 	}
 
+	tenants := make(map[string]*iam_pb.Tenant)
+	for _, tenant := range config.Tenants {
+		tenants[tenant.Name] = tenant
+	}
+
 	iam.m.Lock()
 	// atomically switch
 	iam.identities = identities
 	iam.identityAnonymous = identityAnonymous
+	iam.tenants = tenants
 	iam.accounts = accounts
 	iam.emailAccount = emailAccount
 	iam.accessKeyIdent = accessKeyIdent
@@ -378,6 +401,24 @@ func (iam *IdentityAccessManagement) lookupAnonymous() (identity *Identity, foun
 	return nil, false
 }
 
+// lookupByClientCertificate finds the identity of the verified TLS client certificate of a request
+func (iam *IdentityAccessManagement) lookupByClientCertificate(r *http.Request) (identity *Identity, found bool) {
+	names := security.ClientCertificateNames(r)
+	if len(names) == 0 {
+		return nil, false
+	}
+	iam.m.RLock()
+	defer iam.m.RUnlock()
+	for _, ident := range iam.identities {
+		for _, pattern := range ident.ClientCertificates {
+			if security.MatchCertificateName(pattern, names) {
+				return ident, true
+			}
+		}
+	}
+	return nil, false
+}
+
 // generatePrincipalArn generates an ARN for a user identity
 func generatePrincipalArn(identityName string) string {
 	// Handle special cases
@@ -411,6 +452,8 @@ func (iam *IdentityAccessManagement) GetAccountIdByEmail(email string) string {
 
 func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// only set after authentication
+		r.Header.Del(s3_constants.AmzTenantId)
 		if !iam.isEnabled() {
 			f(w, r)
 			return
@@ -423,7 +466,7 @@ func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) htt
 			if identity != nil && identity.Name != "" {
 				r.Header.Set(s3_constants.AmzIdentityId, identity.Name)
 			}
-			f(w, r)
+			f(w, scopeToTenant(r, identity))
 			return
 		}
 		s3err.WriteErrorResponse(w, r, errCode)
@@ -467,6 +510,11 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 			return identity, s3err.ErrNotImplemented
 		}
 	case authTypeAnonymous:
+		// a request without signature can still be authenticated by its TLS client certificate
+		if identity, found = iam.lookupByClientCertificate(r); found {
+			authType = "ClientCertificate"
+			break
+		}
 		authType = "Anonymous"
 		if identity, found = iam.lookupAnonymous(); !found {
 			r.Header.Set(s3_constants.AmzAuthType, authType)
@@ -486,6 +534,9 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 	glog.V(3).Infof("user name: %v actions: %v, action: %v", identity.Name, identity.Actions, action)
 	bucket, object := s3_constants.GetBucketAndObject(r)
 	prefix := s3_constants.GetPrefix(r)
+	if bucket, s3Err = identity.tenantLocalBucket(bucket); s3Err != s3err.ErrNone {
+		return identity, s3Err
+	}
 
 	// For List operations, use prefix for permission checking if available
 	if action == s3_constants.ACTION_LIST && object == "" && prefix != "" {
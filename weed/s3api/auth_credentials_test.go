@@ -1,12 +1,20 @@
 package s3api
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	. "github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
@@ -270,6 +278,93 @@ func TestLoadS3ApiConfiguration(t *testing.T) {
 	}
 }
 
+func TestLoadS3ApiConfigurationManagedPolicies(t *testing.T) {
+	readPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::team-bucket","arn:aws:s3:::team-bucket/*"]}]}`
+	writePolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:PutObject","Resource":"arn:aws:s3:::uploads/*"}]}`
+	denyPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"s3:*","Resource":"*"}]}`
+
+	config := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{Name: "alice", Credentials: []*iam_pb.Credential{{AccessKey: "alice_key", SecretKey: "alice_secret"}}, PolicyNames: []string{"uploads-write"}},
+			{Name: "bob", Credentials: []*iam_pb.Credential{{AccessKey: "bob_key", SecretKey: "bob_secret"}}, Actions: []string{"Read:other-bucket"}},
+			{Name: "carol", Credentials: []*iam_pb.Credential{{AccessKey: "carol_key", SecretKey: "carol_secret"}}, PolicyNames: []string{"deny-all"}},
+		},
+		Groups: []*iam_pb.Group{
+			{Name: "team", Members: []string{"alice", "bob"}, PolicyNames: []string{"team-read"}},
+		},
+		Policies: []*iam_pb.ManagedPolicy{
+			{Name: "team-read", DefaultVersionId: "v2", Versions: []*iam_pb.PolicyVersion{
+				{VersionId: "v1", Document: writePolicy},
+				{VersionId: "v2", Document: readPolicy},
+			}},
+			{Name: "uploads-write", DefaultVersionId: "v1", Versions: []*iam_pb.PolicyVersion{{VersionId: "v1", Document: writePolicy}}},
+			{Name: "deny-all", DefaultVersionId: "v1", Versions: []*iam_pb.PolicyVersion{{VersionId: "v1", Document: denyPolicy}}},
+		},
+	}
+
+	iam := IdentityAccessManagement{}
+	assert.NoError(t, iam.loadS3ApiConfiguration(config))
+
+	alice, _, found := iam.lookupByAccessKey("alice_key")
+	assert.True(t, found)
+	assert.Equal(t, []Action{"Write:uploads/*", "Read:team-bucket", "List:team-bucket", "Read:team-bucket/*", "List:team-bucket/*"}, alice.Actions)
+	assert.True(t, alice.canDo(ACTION_READ, "team-bucket", "/report.csv"))
+	assert.True(t, alice.canDo(ACTION_WRITE, "uploads", "/incoming.bin"))
+	assert.False(t, alice.canDo(ACTION_WRITE, "team-bucket", "/report.csv"))
+
+	// group permissions are added to the identity's own actions
+	bob, _, found := iam.lookupByAccessKey("bob_key")
+	assert.True(t, found)
+	assert.True(t, bob.canDo(ACTION_READ, "other-bucket", "/a"))
+	assert.True(t, bob.canDo(ACTION_LIST, "team-bucket", ""))
+	assert.False(t, bob.canDo(ACTION_WRITE, "uploads", "/incoming.bin"))
+
+	// policies that cannot be expressed as identity actions grant nothing
+	carol, _, found := iam.lookupByAccessKey("carol_key")
+	assert.True(t, found)
+	assert.Empty(t, carol.Actions)
+}
+
+func TestClientCertificateIdentity(t *testing.T) {
+	config := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{Name: "reporter", Actions: []string{"Read:reports"}, ClientCertificates: []string{"spiffe://example.org/ns/prod/sa/reporter"}},
+			{Name: "batch", Actions: []string{"Write:uploads"}, ClientCertificates: []string{"dns:*.batch.example.org"}},
+		},
+	}
+	iam := IdentityAccessManagement{}
+	assert.NoError(t, iam.loadS3ApiConfiguration(config))
+
+	newRequest := func(bucket, object string, cert *x509.Certificate) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/"+bucket+"/"+object, nil)
+		if cert != nil {
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		return mux.SetURLVars(r, map[string]string{"bucket": bucket, "object": object})
+	}
+	spiffeId, _ := url.Parse("spiffe://example.org/ns/prod/sa/reporter")
+	reporterCert := &x509.Certificate{URIs: []*url.URL{spiffeId}}
+	batchCert := &x509.Certificate{DNSNames: []string{"worker-1.batch.example.org"}}
+	otherCert := &x509.Certificate{Subject: pkix.Name{CommonName: "other"}}
+
+	identity, errCode := iam.authRequest(newRequest("reports", "daily.csv", reporterCert), ACTION_READ)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "reporter", identity.Name)
+
+	_, errCode = iam.authRequest(newRequest("uploads", "daily.csv", reporterCert), ACTION_WRITE)
+	assert.Equal(t, s3err.ErrAccessDenied, errCode)
+
+	identity, found := iam.lookupByClientCertificate(newRequest("uploads", "a.bin", batchCert))
+	assert.True(t, found)
+	assert.Equal(t, "batch", identity.Name)
+
+	// unknown certificates and requests without a certificate stay anonymous
+	_, errCode = iam.authRequest(newRequest("reports", "daily.csv", otherCert), ACTION_READ)
+	assert.Equal(t, s3err.ErrAccessDenied, errCode)
+	_, found = iam.lookupByClientCertificate(newRequest("reports", "daily.csv", nil))
+	assert.False(t, found)
+}
+
 func TestNewIdentityAccessManagementWithStoreEnvVars(t *testing.T) {
 	// Save original environment
 	originalAccessKeyId := os.Getenv("AWS_ACCESS_KEY_ID")
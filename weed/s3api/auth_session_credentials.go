@@ -0,0 +1,100 @@
+package s3api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+const (
+	amzSecurityToken = "X-Amz-Security-Token"
+	userArnPrefix    = "arn:seaweed:iam::user/"
+)
+
+// lookupSigningCredential finds the identity and the credential of the access key a request is signed with.
+// Besides the static access keys, it accepts the temporary credentials issued by the STS endpoint,
+// sent along with their session token in X-Amz-Security-Token.
+func (iam *IdentityAccessManagement) lookupSigningCredential(r *http.Request, accessKey string) (*Identity, *Credential, s3err.ErrorCode) {
+	if identity, cred, found := iam.lookupByAccessKey(accessKey); found {
+		return identity, cred, s3err.ErrNone
+	}
+
+	sessionToken := r.Header.Get(amzSecurityToken)
+	if sessionToken == "" {
+		sessionToken = r.URL.Query().Get(amzSecurityToken)
+	}
+	if sessionToken == "" || iam.iamIntegration == nil || iam.iamIntegration.stsService == nil {
+		return nil, nil, s3err.ErrInvalidAccessKeyID
+	}
+	stsService := iam.iamIntegration.stsService
+
+	session, err := stsService.ValidateSessionToken(r.Context(), sessionToken)
+	if err != nil {
+		glog.V(3).Infof("temporary credential %s: %v", accessKey, err)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, nil, s3err.ErrExpiredToken
+		}
+		return nil, nil, s3err.ErrInvalidAccessKeyID
+	}
+	accessKeyId, secretAccessKey := stsService.TemporaryCredentialKeys(session.SessionId)
+	if accessKeyId != accessKey {
+		glog.V(3).Infof("access key %s is not issued with session %s", accessKey, session.SessionId)
+		return nil, nil, s3err.ErrInvalidAccessKeyID
+	}
+	cred := &Credential{
+		AccessKey: accessKeyId,
+		SecretKey: secretAccessKey,
+	}
+
+	// the session of GetSessionToken acts as the user itself
+	if strings.HasPrefix(session.Principal, userArnPrefix) {
+		identity, found := iam.lookupByPrincipalArn(session.Principal)
+		if !found {
+			glog.V(3).Infof("user %s of session %s no longer exists", session.Principal, session.SessionId)
+			return nil, nil, s3err.ErrInvalidAccessKeyID
+		}
+		return identity, cred, s3err.ErrNone
+	}
+
+	// the session of an assumed role is authorized by the policies of the role, as with a JWT session token
+	identity := &Identity{
+		Name: session.SessionId,
+		Account: &Account{
+			DisplayName:  session.RoleArn,
+			EmailAddress: session.SessionId + "@seaweedfs.local",
+			Id:           session.SessionId,
+		},
+		Actions:      []Action{},
+		PrincipalArn: session.Principal,
+		session:      session,
+	}
+	r.Header.Set("X-SeaweedFS-Session-Token", sessionToken)
+	r.Header.Set("X-SeaweedFS-Principal", session.Principal)
+
+	return identity, cred, s3err.ErrNone
+}
+
+func (iam *IdentityAccessManagement) lookupByPrincipalArn(principalArn string) (identity *Identity, found bool) {
+	iam.m.RLock()
+	defer iam.m.RUnlock()
+	for _, ident := range iam.identities {
+		if ident.PrincipalArn == principalArn {
+			return ident, true
+		}
+	}
+	return nil, false
+}
+
+// canSign checks the permission of an identity while verifying its signature.
+// The session of an assumed role is authorized later by the policies of the role,
+// and the STS calls are authorized by the STS handler.
+func (identity *Identity) canSign(action Action, bucket string, objectKey string, service string) bool {
+	if identity.session != nil || service == stsServiceName {
+		return true
+	}
+	return identity.canDo(action, bucket, objectKey)
+}
@@ -185,13 +185,13 @@ func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r
 	}
 
 	cred := signV4Values.Credential
-	identity, foundCred, found := iam.lookupByAccessKey(cred.accessKey)
-	if !found {
-		return nil, s3err.ErrInvalidAccessKeyID
+	identity, foundCred, errCode := iam.lookupSigningCredential(r, cred.accessKey)
+	if errCode != s3err.ErrNone {
+		return nil, errCode
 	}
 
 	bucket, object := s3_constants.GetBucketAndObject(r)
-	canDoResult := identity.canDo(s3_constants.ACTION_WRITE, bucket, object)
+	canDoResult := identity.canSign(s3_constants.ACTION_WRITE, bucket, object, cred.scope.service)
 	if !canDoResult {
 		return nil, s3err.ErrAccessDenied
 	}
@@ -313,14 +313,14 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	}
 
 	// Look up identity by access key
-	identity, foundCred, found := iam.lookupByAccessKey(credHeader.accessKey)
-	if !found {
-		return nil, s3err.ErrInvalidAccessKeyID
+	identity, foundCred, errCode := iam.lookupSigningCredential(r, credHeader.accessKey)
+	if errCode != s3err.ErrNone {
+		return nil, errCode
 	}
 
 	// Check permissions
 	bucket, object := s3_constants.GetBucketAndObject(r)
-	if !identity.canDo(s3_constants.ACTION_READ, bucket, object) {
+	if !identity.canSign(s3_constants.ACTION_READ, bucket, object, credHeader.scope.service) {
 		return nil, s3err.ErrAccessDenied
 	}
 
@@ -364,7 +364,6 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	queryForCanonical.Del("X-Amz-Signature")
 	queryStr := strings.Replace(queryForCanonical.Encode(), "+", "%20", -1)
 
-	var errCode s3err.ErrorCode
 	// Check if reverse proxy is forwarding with prefix for presigned URLs
 	if forwardedPrefix := r.Header.Get("X-Forwarded-Prefix"); forwardedPrefix != "" {
 		// Try signature verification with the forwarded prefix first.
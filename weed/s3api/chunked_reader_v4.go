@@ -81,13 +81,13 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 		return nil, "", "", "", time.Time{}, errCode
 	}
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
-	if !found {
-		return nil, "", "", "", time.Time{}, s3err.ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupSigningCredential(r, signV4Values.Credential.accessKey)
+	if errCode != s3err.ErrNone {
+		return nil, "", "", "", time.Time{}, errCode
 	}
 
 	bucket, object := s3_constants.GetBucketAndObject(r)
-	if !identity.canDo(s3_constants.ACTION_WRITE, bucket, object) {
+	if !identity.canSign(s3_constants.ACTION_WRITE, bucket, object, signV4Values.Credential.scope.service) {
 		errCode = s3err.ErrAccessDenied
 		return
 	}
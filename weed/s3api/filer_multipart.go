@@ -357,7 +357,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 
 		if err != nil {
 			glog.Errorf("completeMultipartUpload: failed to create version %s: %v", versionId, err)
-			return nil, s3err.ErrInternalError
+			return nil, filerErrorToS3Error(err.Error())
 		}
 
 		// Update the .versions directory metadata to indicate this is the latest version
@@ -419,7 +419,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 
 		if err != nil {
 			glog.Errorf("completeMultipartUpload: failed to create suspended versioning object: %v", err)
-			return nil, s3err.ErrInternalError
+			return nil, filerErrorToS3Error(err.Error())
 		}
 
 		// Note: Suspended versioning should NOT return VersionId field according to AWS S3 spec
@@ -472,7 +472,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 
 		if err != nil {
 			glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
-			return nil, s3err.ErrInternalError
+			return nil, filerErrorToS3Error(err.Error())
 		}
 
 		// For non-versioned buckets, return response without VersionId
@@ -1,6 +1,7 @@
 package policy_engine
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"testing"
@@ -211,6 +212,46 @@ func TestConditionEvaluators(t *testing.T) {
 	}
 }
 
+func TestConvertPolicyToLegacyActions(t *testing.T) {
+	var policy PolicyDocument
+	if err := json.Unmarshal([]byte(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Action": ["s3:GetObject", "s3:PutObject"],
+			"Resource": "arn:aws:s3:::bucket1/*"
+		}]
+	}`), &policy); err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+	actions, err := ConvertPolicyToLegacyActions(&policy)
+	if err != nil {
+		t.Fatalf("Failed to convert policy: %v", err)
+	}
+	if len(actions) != 2 || actions[0] != "Read:bucket1/*" || actions[1] != "Write:bucket1/*" {
+		t.Errorf("Unexpected actions %v", actions)
+	}
+
+	// a condition can not be expressed as an identity action, so the policy is rejected
+	policy.Statement[0].Condition = PolicyConditions{
+		"IpAddress": {"aws:SourceIp": NewStringOrStringSlice("10.0.0.0/8")},
+	}
+	if actions, err := ConvertPolicyToLegacyActions(&policy); err == nil {
+		t.Errorf("Expected a conditional policy to be rejected, got %v", actions)
+	}
+
+	// an unknown s3 action is rejected, an action of another service is ignored
+	policy.Statement[0].Condition = nil
+	policy.Statement[0].Action = NewStringOrStringSlice("s3:GetObject", "s3:InvalidAction")
+	if actions, err := ConvertPolicyToLegacyActions(&policy); err == nil {
+		t.Errorf("Expected an unknown action to be rejected, got %v", actions)
+	}
+	policy.Statement[0].Action = NewStringOrStringSlice("s3:GetObject", "sqs:SendMessage")
+	if actions, err := ConvertPolicyToLegacyActions(&policy); err != nil || len(actions) != 1 || actions[0] != "Read:bucket1/*" {
+		t.Errorf("Unexpected actions %v: %v", actions, err)
+	}
+}
+
 func TestConvertIdentityToPolicy(t *testing.T) {
 	identityActions := []string{
 		"Read:bucket1/*",
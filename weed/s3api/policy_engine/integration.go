@@ -501,6 +501,109 @@ func CreatePolicyFromLegacyIdentity(identityName string, actions []string) (*Pol
 	}, nil
 }
 
+// ConvertPolicyToLegacyActions converts an identity-based policy document into
+// legacy identity actions such as "Read:bucket/prefix/*".
+// Statements with an effect other than Allow, or with a condition, cannot be
+// expressed as legacy actions, so they are rejected rather than silently widening access.
+// Unknown s3 actions are rejected as well, while actions and resources outside the s3 service are ignored.
+// Inline user policies of the IAM API and managed policies are both converted here, so that the
+// same document grants the same actions either way.
+func ConvertPolicyToLegacyActions(policy *PolicyDocument) ([]string, error) {
+	var actions []string
+	seen := make(map[string]bool)
+
+	for _, statement := range policy.Statement {
+		if statement.Effect != PolicyEffectAllow {
+			return nil, fmt.Errorf("not a valid effect: '%s'. Only 'Allow' is possible", statement.Effect)
+		}
+		if len(statement.Condition) > 0 {
+			return nil, fmt.Errorf("statement %q has a condition, which is not supported for identity actions", statement.Sid)
+		}
+		for _, resource := range statement.Resource.Strings() {
+			path, ok := legacyResourcePath(resource)
+			if !ok {
+				glog.V(3).Infof("skipping non-s3 resource %s", resource)
+				continue
+			}
+			for _, action := range statement.Action.Strings() {
+				if action != "*" && !strings.HasPrefix(action, "s3:") {
+					glog.V(3).Infof("skipping non-s3 action %s", action)
+					continue
+				}
+				legacyAction := legacyActionForS3Action(action)
+				if legacyAction == "" {
+					return nil, fmt.Errorf("not a valid action: '%s'", strings.TrimPrefix(action, "s3:"))
+				}
+				if path != "*" {
+					legacyAction = fmt.Sprintf("%s:%s", legacyAction, path)
+				}
+				if !seen[legacyAction] {
+					seen[legacyAction] = true
+					actions = append(actions, legacyAction)
+				}
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// legacyResourcePath extracts "bucket/path" from "arn:aws:s3:::bucket/path"
+func legacyResourcePath(resource string) (string, bool) {
+	if resource == "*" {
+		return "*", true
+	}
+	res := strings.SplitN(resource, ":", 6)
+	if len(res) != 6 || res[0] != "arn" || res[2] != "s3" || res[5] == "" {
+		return "", false
+	}
+	return res[5], true
+}
+
+// legacyActionForS3Action maps an s3 policy action onto the legacy action that covers it
+func legacyActionForS3Action(action string) string {
+	if action == "*" {
+		return "Admin"
+	}
+	parts := strings.SplitN(action, ":", 2)
+	if len(parts) != 2 || parts[0] != "s3" {
+		return ""
+	}
+
+	switch parts[1] {
+	case "*":
+		return "Admin"
+	case "Get*":
+		return "Read"
+	case "Put*", "Delete*":
+		return "Write"
+	case "List*", "ListBucket", "ListBucketVersions", "ListAllMyBuckets":
+		return "List"
+	case "Tagging*", "GetObjectTagging", "PutObjectTagging", "DeleteObjectTagging",
+		"GetBucketTagging", "PutBucketTagging", "DeleteBucketTagging":
+		return "Tagging"
+	case "DeleteBucket*", "DeleteBucket":
+		return "DeleteBucket"
+	case "GetBucketAcl":
+		return "ReadAcp"
+	case "PutBucketAcl":
+		return "WriteAcp"
+	}
+
+	mappings := GetActionMappings()
+	for _, legacyAction := range []string{"Read", "Write",
+		"BypassGovernanceRetention", "GetObjectRetention", "PutObjectRetention",
+		"GetObjectLegalHold", "PutObjectLegalHold",
+		"GetBucketObjectLockConfiguration", "PutBucketObjectLockConfiguration"} {
+		for _, s3Action := range mappings[legacyAction] {
+			if s3Action == action {
+				return legacyAction
+			}
+		}
+	}
+	return ""
+}
+
 // HasPolicyForBucket checks if a bucket has a policy
 func (p *PolicyBackedIAM) HasPolicyForBucket(bucketName string) bool {
 	return p.policyEngine.HasPolicyForBucket(bucketName)
@@ -126,6 +126,7 @@ const (
 	AmzIdentityId = "s3-identity-id"
 	AmzAccountId  = "s3-account-id"
 	AmzAuthType   = "s3-auth-type"
+	AmzTenantId   = "s3-tenant-id"
 )
 
 func GetBucketAndObject(r *http.Request) (bucket, object string) {
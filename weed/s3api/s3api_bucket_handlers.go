@@ -60,25 +60,38 @@ func (s3a *S3ApiServer) ListBucketsHandler(w http.ResponseWriter, r *http.Reques
 	var listBuckets ListAllMyBucketsList
 	for _, entry := range entries {
 		if entry.IsDirectory {
+			// Only list the buckets of the identity's tenant, by their names in the tenant
+			bucketName := entry.Name
+			if identity != nil {
+				if identity.Tenant != "" {
+					tenantPrefix := s3bucket.TenantBucketPrefix(identity.Tenant)
+					if !strings.HasPrefix(bucketName, tenantPrefix) {
+						continue
+					}
+					bucketName = strings.TrimPrefix(bucketName, tenantPrefix)
+				} else if _, errCode := identity.tenantLocalBucket(bucketName); errCode != s3err.ErrNone {
+					continue
+				}
+			}
 			// Check permissions for each bucket
 			if identity != nil {
 				// For JWT-authenticated users, use IAM authorization
 				sessionToken := r.Header.Get("X-SeaweedFS-Session-Token")
 				if s3a.iam.iamIntegration != nil && sessionToken != "" {
 					// Use IAM authorization for JWT users
-					errCode := s3a.iam.authorizeWithIAM(r, identity, s3_constants.ACTION_LIST, entry.Name, "")
+					errCode := s3a.iam.authorizeWithIAM(r, identity, s3_constants.ACTION_LIST, bucketName, "")
 					if errCode != s3err.ErrNone {
 						continue
 					}
 				} else {
 					// Use legacy authorization for non-JWT users
-					if !identity.canDo(s3_constants.ACTION_LIST, entry.Name, "") {
+					if !identity.canDo(s3_constants.ACTION_LIST, bucketName, "") {
 						continue
 					}
 				}
 			}
 			listBuckets.Bucket = append(listBuckets.Bucket, ListAllMyBucketsEntry{
-				Name:         entry.Name,
+				Name:         bucketName,
 				CreationDate: time.Unix(entry.Attributes.Crtime, 0).UTC(),
 			})
 		}
@@ -101,12 +114,16 @@ func (s3a *S3ApiServer) PutBucketHandler(w http.ResponseWriter, r *http.Request)
 	bucket, _ := s3_constants.GetBucketAndObject(r)
 
 	// validate the bucket name
-	err := s3bucket.VerifyS3BucketName(bucket)
+	err := s3bucket.VerifyBucketFolderName(bucket)
 	if err != nil {
 		glog.Errorf("put invalid bucket name: %v %v", bucket, err)
 		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidBucketName)
 		return
 	}
+	if errCode := s3a.checkTenantBucketLimit(bucket); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
 
 	// avoid duplicated buckets
 	errCode := s3err.ErrNone
@@ -337,6 +354,7 @@ func isPublicReadGrants(grants []*s3.Grant) bool {
 // AuthWithPublicRead creates an auth wrapper that allows anonymous access for public-read buckets
 func (s3a *S3ApiServer) AuthWithPublicRead(handler http.HandlerFunc, action Action) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(s3_constants.AmzTenantId)
 		bucket, _ := s3_constants.GetBucketAndObject(r)
 		authType := getRequestAuthType(r)
 		isAnonymous := authType == authTypeAnonymous
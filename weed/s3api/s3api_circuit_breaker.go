@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type CircuitBreaker struct {
@@ -21,6 +22,10 @@ type CircuitBreaker struct {
 	Enabled     bool
 	counters    map[string]*int64
 	limitations map[string]int64
+
+	// tenantRequestRate returns the requests per second allowed for a tenant, 0 for unlimited
+	tenantRequestRate func(tenant string) int64
+	tenantRequests    tenantRateLimiter
 }
 
 func NewCircuitBreaker(option *S3ApiServerOption) *CircuitBreaker {
@@ -89,6 +94,10 @@ func (cb *CircuitBreaker) loadCircuitBreakerConfig(cfg *s3_pb.S3CircuitBreakerCo
 
 func (cb *CircuitBreaker) Limit(f func(w http.ResponseWriter, r *http.Request), action string) (http.HandlerFunc, Action) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if errCode := cb.limitTenant(r); errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
 		if !cb.Enabled {
 			f(w, r)
 			return
@@ -112,6 +121,20 @@ func (cb *CircuitBreaker) Limit(f func(w http.ResponseWriter, r *http.Request),
 	}, Action(action)
 }
 
+// limitTenant checks the request rate of the tenant of an authenticated request, see scopeToTenant
+func (cb *CircuitBreaker) limitTenant(r *http.Request) s3err.ErrorCode {
+	tenant := r.Header.Get(s3_constants.AmzTenantId)
+	if tenant == "" || cb.tenantRequestRate == nil {
+		return s3err.ErrNone
+	}
+	maxPerSecond := cb.tenantRequestRate(tenant)
+	if maxPerSecond <= 0 || cb.tenantRequests.allow(tenant, maxPerSecond, time.Now()) {
+		return s3err.ErrNone
+	}
+	glog.V(2).Infof("tenant %s exceeds %d requests per second", tenant, maxPerSecond)
+	return s3err.ErrSlowDown
+}
+
 func (cb *CircuitBreaker) limit(r *http.Request, bucket string, action string) (rollback []func(), errCode s3err.ErrorCode) {
 
 	//bucket simultaneous request count
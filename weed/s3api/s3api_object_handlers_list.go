@@ -94,7 +94,7 @@ func (s3a *S3ApiServer) ListObjectsV2Handler(w http.ResponseWriter, r *http.Requ
 	}
 
 	responseV2 := &ListBucketResultV2{
-		Name:                  response.Name,
+		Name:                  tenantLocalBucketName(r, response.Name),
 		CommonPrefixes:        response.CommonPrefixes,
 		Contents:              response.Contents,
 		ContinuationToken:     continuationToken,
@@ -156,6 +156,7 @@ func (s3a *S3ApiServer) ListObjectsV1Handler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	response.Name = tenantLocalBucketName(r, response.Name)
 	writeSuccessResponseXML(w, r, response)
 }
 
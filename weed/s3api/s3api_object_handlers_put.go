@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/pquerna/cachecontrol/cacheobject"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/s3_pb"
@@ -384,6 +385,8 @@ func filerErrorToS3Error(errString string) s3err.ErrorCode {
 		return s3err.ErrExistingObjectIsDirectory
 	case strings.HasSuffix(errString, "is a file"):
 		return s3err.ErrExistingObjectIsFile
+	case strings.Contains(errString, filer.ErrQuotaExceeded.Error()):
+		return s3err.ErrQuotaExceeded
 	default:
 		return s3err.ErrInternalError
 	}
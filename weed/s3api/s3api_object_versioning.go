@@ -759,6 +759,7 @@ func (s3a *S3ApiServer) ListObjectVersionsHandler(w http.ResponseWriter, r *http
 
 	// Set the original prefix in the response (not the normalized internal prefix)
 	result.Prefix = originalPrefix
+	result.Name = tenantLocalBucketName(r, result.Name)
 
 	writeSuccessResponseXML(w, r, result)
 }
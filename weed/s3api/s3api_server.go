@@ -97,6 +97,7 @@ func NewS3ApiServerWithStore(router *mux.Router, option *S3ApiServerOption, expl
 		credentialManager: iam.credentialManager,
 		bucketConfigCache: NewBucketConfigCache(60 * time.Minute), // Increased TTL since cache is now event-driven
 	}
+	s3ApiServer.cb.tenantRequestRate = iam.tenantRequestRate
 
 	// Initialize advanced IAM system if config is provided
 	if option.IamConfig != "" {
@@ -405,6 +406,10 @@ func (s3a *S3ApiServer) registerRouter(router *mux.Router) {
 			}
 		})
 
+	// STS
+	apiRouter.Methods(http.MethodPost).Path("/").HandlerFunc(track(s3a.StsHandler, "STS"))
+	apiRouter.Methods(http.MethodGet).Path("/").Queries("Action", "{action}").HandlerFunc(track(s3a.StsHandler, "STS"))
+
 	// ListBuckets
 	apiRouter.Methods(http.MethodGet).Path("/").HandlerFunc(track(s3a.ListBucketsHandler, "LIST"))
 
@@ -0,0 +1,319 @@
+package s3api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/sts"
+	"github.com/seaweedfs/seaweedfs/weed/iam/utils"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+const (
+	stsServiceName = "sts"
+	stsApiVersion  = "2011-06-15"
+)
+
+// stsError is an error of the STS API, with its AWS error code
+type stsError struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func newStsError(code string, statusCode int, format string, args ...interface{}) *stsError {
+	return &stsError{Code: code, Message: fmt.Sprintf(format, args...), StatusCode: statusCode}
+}
+
+// StsHandler serves the AWS Security Token Service API: AssumeRole, AssumeRoleWithWebIdentity,
+// GetSessionToken and GetCallerIdentity, as POST / with a form, or GET /?Action=...
+// AssumeRoleWithWebIdentity is authenticated by its web identity token, the others are signed with SigV4.
+func (s3a *S3ApiServer) StsHandler(w http.ResponseWriter, r *http.Request) {
+	values, err := readStsRequestValues(r)
+	if err != nil {
+		writeStsErrorResponse(w, r, newStsError("InvalidParameterValue", http.StatusBadRequest, "read request: %v", err))
+		return
+	}
+	if version := values.Get("Version"); version != "" && version != stsApiVersion {
+		writeStsErrorResponse(w, r, newStsError("InvalidParameterValue", http.StatusBadRequest, "unsupported version %s", version))
+		return
+	}
+
+	action := values.Get("Action")
+	glog.V(3).Infof("sts %s: %+v", action, values)
+
+	var response interface{}
+	var stsErr *stsError
+	switch action {
+	case "AssumeRoleWithWebIdentity":
+		response, stsErr = s3a.stsAssumeRoleWithWebIdentity(r, values)
+	case "AssumeRole", "GetSessionToken", "GetCallerIdentity":
+		identity, errCode := s3a.iam.reqSignatureV4Verify(r)
+		if errCode != s3err.ErrNone {
+			writeStsErrorResponse(w, r, stsErrorOfAuth(errCode))
+			return
+		}
+		switch action {
+		case "AssumeRole":
+			response, stsErr = s3a.stsAssumeRole(r, identity, values)
+		case "GetSessionToken":
+			response, stsErr = s3a.stsGetSessionToken(r, identity, values)
+		case "GetCallerIdentity":
+			response = stsGetCallerIdentity(identity)
+		}
+	default:
+		stsErr = newStsError("InvalidAction", http.StatusBadRequest, "could not find operation %s for version %s", action, stsApiVersion)
+	}
+
+	if stsErr != nil {
+		writeStsErrorResponse(w, r, stsErr)
+		return
+	}
+	s3err.WriteXMLResponse(w, r, http.StatusOK, response)
+}
+
+// readStsRequestValues reads the parameters from the query and the form body,
+// and keeps the body to verify its signature
+func readStsRequestValues(r *http.Request) (url.Values, error) {
+	values := r.URL.Query()
+	if r.Body == nil {
+		return values, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, iamRequestBodyLimit))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return values, nil
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range form {
+		values[k] = append(values[k], v...)
+	}
+	return values, nil
+}
+
+func (s3a *S3ApiServer) stsAssumeRoleWithWebIdentity(r *http.Request, values url.Values) (interface{}, *stsError) {
+	iamIntegration := s3a.iam.iamIntegration
+	if iamIntegration == nil || iamIntegration.iamManager == nil {
+		return nil, stsNotConfiguredError()
+	}
+	if stsErr := requireStsParameters(values, "RoleArn", "RoleSessionName", "WebIdentityToken"); stsErr != nil {
+		return nil, stsErr
+	}
+	durationSeconds, stsErr := parseStsDurationSeconds(values)
+	if stsErr != nil {
+		return nil, stsErr
+	}
+	webIdentityToken := values.Get("WebIdentityToken")
+
+	ctx := r.Context()
+	if _, _, err := iamIntegration.stsService.ValidateWebIdentityToken(ctx, webIdentityToken); err != nil {
+		return nil, newStsError("InvalidIdentityToken", http.StatusBadRequest, "%v", err)
+	}
+
+	request := &sts.AssumeRoleWithWebIdentityRequest{
+		RoleArn:          values.Get("RoleArn"),
+		WebIdentityToken: webIdentityToken,
+		RoleSessionName:  values.Get("RoleSessionName"),
+		DurationSeconds:  durationSeconds,
+	}
+	if policy := values.Get("Policy"); policy != "" {
+		request.Policy = &policy
+	}
+	assumed, err := iamIntegration.iamManager.AssumeRoleWithWebIdentity(ctx, request)
+	if err != nil {
+		return nil, stsErrorOf("AssumeRoleWithWebIdentity", err)
+	}
+
+	response := &AssumeRoleWithWebIdentityResponse{}
+	result := &response.AssumeRoleWithWebIdentityResult
+	result.Credentials = toStsCredentials(assumed.Credentials)
+	result.AssumedRoleUser = toStsAssumedRoleUser(request.RoleArn, request.RoleSessionName, assumed.AssumedRoleUser)
+	result.SubjectFromWebIdentityToken = assumed.AssumedRoleUser.Subject
+	if claims, err := parseJWTToken(webIdentityToken); err == nil {
+		result.Provider, _ = claims["iss"].(string)
+		if audience, err := claims.GetAudience(); err == nil && len(audience) > 0 {
+			result.Audience = audience[0]
+		}
+	}
+	response.ResponseMetadata.SetRequestId()
+	return response, nil
+}
+
+func (s3a *S3ApiServer) stsAssumeRole(r *http.Request, identity *Identity, values url.Values) (interface{}, *stsError) {
+	iamIntegration := s3a.iam.iamIntegration
+	if iamIntegration == nil || iamIntegration.iamManager == nil {
+		return nil, stsNotConfiguredError()
+	}
+	if stsErr := requireStsParameters(values, "RoleArn", "RoleSessionName"); stsErr != nil {
+		return nil, stsErr
+	}
+	durationSeconds, stsErr := parseStsDurationSeconds(values)
+	if stsErr != nil {
+		return nil, stsErr
+	}
+
+	request := &sts.AssumeRoleRequest{
+		RoleArn:         values.Get("RoleArn"),
+		RoleSessionName: values.Get("RoleSessionName"),
+		CallerArn:       callerArnOf(identity),
+		DurationSeconds: durationSeconds,
+	}
+	if policy := values.Get("Policy"); policy != "" {
+		request.Policy = &policy
+	}
+	assumed, err := iamIntegration.iamManager.AssumeRole(r.Context(), request)
+	if err != nil {
+		return nil, stsErrorOf("AssumeRole", err)
+	}
+
+	response := &AssumeRoleResponse{}
+	response.AssumeRoleResult.Credentials = toStsCredentials(assumed.Credentials)
+	response.AssumeRoleResult.AssumedRoleUser = toStsAssumedRoleUser(request.RoleArn, request.RoleSessionName, assumed.AssumedRoleUser)
+	response.ResponseMetadata.SetRequestId()
+	return response, nil
+}
+
+func (s3a *S3ApiServer) stsGetSessionToken(r *http.Request, identity *Identity, values url.Values) (interface{}, *stsError) {
+	iamIntegration := s3a.iam.iamIntegration
+	if iamIntegration == nil || iamIntegration.stsService == nil {
+		return nil, stsNotConfiguredError()
+	}
+	// as with AWS, temporary credentials can not issue more temporary credentials
+	if identity.session != nil || r.Header.Get(amzSecurityToken) != "" || r.URL.Query().Get(amzSecurityToken) != "" {
+		return nil, newStsError("AccessDenied", http.StatusForbidden, "Cannot call GetSessionToken with session credentials")
+	}
+	durationSeconds, stsErr := parseStsDurationSeconds(values)
+	if stsErr != nil {
+		return nil, stsErr
+	}
+
+	credentials, err := iamIntegration.stsService.GetSessionToken(r.Context(), &sts.GetSessionTokenRequest{
+		CallerArn:       callerArnOf(identity),
+		CallerName:      identity.Name,
+		DurationSeconds: durationSeconds,
+	})
+	if err != nil {
+		return nil, stsErrorOf("GetSessionToken", err)
+	}
+
+	response := &GetSessionTokenResponse{}
+	response.GetSessionTokenResult.Credentials = toStsCredentials(credentials)
+	response.ResponseMetadata.SetRequestId()
+	return response, nil
+}
+
+func stsGetCallerIdentity(identity *Identity) interface{} {
+	response := &GetCallerIdentityResponse{}
+	result := &response.GetCallerIdentityResult
+	result.Arn = callerArnOf(identity)
+	result.UserId = identity.Name
+	if identity.session != nil {
+		result.UserId = utils.ExtractRoleNameFromArn(identity.session.RoleArn) + ":" + identity.session.SessionName
+	}
+	if identity.Account != nil {
+		result.Account = identity.Account.Id
+	}
+	response.ResponseMetadata.SetRequestId()
+	return response
+}
+
+func callerArnOf(identity *Identity) string {
+	if identity.PrincipalArn != "" {
+		return identity.PrincipalArn
+	}
+	return generatePrincipalArn(identity.Name)
+}
+
+func toStsCredentials(credentials *sts.Credentials) StsCredentials {
+	return StsCredentials{
+		AccessKeyId:     credentials.AccessKeyId,
+		SecretAccessKey: credentials.SecretAccessKey,
+		SessionToken:    credentials.SessionToken,
+		Expiration:      credentials.Expiration.UTC().Format(time.RFC3339),
+	}
+}
+
+func toStsAssumedRoleUser(roleArn, sessionName string, user *sts.AssumedRoleUser) StsAssumedRoleUser {
+	return StsAssumedRoleUser{
+		AssumedRoleId: utils.ExtractRoleNameFromArn(roleArn) + ":" + sessionName,
+		Arn:           user.Arn,
+	}
+}
+
+func requireStsParameters(values url.Values, names ...string) *stsError {
+	for _, name := range names {
+		if values.Get(name) == "" {
+			return newStsError("MissingParameter", http.StatusBadRequest, "The request must contain the parameter %s", name)
+		}
+	}
+	return nil
+}
+
+func parseStsDurationSeconds(values url.Values) (*int64, *stsError) {
+	value := values.Get("DurationSeconds")
+	if value == "" {
+		return nil, nil
+	}
+	durationSeconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, newStsError("ValidationError", http.StatusBadRequest, "invalid DurationSeconds %q", value)
+	}
+	return &durationSeconds, nil
+}
+
+func stsNotConfiguredError() *stsError {
+	return newStsError("InvalidAction", http.StatusBadRequest, "STS is not configured, start the S3 gateway with -iam.config")
+}
+
+// stsErrorOf classifies the errors of the STS service
+func stsErrorOf(action string, err error) *stsError {
+	glog.V(1).Infof("sts %s: %v", action, err)
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "invalid request"), strings.HasPrefix(message, "session policies"):
+		return newStsError("ValidationError", http.StatusBadRequest, "%s", message)
+	case strings.HasPrefix(message, "failed to validate web identity token"):
+		return newStsError("InvalidIdentityToken", http.StatusBadRequest, "%s", message)
+	case strings.HasPrefix(message, "failed to generate"):
+		return newStsError("InternalFailure", http.StatusInternalServerError, "failed to issue credentials")
+	}
+	return newStsError("AccessDenied", http.StatusForbidden, "Not authorized to perform sts:%s: %s", action, message)
+}
+
+// stsErrorOfAuth converts the errors of the signature verification
+func stsErrorOfAuth(errCode s3err.ErrorCode) *stsError {
+	switch errCode {
+	case s3err.ErrInvalidAccessKeyID:
+		return newStsError("InvalidClientTokenId", http.StatusForbidden, "The security token included in the request is invalid.")
+	case s3err.ErrExpiredToken:
+		return newStsError("ExpiredToken", http.StatusBadRequest, "The security token included in the request is expired")
+	}
+	apiError := s3err.GetAPIError(errCode)
+	return newStsError(apiError.Code, apiError.HTTPStatusCode, "%s", apiError.Description)
+}
+
+func writeStsErrorResponse(w http.ResponseWriter, r *http.Request, stsErr *stsError) {
+	errorResponse := &StsErrorResponse{}
+	errorResponse.Error.Type = "Sender"
+	if stsErr.StatusCode >= http.StatusInternalServerError {
+		errorResponse.Error.Type = "Receiver"
+	}
+	errorResponse.Error.Code = stsErr.Code
+	errorResponse.Error.Message = stsErr.Message
+	errorResponse.RequestId = newStsRequestId()
+	s3err.WriteXMLResponse(w, r, stsErr.StatusCode, errorResponse)
+}
@@ -0,0 +1,82 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// The responses of the AWS Security Token Service API, version 2011-06-15
+
+type StsResponseMetadata struct {
+	RequestId string `xml:"RequestId"`
+}
+
+type StsCredentials struct {
+	AccessKeyId     string `xml:"AccessKeyId"`
+	SecretAccessKey string `xml:"SecretAccessKey"`
+	SessionToken    string `xml:"SessionToken"`
+	Expiration      string `xml:"Expiration"`
+}
+
+type StsAssumedRoleUser struct {
+	AssumedRoleId string `xml:"AssumedRoleId"`
+	Arn           string `xml:"Arn"`
+}
+
+type AssumeRoleResponse struct {
+	XMLName          xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleResponse"`
+	AssumeRoleResult struct {
+		Credentials     StsCredentials     `xml:"Credentials"`
+		AssumedRoleUser StsAssumedRoleUser `xml:"AssumedRoleUser"`
+	} `xml:"AssumeRoleResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type AssumeRoleWithWebIdentityResponse struct {
+	XMLName                         xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithWebIdentityResponse"`
+	AssumeRoleWithWebIdentityResult struct {
+		Credentials                 StsCredentials     `xml:"Credentials"`
+		SubjectFromWebIdentityToken string             `xml:"SubjectFromWebIdentityToken"`
+		AssumedRoleUser             StsAssumedRoleUser `xml:"AssumedRoleUser"`
+		Provider                    string             `xml:"Provider,omitempty"`
+		Audience                    string             `xml:"Audience,omitempty"`
+	} `xml:"AssumeRoleWithWebIdentityResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type GetSessionTokenResponse struct {
+	XMLName               xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ GetSessionTokenResponse"`
+	GetSessionTokenResult struct {
+		Credentials StsCredentials `xml:"Credentials"`
+	} `xml:"GetSessionTokenResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type GetCallerIdentityResponse struct {
+	XMLName                 xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ GetCallerIdentityResponse"`
+	GetCallerIdentityResult struct {
+		Arn     string `xml:"Arn"`
+		UserId  string `xml:"UserId"`
+		Account string `xml:"Account"`
+	} `xml:"GetCallerIdentityResult"`
+	ResponseMetadata StsResponseMetadata `xml:"ResponseMetadata"`
+}
+
+type StsErrorResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ ErrorResponse"`
+	Error   struct {
+		Type    string `xml:"Type"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+	RequestId string `xml:"RequestId"`
+}
+
+func (m *StsResponseMetadata) SetRequestId() {
+	m.RequestId = newStsRequestId()
+}
+
+func newStsRequestId() string {
+	return fmt.Sprintf("%d", time.Now().UnixNano())
+}
@@ -0,0 +1,125 @@
+package s3api
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3bucket"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+// getTenant returns the configuration of a tenant, or nil if the tenant has no limits configured
+func (iam *IdentityAccessManagement) getTenant(name string) *iam_pb.Tenant {
+	iam.m.RLock()
+	defer iam.m.RUnlock()
+	return iam.tenants[name]
+}
+
+// tenantRequestRate returns the maximum requests per second of a tenant, 0 for unlimited
+func (iam *IdentityAccessManagement) tenantRequestRate(name string) int64 {
+	if tenant := iam.getTenant(name); tenant != nil {
+		return tenant.MaxRequestsPerSecond
+	}
+	return 0
+}
+
+// tenantLocalBucket returns the bucket name to authorize the identity with.
+// The actions of a tenant's identity name the buckets without the tenant prefix,
+// and only admins outside of any tenant can reach the tenants' buckets by their folder names.
+func (identity *Identity) tenantLocalBucket(bucket string) (string, s3err.ErrorCode) {
+	if identity.Tenant != "" {
+		return strings.TrimPrefix(bucket, s3bucket.TenantBucketPrefix(identity.Tenant)), s3err.ErrNone
+	}
+	if strings.Contains(bucket, s3bucket.TenantSeparator) && !identity.isAdmin() {
+		return bucket, s3err.ErrAccessDenied
+	}
+	return bucket, s3err.ErrNone
+}
+
+// scopeToTenant points an authorized request of a tenant's identity to the tenant's buckets,
+// by prefixing the bucket, including the bucket of a copy source, with the tenant name.
+func scopeToTenant(r *http.Request, identity *Identity) *http.Request {
+	if identity == nil || identity.Tenant == "" {
+		return r
+	}
+	r.Header.Set(s3_constants.AmzTenantId, identity.Tenant)
+
+	vars := mux.Vars(r)
+	if bucket := vars["bucket"]; bucket != "" {
+		scoped := make(map[string]string, len(vars))
+		for k, v := range vars {
+			scoped[k] = v
+		}
+		scoped["bucket"] = s3bucket.TenantBucket(identity.Tenant, bucket)
+		r = mux.SetURLVars(r, scoped)
+	}
+
+	if copySource := r.Header.Get("X-Amz-Copy-Source"); copySource != "" {
+		r.Header.Set("X-Amz-Copy-Source", "/"+s3bucket.TenantBucketPrefix(identity.Tenant)+strings.TrimPrefix(copySource, "/"))
+	}
+	return r
+}
+
+// tenantLocalBucketName returns the bucket name to show in a response, as the client of a tenant knows it
+func tenantLocalBucketName(r *http.Request, bucket string) string {
+	if tenant := r.Header.Get(s3_constants.AmzTenantId); tenant != "" {
+		return strings.TrimPrefix(bucket, s3bucket.TenantBucketPrefix(tenant))
+	}
+	return bucket
+}
+
+// checkTenantBucketLimit fails with ErrTooManyBuckets if the tenant of a new bucket already has its maximum number of buckets
+func (s3a *S3ApiServer) checkTenantBucketLimit(bucket string) s3err.ErrorCode {
+	tenantName, _ := s3bucket.SplitTenantBucket(bucket)
+	if tenantName == "" {
+		return s3err.ErrNone
+	}
+	tenant := s3a.iam.getTenant(tenantName)
+	if tenant == nil || tenant.MaxBuckets <= 0 {
+		return s3err.ErrNone
+	}
+	entries, _, err := s3a.list(s3a.option.BucketsPath, s3bucket.TenantBucketPrefix(tenantName), "", false, uint32(tenant.MaxBuckets)+1)
+	if err != nil {
+		glog.Errorf("list buckets of tenant %s: %v", tenantName, err)
+		return s3err.ErrInternalError
+	}
+	count := int64(0)
+	for _, entry := range entries {
+		if entry.IsDirectory {
+			count++
+		}
+	}
+	if count >= tenant.MaxBuckets {
+		glog.V(1).Infof("tenant %s has %d of %d buckets", tenantName, count, tenant.MaxBuckets)
+		return s3err.ErrTooManyBuckets
+	}
+	return s3err.ErrNone
+}
+
+// tenantRateLimiter counts the requests of each tenant in the current second
+type tenantRateLimiter struct {
+	sync.Mutex
+	second   int64
+	counters map[string]int64
+}
+
+// allow counts a request of the tenant, and checks it against the tenant's requests per second
+func (l *tenantRateLimiter) allow(tenant string, maxPerSecond int64, now time.Time) bool {
+	l.Lock()
+	defer l.Unlock()
+	if second := now.Unix(); second != l.second || l.counters == nil {
+		l.second = second
+		l.counters = make(map[string]int64)
+	}
+	if l.counters[tenant] >= maxPerSecond {
+		return false
+	}
+	l.counters[tenant]++
+	return true
+}
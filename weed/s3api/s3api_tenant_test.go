@@ -0,0 +1,86 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantLocalBucket(t *testing.T) {
+	tenantUser := &Identity{Name: "alice", Tenant: "team1", Actions: []Action{"Read"}}
+	bucket, errCode := tenantUser.tenantLocalBucket("team1_photos")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "photos", bucket)
+
+	user := &Identity{Name: "bob", Actions: []Action{"Read"}}
+	_, errCode = user.tenantLocalBucket("team1_photos")
+	assert.Equal(t, s3err.ErrAccessDenied, errCode)
+	bucket, errCode = user.tenantLocalBucket("photos")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "photos", bucket)
+
+	admin := &Identity{Name: "admin", Actions: []Action{s3_constants.ACTION_ADMIN}}
+	bucket, errCode = admin.tenantLocalBucket("team1_photos")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "team1_photos", bucket)
+}
+
+func TestScopeToTenant(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/photos/a.jpg", nil)
+	r = mux.SetURLVars(r, map[string]string{"bucket": "photos", "object": "a.jpg"})
+	r.Header.Set("X-Amz-Copy-Source", "/other/b.jpg")
+
+	r = scopeToTenant(r, &Identity{Name: "alice", Tenant: "team1"})
+	assert.Equal(t, "team1", r.Header.Get(s3_constants.AmzTenantId))
+	assert.Equal(t, "team1_photos", mux.Vars(r)["bucket"])
+	assert.Equal(t, "a.jpg", mux.Vars(r)["object"])
+	assert.Equal(t, "/team1_other/b.jpg", r.Header.Get("X-Amz-Copy-Source"))
+	assert.Equal(t, "photos", tenantLocalBucketName(r, "team1_photos"))
+
+	plain := httptest.NewRequest(http.MethodGet, "/photos", nil)
+	plain = mux.SetURLVars(plain, map[string]string{"bucket": "photos"})
+	plain = scopeToTenant(plain, &Identity{Name: "bob"})
+	assert.Equal(t, "", plain.Header.Get(s3_constants.AmzTenantId))
+	assert.Equal(t, "photos", mux.Vars(plain)["bucket"])
+}
+
+func TestTenantRateLimiter(t *testing.T) {
+	var limiter tenantRateLimiter
+	now := time.Unix(1000, 0)
+	assert.True(t, limiter.allow("team1", 2, now))
+	assert.True(t, limiter.allow("team1", 2, now))
+	assert.False(t, limiter.allow("team1", 2, now))
+	assert.True(t, limiter.allow("team2", 2, now))
+	assert.True(t, limiter.allow("team1", 2, now.Add(time.Second)))
+}
+
+func TestLimitTenant(t *testing.T) {
+	cb := &CircuitBreaker{
+		tenantRequestRate: func(tenant string) int64 {
+			if tenant == "team1" {
+				return 1
+			}
+			return 0
+		},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/photos", nil)
+	assert.Equal(t, s3err.ErrNone, cb.limitTenant(r))
+
+	r.Header.Set(s3_constants.AmzTenantId, "team2")
+	assert.Equal(t, s3err.ErrNone, cb.limitTenant(r))
+	assert.Equal(t, s3err.ErrNone, cb.limitTenant(r))
+
+	r.Header.Set(s3_constants.AmzTenantId, "team1")
+	errCode := cb.limitTenant(r)
+	if errCode == s3err.ErrNone {
+		// the first request may have been counted at the end of the previous second
+		errCode = cb.limitTenant(r)
+	}
+	assert.Equal(t, s3err.ErrSlowDown, errCode)
+}
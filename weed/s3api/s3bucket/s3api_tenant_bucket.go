@@ -0,0 +1,60 @@
+package s3bucket
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TenantSeparator joins a tenant name and a bucket name into the name of the bucket folder, e.g. "team1_photos".
+// Bucket names can not contain it, so the buckets of a tenant never clash with other buckets.
+const TenantSeparator = "_"
+
+// TenantBucket returns the bucket folder name of a tenant's bucket
+func TenantBucket(tenant, bucket string) string {
+	if tenant == "" || bucket == "" {
+		return bucket
+	}
+	return tenant + TenantSeparator + bucket
+}
+
+// SplitTenantBucket returns the tenant and the bucket name of a bucket folder name.
+// The tenant is empty for the buckets outside of any tenant.
+func SplitTenantBucket(name string) (tenant, bucket string) {
+	if tenant, bucket, found := strings.Cut(name, TenantSeparator); found {
+		return tenant, bucket
+	}
+	return "", name
+}
+
+// TenantBucketPrefix is the prefix of the bucket folder names of a tenant
+func TenantBucketPrefix(tenant string) string {
+	return tenant + TenantSeparator
+}
+
+// VerifyTenantName checks a tenant name: 1 to 32 lower case characters, numbers and hyphens,
+// starting and ending with a lower case character or number
+func VerifyTenantName(name string) error {
+	if len(name) < 1 || len(name) > 32 {
+		return fmt.Errorf("tenant name must be between [1, 32] characters")
+	}
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '-') {
+			return fmt.Errorf("tenant name can only contain lower case characters, numbers, and hyphens")
+		}
+	}
+	if name[0] == '-' || name[len(name)-1] == '-' {
+		return fmt.Errorf("tenant name must start and end with number or lower case character")
+	}
+	return nil
+}
+
+// VerifyBucketFolderName checks the name of a bucket folder, either a bucket name or a tenant's bucket
+func VerifyBucketFolderName(name string) error {
+	tenant, bucket := SplitTenantBucket(name)
+	if tenant != "" {
+		if err := VerifyTenantName(tenant); err != nil {
+			return err
+		}
+	}
+	return VerifyS3BucketName(bucket)
+}
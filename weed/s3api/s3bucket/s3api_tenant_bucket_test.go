@@ -0,0 +1,37 @@
+package s3bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantBucket(t *testing.T) {
+	assert.Equal(t, "team1_photos", TenantBucket("team1", "photos"))
+	assert.Equal(t, "photos", TenantBucket("", "photos"))
+
+	tenant, bucket := SplitTenantBucket("team1_photos")
+	assert.Equal(t, "team1", tenant)
+	assert.Equal(t, "photos", bucket)
+
+	tenant, bucket = SplitTenantBucket("photos")
+	assert.Equal(t, "", tenant)
+	assert.Equal(t, "photos", bucket)
+}
+
+func TestVerifyTenantName(t *testing.T) {
+	for _, name := range []string{"team1", "a", "team-1", "0123456789abcdef0123456789abcdef"} {
+		assert.NoError(t, VerifyTenantName(name), name)
+	}
+	for _, name := range []string{"", "Team1", "team_1", "-team", "team-", "team.1", "0123456789abcdef0123456789abcdef0"} {
+		assert.Error(t, VerifyTenantName(name), name)
+	}
+}
+
+func TestVerifyBucketFolderName(t *testing.T) {
+	assert.NoError(t, VerifyBucketFolderName("photos"))
+	assert.NoError(t, VerifyBucketFolderName("team1_photos"))
+	assert.Error(t, VerifyBucketFolderName("Team1_photos"))
+	assert.Error(t, VerifyBucketFolderName("team1_ph"))
+	assert.Error(t, VerifyBucketFolderName("team1_photos_2024"))
+}
@@ -98,6 +98,7 @@ const (
 	ErrSignatureDoesNotMatch
 	ErrContentSHA256Mismatch
 	ErrInvalidAccessKeyID
+	ErrExpiredToken
 	ErrRequestNotReadyYet
 	ErrMissingDateHeader
 	ErrInvalidRequest
@@ -108,9 +109,12 @@ const (
 
 	ErrExistingObjectIsDirectory
 	ErrExistingObjectIsFile
+	ErrQuotaExceeded
 
 	ErrTooManyRequest
 	ErrRequestBytesExceed
+	ErrSlowDown
+	ErrTooManyBuckets
 
 	OwnershipControlsNotFoundError
 	ErrNoSuchTagSet
@@ -417,6 +421,12 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		HTTPStatusCode: http.StatusForbidden,
 	},
 
+	ErrExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The provided token has expired.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+
 	ErrRequestNotReadyYet: {
 		Code:           "AccessDenied",
 		Description:    "Request is not valid yet",
@@ -479,6 +489,11 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "Existing Object is a file.",
 		HTTPStatusCode: http.StatusConflict,
 	},
+	ErrQuotaExceeded: {
+		Code:           "QuotaExceeded",
+		Description:    "The directory quota of this location is exceeded.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrTooManyRequest: {
 		Code:           "ErrTooManyRequest",
 		Description:    "Too many simultaneous request count",
@@ -489,6 +504,16 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "Simultaneous request bytes exceed limitations",
 		HTTPStatusCode: http.StatusTooManyRequests,
 	},
+	ErrSlowDown: {
+		Code:           "SlowDown",
+		Description:    "Please reduce your request rate.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrTooManyBuckets: {
+		Code:           "TooManyBuckets",
+		Description:    "You have attempted to create more buckets than allowed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	OwnershipControlsNotFoundError: {
 		Code:           "OwnershipControlsNotFoundError",
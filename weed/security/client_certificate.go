@@ -0,0 +1,114 @@
+package security
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// ClientCertificateNames returns the names proven by the verified client certificate of a request:
+// the URI SANs such as SPIFFE IDs as they are, and the DNS SANs, email SANs and subject common name
+// prefixed with "dns:", "email:" and "cn:". It returns nil if the client sent no verified certificate.
+func ClientCertificateNames(r *http.Request) []string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return CertificateNames(r.TLS.VerifiedChains[0][0])
+}
+
+// CertificateNames returns the names of a certificate, see ClientCertificateNames
+func CertificateNames(cert *x509.Certificate) (names []string) {
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	for _, dnsName := range cert.DNSNames {
+		names = append(names, "dns:"+dnsName)
+	}
+	for _, email := range cert.EmailAddresses {
+		names = append(names, "email:"+email)
+	}
+	if cert.Subject.CommonName != "" {
+		names = append(names, "cn:"+cert.Subject.CommonName)
+	}
+	return names
+}
+
+// MatchCertificateName checks if one of the names matches the pattern,
+// which is either exact or has one "*" matching any characters, like "dns:*.example.org"
+func MatchCertificateName(pattern string, names []string) bool {
+	prefix, suffix, hasWildcard := strings.Cut(pattern, "*")
+	for _, name := range names {
+		if !hasWildcard {
+			if name == pattern {
+				return true
+			}
+		} else if len(name) >= len(prefix)+len(suffix) && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientCertificateRule grants the clients with a matching certificate access to a path prefix
+type ClientCertificateRule struct {
+	Name       string // certificate name pattern, see MatchCertificateName
+	Write      bool   // read and write access, otherwise read only
+	PathPrefix string
+}
+
+// ParseClientCertificateRule parses a rule in the format "<certificate name> <r|rw> <path prefix>"
+func ParseClientCertificateRule(s string) (*ClientCertificateRule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("client certificate rule %q: expecting \"<certificate name> <r|rw> <path prefix>\"", s)
+	}
+	rule := &ClientCertificateRule{Name: fields[0], PathPrefix: path.Clean("/" + fields[2])}
+	switch fields[1] {
+	case "r":
+	case "rw":
+		rule.Write = true
+	default:
+		return nil, fmt.Errorf("client certificate rule %q: unknown access %q", s, fields[1])
+	}
+	return rule, nil
+}
+
+// LoadClientCertificateRules reads the client_certificate_rules of a component in security.toml
+func LoadClientCertificateRules(config *util.ViperProxy, component string) (rules []*ClientCertificateRule) {
+	for _, s := range config.GetStringSlice(component + ".client_certificate_rules") {
+		rule, err := ParseClientCertificateRule(s)
+		if err != nil {
+			glog.Warningf("skip %v", err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// CheckClientCertificate authorizes a request by its client certificate.
+// The certificate is known if it matches any rule, and is granted access if a matching rule covers the path.
+func CheckClientCertificate(rules []*ClientCertificateRule, names []string, p string, isWrite bool) (known, granted bool) {
+	if len(names) == 0 {
+		return false, false
+	}
+	p = path.Clean("/" + p)
+	for _, rule := range rules {
+		if !MatchCertificateName(rule.Name, names) {
+			continue
+		}
+		known = true
+		if isWrite && !rule.Write {
+			continue
+		}
+		if rule.PathPrefix == "/" || p == rule.PathPrefix || strings.HasPrefix(p, rule.PathPrefix+"/") {
+			return true, true
+		}
+	}
+	return known, false
+}
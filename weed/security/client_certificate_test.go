@@ -0,0 +1,69 @@
+package security
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestCertificateNames(t *testing.T) {
+	spiffeId, _ := url.Parse("spiffe://example.org/ns/prod/sa/uploader")
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "uploader"},
+		URIs:           []*url.URL{spiffeId},
+		DNSNames:       []string{"uploader.prod.svc"},
+		EmailAddresses: []string{"ops@example.org"},
+	}
+	expected := []string{"spiffe://example.org/ns/prod/sa/uploader", "dns:uploader.prod.svc", "email:ops@example.org", "cn:uploader"}
+	if names := CertificateNames(cert); !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, expected %v", names, expected)
+	}
+}
+
+func TestCheckClientCertificate(t *testing.T) {
+	var rules []*ClientCertificateRule
+	for _, s := range []string{
+		"spiffe://example.org/ns/prod/* r /reports",
+		"spiffe://example.org/ns/prod/sa/uploader rw /buckets/uploads",
+		"cn:admin rw /",
+		"dns:*.batch.example.org r /batch",
+	} {
+		rule, err := ParseClientCertificateRule(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+	if _, err := ParseClientCertificateRule("cn:x rwx /"); err == nil {
+		t.Error("expected an unknown access to be rejected")
+	}
+
+	uploader := []string{"spiffe://example.org/ns/prod/sa/uploader"}
+	reader := []string{"spiffe://example.org/ns/prod/sa/reader"}
+	tests := []struct {
+		names          []string
+		path           string
+		isWrite        bool
+		known, granted bool
+	}{
+		{uploader, "/buckets/uploads/a.bin", true, true, true},
+		{uploader, "/buckets/uploads", false, true, true},
+		{uploader, "/buckets/uploads-other/a.bin", true, true, false},
+		{uploader, "/reports/daily.csv", false, true, true},
+		{reader, "/reports/daily.csv", false, true, true},
+		{reader, "/reports/daily.csv", true, true, false},
+		{[]string{"cn:admin"}, "/anything", true, true, true},
+		{[]string{"dns:worker-1.batch.example.org"}, "/batch/job.json", false, true, true},
+		{[]string{"dns:batch.example.org"}, "/batch/job.json", false, false, false},
+		{[]string{"cn:guest"}, "/reports/daily.csv", false, false, false},
+		{nil, "/reports/daily.csv", false, false, false},
+	}
+	for _, tt := range tests {
+		known, granted := CheckClientCertificate(rules, tt.names, tt.path, tt.isWrite)
+		if known != tt.known || granted != tt.granted {
+			t.Errorf("%v on %s write=%v: got known=%v granted=%v", tt.names, tt.path, tt.isWrite, known, granted)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package security
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"path"
+	"slices"
 	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"google.golang.org/grpc/metadata"
 )
 
 type EncodedJwt string
@@ -20,13 +24,73 @@ type SeaweedFileIdClaims struct {
 	jwt.RegisteredClaims
 }
 
+// the actions on filer paths, used by the jwt claims and the filer.conf access rules
+const (
+	FilerActionRead   = "read"
+	FilerActionWrite  = "write"
+	FilerActionDelete = "delete"
+)
+
 // SeaweedFilerClaims is created e.g. by S3 proxy server and consumed by Filer server.
-// Right now, it only contains the standard claims; but this might be extended later
-// for more fine-grained permissions.
+// The subject is the identity checked against the filer.conf access rules.
+// If set, Paths and Actions restrict the token to the path prefixes and the actions.
 type SeaweedFilerClaims struct {
+	Paths   []string `json:"paths,omitempty"`
+	Actions []string `json:"actions,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IsRestricted tells if the claims carry an identity or restrictions,
+// otherwise the token is a cluster internal one with full access
+func (c *SeaweedFilerClaims) IsRestricted() bool {
+	return c.Subject != "" || len(c.Paths) > 0 || len(c.Actions) > 0
+}
+
+// Allows checks if the claims permit the action on the path
+func (c *SeaweedFilerClaims) Allows(action, p string) bool {
+	if len(c.Actions) > 0 && !slices.Contains(c.Actions, action) {
+		return false
+	}
+	if len(c.Paths) == 0 {
+		return true
+	}
+	p = path.Clean("/" + p)
+	for _, prefix := range c.Paths {
+		prefix = path.Clean("/" + prefix)
+		if prefix == "/" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// SeaweedAdminClaims is created by workers and consumed by the admin server,
+// carrying the admin role of the worker, see jwt.admin_signing in security.toml.
+type SeaweedAdminClaims struct {
+	Role string `json:"role"`
 	jwt.RegisteredClaims
 }
 
+// GenJwtForAdminServer creates a JSON-web-token for the subject with the admin role
+func GenJwtForAdminServer(signingKey SigningKey, expiresAfterSec int, subject, role string) EncodedJwt {
+	if len(signingKey) == 0 {
+		return ""
+	}
+
+	claims := SeaweedAdminClaims{Role: role}
+	claims.Subject = subject
+	if expiresAfterSec > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Second * time.Duration(expiresAfterSec)))
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	encoded, e := t.SignedString([]byte(signingKey))
+	if e != nil {
+		glog.V(0).Infof("Failed to sign claims %+v: %v", t.Claims, e)
+		return ""
+	}
+	return EncodedJwt(encoded)
+}
+
 func GenJwtForVolumeServer(signingKey SigningKey, expiresAfterSec int, fileId string) EncodedJwt {
 	if len(signingKey) == 0 {
 		return ""
@@ -51,13 +115,21 @@ func GenJwtForVolumeServer(signingKey SigningKey, expiresAfterSec int, fileId st
 // GenJwtForFilerServer creates a JSON-web-token for using the authenticated Filer API. Used f.e. inside
 // the S3 API
 func GenJwtForFilerServer(signingKey SigningKey, expiresAfterSec int) EncodedJwt {
+	return GenRestrictedJwtForFilerServer(signingKey, expiresAfterSec, "", nil, nil)
+}
+
+// GenRestrictedJwtForFilerServer creates a filer JSON-web-token for the identity,
+// only allowing the actions under the path prefixes. Empty paths or actions are not restricted.
+func GenRestrictedJwtForFilerServer(signingKey SigningKey, expiresAfterSec int, identity string, paths, actions []string) EncodedJwt {
 	if len(signingKey) == 0 {
 		return ""
 	}
 
 	claims := SeaweedFilerClaims{
-		jwt.RegisteredClaims{},
+		Paths:   paths,
+		Actions: actions,
 	}
+	claims.Subject = identity
 	if expiresAfterSec > 0 {
 		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Second * time.Duration(expiresAfterSec)))
 	}
@@ -103,3 +175,19 @@ func DecodeJwt(signingKey SigningKey, tokenString EncodedJwt, claims jwt.Claims)
 		return []byte(signingKey), nil
 	})
 }
+
+// WithGrpcJwt adds the jwt to the outgoing gRPC metadata, to be read by GetGrpcJwt
+func WithGrpcJwt(ctx context.Context, tokenString EncodedJwt) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+string(tokenString))
+}
+
+// GetGrpcJwt returns the bearer jwt of the incoming gRPC metadata
+func GetGrpcJwt(ctx context.Context) EncodedJwt {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, bearer := range md.Get("authorization") {
+		if len(bearer) > 7 && strings.ToUpper(bearer[0:6]) == "BEARER" {
+			return EncodedJwt(bearer[7:])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestrictedFilerJwt(t *testing.T) {
+	signingKey := SigningKey("secret")
+
+	token := GenRestrictedJwtForFilerServer(signingKey, 60, "tenant1", []string{"/home/tenant1/"}, []string{FilerActionRead, FilerActionWrite})
+	claims := &SeaweedFilerClaims{}
+	decoded, err := DecodeJwt(signingKey, token, claims)
+	assert.Nil(t, err)
+	assert.True(t, decoded.Valid)
+	assert.Equal(t, "tenant1", claims.Subject)
+	assert.True(t, claims.IsRestricted())
+
+	assert.True(t, claims.Allows(FilerActionRead, "/home/tenant1"))
+	assert.True(t, claims.Allows(FilerActionWrite, "/home/tenant1/a/b.txt"))
+	assert.False(t, claims.Allows(FilerActionDelete, "/home/tenant1/a/b.txt"))
+	assert.False(t, claims.Allows(FilerActionRead, "/home/tenant12/a.txt"))
+	assert.False(t, claims.Allows(FilerActionRead, "/home/tenant1/../tenant2/a.txt"))
+
+	_, err = DecodeJwt(SigningKey("other"), token, &SeaweedFilerClaims{})
+	assert.NotNil(t, err)
+}
+
+func TestClusterFilerJwt(t *testing.T) {
+	signingKey := SigningKey("secret")
+	claims := &SeaweedFilerClaims{}
+	_, err := DecodeJwt(signingKey, GenJwtForFilerServer(signingKey, 60), claims)
+	assert.Nil(t, err)
+	assert.False(t, claims.IsRestricted())
+	assert.True(t, claims.Allows(FilerActionDelete, "/any/path"))
+}
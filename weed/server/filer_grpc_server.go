@@ -15,6 +15,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
@@ -23,6 +24,10 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 
 	glog.V(4).InfofCtx(ctx, "LookupDirectoryEntry %s", filepath.Join(req.Directory, req.Name))
 
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionRead, util.Join(req.Directory, req.Name)}); err != nil {
+		return nil, err
+	}
+
 	entry, err := fs.filer.FindEntry(ctx, util.JoinPath(req.Directory, req.Name))
 	if err == filer_pb.ErrNotFound {
 		return &filer_pb.LookupDirectoryEntryResponse{}, err
@@ -41,6 +46,10 @@ func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream file
 
 	glog.V(4).Infof("ListEntries %v", req)
 
+	if err := fs.checkGrpcAccess(stream.Context(), filerAccess{security.FilerActionRead, req.Directory}); err != nil {
+		return err
+	}
+
 	limit := int(req.Limit)
 	if limit == 0 {
 		limit = fs.option.DirListingLimit
@@ -140,6 +149,10 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 
 	glog.V(4).InfofCtx(ctx, "CreateEntry %v/%v", req.Directory, req.Entry.Name)
 
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, util.Join(req.Directory, req.Entry.Name)}); err != nil {
+		return nil, err
+	}
+
 	resp = &filer_pb.CreateEntryResponse{}
 
 	chunks, garbage, err2 := fs.cleanupChunks(ctx, util.Join(req.Directory, req.Entry.Name), nil, req.Entry)
@@ -172,6 +185,10 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 	glog.V(4).InfofCtx(ctx, "UpdateEntry %v", req)
 
 	fullpath := util.Join(req.Directory, req.Entry.Name)
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, fullpath}); err != nil {
+		return nil, err
+	}
+
 	entry, err := fs.filer.FindEntry(ctx, util.FullPath(fullpath))
 	if err != nil {
 		return &filer_pb.UpdateEntryResponse{}, fmt.Errorf("not found %s: %v", fullpath, err)
@@ -189,7 +206,21 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		return &filer_pb.UpdateEntryResponse{}, err
 	}
 
+	if !req.IsFromOtherCluster {
+		if err = fs.filer.CheckQuota(ctx, entry, newEntry); err != nil {
+			return &filer_pb.UpdateEntryResponse{}, err
+		}
+		fs.filer.AdvanceVectorClock(entry, newEntry)
+	}
+
 	if err = fs.filer.UpdateEntry(ctx, entry, newEntry); err == nil {
+		if !req.IsFromOtherCluster && fs.filer.MaybeSaveVersion(ctx, entry, newEntry) {
+			// the replaced chunks are kept by the saved version
+			if garbage, err = filer.MinusChunks(ctx, fs.lookupFileId, garbage, entry.GetChunks()); err != nil {
+				glog.ErrorfCtx(ctx, "UpdateEntry %s keep versioned chunks: %v", fullpath, err)
+				garbage, err = nil, nil
+			}
+		}
 		fs.filer.DeleteChunksNotRecursive(garbage)
 
 		fs.filer.NotifyUpdateEvent(ctx, entry, newEntry, true, req.IsFromOtherCluster, req.Signatures)
@@ -243,6 +274,9 @@ func (fs *FilerServer) AppendToEntry(ctx context.Context, req *filer_pb.AppendTo
 
 	glog.V(4).InfofCtx(ctx, "AppendToEntry %v", req)
 	fullpath := util.NewFullPath(req.Directory, req.EntryName)
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, string(fullpath)}); err != nil {
+		return nil, err
+	}
 
 	lockClient := cluster.NewLockClient(fs.grpcDialOption, fs.option.Host)
 	lock := lockClient.NewShortLivedLock(string(fullpath), string(fs.option.Host))
@@ -291,6 +325,10 @@ func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntr
 
 	glog.V(4).InfofCtx(ctx, "DeleteEntry %v", req)
 
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionDelete, util.Join(req.Directory, req.Name)}); err != nil {
+		return nil, err
+	}
+
 	err = fs.filer.DeleteEntryMetaAndData(ctx, util.JoinPath(req.Directory, req.Name), req.IsRecursive, req.IgnoreRecursiveError, req.IsDeleteData, req.IsFromOtherCluster, req.Signatures, req.IfNotModifiedAfter)
 	resp = &filer_pb.DeleteEntryResponse{}
 	if err != nil && err != filer_pb.ErrNotFound {
@@ -301,6 +339,11 @@ func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntr
 
 func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVolumeRequest) (resp *filer_pb.AssignVolumeResponse, err error) {
 
+	// the path picks the storage rules, and the assigned chunk is written to it
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, req.Path}); err != nil {
+		return nil, err
+	}
+
 	if req.DiskType == "" {
 		req.DiskType = fs.option.DiskType
 	}
@@ -334,6 +377,7 @@ func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVol
 		Auth:        string(assignResult.Auth),
 		Collection:  so.Collection,
 		Replication: so.Replication,
+		Cipher:      so.Cipher,
 	}, nil
 }
 
@@ -363,6 +407,11 @@ func (fs *FilerServer) DeleteCollection(ctx context.Context, req *filer_pb.Delet
 
 	glog.V(4).InfofCtx(ctx, "DeleteCollection %v", req)
 
+	// a collection holds the chunks of any path
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionDelete); err != nil {
+		return nil, err
+	}
+
 	err = fs.filer.DoDeleteCollection(req.GetCollection())
 
 	return &filer_pb.DeleteCollectionResponse{}, err
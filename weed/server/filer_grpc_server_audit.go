@@ -0,0 +1,49 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/audit"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/notification"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+)
+
+func (fs *FilerServer) AppendAuditRecord(ctx context.Context, req *filer_pb.AppendAuditRecordRequest) (*filer_pb.AppendAuditRecordResponse, error) {
+
+	glog.V(1).Infof("AppendAuditRecord %v", req)
+
+	if req.Record == nil {
+		return nil, fmt.Errorf("missing audit record")
+	}
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, audit.Directory}); err != nil {
+		return nil, err
+	}
+
+	record := req.Record
+	if record.SourceIp == "" {
+		// the operation came from the client itself, e.g. a weed shell
+		if host, _, err := net.SplitHostPort(findClientAddress(ctx, 0)); err == nil {
+			record.SourceIp = host
+		}
+	}
+
+	record, err := fs.filer.AppendAuditRecord(ctx, string(fs.option.Host), record)
+	if err != nil {
+		return nil, err
+	}
+
+	if fs.option.auditNotification && notification.Queue != nil {
+		if err := notification.Queue.SendMessage(audit.LogFile(record.Host, time.Unix(0, record.TsNs)), record); err != nil {
+			glog.Errorf("notify audit record %s %d: %v", record.Host, record.Sequence, err)
+		}
+	}
+
+	return &filer_pb.AppendAuditRecordResponse{
+		Record: record,
+	}, nil
+}
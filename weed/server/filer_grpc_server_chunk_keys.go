@@ -0,0 +1,31 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func (fs *FilerServer) RewrapChunkKeys(ctx context.Context, req *filer_pb.RewrapChunkKeysRequest) (*filer_pb.RewrapChunkKeysResponse, error) {
+
+	glog.V(1).Infof("RewrapChunkKeys %v", req)
+
+	dir := util.FullPath(filepath.ToSlash(req.Directory))
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionWrite, string(dir)}); err != nil {
+		return nil, err
+	}
+
+	fileCount, err := fs.filer.RewrapChunkKeys(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("rewrap chunk keys under %s stopped after %d files: %v", dir, fileCount, err)
+	}
+
+	return &filer_pb.RewrapChunkKeysResponse{
+		FileCount: fileCount,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func (fs *FilerServer) CloneTree(ctx context.Context, req *filer_pb.CloneTreeRequest) (*filer_pb.CloneTreeResponse, error) {
+
+	glog.V(1).Infof("CloneTree %v", req)
+
+	src := util.FullPath(filepath.ToSlash(req.SourcePath))
+	dst := util.FullPath(filepath.ToSlash(req.TargetPath))
+	if err := fs.checkGrpcAccess(ctx, filerAccess{security.FilerActionRead, string(src)}, filerAccess{security.FilerActionWrite, string(dst)}); err != nil {
+		return nil, err
+	}
+
+	stats, err := fs.filer.CloneTree(ctx, src, dst, req.Signatures)
+	if err != nil {
+		if stats != nil && stats.Files+stats.Directories > 0 {
+			return nil, fmt.Errorf("clone %s to %s stopped after %d files and %d directories: %v", src, dst, stats.Files, stats.Directories, err)
+		}
+		return nil, fmt.Errorf("clone %s to %s: %v", src, dst, err)
+	}
+
+	return &filer_pb.CloneTreeResponse{
+		FileCount:      stats.Files,
+		DirectoryCount: stats.Directories,
+		ByteCount:      stats.Bytes,
+	}, nil
+}
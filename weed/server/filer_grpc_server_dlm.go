@@ -9,6 +9,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,6 +17,10 @@ import (
 // DistributedLock is a grpc handler to handle FilerServer's LockRequest
 func (fs *FilerServer) DistributedLock(ctx context.Context, req *filer_pb.LockRequest) (resp *filer_pb.LockResponse, err error) {
 
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionWrite); err != nil {
+		return nil, err
+	}
+
 	resp = &filer_pb.LockResponse{}
 
 	var movedTo pb.ServerAddress
@@ -53,6 +58,10 @@ func (fs *FilerServer) DistributedLock(ctx context.Context, req *filer_pb.LockRe
 // Unlock is a grpc handler to handle FilerServer's UnlockRequest
 func (fs *FilerServer) DistributedUnlock(ctx context.Context, req *filer_pb.UnlockRequest) (resp *filer_pb.UnlockResponse, err error) {
 
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionWrite); err != nil {
+		return nil, err
+	}
+
 	resp = &filer_pb.UnlockResponse{}
 
 	var movedTo pb.ServerAddress
@@ -82,6 +91,10 @@ func (fs *FilerServer) DistributedUnlock(ctx context.Context, req *filer_pb.Unlo
 }
 
 func (fs *FilerServer) FindLockOwner(ctx context.Context, req *filer_pb.FindLockOwnerRequest) (*filer_pb.FindLockOwnerResponse, error) {
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionRead); err != nil {
+		return nil, err
+	}
+
 	owner, movedTo, err := fs.filer.Dlm.FindLockOwner(req.Name)
 	if !req.IsMoved && movedTo != "" || err == lock_manager.LockNotFound {
 		err = pb.WithFilerClient(false, 0, movedTo, fs.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
@@ -116,6 +129,10 @@ func (fs *FilerServer) FindLockOwner(ctx context.Context, req *filer_pb.FindLock
 // TransferLocks is a grpc handler to handle FilerServer's TransferLocksRequest
 func (fs *FilerServer) TransferLocks(ctx context.Context, req *filer_pb.TransferLocksRequest) (*filer_pb.TransferLocksResponse, error) {
 
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionWrite); err != nil {
+		return nil, err
+	}
+
 	for _, lock := range req.Locks {
 		fs.filer.Dlm.InsertLock(lock.Name, lock.ExpiredAtNs, lock.RenewToken, lock.Owner)
 	}
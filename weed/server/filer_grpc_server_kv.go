@@ -4,10 +4,15 @@ import (
 	"context"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 )
 
 func (fs *FilerServer) KvGet(ctx context.Context, req *filer_pb.KvGetRequest) (*filer_pb.KvGetResponse, error) {
 
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionRead); err != nil {
+		return nil, err
+	}
+
 	value, err := fs.filer.Store.KvGet(ctx, req.Key)
 	if err == filer.ErrKvNotFound {
 		return &filer_pb.KvGetResponse{}, nil
@@ -26,6 +31,10 @@ func (fs *FilerServer) KvGet(ctx context.Context, req *filer_pb.KvGetRequest) (*
 // KvPut sets the key~value. if empty value, delete the kv entry
 func (fs *FilerServer) KvPut(ctx context.Context, req *filer_pb.KvPutRequest) (*filer_pb.KvPutResponse, error) {
 
+	if err := fs.checkGrpcAdmin(ctx, security.FilerActionWrite); err != nil {
+		return nil, err
+	}
+
 	if len(req.Value) == 0 {
 		if err := fs.filer.Store.KvDelete(ctx, req.Key); err != nil {
 			return &filer_pb.KvPutResponse{Error: err.Error()}, nil
@@ -41,3 +50,20 @@ func (fs *FilerServer) KvPut(ctx context.Context, req *filer_pb.KvPutRequest) (*
 	return &filer_pb.KvPutResponse{}, nil
 
 }
+
+// KvList streams all key~value pairs, if the store can list them.
+// The pairs hold the filer's own data, like the wrapped chunk keys, so only admin callers can list them.
+func (fs *FilerServer) KvList(req *filer_pb.KvListRequest, stream filer_pb.SeaweedFiler_KvListServer) error {
+
+	if err := fs.checkGrpcAdmin(stream.Context(), security.FilerActionRead); err != nil {
+		return err
+	}
+
+	return fs.filer.Store.KvList(stream.Context(), func(key, value []byte) error {
+		return stream.Send(&filer_pb.KvListResponse{
+			Key:   key,
+			Value: value,
+		})
+	})
+
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -16,6 +17,12 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 
 	glog.V(1).Infof("AtomicRenameEntry %v", req)
 
+	if err := fs.checkGrpcAccess(ctx,
+		filerAccess{security.FilerActionDelete, util.Join(req.OldDirectory, req.OldName)},
+		filerAccess{security.FilerActionWrite, util.Join(req.NewDirectory, req.NewName)}); err != nil {
+		return nil, err
+	}
+
 	oldParent := util.FullPath(filepath.ToSlash(req.OldDirectory))
 	newParent := util.FullPath(filepath.ToSlash(req.NewDirectory))
 
@@ -52,6 +59,12 @@ func (fs *FilerServer) StreamRenameEntry(req *filer_pb.StreamRenameEntryRequest,
 
 	glog.V(1).Infof("StreamRenameEntry %v", req)
 
+	if err := fs.checkGrpcAccess(stream.Context(),
+		filerAccess{security.FilerActionDelete, util.Join(req.OldDirectory, req.OldName)},
+		filerAccess{security.FilerActionWrite, util.Join(req.NewDirectory, req.NewName)}); err != nil {
+		return err
+	}
+
 	oldParent := util.FullPath(filepath.ToSlash(req.OldDirectory))
 	newParent := util.FullPath(filepath.ToSlash(req.NewDirectory))
 
@@ -0,0 +1,108 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func (fs *FilerServer) SearchEntries(req *filer_pb.SearchEntriesRequest, stream filer_pb.SeaweedFiler_SearchEntriesServer) error {
+
+	glog.V(3).Infof("SearchEntries %v", req)
+
+	if err := fs.checkGrpcAccess(stream.Context(), filerAccess{security.FilerActionRead, req.Directory}); err != nil {
+		return err
+	}
+	if fs.searchIndex == nil {
+		return status.Errorf(codes.Unimplemented, "search index is not enabled on filer %s, start it with -searchIndexDir", fs.option.Host)
+	}
+
+	var sendErr error
+	if err := fs.searchIndex.Search(req, func(dir string, entry *filer_pb.Entry) bool {
+		if sendErr = stream.Send(&filer_pb.SearchEntriesResponse{
+			Directory: dir,
+			Entry:     entry,
+		}); sendErr != nil {
+			return false
+		}
+		return true
+	}); err != nil {
+		return status.Errorf(codes.InvalidArgument, "search: %v", err)
+	}
+	return sendErr
+}
+
+// loopUpdateSearchIndex builds the search index on first use, and then keeps it
+// up to date from the metadata log of the filer cluster
+func (fs *FilerServer) loopUpdateSearchIndex() {
+	for {
+		if err := fs.updateSearchIndex(); err != nil {
+			glog.Errorf("update search index: %v", err)
+		}
+		time.Sleep(1747 * time.Millisecond)
+	}
+}
+
+func (fs *FilerServer) updateSearchIndex() error {
+	offsetTsNs, err := fs.searchIndex.Offset()
+	if err != nil {
+		return fmt.Errorf("read search index offset: %w", err)
+	}
+	if offsetTsNs == 0 {
+		startTsNs := time.Now().UnixNano()
+		glog.V(0).Infof("building search index in %s", fs.option.SearchIndexDir)
+		count, err := fs.buildSearchIndex(context.Background())
+		if err != nil {
+			return fmt.Errorf("build search index: %w", err)
+		}
+		if err = fs.searchIndex.SetOffset(startTsNs); err != nil {
+			return fmt.Errorf("save search index offset: %w", err)
+		}
+		glog.V(0).Infof("search index built with %d entries", count)
+		offsetTsNs = startTsNs
+	}
+
+	return pb.FollowMetadata(fs.option.Host, fs.grpcDialOption, &pb.MetadataFollowOption{
+		ClientName:     "filer:search_index",
+		ClientId:       util.RandomInt32(),
+		PathPrefix:     "/",
+		StartTsNs:      offsetTsNs,
+		EventErrorType: pb.RetryForeverOnError,
+	}, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		if strings.HasPrefix(resp.Directory, filer.SystemLogDir) {
+			return nil
+		}
+		return fs.searchIndex.Update(resp)
+	})
+}
+
+func (fs *FilerServer) buildSearchIndex(ctx context.Context) (count int, err error) {
+	queue := util.NewQueue[util.FullPath]()
+	queue.Enqueue(util.FullPath("/"))
+	for dir := queue.Dequeue(); dir != ""; dir = queue.Dequeue() {
+		if err = fs.iterateDirectory(ctx, dir, func(entry *filer.Entry) error {
+			if entry.FullPath == util.FullPath(filer.SystemLogDir) {
+				return nil
+			}
+			if entry.IsDirectory() {
+				queue.Enqueue(entry.FullPath)
+			}
+			count++
+			return fs.searchIndex.Put(string(dir), entry.ToProtoEntry())
+		}); err != nil {
+			return
+		}
+	}
+	return
+}
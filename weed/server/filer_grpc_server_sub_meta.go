@@ -26,6 +26,9 @@ const (
 func (fs *FilerServer) SubscribeMetadata(req *filer_pb.SubscribeMetadataRequest, stream filer_pb.SeaweedFiler_SubscribeMetadataServer) error {
 
 	ctx := stream.Context()
+	if err := fs.checkGrpcAccess(ctx, subscriptionAccesses(req)...); err != nil {
+		return err
+	}
 	peerAddress := findClientAddress(ctx, 0)
 
 	isReplacing, alreadyKnown, clientName := fs.addClient("", req.ClientName, peerAddress, req.ClientId, req.ClientEpoch)
@@ -122,6 +125,9 @@ func (fs *FilerServer) SubscribeMetadata(req *filer_pb.SubscribeMetadataRequest,
 func (fs *FilerServer) SubscribeLocalMetadata(req *filer_pb.SubscribeMetadataRequest, stream filer_pb.SeaweedFiler_SubscribeLocalMetadataServer) error {
 
 	ctx := stream.Context()
+	if err := fs.checkGrpcAccess(ctx, subscriptionAccesses(req)...); err != nil {
+		return err
+	}
 	peerAddress := findClientAddress(ctx, 0)
 
 	// use negative client id to differentiate from addClient()/deleteClient() used in SubscribeMetadata()
@@ -304,6 +310,12 @@ func (fs *FilerServer) eachEventNotificationFn(req *filer_pb.SubscribeMetadataRe
 		// collect timestamps for path
 		stats.FilerServerLastSendTsOfSubscribeGauge.WithLabelValues(fs.option.Host.String(), req.ClientName, req.PathPrefix).Set(float64(tsNs))
 
+		// the subscribers read the chunks, so they get the plain chunk keys like from the other filer RPCs
+		if err := fs.filer.UnwrapEventChunkKeys(stream.Context(), dirPath, eventNotification); err != nil {
+			glog.V(0).Infof("=> client %v: %v", clientName, err)
+			return err
+		}
+
 		message := &filer_pb.SubscribeMetadataResponse{
 			Directory:         dirPath,
 			EventNotification: eventNotification,
@@ -6,6 +6,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/viant/ptrie"
 )
@@ -14,6 +15,10 @@ func (fs *FilerServer) TraverseBfsMetadata(req *filer_pb.TraverseBfsMetadataRequ
 
 	glog.V(0).Infof("TraverseBfsMetadata %v", req)
 
+	if err := fs.checkGrpcAccess(stream.Context(), filerAccess{security.FilerActionRead, req.Directory}); err != nil {
+		return err
+	}
+
 	excludedTrie := ptrie.New[bool]()
 	for _, excluded := range req.ExcludedPrefixes {
 		excludedTrie.Put([]byte(excluded), true)
@@ -40,10 +40,16 @@ import (
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/redis"
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/redis2"
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/redis3"
+	"github.com/seaweedfs/seaweedfs/weed/filer/search_index"
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/sqlite"
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/tarantool"
 	_ "github.com/seaweedfs/seaweedfs/weed/filer/ydb"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/kms"
+	_ "github.com/seaweedfs/seaweedfs/weed/kms/aws"
+	_ "github.com/seaweedfs/seaweedfs/weed/kms/gcp"
+	_ "github.com/seaweedfs/seaweedfs/weed/kms/local"
+	_ "github.com/seaweedfs/seaweedfs/weed/kms/openbao"
 	"github.com/seaweedfs/seaweedfs/weed/notification"
 	_ "github.com/seaweedfs/seaweedfs/weed/notification/aws_sqs"
 	_ "github.com/seaweedfs/seaweedfs/weed/notification/gocdk_pub_sub"
@@ -69,6 +75,7 @@ type FilerOption struct {
 	DisableHttp           bool
 	Host                  pb.ServerAddress
 	recursiveDelete       bool
+	auditNotification     bool
 	Cipher                bool
 	SaveToFilerLimit      int64
 	ConcurrentUploadLimit int64
@@ -77,6 +84,7 @@ type FilerOption struct {
 	DiskType              string
 	AllowedOrigins        []string
 	ExposeDirectoryData   bool
+	SearchIndexDir        string
 }
 
 type FilerServer struct {
@@ -97,6 +105,12 @@ type FilerServer struct {
 	volumeGuard    *security.Guard
 	grpcDialOption grpc.DialOption
 
+	// path permissions of verified client certificates, accepted in place of a jwt
+	clientCertificateRules []*security.ClientCertificateRule
+
+	// check gRPC calls without a jwt against the access rules for everyone, instead of trusting them
+	grpcAnonymousAccessRules bool
+
 	// metrics read from the master
 	metricsAddress     string
 	metricsIntervalSec int
@@ -104,6 +118,9 @@ type FilerServer struct {
 	// track known metadata listeners
 	knownListenersLock sync.Mutex
 	knownListeners     map[int32]int32
+
+	// optional index to serve SearchEntries
+	searchIndex *search_index.SearchIndex
 }
 
 func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption) (fs *FilerServer, err error) {
@@ -173,6 +190,8 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	whiteList := util.StringSplit(v.GetString("guard.white_list"), ",")
 	fs.filerGuard = security.NewGuard(whiteList, signingKey, expiresAfterSec, readSigningKey, readExpiresAfterSec)
 	fs.volumeGuard = security.NewGuard([]string{}, volumeSigningKey, volumeExpiresAfterSec, volumeReadSigningKey, volumeReadExpiresAfterSec)
+	fs.clientCertificateRules = security.LoadClientCertificateRules(v, "https.filer")
+	fs.grpcAnonymousAccessRules = v.GetBool("jwt.filer_signing.grpc_anonymous_access_rules")
 
 	fs.checkWithMaster()
 
@@ -180,6 +199,7 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	go fs.filer.KeepMasterClientConnected(context.Background())
 
 	fs.option.recursiveDelete = v.GetBool("filer.options.recursive_delete")
+	fs.option.auditNotification = v.GetBool("filer.options.audit_notification")
 	v.SetDefault("filer.options.buckets_folder", "/buckets")
 	fs.filer.DirBucketsPath = v.GetString("filer.options.buckets_folder")
 	// TODO deprecated, will be removed after 2020-12-31
@@ -187,6 +207,11 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	// fs.filer.FsyncBuckets = v.GetStringSlice("filer.options.buckets_fsync")
 	isFresh := fs.filer.LoadConfiguration(v)
 
+	// the kms providers wrapping the chunk keys of locations with a kms key
+	if err := kms.LoadKMSFromFilerToml(v); err != nil {
+		glog.Warningf("load kms configuration: %v", err)
+	}
+
 	notification.LoadConfiguration(v, "notification.")
 
 	handleStaticResources(defaultMux)
@@ -214,9 +239,23 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	fs.filer.LoadRemoteStorageConfAndMapping()
 
+	fs.filer.LoadSnapshotProtection()
+
+	go fs.filer.LoopPruneVersions()
+
+	if option.SearchIndexDir != "" {
+		if fs.searchIndex, err = search_index.Open(option.SearchIndexDir); err != nil {
+			return nil, err
+		}
+		go fs.loopUpdateSearchIndex()
+	}
+
 	grace.OnReload(fs.Reload)
 	grace.OnInterrupt(func() {
 		fs.filer.Shutdown()
+		if fs.searchIndex != nil {
+			fs.searchIndex.Close()
+		}
 	})
 
 	fs.filer.Dlm.LockRing.SetTakeSnapshotCallback(fs.OnDlmChangeSnapshot)
@@ -0,0 +1,138 @@
+package weed_server
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// filerAccess is an action of a request on a filer path
+type filerAccess struct {
+	action string
+	path   string
+}
+
+// filerRequestAccesses returns the actions of an HTTP request on the paths it touches
+func filerRequestAccesses(r *http.Request) []filerAccess {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return []filerAccess{{security.FilerActionRead, r.URL.Path}}
+	case http.MethodDelete:
+		if _, ok := r.URL.Query()["tagging"]; ok {
+			return []filerAccess{{security.FilerActionWrite, r.URL.Path}}
+		}
+		return []filerAccess{{security.FilerActionDelete, r.URL.Path}}
+	}
+	accesses := []filerAccess{{security.FilerActionWrite, r.URL.Path}}
+	query := r.URL.Query()
+	if src := query.Get("mv.from"); src != "" {
+		accesses = append(accesses, filerAccess{security.FilerActionDelete, src})
+	} else if src := query.Get("cp.from"); src != "" {
+		accesses = append(accesses, filerAccess{security.FilerActionRead, src})
+	}
+	return accesses
+}
+
+// isAccessAllowed checks the action on the path against the jwt claims and the filer.conf access rules.
+// A jwt without identity and restrictions is issued inside the cluster, e.g. by the S3 gateway, and has full access.
+func (fs *FilerServer) isAccessAllowed(claims *security.SeaweedFilerClaims, identity, action, p string) bool {
+	p = path.Clean("/" + p)
+	if claims != nil {
+		if !claims.IsRestricted() {
+			return true
+		}
+		if !claims.Allows(action, p) {
+			return false
+		}
+	}
+	return fs.filer.FilerConf.IsAccessAllowed(p, identity, action)
+}
+
+// checkGrpcAccess authorizes a gRPC request by its jwt, see security.WithGrpcJwt, and the filer.conf access rules.
+// The cluster components call without a jwt, so such requests are trusted, unless
+// jwt.filer_signing.grpc_anonymous_access_rules is set: then only the access rules for everyone apply to them, like on HTTP.
+func (fs *FilerServer) checkGrpcAccess(ctx context.Context, accesses ...filerAccess) error {
+	claims, err := fs.grpcClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims == nil && !fs.grpcAnonymousAccessRules {
+		return nil
+	}
+	var identity string
+	if claims != nil {
+		identity = claims.Subject
+	}
+	for _, access := range accesses {
+		if !fs.isAccessAllowed(claims, identity, access.action, access.path) {
+			glog.V(1).Infof("grpc %s on %s denied for %q", access.action, access.path, identity)
+			return status.Errorf(codes.PermissionDenied, "%s on %s is denied", access.action, access.path)
+		}
+	}
+	return nil
+}
+
+// checkGrpcAdmin authorizes a gRPC request on the filer's own data, like the key value pairs, the locks
+// and the collections. A jwt with an identity or restrictions is denied. Requests without a jwt are trusted
+// like in checkGrpcAccess, or checked against the filer.conf access rules of the filer's system directory.
+func (fs *FilerServer) checkGrpcAdmin(ctx context.Context, action string) error {
+	claims, err := fs.grpcClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims != nil {
+		if claims.IsRestricted() {
+			glog.V(1).Infof("grpc %s on the filer data denied for %q", action, claims.Subject)
+			return status.Errorf(codes.PermissionDenied, "%s on the filer data is denied", action)
+		}
+		return nil
+	}
+	if !fs.grpcAnonymousAccessRules {
+		return nil
+	}
+	if !fs.filer.FilerConf.IsAccessAllowed(filer.DirectoryEtcSeaweedFS, "", action) {
+		glog.V(1).Infof("grpc %s on the filer data denied for anonymous", action)
+		return status.Errorf(codes.PermissionDenied, "%s on the filer data is denied", action)
+	}
+	return nil
+}
+
+// subscriptionAccesses returns the reads of a metadata subscription. A prefix not ending with a slash
+// also matches the siblings starting with it, so its parent directory is read.
+func subscriptionAccesses(req *filer_pb.SubscribeMetadataRequest) []filerAccess {
+	var accesses []filerAccess
+	for _, prefix := range append([]string{req.PathPrefix}, req.PathPrefixes...) {
+		if prefix == "" && (len(req.PathPrefixes) > 0 || len(req.Directories) > 0) {
+			continue
+		}
+		if !strings.HasSuffix(prefix, "/") {
+			prefix = path.Dir("/" + prefix)
+		}
+		accesses = append(accesses, filerAccess{security.FilerActionRead, prefix})
+	}
+	for _, dir := range req.Directories {
+		accesses = append(accesses, filerAccess{security.FilerActionRead, dir})
+	}
+	return accesses
+}
+
+// grpcClaims returns the verified claims of the gRPC request, nil without a jwt or signing key
+func (fs *FilerServer) grpcClaims(ctx context.Context) (*security.SeaweedFilerClaims, error) {
+	tokenStr := security.GetGrpcJwt(ctx)
+	if tokenStr == "" || len(fs.filerGuard.SigningKey) == 0 {
+		return nil, nil
+	}
+	claims := &security.SeaweedFilerClaims{}
+	if token, err := security.DecodeJwt(fs.filerGuard.SigningKey, tokenStr, claims); err != nil || !token.Valid {
+		return nil, status.Errorf(codes.Unauthenticated, "wrong jwt: %v", err)
+	}
+	return claims, nil
+}
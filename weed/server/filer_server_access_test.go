@@ -0,0 +1,196 @@
+package weed_server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestFilerRequestAccesses(t *testing.T) {
+	tests := []struct {
+		method   string
+		url      string
+		expected []filerAccess
+	}{
+		{"GET", "/home/a.txt", []filerAccess{{security.FilerActionRead, "/home/a.txt"}}},
+		{"DELETE", "/home/a.txt", []filerAccess{{security.FilerActionDelete, "/home/a.txt"}}},
+		{"DELETE", "/home/a.txt?tagging", []filerAccess{{security.FilerActionWrite, "/home/a.txt"}}},
+		{"POST", "/home/b.txt?mv.from=/home/a.txt", []filerAccess{
+			{security.FilerActionWrite, "/home/b.txt"},
+			{security.FilerActionDelete, "/home/a.txt"},
+		}},
+		{"POST", "/home/b.txt?cp.from=/shared/a.txt", []filerAccess{
+			{security.FilerActionWrite, "/home/b.txt"},
+			{security.FilerActionRead, "/shared/a.txt"},
+		}},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.url, nil)
+		assert.Equal(t, tt.expected, filerRequestAccesses(r), "%s %s", tt.method, tt.url)
+	}
+}
+
+func TestSubscriptionAccesses(t *testing.T) {
+	tests := []struct {
+		req      *filer_pb.SubscribeMetadataRequest
+		expected []filerAccess
+	}{
+		{&filer_pb.SubscribeMetadataRequest{}, []filerAccess{{security.FilerActionRead, "/"}}},
+		{&filer_pb.SubscribeMetadataRequest{PathPrefix: "/home/tenant1/"}, []filerAccess{{security.FilerActionRead, "/home/tenant1/"}}},
+		{&filer_pb.SubscribeMetadataRequest{PathPrefix: "/home/tenant1"}, []filerAccess{{security.FilerActionRead, "/home"}}},
+		{&filer_pb.SubscribeMetadataRequest{PathPrefixes: []string{"/a/"}, Directories: []string{"/b"}}, []filerAccess{
+			{security.FilerActionRead, "/a/"},
+			{security.FilerActionRead, "/b"},
+		}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, subscriptionAccesses(tt.req), "%v", tt.req)
+	}
+}
+
+func TestCheckAuthorizationClientCertificate(t *testing.T) {
+	rule, err := security.ParseClientCertificateRule("cn:uploader rw /buckets/uploads")
+	assert.NoError(t, err)
+	fs := &FilerServer{
+		clientCertificateRules: []*security.ClientCertificateRule{rule},
+		filer:                  &filer.Filer{FilerConf: filer.NewFilerConf()},
+	}
+	tests := []struct {
+		url     string
+		granted bool
+	}{
+		{"/buckets/uploads/b.txt", true},
+		{"/buckets/uploads/b.txt?mv.from=/buckets/uploads/a.txt", true},
+		{"/buckets/uploads/b.txt?mv.from=/buckets/other/a.txt", false},
+		{"/buckets/uploads/b.txt?cp.from=/buckets/other/a.txt", false},
+		{"/buckets/other/b.txt", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, tt.url, nil)
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "uploader"}}}}}
+		w := httptest.NewRecorder()
+		assert.Equal(t, tt.granted, fs.checkAuthorization(w, r, true), tt.url)
+	}
+}
+
+// the filer RPCs that only return cluster information, which every client needs to read and write chunks
+var publicFilerRpcs = map[string]bool{
+	"LookupVolume":          true,
+	"CollectionList":        true,
+	"Statistics":            true,
+	"Ping":                  true,
+	"GetFilerConfiguration": true,
+}
+
+// TestFilerRpcsCheckAccess calls every filer RPC with a jwt restricted to another path,
+// so that a new RPC can not skip the access check.
+func TestFilerRpcsCheckAccess(t *testing.T) {
+	signingKey := security.SigningKey("secret")
+	fs := &FilerServer{
+		option:     &FilerOption{},
+		filer:      &filer.Filer{FilerConf: filer.NewFilerConf()},
+		filerGuard: security.NewGuard(nil, string(signingKey), 10, "", 10),
+	}
+	token := security.GenRestrictedJwtForFilerServer(signingKey, 10, "tenant1", []string{"/tenant1/"},
+		[]string{security.FilerActionRead, security.FilerActionWrite, security.FilerActionDelete})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+string(token)))
+	decode := func(m any) error {
+		fillRequest(m.(proto.Message).ProtoReflect())
+		return nil
+	}
+
+	desc := filer_pb.SeaweedFiler_ServiceDesc
+	for _, method := range desc.Methods {
+		if publicFilerRpcs[method.MethodName] {
+			continue
+		}
+		err := callUnchecked(func() error {
+			_, err := method.Handler(fs, ctx, decode, nil)
+			return err
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "%s: %v", method.MethodName, err)
+	}
+	for _, stream := range desc.Streams {
+		assert.False(t, publicFilerRpcs[stream.StreamName], stream.StreamName)
+		err := callUnchecked(func() error {
+			return stream.Handler(fs, &requestServerStream{ctx: ctx, decode: decode})
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "%s: %v", stream.StreamName, err)
+	}
+
+	var names []string
+	for _, method := range desc.Methods {
+		names = append(names, method.MethodName)
+	}
+	for name := range publicFilerRpcs {
+		assert.Contains(t, names, name)
+	}
+}
+
+func TestCheckGrpcAccessAnonymous(t *testing.T) {
+	fc := filer.NewFilerConf()
+	assert.NoError(t, fc.SetLocationConf(&filer_pb.FilerConf_PathConf{
+		LocationPrefix: "/home/",
+		AccessRules:    []*filer_pb.FilerConf_AccessRule{{Identity: "*", Actions: []string{security.FilerActionRead}}},
+	}))
+	fs := &FilerServer{
+		filer:      &filer.Filer{FilerConf: fc},
+		filerGuard: security.NewGuard(nil, "secret", 10, "", 10),
+	}
+	write := filerAccess{security.FilerActionWrite, "/home/a.txt"}
+
+	// the cluster components call without a jwt
+	assert.NoError(t, fs.checkGrpcAccess(context.Background(), write))
+	assert.NoError(t, fs.checkGrpcAdmin(context.Background(), security.FilerActionWrite))
+
+	fs.grpcAnonymousAccessRules = true
+	assert.NoError(t, fs.checkGrpcAccess(context.Background(), filerAccess{security.FilerActionRead, "/home/a.txt"}))
+	assert.Equal(t, codes.PermissionDenied, status.Code(fs.checkGrpcAccess(context.Background(), write)))
+}
+
+// callUnchecked turns the panic of an RPC reaching the unset filer parts of the test into an error
+func callUnchecked(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("passed the access check: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// fillRequest sets the message fields of the request, which the handlers expect
+func fillRequest(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if field := fields.Get(i); field.Kind() == protoreflect.MessageKind && field.Cardinality() != protoreflect.Repeated {
+			m.Mutable(field)
+		}
+	}
+}
+
+// requestServerStream passes the request to a streaming RPC, and drops its responses
+type requestServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	decode func(m any) error
+}
+
+func (s *requestServerStream) Context() context.Context { return s.ctx }
+func (s *requestServerStream) RecvMsg(m any) error      { return s.decode(m) }
+func (s *requestServerStream) SendMsg(m any) error      { return nil }
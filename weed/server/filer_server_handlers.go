@@ -74,8 +74,7 @@ func (fs *FilerServer) filerHandler(w http.ResponseWriter, r *http.Request) {
 	}(&requestMethod)
 
 	isReadHttpCall := r.Method == http.MethodGet || r.Method == http.MethodHead
-	if !fs.maybeCheckJwtAuthorization(r, !isReadHttpCall) {
-		writeJsonError(w, r, http.StatusUnauthorized, errors.New("wrong jwt"))
+	if !fs.checkAuthorization(w, r, !isReadHttpCall) {
 		return
 	}
 
@@ -162,8 +161,7 @@ func (fs *FilerServer) readonlyFilerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if !fs.maybeCheckJwtAuthorization(r, false) {
-		writeJsonError(w, r, http.StatusUnauthorized, errors.New("wrong jwt"))
+	if !fs.checkAuthorization(w, r, false) {
 		return
 	}
 
@@ -189,20 +187,66 @@ func OptionsHandler(w http.ResponseWriter, r *http.Request, isReadOnly bool) {
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 }
 
-// maybeCheckJwtAuthorization returns true if access should be granted, false if it should be denied
-func (fs *FilerServer) maybeCheckJwtAuthorization(r *http.Request, isWrite bool) bool {
+// checkAuthorization returns true if access should be granted, or writes the error response and returns false.
+// A client certificate matching a rule is authorized by the rules, other requests by their jwt.
+// Then the jwt claims and the filer.conf access rules are checked for each path of the request.
+func (fs *FilerServer) checkAuthorization(w http.ResponseWriter, r *http.Request, isWrite bool) bool {
+	var identity string
+	var claims *security.SeaweedFilerClaims
+	accesses := filerRequestAccesses(r)
+	certificateGranted := false
+	if len(fs.clientCertificateRules) > 0 {
+		names := security.ClientCertificateNames(r)
+		// every path of the request needs a rule, e.g. moving from mv.from writes there
+		for _, access := range accesses {
+			known, granted := security.CheckClientCertificate(fs.clientCertificateRules, names, access.path, access.action != security.FilerActionRead)
+			if known && !granted {
+				glog.V(1).Infof("client certificate %v from %s denied on %s", names, r.RemoteAddr, access.path)
+				writeJsonError(w, r, http.StatusForbidden, errors.New("access denied for the client certificate"))
+				return false
+			}
+			certificateGranted = granted
+		}
+		if certificateGranted {
+			// the first name, usually the SPIFFE ID, identifies the client in the access rules
+			identity = names[0]
+		}
+	}
+	if !certificateGranted {
+		var ok bool
+		if claims, ok = fs.maybeCheckJwtAuthorization(r, isWrite); !ok {
+			writeJsonError(w, r, http.StatusUnauthorized, errors.New("wrong jwt"))
+			return false
+		}
+		if claims != nil {
+			identity = claims.Subject
+		}
+	}
+	for _, access := range accesses {
+		if !fs.isAccessAllowed(claims, identity, access.action, access.path) {
+			glog.V(1).Infof("%s on %s denied for %q from %s", access.action, access.path, identity, r.RemoteAddr)
+			writeJsonError(w, r, http.StatusForbidden, errors.New("access denied"))
+			return false
+		}
+	}
+	return true
+}
+
+// maybeCheckJwtAuthorization returns the verified claims, if any, and false if access should be denied.
+// Without a signing key for the operation, the request is accepted, and a jwt signed with the write key still identifies it.
+func (fs *FilerServer) maybeCheckJwtAuthorization(r *http.Request, isWrite bool) (*security.SeaweedFilerClaims, bool) {
 
 	var signingKey security.SigningKey
 
 	if isWrite {
 		if len(fs.filerGuard.SigningKey) == 0 {
-			return true
+			return nil, true
 		} else {
 			signingKey = fs.filerGuard.SigningKey
 		}
 	} else {
 		if len(fs.filerGuard.ReadSigningKey) == 0 {
-			return true
+			return fs.optionalJwtClaims(r), true
 		} else {
 			signingKey = fs.filerGuard.ReadSigningKey
 		}
@@ -211,20 +255,34 @@ func (fs *FilerServer) maybeCheckJwtAuthorization(r *http.Request, isWrite bool)
 	tokenStr := security.GetJwt(r)
 	if tokenStr == "" {
 		glog.V(1).Infof("missing jwt from %s", r.RemoteAddr)
-		return false
+		return nil, false
 	}
 
-	token, err := security.DecodeJwt(signingKey, tokenStr, &security.SeaweedFilerClaims{})
+	claims := &security.SeaweedFilerClaims{}
+	token, err := security.DecodeJwt(signingKey, tokenStr, claims)
 	if err != nil {
 		glog.V(1).Infof("jwt verification error from %s: %v", r.RemoteAddr, err)
-		return false
+		return nil, false
 	}
 	if !token.Valid {
 		glog.V(1).Infof("jwt invalid from %s: %v", r.RemoteAddr, tokenStr)
-		return false
+		return nil, false
 	} else {
-		return true
+		return claims, true
+	}
+}
+
+// optionalJwtClaims returns the claims of a jwt signed with the write key, or nil
+func (fs *FilerServer) optionalJwtClaims(r *http.Request) *security.SeaweedFilerClaims {
+	tokenStr := security.GetJwt(r)
+	if tokenStr == "" || len(fs.filerGuard.SigningKey) == 0 {
+		return nil
+	}
+	claims := &security.SeaweedFilerClaims{}
+	if token, err := security.DecodeJwt(fs.filerGuard.SigningKey, tokenStr, claims); err != nil || !token.Valid {
+		return nil
 	}
+	return claims
 }
 
 func (fs *FilerServer) filerHealthzHandler(w http.ResponseWriter, r *http.Request) {
@@ -279,6 +279,7 @@ func (fs *FilerServer) detectStorageOption(ctx context.Context, requestURI, qCol
 		Fsync:             rule.Fsync,
 		VolumeGrowthCount: rule.VolumeGrowthCount,
 		MaxFileNameLength: rule.MaxFileNameLength,
+		Cipher:            fs.option.Cipher || rule.KmsKeyId != "",
 	}, nil
 }
 
@@ -56,6 +56,8 @@ func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *
 			writeJsonError(w, r, util.HttpStatusCancelled, err)
 		} else if strings.HasSuffix(err.Error(), "is a file") || strings.HasSuffix(err.Error(), "already exists") {
 			writeJsonError(w, r, http.StatusConflict, err)
+		} else if filer.IsQuotaExceeded(err) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, err)
 		} else {
 			writeJsonError(w, r, http.StatusInternalServerError, err)
 		}
@@ -400,7 +402,7 @@ func (fs *FilerServer) saveAsChunk(ctx context.Context, so *operation.StorageOpt
 			uploadOption := &operation.UploadOption{
 				UploadUrl:         urlLocation,
 				Filename:          name,
-				Cipher:            fs.option.Cipher,
+				Cipher:            so.Cipher,
 				IsInputCompressed: false,
 				MimeType:          "",
 				PairMap:           nil,
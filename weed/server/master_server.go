@@ -114,6 +114,16 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers map[string]pb.Se
 	topology.VolumeGrowStrategy.Copy3Count = v.GetUint32("master.volume_growth.copy_3")
 	topology.VolumeGrowStrategy.CopyOtherCount = v.GetUint32("master.volume_growth.copy_other")
 	topology.VolumeGrowStrategy.Threshold = v.GetFloat64("master.volume_growth.threshold")
+
+	v.SetDefault("master.placement.strategy", topology.SlotsPlacement)
+	if err := topology.SetPlacementStrategy("", v.GetString("master.placement.strategy")); err != nil {
+		glog.Fatalf("master.placement.strategy: %v", err)
+	}
+	for collection, strategy := range v.GetStringMapString("master.placement.collections") {
+		if err := topology.SetPlacementStrategy(collection, strategy); err != nil {
+			glog.Fatalf("master.placement.collections.%s: %v", collection, err)
+		}
+	}
 	whiteList := util.StringSplit(v.GetString("guard.white_list"), ",")
 
 	var preallocateSize int64
@@ -18,16 +18,31 @@ func (vs *VolumeServer) VolumeIncrementalCopy(req *volume_server_pb.VolumeIncrem
 	}
 
 	stopOffset, _, _ := v.FileStat()
-	foundOffset, isLastOne, err := v.BinarySearchByAppendAtNs(req.SinceNs)
-	if err != nil {
-		return fmt.Errorf("fail to locate by appendAtNs %d: %s", req.SinceNs, err)
+	if req.StopOffset > 0 {
+		if req.StopOffset > stopOffset {
+			return fmt.Errorf("volume %d size %d is less than stop offset %d", req.VolumeId, stopOffset, req.StopOffset)
+		}
+		stopOffset = req.StopOffset
 	}
 
-	if isLastOne {
-		return nil
-	}
+	var startOffset int64
+	if req.SinceOffset > 0 {
+		if req.SinceOffset > stopOffset {
+			return fmt.Errorf("volume %d since offset %d is beyond %d", req.VolumeId, req.SinceOffset, stopOffset)
+		}
+		startOffset = int64(req.SinceOffset)
+	} else {
+		foundOffset, isLastOne, err := v.BinarySearchByAppendAtNs(req.SinceNs)
+		if err != nil {
+			return fmt.Errorf("fail to locate by appendAtNs %d: %s", req.SinceNs, err)
+		}
 
-	startOffset := foundOffset.ToActualOffset()
+		if isLastOne {
+			return nil
+		}
+
+		startOffset = foundOffset.ToActualOffset()
+	}
 
 	buf := make([]byte, 1024*1024*2)
 	return sendFileContent(v.DataBackend, buf, startOffset, int64(stopOffset), stream)
@@ -50,10 +65,14 @@ func (vs *VolumeServer) VolumeSyncStatus(ctx context.Context, req *volume_server
 func sendFileContent(datBackend backend.BackendStorageFile, buf []byte, startOffset, stopOffset int64, stream volume_server_pb.VolumeServer_VolumeIncrementalCopyServer) error {
 	var blockSizeLimit = int64(len(buf))
 	for i := int64(0); i < stopOffset-startOffset; i += blockSizeLimit {
-		n, readErr := datBackend.ReadAt(buf, startOffset+i)
+		block := buf
+		if remaining := stopOffset - startOffset - i; remaining < blockSizeLimit {
+			block = buf[:remaining]
+		}
+		n, readErr := datBackend.ReadAt(block, startOffset+i)
 		if readErr == nil || readErr == io.EOF {
 			resp := &volume_server_pb.VolumeIncrementalCopyResponse{}
-			resp.FileContent = buf[:int64(n)]
+			resp.FileContent = block[:int64(n)]
 			sendErr := stream.Send(resp)
 			if sendErr != nil {
 				return sendErr
@@ -0,0 +1,154 @@
+package weed_server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+)
+
+var ErrWebDavUnauthorized = errors.New("invalid user name or password")
+
+// WebDavUser is an authenticated WebDAV user
+type WebDavUser struct {
+	Name     string
+	CanRead  bool
+	CanWrite bool
+}
+
+// WebDavAuthenticator checks the user name and password of a WebDAV request
+type WebDavAuthenticator interface {
+	Authenticate(ctx context.Context, userName, password string) (*WebDavUser, error)
+}
+
+// CredentialWebDavAuthenticator checks passwords against the S3 identities of a credential store.
+// A user logs in with the identity name or an access key, and the secret key as the password.
+type CredentialWebDavAuthenticator struct {
+	credentialManager *credential.CredentialManager
+	refreshInterval   time.Duration
+
+	sync.Mutex
+	identities map[string]*iam_pb.Identity // by name and by access key
+	loadedAt   time.Time
+}
+
+func NewCredentialWebDavAuthenticator(credentialManager *credential.CredentialManager) *CredentialWebDavAuthenticator {
+	return &CredentialWebDavAuthenticator{
+		credentialManager: credentialManager,
+		refreshInterval:   30 * time.Second,
+	}
+}
+
+func (a *CredentialWebDavAuthenticator) Authenticate(ctx context.Context, userName, password string) (*WebDavUser, error) {
+	identity, err := a.lookup(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil || password == "" {
+		return nil, ErrWebDavUnauthorized
+	}
+	matched := false
+	for _, cred := range identity.Credentials {
+		if identity.Name != userName && cred.AccessKey != userName {
+			continue
+		}
+		if cred.SecretKey != "" && subtle.ConstantTimeCompare([]byte(cred.SecretKey), []byte(password)) == 1 {
+			matched = true
+		}
+	}
+	if !matched {
+		return nil, ErrWebDavUnauthorized
+	}
+
+	user := &WebDavUser{Name: identity.Name}
+	for _, action := range identity.Actions {
+		// only actions without a bucket apply to the whole file system
+		switch action {
+		case s3_constants.ACTION_ADMIN:
+			user.CanRead, user.CanWrite = true, true
+		case s3_constants.ACTION_WRITE:
+			user.CanWrite = true
+		case s3_constants.ACTION_READ, s3_constants.ACTION_LIST:
+			user.CanRead = true
+		}
+	}
+	return user, nil
+}
+
+func (a *CredentialWebDavAuthenticator) lookup(ctx context.Context, userName string) (*iam_pb.Identity, error) {
+	a.Lock()
+	defer a.Unlock()
+	if a.identities == nil || time.Since(a.loadedAt) > a.refreshInterval {
+		config, err := a.credentialManager.LoadConfiguration(ctx)
+		if err != nil {
+			if a.identities == nil {
+				return nil, err
+			}
+			glog.Warningf("reload webdav users: %v", err)
+		} else {
+			identities := make(map[string]*iam_pb.Identity)
+			for _, identity := range config.Identities {
+				identities[identity.Name] = identity
+				for _, cred := range identity.Credentials {
+					identities[cred.AccessKey] = identity
+				}
+			}
+			a.identities, a.loadedAt = identities, time.Now()
+		}
+	}
+	return a.identities[userName], nil
+}
+
+func isWebDavReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		return true
+	}
+	return false
+}
+
+// authenticate returns the user of the request, or writes the error response and returns nil
+func (ws *WebDavServer) authenticate(w http.ResponseWriter, r *http.Request) *WebDavUser {
+	userName, password, ok := r.BasicAuth()
+	if !ok {
+		ws.requestCredentials(w)
+		return nil
+	}
+	user, err := ws.option.Authenticator.Authenticate(r.Context(), userName, password)
+	if err != nil {
+		if err != ErrWebDavUnauthorized {
+			glog.Errorf("webdav authenticate %s: %v", userName, err)
+			http.Error(w, "authentication is not available", http.StatusServiceUnavailable)
+			return nil
+		}
+		glog.V(1).Infof("webdav user %s from %s: %v", userName, r.RemoteAddr, err)
+		ws.requestCredentials(w)
+		return nil
+	}
+	if isWebDavReadMethod(r.Method) && !user.CanRead || !isWebDavReadMethod(r.Method) && !user.CanWrite {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return nil
+	}
+	return user
+}
+
+func (ws *WebDavServer) requestCredentials(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="SeaweedFS WebDAV", charset="UTF-8"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// userRoot returns the root directory of the user under the WebDAV root, or "" to share the WebDAV root
+func (ws *WebDavServer) userRoot(user *WebDavUser) string {
+	if ws.option.UserRootPath == "" || user == nil {
+		return ""
+	}
+	return "/" + strings.Trim(strings.ReplaceAll(ws.option.UserRootPath, "{user}", user.Name), "/")
+}
@@ -0,0 +1,248 @@
+package weed_server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/webdav"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// WebDAV locks are kept in the filer KV store, so every WebDAV server on the same filers
+// sees the same locks, and the locks survive restarts.
+// Changes to the locks are serialized by the filer distributed lock manager.
+const webDavLocksKey = "webdav.locks"
+
+// Locks without a timeout still expire, so a server that dies during a request
+// does not leave its temporary lock behind forever. Clients refresh their locks.
+const webDavMaxLockDuration = 10 * time.Minute
+
+type webDavLock struct {
+	Root       string        `json:"root"`
+	Duration   time.Duration `json:"duration"`
+	OwnerXML   string        `json:"ownerXml,omitempty"`
+	ZeroDepth  bool          `json:"zeroDepth,omitempty"`
+	ExpireAtNs int64         `json:"expireAtNs"`
+}
+
+func (l *webDavLock) isExpired(now time.Time) bool {
+	return l.ExpireAtNs <= now.UnixNano()
+}
+
+// covers returns whether the lock applies to the named resource
+func (l *webDavLock) covers(name string) bool {
+	if l.Root == name {
+		return true
+	}
+	return !l.ZeroDepth && (l.Root == "/" || strings.HasPrefix(name, l.Root+"/"))
+}
+
+// filerLockStore reads and changes the lock table shared by all WebDAV servers
+type filerLockStore struct {
+	option     *WebDavOption
+	lockClient *cluster.LockClient
+	owner      string
+	localLock  sync.Mutex
+}
+
+func newFilerLockStore(option *WebDavOption) *filerLockStore {
+	return &filerLockStore{
+		option:     option,
+		lockClient: cluster.NewLockClient(option.GrpcDialOption, option.Filer),
+		owner:      "webdav-" + uuid.New().String(),
+	}
+}
+
+func (s *filerLockStore) read() (map[string]*webDavLock, error) {
+	var value []byte
+	err := pb.WithGrpcFilerClient(false, 0, s.option.Filer, s.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: []byte(webDavLocksKey)})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		value = resp.Value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	locks := make(map[string]*webDavLock)
+	if len(value) > 0 {
+		if err = json.Unmarshal(value, &locks); err != nil {
+			return nil, err
+		}
+	}
+	return locks, nil
+}
+
+func (s *filerLockStore) write(locks map[string]*webDavLock) error {
+	var value []byte
+	if len(locks) > 0 {
+		var err error
+		if value, err = json.Marshal(locks); err != nil {
+			return err
+		}
+	}
+	return pb.WithGrpcFilerClient(false, 0, s.option.Filer, s.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{Key: []byte(webDavLocksKey), Value: value})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		return nil
+	})
+}
+
+// update changes the lock table while holding the distributed lock
+func (s *filerLockStore) update(now time.Time, fn func(locks map[string]*webDavLock) error) error {
+	s.localLock.Lock()
+	defer s.localLock.Unlock()
+
+	lock := s.lockClient.NewShortLivedLock(webDavLocksKey, s.owner)
+	defer lock.StopShortLivedLock()
+
+	locks, err := s.read()
+	if err != nil {
+		return err
+	}
+	for token, l := range locks {
+		if l.isExpired(now) {
+			delete(locks, token)
+		}
+	}
+	if err = fn(locks); err != nil {
+		return err
+	}
+	return s.write(locks)
+}
+
+// filerLockSystem is the webdav.LockSystem of one WebDAV root. Resource names are
+// stored with the root, so users with their own root directory do not share lock names.
+type filerLockSystem struct {
+	store *filerLockStore
+	root  string
+}
+
+var _ = webdav.LockSystem(&filerLockSystem{})
+
+func (ls *filerLockSystem) withRoot(root string) *filerLockSystem {
+	return &filerLockSystem{
+		store: ls.store,
+		root:  root,
+	}
+}
+
+func (ls *filerLockSystem) toStoredName(name string) string {
+	return string(util.NewFullPath(ls.root, path.Clean("/"+name)))
+}
+
+func (ls *filerLockSystem) Confirm(now time.Time, name0, name1 string, conditions ...webdav.Condition) (release func(), err error) {
+	locks, err := ls.store.read()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{name0, name1} {
+		if name == "" {
+			continue
+		}
+		if !isLockedByAnyOf(locks, ls.toStoredName(name), now, conditions) {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+	return func() {}, nil
+}
+
+func isLockedByAnyOf(locks map[string]*webDavLock, name string, now time.Time, conditions []webdav.Condition) bool {
+	for _, c := range conditions {
+		if l, found := locks[c.Token]; found && !l.isExpired(now) && l.covers(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ls *filerLockSystem) Create(now time.Time, details webdav.LockDetails) (token string, err error) {
+	name := ls.toStoredName(details.Root)
+	err = ls.store.update(now, func(locks map[string]*webDavLock) error {
+		for _, l := range locks {
+			if l.covers(name) {
+				return webdav.ErrLocked
+			}
+			// a lock of infinite depth can not cover a locked descendant
+			if !details.ZeroDepth && (name == "/" || strings.HasPrefix(l.Root, name+"/")) {
+				return webdav.ErrLocked
+			}
+		}
+		token = "opaquelocktoken:" + uuid.New().String()
+		locks[token] = &webDavLock{
+			Root:       name,
+			Duration:   details.Duration,
+			OwnerXML:   details.OwnerXML,
+			ZeroDepth:  details.ZeroDepth,
+			ExpireAtNs: expireAtNs(now, details.Duration),
+		}
+		return nil
+	})
+	return
+}
+
+func (ls *filerLockSystem) Refresh(now time.Time, token string, duration time.Duration) (details webdav.LockDetails, err error) {
+	err = ls.store.update(now, func(locks map[string]*webDavLock) error {
+		l, found := locks[token]
+		if !found {
+			return webdav.ErrNoSuchLock
+		}
+		l.Duration = duration
+		l.ExpireAtNs = expireAtNs(now, duration)
+		details = webdav.LockDetails{
+			Root:      ls.fromStoredName(l.Root),
+			Duration:  l.Duration,
+			OwnerXML:  l.OwnerXML,
+			ZeroDepth: l.ZeroDepth,
+		}
+		return nil
+	})
+	return
+}
+
+func (ls *filerLockSystem) Unlock(now time.Time, token string) error {
+	return ls.store.update(now, func(locks map[string]*webDavLock) error {
+		if _, found := locks[token]; !found {
+			return webdav.ErrNoSuchLock
+		}
+		delete(locks, token)
+		return nil
+	})
+}
+
+func (ls *filerLockSystem) fromStoredName(name string) string {
+	if ls.root == "" || ls.root == "/" {
+		return name
+	}
+	if name = strings.TrimPrefix(name, strings.TrimSuffix(ls.root, "/")); name == "" {
+		return "/"
+	}
+	return name
+}
+
+func expireAtNs(now time.Time, duration time.Duration) int64 {
+	if duration < 0 || duration > webDavMaxLockDuration {
+		duration = webDavMaxLockDuration
+	}
+	return now.Add(duration).UnixNano()
+}
@@ -0,0 +1,48 @@
+package weed_server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebDavLockCovers(t *testing.T) {
+	lock := &webDavLock{Root: "/home/a/docs"}
+	for name, expected := range map[string]bool{
+		"/home/a/docs":       true,
+		"/home/a/docs/x.txt": true,
+		"/home/a/docs2":      false,
+		"/home/a":            false,
+	} {
+		if lock.covers(name) != expected {
+			t.Errorf("covers(%s) = %v, want %v", name, !expected, expected)
+		}
+	}
+
+	lock.ZeroDepth = true
+	if lock.covers("/home/a/docs/x.txt") {
+		t.Errorf("a zero depth lock should not cover its children")
+	}
+
+	root := &webDavLock{Root: "/"}
+	if !root.covers("/any/file") {
+		t.Errorf("a lock on the root should cover everything")
+	}
+}
+
+func TestWebDavLockStoredName(t *testing.T) {
+	ls := (&filerLockSystem{}).withRoot("/home/a")
+	if name := ls.toStoredName("docs/../x.txt"); name != "/home/a/x.txt" {
+		t.Errorf("unexpected stored name %s", name)
+	}
+	if name := ls.fromStoredName("/home/a/x.txt"); name != "/x.txt" {
+		t.Errorf("unexpected name %s", name)
+	}
+	if name := ls.fromStoredName(ls.toStoredName("/")); name != "/" {
+		t.Errorf("unexpected root name %s", name)
+	}
+
+	now := time.Now()
+	if expireAtNs(now, -1) != now.Add(webDavMaxLockDuration).UnixNano() {
+		t.Errorf("an infinite lock should expire after %v", webDavMaxLockDuration)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/util/version"
@@ -41,6 +44,8 @@ type WebDavOption struct {
 	CacheDir       string
 	CacheSizeMB    int64
 	MaxMB          int
+	Authenticator  WebDavAuthenticator // nil to allow anonymous access
+	UserRootPath   string              // the root of each user under the WebDAV root, {user} is the user name
 }
 
 type WebDavServer struct {
@@ -49,6 +54,8 @@ type WebDavServer struct {
 	filer          *filer.Filer
 	grpcDialOption grpc.DialOption
 	Handler        *webdav.Handler
+	lockSystem     *filerLockSystem
+	userHandlers   sync.Map // user root -> *webdav.Handler
 }
 
 func max(x, y int64) int64 {
@@ -71,18 +78,82 @@ func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
 		fs = NewWrappedFs(fs, path.Clean(option.FilerRootPath))
 	}
 
+	lockSystem := &filerLockSystem{store: newFilerLockStore(option)}
 	ws = &WebDavServer{
 		option:         option,
 		grpcDialOption: security.LoadClientTLS(util.GetViper(), "grpc.filer"),
 		Handler: &webdav.Handler{
 			FileSystem: fs,
-			LockSystem: webdav.NewMemLS(),
+			LockSystem: lockSystem,
 		},
+		lockSystem: lockSystem,
 	}
 
 	return ws, nil
 }
 
+type quotaExceededKey struct{}
+
+// quotaResponseWriter turns the error status of a request that hit a directory quota into 507 Insufficient Storage.
+// The webdav handler maps all write failures to generic statuses, so the file system flags the quota in the request context.
+type quotaResponseWriter struct {
+	http.ResponseWriter
+	quotaExceeded *atomic.Bool
+}
+
+func (w *quotaResponseWriter) WriteHeader(statusCode int) {
+	if statusCode >= http.StatusBadRequest && w.quotaExceeded.Load() {
+		statusCode = http.StatusInsufficientStorage
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (ws *WebDavServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler := ws.Handler
+	if ws.option.Authenticator != nil {
+		user := ws.authenticate(w, r)
+		if user == nil {
+			return
+		}
+		if root := ws.userRoot(user); root != "" {
+			var err error
+			if handler, err = ws.userHandler(r.Context(), root); err != nil {
+				glog.Errorf("webdav root %s of %s: %v", root, user.Name, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	quotaExceeded := &atomic.Bool{}
+	ctx := context.WithValue(r.Context(), quotaExceededKey{}, quotaExceeded)
+	handler.ServeHTTP(&quotaResponseWriter{ResponseWriter: w, quotaExceeded: quotaExceeded}, r.WithContext(ctx))
+}
+
+// userHandler serves the root directory of one user, creating the directory on first use
+func (ws *WebDavServer) userHandler(ctx context.Context, root string) (*webdav.Handler, error) {
+	if handler, found := ws.userHandlers.Load(root); found {
+		return handler.(*webdav.Handler), nil
+	}
+	if err := ws.Handler.FileSystem.Mkdir(ctx, root, 0755); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+	handler, _ := ws.userHandlers.LoadOrStore(root, &webdav.Handler{
+		FileSystem: NewWrappedFs(ws.Handler.FileSystem, root),
+		LockSystem: ws.lockSystem.withRoot(root),
+	})
+	return handler.(*webdav.Handler), nil
+}
+
+func markIfQuotaExceeded(ctx context.Context, err error) error {
+	if filer.IsQuotaExceeded(err) {
+		if quotaExceeded, ok := ctx.Value(quotaExceededKey{}).(*atomic.Bool); ok {
+			quotaExceeded.Store(true)
+		}
+	}
+	return err
+}
+
 // adapted from https://github.com/mattn/davfs/blob/master/plugin/mysql/mysql.go
 
 type WebDavFileSystem struct {
@@ -213,7 +284,7 @@ func (fs *WebDavFileSystem) Mkdir(ctx context.Context, fullDirPath string, perm
 
 		glog.V(1).Infof("mkdir: %v", request)
 		if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
-			return fmt.Errorf("mkdir %s/%s: %v", dir, name, err)
+			return markIfQuotaExceeded(ctx, fmt.Errorf("mkdir %s/%s: %v", dir, name, err))
 		}
 
 		return nil
@@ -259,7 +330,7 @@ func (fs *WebDavFileSystem) OpenFile(ctx context.Context, fullFilePath string, f
 				},
 				Signatures: []int32{fs.signature},
 			}); err != nil {
-				return fmt.Errorf("create %s: %v", fullFilePath, err)
+				return markIfQuotaExceeded(ctx, fmt.Errorf("create %s: %v", fullFilePath, err))
 			}
 			return nil
 		})
@@ -507,7 +578,7 @@ func (f *WebDavFile) Write(buf []byte) (int, error) {
 				}
 
 				if _, err := client.UpdateEntry(ctx, request); err != nil {
-					return fmt.Errorf("update %s: %v", f.name, err)
+					return markIfQuotaExceeded(f.ctx, fmt.Errorf("update %s: %v", f.name, err))
 				}
 
 				return nil
@@ -2,6 +2,7 @@
 package auth
 
 import (
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
 	"golang.org/x/crypto/ssh"
 )
@@ -20,8 +21,10 @@ type Manager struct {
 	enabledAuthMethods []string
 }
 
-// NewManager creates a new authentication manager
-func NewManager(userStore user.Store, enabledAuthMethods []string) *Manager {
+// NewManager creates a new authentication manager.
+// The directory, if not nil, verifies the passwords of users not in the user store.
+// The trusted CAs sign the SSH certificates accepted for public key authentication.
+func NewManager(userStore user.Store, directory providers.IdentityProvider, enabledAuthMethods []string, trustedCAs []ssh.PublicKey) *Manager {
 	manager := &Manager{
 		userStore:          userStore,
 		enabledAuthMethods: enabledAuthMethods,
@@ -40,8 +43,8 @@ func NewManager(userStore user.Store, enabledAuthMethods []string) *Manager {
 		}
 	}
 
-	manager.passwordAuth = NewPasswordAuthenticator(userStore, passwordEnabled)
-	manager.publicKeyAuth = NewPublicKeyAuthenticator(userStore, publicKeyEnabled)
+	manager.passwordAuth = NewPasswordAuthenticator(userStore, directory, passwordEnabled)
+	manager.publicKeyAuth = NewPublicKeyAuthenticator(userStore, publicKeyEnabled, trustedCAs)
 
 	return manager
 }
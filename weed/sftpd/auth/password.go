@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"math/rand/v2"
+	"strings"
 	"time"
 
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
 	"golang.org/x/crypto/ssh"
 )
@@ -12,13 +16,16 @@ import (
 // PasswordAuthenticator handles password-based authentication
 type PasswordAuthenticator struct {
 	userStore user.Store
+	directory providers.IdentityProvider
 	enabled   bool
 }
 
-// NewPasswordAuthenticator creates a new password authenticator
-func NewPasswordAuthenticator(userStore user.Store, enabled bool) *PasswordAuthenticator {
+// NewPasswordAuthenticator creates a new password authenticator.
+// Users not in the user store are verified by the directory, if any.
+func NewPasswordAuthenticator(userStore user.Store, directory providers.IdentityProvider, enabled bool) *PasswordAuthenticator {
 	return &PasswordAuthenticator{
 		userStore: userStore,
+		directory: directory,
 		enabled:   enabled,
 	}
 }
@@ -46,6 +53,20 @@ func (a *PasswordAuthenticator) Authenticate(conn ssh.ConnMetadata, password []b
 		}, nil
 	}
 
+	// Fall back to the directory, like LDAP or Active Directory
+	if a.directory != nil && !strings.Contains(username, ":") {
+		_, err := a.directory.Authenticate(context.Background(), username+":"+string(password))
+		if err == nil {
+			return &ssh.Permissions{
+				Extensions: map[string]string{
+					"username": username,
+					"provider": a.directory.Name(),
+				},
+			}, nil
+		}
+		glog.V(1).Infof("directory %s rejected user %s: %v", a.directory.Name(), username, err)
+	}
+
 	// Add delay to prevent brute force attacks
 	time.Sleep(time.Duration(100+rand.IntN(100)) * time.Millisecond)
 
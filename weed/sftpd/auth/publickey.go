@@ -1,24 +1,43 @@
 package auth
 
 import (
+	"bytes"
 	"fmt"
 
+	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
 	"golang.org/x/crypto/ssh"
 )
 
 // PublicKeyAuthenticator handles public key-based authentication
 type PublicKeyAuthenticator struct {
-	userStore user.Store
-	enabled   bool
+	userStore   user.Store
+	enabled     bool
+	trustedCAs  []ssh.PublicKey
+	certChecker *ssh.CertChecker
 }
 
-// NewPublicKeyAuthenticator creates a new public key authenticator
-func NewPublicKeyAuthenticator(userStore user.Store, enabled bool) *PublicKeyAuthenticator {
-	return &PublicKeyAuthenticator{
-		userStore: userStore,
-		enabled:   enabled,
+// NewPublicKeyAuthenticator creates a new public key authenticator.
+// Certificates signed by one of the trusted CAs log in the users named in their principals.
+func NewPublicKeyAuthenticator(userStore user.Store, enabled bool, trustedCAs []ssh.PublicKey) *PublicKeyAuthenticator {
+	a := &PublicKeyAuthenticator{
+		userStore:  userStore,
+		enabled:    enabled,
+		trustedCAs: trustedCAs,
 	}
+	a.certChecker = &ssh.CertChecker{
+		IsUserAuthority: a.isTrustedCA,
+	}
+	return a
+}
+
+func (a *PublicKeyAuthenticator) isTrustedCA(auth ssh.PublicKey) bool {
+	for _, ca := range a.trustedCAs {
+		if bytes.Equal(ca.Marshal(), auth.Marshal()) {
+			return true
+		}
+	}
+	return false
 }
 
 // Enabled returns whether public key authentication is enabled
@@ -35,6 +54,10 @@ func (a *PublicKeyAuthenticator) Authenticate(conn ssh.ConnMetadata, key ssh.Pub
 		return nil, fmt.Errorf("public key authentication disabled")
 	}
 
+	if cert, ok := key.(*ssh.Certificate); ok {
+		return a.authenticateCertificate(conn, cert)
+	}
+
 	// Convert key to string format for comparison
 	keyData := string(key.Marshal())
 
@@ -49,3 +72,25 @@ func (a *PublicKeyAuthenticator) Authenticate(conn ssh.ConnMetadata, key ssh.Pub
 
 	return nil, fmt.Errorf("authentication failed")
 }
+
+// authenticateCertificate accepts a valid user certificate of a trusted CA for a user in the store
+func (a *PublicKeyAuthenticator) authenticateCertificate(conn ssh.ConnMetadata, cert *ssh.Certificate) (*ssh.Permissions, error) {
+	username := conn.User()
+	if len(a.trustedCAs) == 0 {
+		return nil, fmt.Errorf("no trusted certificate authority")
+	}
+	// checks the CA, signature, validity period, principals and critical options
+	if _, err := a.certChecker.Authenticate(conn, cert); err != nil {
+		glog.V(1).Infof("certificate of user %s rejected: %v", username, err)
+		return nil, fmt.Errorf("authentication failed")
+	}
+	if _, err := a.userStore.GetUser(username); err != nil {
+		return nil, fmt.Errorf("authentication failed")
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			"username": username,
+			"cert-id":  cert.KeyId,
+		},
+	}, nil
+}
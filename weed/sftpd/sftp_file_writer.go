@@ -106,5 +106,5 @@ func (w *SeaweedSftpFileWriter) Close() error {
 	}
 
 	// Stream the file instead of loading it
-	return w.fs.putFile(w.req.Filepath, w.tmpFile, w.fs.user)
+	return w.fs.putFile(w.fs.toFilerPath(w.req.Filepath), w.tmpFile, w.fs.user)
 }
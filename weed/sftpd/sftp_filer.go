@@ -11,9 +11,11 @@ import (
 	"os"
 	"path"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pkg/sftp"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	filer_pb "github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
@@ -73,6 +75,14 @@ func isNotExistError(err error) bool {
 		err == os.ErrNotExist
 }
 
+// toQuotaError reports a directory quota rejected by the filer as EDQUOT, so clients see "disk quota exceeded".
+func toQuotaError(op, p string, err error) error {
+	if filer.IsQuotaExceeded(err) {
+		return &os.PathError{Op: op, Path: p, Err: syscall.EDQUOT}
+	}
+	return err
+}
+
 // updateEntry sends an UpdateEntryRequest for the given entry.
 func (fs *SftpServer) updateEntry(dir string, entry *filer_pb.Entry) error {
 	return fs.callWithClient(false, func(ctx context.Context, client filer_pb.SeaweedFilerClient) error {
@@ -120,10 +130,11 @@ func (fs *SftpServer) dispatchCmd(r *sftp.Request) error {
 // ==================== File Operations ====================
 
 func (fs *SftpServer) readFile(r *sftp.Request) (io.ReaderAt, error) {
-	if err := fs.checkFilePermission(r.Filepath, "read"); err != nil {
+	p := fs.toFilerPath(r.Filepath)
+	if err := fs.checkFilePermission(p, "read"); err != nil {
 		return nil, err
 	}
-	entry, err := fs.getEntry(r.Filepath)
+	entry, err := fs.getEntry(p)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +142,14 @@ func (fs *SftpServer) readFile(r *sftp.Request) (io.ReaderAt, error) {
 }
 
 func (fs *SftpServer) newFileWriter(r *sftp.Request) (io.WriterAt, error) {
-	dir, _ := util.FullPath(r.Filepath).DirAndName()
-	if err := fs.checkFilePermission(dir, "write"); err != nil {
-		glog.Errorf("Permission denied for %s", dir)
+	p := fs.toFilerPath(r.Filepath)
+	// a policy authorizes the object itself, file permissions the directory it is written to
+	target := p
+	if fs.user.Policy == nil {
+		target, _ = util.FullPath(p).DirAndName()
+	}
+	if err := fs.checkFilePermission(target, "write"); err != nil {
+		glog.Errorf("Permission denied for %s", target)
 		return nil, err
 	}
 	// Create a temporary file to buffer writes
@@ -154,15 +170,19 @@ func (fs *SftpServer) newFileWriter(r *sftp.Request) (io.WriterAt, error) {
 }
 
 func (fs *SftpServer) removeEntry(r *sftp.Request) error {
-	return fs.deleteEntry(r.Filepath, false)
+	return fs.deleteEntry(fs.toFilerPath(r.Filepath), false)
 }
 
 func (fs *SftpServer) renameEntry(r *sftp.Request) error {
-	if err := fs.checkFilePermission(r.Filepath, "rename"); err != nil {
+	oldPath, newPath := fs.toFilerPath(r.Filepath), fs.toFilerPath(r.Target)
+	if err := fs.checkFilePermission(oldPath, "rename"); err != nil {
 		return err
 	}
-	oldDir, oldName := util.FullPath(r.Filepath).DirAndName()
-	newDir, newName := util.FullPath(r.Target).DirAndName()
+	if err := fs.checkFilePermission(newPath, "write"); err != nil {
+		return err
+	}
+	oldDir, oldName := util.FullPath(oldPath).DirAndName()
+	newDir, newName := util.FullPath(newPath).DirAndName()
 	return fs.callWithClient(false, func(ctx context.Context, client filer_pb.SeaweedFilerClient) error {
 		_, err := client.AtomicRenameEntry(ctx, &filer_pb.AtomicRenameEntryRequest{
 			OldDirectory: oldDir, OldName: oldName,
@@ -173,14 +193,15 @@ func (fs *SftpServer) renameEntry(r *sftp.Request) error {
 }
 
 func (fs *SftpServer) setFileStat(r *sftp.Request) error {
-	if err := fs.checkFilePermission(r.Filepath, "write"); err != nil {
+	p := fs.toFilerPath(r.Filepath)
+	if err := fs.checkFilePermission(p, "write"); err != nil {
 		return err
 	}
-	entry, err := fs.getEntry(r.Filepath)
+	entry, err := fs.getEntry(p)
 	if err != nil {
 		return err
 	}
-	dir, _ := util.FullPath(r.Filepath).DirAndName()
+	dir, _ := util.FullPath(p).DirAndName()
 	// apply attrs
 	if r.AttrFlags().Permissions {
 		entry.Attributes.FileMode = uint32(r.Attributes().FileMode())
@@ -201,18 +222,19 @@ func (fs *SftpServer) setFileStat(r *sftp.Request) error {
 // ==================== Directory Operations ====================
 
 func (fs *SftpServer) listDir(r *sftp.Request) (sftp.ListerAt, error) {
-	if err := fs.checkFilePermission(r.Filepath, "list"); err != nil {
+	p := fs.toFilerPath(r.Filepath)
+	if err := fs.checkFilePermission(p, "list"); err != nil {
 		return nil, err
 	}
 	if r.Method == "Stat" || r.Method == "Lstat" {
-		entry, err := fs.getEntry(r.Filepath)
+		entry, err := fs.getEntry(p)
 		if err != nil {
 			return nil, err
 		}
 		fi := &EnhancedFileInfo{FileInfo: FileInfoFromEntry(entry), uid: entry.Attributes.Uid, gid: entry.Attributes.Gid}
 		return listerat([]os.FileInfo{fi}), nil
 	}
-	return fs.listAllPages(r.Filepath)
+	return fs.listAllPages(p)
 }
 
 func (fs *SftpServer) listAllPages(dirPath string) (sftp.ListerAt, error) {
@@ -263,14 +285,15 @@ func (fs *SftpServer) makeDir(r *sftp.Request) error {
 	if fs.user == nil {
 		return fmt.Errorf("cannot create directory: no user info")
 	}
-	dir, name := util.FullPath(r.Filepath).DirAndName()
-	if err := fs.checkFilePermission(r.Filepath, "mkdir"); err != nil {
+	p := fs.toFilerPath(r.Filepath)
+	dir, name := util.FullPath(p).DirAndName()
+	if err := fs.checkFilePermission(p, "mkdir"); err != nil {
 		return err
 	}
 	// default mode and ownership
 	err := filer_pb.Mkdir(context.Background(), fs, string(dir), name, func(entry *filer_pb.Entry) {
 		mode := uint32(0755 | os.ModeDir)
-		if strings.HasPrefix(r.Filepath, fs.user.HomeDir) {
+		if strings.HasPrefix(p, fs.user.HomeDir) {
 			mode = uint32(0700 | os.ModeDir)
 		}
 		entry.Attributes.FileMode = mode
@@ -284,12 +307,12 @@ func (fs *SftpServer) makeDir(r *sftp.Request) error {
 		}
 		entry.Extended["creator"] = []byte(fs.user.Username)
 	})
-	return err
+	return toQuotaError("mkdir", r.Filepath, err)
 }
 
 // removeDir deletes a directory.
 func (fs *SftpServer) removeDir(r *sftp.Request) error {
-	return fs.deleteEntry(r.Filepath, false)
+	return fs.deleteEntry(fs.toFilerPath(r.Filepath), false)
 }
 
 func (fs *SftpServer) putFile(filepath string, reader io.Reader, user *user.User) error {
@@ -319,7 +342,7 @@ func (fs *SftpServer) putFile(filepath string, reader io.Reader, user *user.User
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(respBody))
+		return toQuotaError("write", filepath, fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result weed_server.FilerPostResult
@@ -327,7 +350,7 @@ func (fs *SftpServer) putFile(filepath string, reader io.Reader, user *user.User
 		return fmt.Errorf("parse response: %w", err)
 	}
 	if result.Error != "" {
-		return fmt.Errorf("filer error: %s", result.Error)
+		return toQuotaError("write", filepath, fmt.Errorf("filer error: %s", result.Error))
 	}
 	// Update file ownership using the same pattern as other functions
 	if user != nil {
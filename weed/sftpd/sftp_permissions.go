@@ -3,10 +3,12 @@ package sftpd
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
 )
 
@@ -59,6 +61,15 @@ func (fs *SftpServer) CheckFilePermission(path string, perm string) error {
 		return os.ErrPermission
 	}
 
+	// Users with a policy are authorized like their S3 requests
+	if fs.user.Policy != nil {
+		if fs.hasPolicyPermission(path, perm) {
+			return nil
+		}
+		glog.V(0).Infof("permission denied by policy for user %s on path %s for permission %s", fs.user.Username, path, perm)
+		return os.ErrPermission
+	}
+
 	// Special case for "create" or "write" permissions on non-existent paths
 	// Check parent directory permissions instead
 	entry, err := fs.getEntry(path)
@@ -114,6 +125,75 @@ func (fs *SftpServer) CheckFilePermission(path string, perm string) error {
 	return os.ErrPermission
 }
 
+// hasPolicyPermission evaluates the user's policy on a path.
+// Paths in the buckets folder are the S3 buckets and objects, so the policy grants the same access
+// over SFTP as over S3. Outside of the buckets, the user has full access to its home directory only.
+// The folders above both can be listed, showing only the entries the user has access to.
+func (fs *SftpServer) hasPolicyPermission(p string, perm string) bool {
+	p = path.Clean("/" + p)
+	bucketsPrefix := strings.TrimSuffix(fs.dirBuckets, "/") + "/"
+	if !strings.HasPrefix(p, bucketsPrefix) {
+		if p == fs.user.HomeDir || strings.HasPrefix(p, strings.TrimSuffix(fs.user.HomeDir, "/")+"/") {
+			return true
+		}
+		if isBrowsePermission(perm) && (isAncestorPath(p, fs.user.HomeDir) || isAncestorPath(p, fs.dirBuckets)) {
+			return true
+		}
+		return false
+	}
+
+	bucket, object, _ := strings.Cut(strings.TrimPrefix(p, bucketsPrefix), "/")
+	action := policyAction(perm, object == "")
+	if action == "" || fs.policyEngine == nil {
+		return false
+	}
+	resource := "arn:aws:s3:::" + bucket
+	if object != "" {
+		resource += "/" + object
+	}
+	result := fs.policyEngine.EvaluatePolicy(fs.user.Username, &policy_engine.PolicyEvaluationArgs{
+		Action:    action,
+		Resource:  resource,
+		Principal: fs.user.Username,
+	})
+	return result == policy_engine.PolicyResultAllow
+}
+
+// policyAction maps an SFTP permission on a bucket or an object to the S3 action
+func policyAction(perm string, isBucket bool) string {
+	switch perm {
+	case PermRead:
+		if isBucket {
+			return "s3:ListBucket"
+		}
+		return "s3:GetObject"
+	case PermList, PermExecute, PermTraverse:
+		return "s3:ListBucket"
+	case PermWrite:
+		return "s3:PutObject"
+	case PermMkdir:
+		if isBucket {
+			return "s3:CreateBucket"
+		}
+		return "s3:PutObject"
+	case PermDelete, "rename":
+		if isBucket {
+			return "s3:DeleteBucket"
+		}
+		return "s3:DeleteObject"
+	}
+	return ""
+}
+
+func isBrowsePermission(perm string) bool {
+	return perm == PermList || perm == PermRead || perm == PermExecute || perm == PermTraverse
+}
+
+// isAncestorPath checks if dir is the path or one of its parent directories
+func isAncestorPath(dir, p string) bool {
+	return dir == p || dir == "/" || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
 // isPathInHomeDirectory checks if a path is in the user's home directory
 func isPathInHomeDirectory(user *user.User, path string) bool {
 	return strings.HasPrefix(path, user.HomeDir)
@@ -0,0 +1,75 @@
+package sftpd
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
+)
+
+func TestPolicyPermission(t *testing.T) {
+	policy, err := policy_engine.CreatePolicyFromLegacyIdentity("alice", []string{"Read:photos/*", "Write:uploads/alice/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := user.NewUser("alice")
+	alice.Policy = policy
+	fs := NewSftpServer("", nil, "", "", "/buckets", alice)
+
+	tests := []struct {
+		path    string
+		perm    string
+		allowed bool
+	}{
+		{"/", PermList, true},
+		{"/buckets", PermList, true},
+		{"/buckets", PermWrite, false},
+		{"/buckets/photos", PermList, true},
+		{"/buckets/photos/2024/cat.jpg", PermRead, true},
+		{"/buckets/photos/2024/cat.jpg", PermWrite, false},
+		{"/buckets/photos/2024/cat.jpg", PermDelete, false},
+		{"/buckets/uploads/alice/report.pdf", PermWrite, true},
+		{"/buckets/uploads/bob/report.pdf", PermWrite, false},
+		{"/buckets/secrets", PermList, false},
+		{"/buckets/newbucket", PermMkdir, false},
+		{"/home", PermList, true},
+		{"/home/alice/notes.txt", PermWrite, true},
+		{"/home/bob", PermList, false},
+		{"/etc", PermRead, false},
+	}
+	for _, tt := range tests {
+		if got := fs.CheckFilePermission(tt.path, tt.perm) == nil; got != tt.allowed {
+			t.Errorf("%s on %s: allowed %v, expected %v", tt.perm, tt.path, got, tt.allowed)
+		}
+	}
+}
+
+func TestPolicyPermissionWithoutActions(t *testing.T) {
+	bob := user.NewUser("bob")
+	bob.Policy = &policy_engine.PolicyDocument{Version: policy_engine.PolicyVersion2012_10_17}
+	fs := NewSftpServer("", nil, "", "", "/buckets", bob)
+
+	if err := fs.CheckFilePermission("/home/bob/file", PermWrite); err != nil {
+		t.Errorf("expected access to the home directory: %v", err)
+	}
+	if err := fs.CheckFilePermission("/buckets/photos", PermList); err == nil {
+		t.Error("expected buckets to be denied without any action")
+	}
+}
+
+func TestChrootPath(t *testing.T) {
+	alice := user.NewUser("alice")
+	alice.Chroot = true
+	fs := NewSftpServer("", nil, "", "", "", alice)
+
+	for clientPath, filerPath := range map[string]string{
+		"/":              "/home/alice",
+		"/docs/a.txt":    "/home/alice/docs/a.txt",
+		"../../etc":      "/home/alice/etc",
+		"/docs/../../..": "/home/alice",
+	} {
+		if got := fs.toFilerPath(clientPath); got != filerPath {
+			t.Errorf("%s: got %s, expected %s", clientPath, got, filerPath)
+		}
+	}
+}
@@ -3,15 +3,18 @@ package sftpd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"time"
 
 	"github.com/pkg/sftp"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	filer_pb "github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"google.golang.org/grpc"
@@ -22,19 +25,47 @@ type SftpServer struct {
 	grpcDialOption grpc.DialOption
 	dataCenter     string
 	filerGroup     string
+	dirBuckets     string
 	user           *user.User
+	policyEngine   *policy_engine.PolicyEngine // evaluates the user's policy, if any
 }
 
 // NewSftpServer constructs the server.
-func NewSftpServer(filerAddr pb.ServerAddress, grpcDialOption grpc.DialOption, dataCenter, filerGroup string, user *user.User) SftpServer {
+func NewSftpServer(filerAddr pb.ServerAddress, grpcDialOption grpc.DialOption, dataCenter, filerGroup, dirBuckets string, user *user.User) SftpServer {
 
-	return SftpServer{
+	fs := SftpServer{
 		filerAddr:      filerAddr,
 		grpcDialOption: grpcDialOption,
 		dataCenter:     dataCenter,
 		filerGroup:     filerGroup,
+		dirBuckets:     dirBuckets,
 		user:           user,
 	}
+	if fs.dirBuckets == "" {
+		fs.dirBuckets = "/buckets"
+	}
+	if user.Policy != nil && len(user.Policy.Statement) > 0 {
+		// without an engine, a user with a policy is denied everything outside its home directory
+		engine := policy_engine.NewPolicyEngine()
+		policyJSON, err := json.Marshal(user.Policy)
+		if err == nil {
+			err = engine.SetBucketPolicy(user.Username, string(policyJSON))
+		}
+		if err != nil {
+			glog.Errorf("policy of user %s: %v", user.Username, err)
+		} else {
+			fs.policyEngine = engine
+		}
+	}
+	return fs
+}
+
+// toFilerPath translates a client path into the filer path, below the home directory of a chrooted user
+func (fs *SftpServer) toFilerPath(p string) string {
+	if !fs.user.Chroot {
+		return p
+	}
+	return path.Join(fs.user.HomeDir, path.Clean("/"+p))
 }
 
 // Fileread is invoked for “get” requests.
@@ -8,10 +8,14 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/sftp"
+	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/iam/ldap"
+	"github.com/seaweedfs/seaweedfs/weed/iam/providers"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/auth"
 	"github.com/seaweedfs/seaweedfs/weed/sftpd/user"
@@ -31,6 +35,7 @@ type SFTPServiceOptions struct {
 	GrpcDialOption grpc.DialOption
 	DataCenter     string
 	FilerGroup     string
+	DirBuckets     string // Filer folder of the S3 buckets, where policies apply
 	Filer          pb.ServerAddress
 
 	// SSH Configuration
@@ -46,7 +51,10 @@ type SFTPServiceOptions struct {
 	ClientAliveCountMax int           // Max missed keep-alives before disconnect
 
 	// User Management
-	UserStoreFile string // Path to user store file
+	UserStoreFile         string // Path to user store file
+	CredentialStore       string // Credential store of the users instead of the user store file, e.g. filer_etc or postgres
+	TrustedUserCAKeysFile string // CA public keys in authorized_keys format, signing accepted user certificates
+	LdapConfigFile        string // Path to LDAP config file, for password auth of directory users
 }
 
 // NewSFTPService creates a new service instance.
@@ -54,18 +62,74 @@ func NewSFTPService(options *SFTPServiceOptions) *SFTPService {
 	service := SFTPService{options: *options}
 
 	// Initialize user store
-	userStore, err := user.NewFileStore(options.UserStoreFile)
-	if err != nil {
-		glog.Fatalf("Failed to initialize user store: %v", err)
+	if options.CredentialStore != "" {
+		credentialManager, err := credential.NewCredentialManagerWithDefaults(credential.CredentialStoreTypeName(options.CredentialStore))
+		if err != nil {
+			glog.Fatalf("Failed to initialize credential store: %v", err)
+		}
+		if filerClientSetter, ok := credentialManager.GetStore().(interface {
+			SetFilerClient(string, grpc.DialOption)
+		}); ok {
+			filerClientSetter.SetFilerClient(string(options.Filer), options.GrpcDialOption)
+		}
+		service.userStore = user.NewCredentialStore(credentialManager)
+	} else {
+		userStore, err := user.NewFileStore(options.UserStoreFile)
+		if err != nil {
+			glog.Fatalf("Failed to initialize user store: %v", err)
+		}
+		service.userStore = userStore
+	}
+
+	// Initialize certificate authorities for SSH user certificates
+	var trustedCAs []ssh.PublicKey
+	if options.TrustedUserCAKeysFile != "" {
+		var err error
+		if trustedCAs, err = loadTrustedUserCAKeys(options.TrustedUserCAKeysFile); err != nil {
+			glog.Fatalf("Failed to load trusted user CA keys: %v", err)
+		}
+	}
+
+	// Initialize directory for users not in the user store
+	var directory providers.IdentityProvider
+	if options.LdapConfigFile != "" {
+		ldapProvider, err := ldap.NewLDAPProviderFromFile("ldap", options.LdapConfigFile)
+		if err != nil {
+			glog.Fatalf("Failed to initialize LDAP provider: %v", err)
+		}
+		directory = ldapProvider
 	}
-	service.userStore = userStore
 
 	// Initialize auth manager
-	service.authManager = auth.NewManager(userStore, options.AuthMethods)
+	service.authManager = auth.NewManager(service.userStore, directory, options.AuthMethods, trustedCAs)
 
 	return &service
 }
 
+// loadTrustedUserCAKeys reads CA public keys in authorized_keys format
+func loadTrustedUserCAKeys(keysFile string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(keysFile)
+	if err != nil {
+		return nil, err
+	}
+	var keys []ssh.PublicKey
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", keysFile, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys in %s", keysFile)
+	}
+	return keys, nil
+}
+
 // Serve accepts incoming connections on the provided listener and handles them.
 func (s *SFTPService) Serve(listener net.Listener) error {
 	// Build SSH server config
@@ -188,6 +252,10 @@ func (s *SFTPService) handleSSHConnection(conn net.Conn, config *ssh.ServerConfi
 
 	// Get user from store
 	sftpUser, err := s.authManager.GetUser(username)
+	if err != nil && sshConn.Permissions.Extensions["provider"] != "" {
+		// a directory user without an entry in the user store works in its home directory
+		sftpUser, err = user.NewUser(username), nil
+	}
 	if err != nil {
 		glog.Errorf("Failed to retrieve user %s: %v", username, err)
 		sshConn.Close()
@@ -200,6 +268,7 @@ func (s *SFTPService) handleSSHConnection(conn net.Conn, config *ssh.ServerConfi
 		s.options.GrpcDialOption,
 		s.options.DataCenter,
 		s.options.FilerGroup,
+		s.options.DirBuckets,
 		sftpUser,
 	)
 
@@ -284,8 +353,13 @@ func (s *SFTPService) handleChannel(newChannel ssh.NewChannel, fs *SftpServer) {
 
 // handleSFTP starts the SFTP server on the SSH channel.
 func (s *SFTPService) handleSFTP(channel ssh.Channel, fs *SftpServer) {
-	// Create server options with initial working directory set to user's home
-	serverOptions := sftp.WithStartDirectory(fs.user.HomeDir)
+	// Create server options with initial working directory set to user's home,
+	// which is the root of a chrooted user
+	startDirectory := fs.user.HomeDir
+	if fs.user.Chroot {
+		startDirectory = "/"
+	}
+	serverOptions := sftp.WithStartDirectory(startDirectory)
 	server := sftp.NewRequestServer(channel, sftp.Handlers{
 		FileGet:  fs,
 		FilePut:  fs,
@@ -0,0 +1,221 @@
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+	"golang.org/x/crypto/ssh"
+)
+
+// CredentialStore implements Store with the identities of a credential store.
+// An identity can log in once it has SFTP settings, and is authorized by the
+// same actions and managed policies as its S3 requests.
+type CredentialStore struct {
+	credentialManager *credential.CredentialManager
+	refreshInterval   time.Duration
+
+	mu       sync.Mutex
+	users    map[string]*User
+	loadedAt time.Time
+}
+
+// NewCredentialStore creates a user store backed by the credential manager
+func NewCredentialStore(credentialManager *credential.CredentialManager) *CredentialStore {
+	return &CredentialStore{
+		credentialManager: credentialManager,
+		refreshInterval:   30 * time.Second,
+	}
+}
+
+// load returns the SFTP users, reloading them from the credential store when stale
+func (s *CredentialStore) load() (map[string]*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.users != nil && time.Since(s.loadedAt) <= s.refreshInterval {
+		return s.users, nil
+	}
+	config, err := s.credentialManager.LoadConfiguration(context.Background())
+	if err != nil {
+		if s.users == nil {
+			return nil, err
+		}
+		glog.Warningf("reload sftp users: %v", err)
+		return s.users, nil
+	}
+	identityActions := credential.NewIdentityActions(config)
+	users := make(map[string]*User)
+	for _, identity := range config.Identities {
+		if identity.Sftp == nil {
+			continue
+		}
+		users[identity.Name] = newUserFromIdentity(identity, identityActions.Actions(identity))
+	}
+	s.users, s.loadedAt = users, time.Now()
+	return users, nil
+}
+
+func newUserFromIdentity(identity *iam_pb.Identity, actions []string) *User {
+	user := NewUser(identity.Name)
+	user.Password = identity.Sftp.PasswordHash
+	if identity.Sftp.HomeDir != "" {
+		user.HomeDir = identity.Sftp.HomeDir
+	}
+	user.Chroot = identity.Sftp.Chroot
+	if identity.Sftp.Uid != 0 {
+		user.Uid = identity.Sftp.Uid
+	}
+	if identity.Sftp.Gid != 0 {
+		user.Gid = identity.Sftp.Gid
+	}
+	for _, keyData := range identity.Sftp.PublicKeys {
+		pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keyData))
+		if err != nil {
+			glog.Warningf("sftp user %s: invalid public key: %v", identity.Name, err)
+			continue
+		}
+		user.PublicKeys = append(user.PublicKeys, string(pubKey.Marshal()))
+	}
+	user.Policy = policyFromActions(identity.Name, actions)
+	return user
+}
+
+// policyFromActions converts identity actions into a policy document.
+// Actions without a bucket apply to all buckets, and actions on a bucket to all its objects.
+// A user without any action gets an empty policy, which denies everything outside its home directory.
+func policyFromActions(name string, actions []string) *policy_engine.PolicyDocument {
+	var normalized []string
+	for _, action := range actions {
+		actionType, resource, found := strings.Cut(action, ":")
+		switch {
+		case !found || resource == "*":
+			normalized = append(normalized, actionType+":*")
+		case !strings.Contains(resource, "/") && !strings.HasSuffix(resource, "*"):
+			normalized = append(normalized, actionType+":"+resource+"/*")
+		default:
+			normalized = append(normalized, action)
+		}
+	}
+	policy, err := policy_engine.CreatePolicyFromLegacyIdentity(name, normalized)
+	if err != nil {
+		return &policy_engine.PolicyDocument{Version: policy_engine.PolicyVersion2012_10_17}
+	}
+	return policy
+}
+
+// GetUser returns the SFTP user of an identity
+func (s *CredentialStore) GetUser(username string) (*User, error) {
+	users, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	user, ok := users[username]
+	if !ok {
+		return nil, &UserNotFoundError{Username: username}
+	}
+	return user, nil
+}
+
+// ValidatePassword checks the password against the identity's password hash
+func (s *CredentialStore) ValidatePassword(username string, password []byte) bool {
+	user, err := s.GetUser(username)
+	if err != nil {
+		return false
+	}
+	return isBcryptHash(user.Password) && user.CheckPassword(password)
+}
+
+// ValidatePublicKey checks if the public key is attached to the identity
+func (s *CredentialStore) ValidatePublicKey(username string, keyData string) bool {
+	user, err := s.GetUser(username)
+	if err != nil {
+		return false
+	}
+	for _, key := range user.PublicKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(keyData)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUserPermissions returns nil, as the users are authorized by their policy
+func (s *CredentialStore) GetUserPermissions(username string, path string) []string {
+	return nil
+}
+
+// SaveUser updates the SFTP settings of an existing identity
+func (s *CredentialStore) SaveUser(user *User) error {
+	ctx := context.Background()
+	identity, err := s.credentialManager.GetUser(ctx, user.Username)
+	if err != nil {
+		return fmt.Errorf("get identity %s: %w", user.Username, err)
+	}
+	if user.Password != "" && !isBcryptHash(user.Password) {
+		return fmt.Errorf("user %s: the credential store only keeps bcrypt password hashes", user.Username)
+	}
+	identity.Sftp = &iam_pb.SftpConfig{
+		PasswordHash: user.Password,
+		HomeDir:      user.HomeDir,
+		Chroot:       user.Chroot,
+		Uid:          user.Uid,
+		Gid:          user.Gid,
+	}
+	for _, keyData := range user.PublicKeys {
+		pubKey, err := ssh.ParsePublicKey([]byte(keyData))
+		if err != nil {
+			// already in authorized_keys format
+			identity.Sftp.PublicKeys = append(identity.Sftp.PublicKeys, keyData)
+			continue
+		}
+		identity.Sftp.PublicKeys = append(identity.Sftp.PublicKeys, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pubKey))))
+	}
+	if err := s.credentialManager.UpdateUser(ctx, user.Username, identity); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// DeleteUser removes the SFTP settings of an identity, keeping the identity itself
+func (s *CredentialStore) DeleteUser(username string) error {
+	ctx := context.Background()
+	identity, err := s.credentialManager.GetUser(ctx, username)
+	if err != nil || identity.Sftp == nil {
+		return &UserNotFoundError{Username: username}
+	}
+	identity.Sftp = nil
+	if err := s.credentialManager.UpdateUser(ctx, username, identity); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// ListUsers returns the names of identities with SFTP settings
+func (s *CredentialStore) ListUsers() ([]string, error) {
+	users, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	usernames := make([]string, 0, len(users))
+	for username := range users {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames, nil
+}
+
+func (s *CredentialStore) invalidate() {
+	s.mu.Lock()
+	s.users = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	_ "github.com/seaweedfs/seaweedfs/weed/credential/memory"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCredentialStore(t *testing.T) {
+	cm, err := credential.NewCredentialManager(credential.StoreTypeMemory, util.GetViper(), "test.")
+	if err != nil {
+		t.Fatalf("create credential manager: %v", err)
+	}
+	defer cm.Shutdown()
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:    "alice",
+				Actions: []string{"Write:uploads"},
+				Sftp: &iam_pb.SftpConfig{
+					PasswordHash: string(passwordHash),
+					PublicKeys:   []string{string(ssh.MarshalAuthorizedKey(sshKey))},
+					HomeDir:      "/home/alice",
+					Chroot:       true,
+					Uid:          1001,
+					Gid:          1001,
+				},
+			},
+			{Name: "s3only", Actions: []string{"Admin"}},
+		},
+		Groups: []*iam_pb.Group{{Name: "readers", Members: []string{"alice"}, PolicyNames: []string{"photos-read"}}},
+		Policies: []*iam_pb.ManagedPolicy{{
+			Name:             "photos-read",
+			DefaultVersionId: "v1",
+			Versions: []*iam_pb.PolicyVersion{{
+				VersionId: "v1",
+				Document:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::photos/*"}]}`,
+			}},
+		}},
+	}
+	if err := cm.SaveConfiguration(context.Background(), config); err != nil {
+		t.Fatalf("save configuration: %v", err)
+	}
+
+	store := NewCredentialStore(cm)
+	if !store.ValidatePassword("alice", []byte("secret")) {
+		t.Error("expected the password to be accepted")
+	}
+	if store.ValidatePassword("alice", []byte("wrong")) {
+		t.Error("expected a wrong password to be rejected")
+	}
+	if !store.ValidatePublicKey("alice", string(sshKey.Marshal())) {
+		t.Error("expected the public key to be accepted")
+	}
+	if _, err := store.GetUser("s3only"); err == nil {
+		t.Error("expected an identity without sftp settings not to be an sftp user")
+	}
+
+	alice, err := store.GetUser("alice")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if alice.HomeDir != "/home/alice" || !alice.Chroot || alice.Uid != 1001 || alice.Gid != 1001 {
+		t.Errorf("unexpected user settings: %+v", alice)
+	}
+	if alice.Policy == nil {
+		t.Fatal("expected a policy")
+	}
+	resources := make(map[string]bool)
+	for _, statement := range alice.Policy.Statement {
+		for _, resource := range statement.Resource.Strings() {
+			resources[resource] = true
+		}
+	}
+	for _, expected := range []string{"arn:aws:s3:::uploads/*", "arn:aws:s3:::photos/*"} {
+		if !resources[expected] {
+			t.Errorf("expected the policy to cover %s, got %v", expected, resources)
+		}
+	}
+
+	users, err := store.ListUsers()
+	if err != nil || len(users) != 1 || users[0] != "alice" {
+		t.Errorf("unexpected users %v: %v", users, err)
+	}
+}
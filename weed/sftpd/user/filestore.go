@@ -149,8 +149,7 @@ func (s *FileStore) ValidatePassword(username string, password []byte) bool {
 		return false
 	}
 
-	// Compare plaintext password using constant time comparison for security
-	return subtle.ConstantTimeCompare([]byte(user.Password), password) == 1
+	return user.CheckPassword(password)
 }
 
 // ValidatePublicKey checks if the public key is valid for the user
@@ -2,19 +2,28 @@
 package user
 
 import (
+	"crypto/subtle"
 	"math/rand/v2"
 	"path/filepath"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // User represents an SFTP user with authentication and permission details
 type User struct {
 	Username    string              // Username for authentication
-	Password    string              // Plaintext password
+	Password    string              // Plaintext password, or a bcrypt hash
 	PublicKeys  []string            // Authorized public keys
 	HomeDir     string              // User's home directory
+	Chroot      bool                // Confine the user to the home directory
 	Permissions map[string][]string // path -> permissions (read, write, list, etc.)
 	Uid         uint32              // User ID for file ownership
 	Gid         uint32              // Group ID for file ownership
+
+	// Policy, if set, authorizes the user like its S3 requests instead of Permissions
+	Policy *policy_engine.PolicyDocument `json:"-"`
 }
 
 // NewUser creates a new user with default settings
@@ -33,6 +42,22 @@ func NewUser(username string) *User {
 	}
 }
 
+// CheckPassword compares the password with the stored one, which is either plaintext or a bcrypt hash
+func (u *User) CheckPassword(password []byte) bool {
+	if u.Password == "" {
+		return false
+	}
+	if isBcryptHash(u.Password) {
+		return bcrypt.CompareHashAndPassword([]byte(u.Password), password) == nil
+	}
+	// Compare plaintext password using constant time comparison for security
+	return subtle.ConstantTimeCompare([]byte(u.Password), password) == 1
+}
+
+func isBcryptHash(s string) bool {
+	return strings.HasPrefix(s, "$2a$") || strings.HasPrefix(s, "$2b$") || strings.HasPrefix(s, "$2y$")
+}
+
 // SetPassword sets a plaintext password for the user
 func (u *User) SetPassword(password string) {
 	u.Password = password
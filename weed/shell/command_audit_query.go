@@ -0,0 +1,168 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/audit"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandAuditQuery{})
+}
+
+type commandAuditQuery struct {
+}
+
+func (c *commandAuditQuery) Name() string {
+	return "audit.query"
+}
+
+func (c *commandAuditQuery) Help() string {
+	return `search the audit log of administrative operations
+
+	audit.query [-since=24h] [-actor=alice] [-component=shell|admin|iam] [-action=volume.delete]
+		[-target=<substring>] [-result=success|failure] [-host=<filer>] [-limit=100] [-verify]
+
+	# deletions in the shell during the last week
+	audit.query -since=168h -component=shell -action=fs.rm
+	# failed operations of one user, and check that no record was changed or removed
+	audit.query -actor=alice -result=failure -verify
+
+	Each filer chains the records it stores with their hashes, in ` + audit.Directory + `/<yyyy-mm-dd>/<filer>.log.
+	With -verify, the chains of the read days are checked, so a changed, removed or reordered record is reported.
+
+`
+}
+
+func (c *commandAuditQuery) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandAuditQuery) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	queryCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	since := queryCommand.Duration("since", 24*time.Hour, "only records newer than this")
+	actor := queryCommand.String("actor", "", "only records of this actor")
+	component := queryCommand.String("component", "", "only records of this component, e.g. shell, admin, iam")
+	action := queryCommand.String("action", "", "only records of this action")
+	target := queryCommand.String("target", "", "only records with a target containing this")
+	result := queryCommand.String("result", "", "only records with this result, success or failure")
+	host := queryCommand.String("host", "", "only records stored by this filer")
+	limit := queryCommand.Int("limit", 100, "show at most this number of the latest matching records")
+	verify := queryCommand.Bool("verify", false, "verify the hash chains of the read days")
+	if err = queryCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	filter := &audit.Filter{
+		Since:     time.Now().Add(-*since),
+		Host:      *host,
+		Actor:     *actor,
+		Component: *component,
+		Action:    *action,
+		Target:    *target,
+		Result:    *result,
+	}
+
+	chains, err := readAuditChains(commandEnv, filter.Since.UTC().Format(time.DateOnly))
+	if err != nil {
+		return err
+	}
+
+	var hosts []string
+	for h := range chains {
+		hosts = append(hosts, h)
+	}
+	sort.Strings(hosts)
+
+	var matched []*filer_pb.AuditRecord
+	for _, h := range hosts {
+		if *verify {
+			if err := audit.VerifyChain(chains[h]); err != nil {
+				fmt.Fprintf(writer, "chain of %s is broken: %v\n", h, err)
+			} else {
+				fmt.Fprintf(writer, "chain of %s verified: %d records\n", h, len(chains[h]))
+			}
+		}
+		for _, record := range chains[h] {
+			if filter.Match(record) {
+				matched = append(matched, record)
+			}
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].TsNs < matched[j].TsNs
+	})
+	if *limit > 0 && len(matched) > *limit {
+		matched = matched[len(matched)-*limit:]
+	}
+	for _, record := range matched {
+		fmt.Fprintf(writer, "%s %s@%s %s %s %q %s", time.Unix(0, record.TsNs).Format(time.RFC3339), record.Actor, record.SourceIp, record.Component, record.Action, record.Target, record.Result)
+		if record.Error != "" {
+			fmt.Fprintf(writer, " %q", record.Error)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return nil
+}
+
+// readAuditChains reads the audit records of each filer from the day on, in sequence order
+func readAuditChains(commandEnv *CommandEnv, fromDay string) (chains map[string][]*filer_pb.AuditRecord, err error) {
+	var days []string
+	err = filer_pb.ReadDirAllEntries(context.Background(), commandEnv, util.FullPath(audit.Directory), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if entry.IsDirectory && entry.Name >= fromDay {
+			days = append(days, entry.Name)
+		}
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", audit.Directory, err)
+	}
+	sort.Strings(days)
+
+	chains = make(map[string][]*filer_pb.AuditRecord)
+	for _, day := range days {
+		dayDir := util.NewFullPath(audit.Directory, day)
+		var files []string
+		err = filer_pb.ReadDirAllEntries(context.Background(), commandEnv, dayDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			if !entry.IsDirectory && strings.HasSuffix(entry.Name, ".log") {
+				files = append(files, entry.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", dayDir, err)
+		}
+		for _, name := range files {
+			var buf bytes.Buffer
+			err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+				return filer.ReadEntry(commandEnv.MasterClient, client, string(dayDir), name, &buf)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("read %s/%s: %w", dayDir, name, err)
+			}
+			records, err := audit.ParseLog(buf.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("parse %s/%s: %w", dayDir, name, err)
+			}
+			h := strings.TrimSuffix(name, ".log")
+			chains[h] = append(chains[h], records...)
+		}
+	}
+	return chains, nil
+}
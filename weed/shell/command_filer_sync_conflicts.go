@@ -0,0 +1,114 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFilerSyncConflicts{})
+}
+
+type commandFilerSyncConflicts struct {
+}
+
+func (c *commandFilerSyncConflicts) Name() string {
+	return "filer.sync.conflicts"
+}
+
+func (c *commandFilerSyncConflicts) Help() string {
+	return `list the conflicts "weed filer.sync" has resolved on this cluster
+
+	filer.sync.conflicts                              # list all conflicts, the latest last
+	filer.sync.conflicts -path=/shared/docs -limit=20 # the latest 20 conflicts under /shared/docs
+	filer.sync.conflicts -clear -olderThanDays=30     # remove the records older than 30 days
+
+	Each line has the time of the conflict, the file, the other filer, and how the conflict is resolved.
+	With -conflict=keepBoth, the losing change is kept next to the file, as shown after "=>".
+
+`
+}
+
+func (c *commandFilerSyncConflicts) HasTag(CommandTag) bool {
+	return false
+}
+
+type syncConflictRecord struct {
+	name     string
+	conflict *filer_pb.SyncConflict
+}
+
+func (c *commandFilerSyncConflicts) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	conflictsCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	path := conflictsCommand.String("path", "/", "only the conflicts of the files under this path")
+	limit := conflictsCommand.Int("limit", 0, "only the latest conflicts, 0 for all")
+	clearRecords := conflictsCommand.Bool("clear", false, "remove the records instead of listing them")
+	olderThanDays := conflictsCommand.Int("olderThanDays", 0, "with -clear, only remove the records older than this many days")
+	if err = conflictsCommand.Parse(args); err != nil {
+		return nil
+	}
+	prefix, err := commandEnv.parseUrl(*path)
+	if err != nil {
+		return err
+	}
+
+	var records []*syncConflictRecord
+	err = filer_pb.ReadDirAllEntries(context.Background(), commandEnv, filer.SyncConflictsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		conflict := &filer_pb.SyncConflict{}
+		if entry.IsDirectory || proto.Unmarshal(entry.Content, conflict) != nil {
+			return nil
+		}
+		if isPathCoveredBy(conflict.Path, prefix) {
+			records = append(records, &syncConflictRecord{name: entry.Name, conflict: conflict})
+		}
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("list %s: %w", filer.SyncConflictsDir, err)
+	}
+
+	if *clearRecords {
+		cutoff := time.Now().Add(-time.Duration(*olderThanDays) * 24 * time.Hour).UnixNano()
+		removed := 0
+		for _, record := range records {
+			if record.conflict.TsNs > cutoff {
+				continue
+			}
+			if err = filer_pb.Remove(context.Background(), commandEnv, filer.SyncConflictsDir, record.name, false, false, false, false, nil); err != nil {
+				return fmt.Errorf("remove %s: %w", record.name, err)
+			}
+			removed++
+		}
+		fmt.Fprintf(writer, "removed %d conflict records\n", removed)
+		return nil
+	}
+
+	if *limit > 0 && len(records) > *limit {
+		records = records[len(records)-*limit:]
+	}
+	for _, record := range records {
+		conflict := record.conflict
+		change := "changed"
+		if conflict.SourceDeleted {
+			change = "deleted"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s on %s\t%s", time.Unix(0, conflict.TsNs).UTC().Format(time.RFC3339), conflict.Path, change, conflict.SourceFiler, conflict.Resolution)
+		if conflict.ConflictPath != "" {
+			fmt.Fprintf(writer, " => %s", conflict.ConflictPath)
+		}
+		fmt.Fprintln(writer)
+	}
+	return nil
+}
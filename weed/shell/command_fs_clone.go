@@ -0,0 +1,76 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsClone{})
+}
+
+type commandFsClone struct {
+}
+
+func (c *commandFsClone) Name() string {
+	return "fs.clone"
+}
+
+func (c *commandFsClone) Help() string {
+	return `instantly copy a file or a folder, sharing the data with the source
+
+	fs.clone <source entry> <destination entry>
+
+	fs.clone /datasets/imagenet /datasets/imagenet-experiment1
+	fs.clone /checkpoints/run1/step1000.pt /checkpoints/run2/
+
+	Only the metadata is copied. The chunks are shared and counted,
+	so deleting or overwriting one side never frees the data the other side still uses.
+	The destination must not exist yet, unless it is a folder ending with "/".
+
+`
+}
+
+func (c *commandFsClone) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsClone) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 2 {
+		return fmt.Errorf("need to have 2 arguments")
+	}
+
+	sourcePath, err := commandEnv.parseUrl(args[0])
+	if err != nil {
+		return err
+	}
+	destinationPath, err := commandEnv.parseUrl(args[1])
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(args[1], "/") {
+		// into the folder
+		_, sourceName := util.FullPath(sourcePath).DirAndName()
+		destinationPath = util.Join(destinationPath, sourceName)
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.CloneTree(context.Background(), &filer_pb.CloneTreeRequest{
+			SourcePath: sourcePath,
+			TargetPath: destinationPath,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "clone: %s => %s, %d files, %d folders, %s\n", sourcePath, destinationPath,
+			resp.FileCount, resp.DirectoryCount, util.BytesToHumanReadable(resp.ByteCount))
+		return nil
+	})
+
+}
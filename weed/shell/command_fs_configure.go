@@ -37,6 +37,23 @@ func (c *commandFsConfigure) Help() string {
 	# example: configure adding only 1 physical volume for each bucket collection
 	fs.configure -locationPrefix=/buckets/ -volumeGrowthCount=1
 
+	# example: limit a team directory to 100GiB and 1 million files and directories
+	fs.configure -locationPrefix=/home/team1/ -quotaMB=102400 -quotaInodes=1000000
+
+	# example: keep the last 10 versions of overwritten or deleted files, for up to 30 days
+	fs.configure -locationPrefix=/home/ -versioning -versionRetentionCount=10 -versionRetentionDays=30
+
+	# example: let filer.sync tell concurrent changes of the same file on both clusters, configured on both clusters
+	fs.configure -locationPrefix=/shared/ -syncVectorClock
+
+	# example: only let tenant1 and the backup identity work under a directory, see fs.jwt for their tokens
+	fs.configure -locationPrefix=/home/tenant1/ -accessRules="tenant1=read,write,delete;backup=read"
+	# gRPC calls without a jwt come from the cluster components and are not restricted,
+	# unless jwt.filer_signing.grpc_anonymous_access_rules is set in security.toml
+
+	# example: encrypt a tenant's files, wrapping their chunk keys with the tenant's kms key
+	fs.configure -locationPrefix=/home/tenant1/ -kmsProvider=vault -kmsKeyId=tenant1
+
 	# apply the changes
 	fs.configure -locationPrefix=/my/folder -collection=abc -apply
 
@@ -68,6 +85,15 @@ func (c *commandFsConfigure) Do(args []string, commandEnv *CommandEnv, writer io
 	rack := fsConfigureCommand.String("rack", "", "assign writes to this rack")
 	dataNode := fsConfigureCommand.String("dataNode", "", "assign writes to this dataNode")
 	volumeGrowthCount := fsConfigureCommand.Int("volumeGrowthCount", 0, "the number of physical volumes to add if no writable volumes")
+	quotaMB := fsConfigureCommand.Uint64("quotaMB", 0, "hard limit of the total file size under the location, in MiB")
+	quotaInodes := fsConfigureCommand.Uint64("quotaInodes", 0, "hard limit of the number of files and directories under the location")
+	versioning := fsConfigureCommand.Bool("versioning", false, "keep the previous content of overwritten or deleted files, see fs.versions and fs.restore")
+	versionRetentionCount := fsConfigureCommand.Uint("versionRetentionCount", 0, "keep at most this many versions of each file, 0 for no limit")
+	versionRetentionDays := fsConfigureCommand.Uint64("versionRetentionDays", 0, "remove versions replaced more than this many days ago, 0 for no limit")
+	syncVectorClock := fsConfigureCommand.Bool("syncVectorClock", false, "track the changes of each file with a vector clock, for filer.sync to tell concurrent changes on both sides")
+	accessRules := fsConfigureCommand.String("accessRules", "", "restrict the location to identities, as \"<identity>=<read|write|delete>,...;*=read\", see fs.jwt")
+	kmsProvider := fsConfigureCommand.String("kmsProvider", "", "the kms provider in filer.toml for -kmsKeyId, empty for the default provider")
+	kmsKeyId := fsConfigureCommand.String("kmsKeyId", "", "encrypt the chunks and wrap their keys with this kms key, see fs.rewrap")
 	isDelete := fsConfigureCommand.Bool("delete", false, "delete the configuration by locationPrefix")
 	apply := fsConfigureCommand.Bool("apply", false, "update and apply filer configuration")
 	if err = fsConfigureCommand.Parse(args); err != nil {
@@ -97,6 +123,24 @@ func (c *commandFsConfigure) Do(args []string, commandEnv *CommandEnv, writer io
 			Worm:                     *worm,
 			WormGracePeriodSeconds:   *wormGracePeriod,
 			WormRetentionTimeSeconds: *wormRetentionTime,
+			QuotaBytes:               *quotaMB * 1024 * 1024,
+			QuotaInodes:              *quotaInodes,
+			Versioning:               *versioning,
+			VersionRetentionCount:    uint32(*versionRetentionCount),
+			VersionRetentionSeconds:  *versionRetentionDays * 24 * 3600,
+			SyncVectorClock:          *syncVectorClock,
+			KmsProvider:              *kmsProvider,
+			KmsKeyId:                 *kmsKeyId,
+		}
+
+		// check kms
+		if *kmsProvider != "" && *kmsKeyId == "" {
+			return fmt.Errorf("kmsProvider is only used with kmsKeyId")
+		}
+
+		// check access rules
+		if locConf.AccessRules, err = filer.ParseAccessRules(*accessRules); err != nil {
+			return err
 		}
 
 		// check collection
@@ -0,0 +1,154 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer/search_index"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsFind{})
+}
+
+type commandFsFind struct {
+}
+
+func (c *commandFsFind) Name() string {
+	return "fs.find"
+}
+
+func (c *commandFsFind) Help() string {
+	return `find entries by name, text, type, size, time and attributes
+
+	fs.find -name "*.pdf" /docs                  # entries with a name matching the wildcard pattern
+	fs.find -text "quarterly report*" /          # entries with all these words in the name or attributes
+	fs.find -mime image/ -minSize 10485760 /     # images larger than 10MB
+	fs.find -type f -newer 24h /buckets/b1       # files changed in the last day
+	fs.find -tag project=apollo /buckets         # objects with the S3 tag project=apollo
+	fs.find -xattr owner,team=infra /home        # entries with the extended attribute owner, and team=infra
+
+	The filer answers the search from its search index when it runs with -searchIndexDir.
+	Otherwise, the whole directory tree is walked and checked here.
+
+`
+}
+
+func (c *commandFsFind) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsFind) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	findCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := findCommand.String("name", "", "wildcard pattern of the entry name")
+	text := findCommand.String("text", "", "space separated words in the name or attribute values, a trailing * matches a word prefix")
+	mime := findCommand.String("mime", "", "mime type prefix")
+	minSize := findCommand.Uint64("minSize", 0, "minimum file size in bytes")
+	maxSize := findCommand.Uint64("maxSize", 0, "maximum file size in bytes")
+	newer := findCommand.Duration("newer", 0, "modified within this duration, e.g. 24h")
+	older := findCommand.Duration("older", 0, "modified before this duration ago, e.g. 720h")
+	xattr := findCommand.String("xattr", "", "comma separated extended attributes as key or key=value")
+	tag := findCommand.String("tag", "", "comma separated S3 object tags as key or key=value")
+	entryType := findCommand.String("type", "", "f for files only, d for directories only")
+	limit := findCommand.Uint("limit", 0, "stop after this many results, 0 for no limit")
+	if err = findCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	path, err := commandEnv.parseUrl(findInputDirectory(findCommand.Args()))
+	if err != nil {
+		return err
+	}
+
+	req := &filer_pb.SearchEntriesRequest{
+		Directory:   path,
+		Terms:       strings.Fields(*text),
+		NamePattern: *name,
+		MimePrefix:  *mime,
+		MinSize:     *minSize,
+		MaxSize:     *maxSize,
+		Limit:       uint32(*limit),
+		Extended:    make(map[string]string),
+	}
+	now := time.Now()
+	if *newer > 0 {
+		req.MtimeAfter = now.Add(-*newer).Unix()
+	}
+	if *older > 0 {
+		req.MtimeBefore = now.Add(-*older).Unix()
+	}
+	parseAttributes(req.Extended, *xattr, "")
+	parseAttributes(req.Extended, *tag, s3_constants.AmzObjectTaggingPrefix)
+	switch *entryType {
+	case "":
+	case "f":
+		req.FilesOnly = true
+	case "d":
+		req.DirectoriesOnly = true
+	default:
+		return fmt.Errorf("unknown type %s, expecting f or d", *entryType)
+	}
+
+	matcher, err := search_index.NewMatcher(req)
+	if err != nil {
+		return err
+	}
+
+	err = commandEnv.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		stream, err := client.SearchEntries(context.Background(), req)
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(writer, "%s\n", util.NewFullPath(resp.Directory, resp.Entry.Name))
+		}
+	})
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+
+	// no search index on the filer, walk the tree instead
+	var count uint32
+	var printLock sync.Mutex
+	return filer_pb.TraverseBfs(commandEnv, util.FullPath(path), func(parentPath util.FullPath, entry *filer_pb.Entry) {
+		if !matcher.Match(entry) {
+			return
+		}
+		printLock.Lock()
+		defer printLock.Unlock()
+		if req.Limit > 0 && count >= req.Limit {
+			return
+		}
+		count++
+		fmt.Fprintf(writer, "%s\n", parentPath.Child(entry.Name))
+	})
+}
+
+func parseAttributes(attributes map[string]string, text string, keyPrefix string) {
+	for _, pair := range strings.Split(text, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		attributes[keyPrefix+key] = value
+	}
+}
@@ -0,0 +1,85 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsJwt{})
+}
+
+type commandFsJwt struct {
+}
+
+func (c *commandFsJwt) Name() string {
+	return "fs.jwt"
+}
+
+func (c *commandFsJwt) Help() string {
+	return `issue a filer jwt limited to an identity, path prefixes and actions
+
+	The token is signed with the jwt.filer_signing key of security.toml, and is sent to the filer
+	as "Authorization: Bearer <jwt>" over HTTP, or in the gRPC metadata.
+	The identity is also checked against the access rules of fs.configure -accessRules.
+
+	# let a tenant read and write its home directory for a day
+	fs.jwt -identity=tenant1 -paths=/home/tenant1 -actions=read,write -expireSeconds=86400
+
+	# a read only token for two directories
+	fs.jwt -identity=reporting -paths=/data/reports,/data/exports -actions=read
+
+`
+}
+
+func (c *commandFsJwt) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsJwt) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+	fsJwtCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	identity := fsJwtCommand.String("identity", "", "the identity of the token holder, required")
+	paths := fsJwtCommand.String("paths", "", "comma separated path prefixes the token is limited to, empty for all paths")
+	actions := fsJwtCommand.String("actions", "", "comma separated actions among read, write and delete, empty for all actions")
+	expireSeconds := fsJwtCommand.Int("expireSeconds", 3600, "the token expires after this many seconds, 0 for never")
+	if err = fsJwtCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *identity == "" {
+		return fmt.Errorf("missing -identity")
+	}
+	signingKey := util.GetViper().GetString("jwt.filer_signing.key")
+	if signingKey == "" {
+		return fmt.Errorf("jwt.filer_signing.key is not configured in security.toml")
+	}
+	actionList := splitNonEmpty(*actions)
+	for _, action := range actionList {
+		switch action {
+		case security.FilerActionRead, security.FilerActionWrite, security.FilerActionDelete:
+		default:
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+
+	token := security.GenRestrictedJwtForFilerServer(security.SigningKey(signingKey), *expireSeconds, *identity, splitNonEmpty(*paths), actionList)
+	if token == "" {
+		return fmt.Errorf("failed to sign the jwt")
+	}
+	fmt.Fprintln(writer, token)
+	return nil
+}
+
+func splitNonEmpty(s string) (parts []string) {
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,106 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsQuota{})
+}
+
+type commandFsQuota struct {
+}
+
+func (c *commandFsQuota) Name() string {
+	return "fs.quota"
+}
+
+func (c *commandFsQuota) Help() string {
+	return `show the usage of directory quotas
+
+	fs.quota                                               # show all directory quotas and their usage
+	fs.quota -locationPrefix=/home/team1/ -recount -apply  # count the usage again from the directory content
+
+	Directory quotas are configured with fs.configure -quotaMB and -quotaInodes.
+	The filer keeps the usage counters up to date on every change, and counts the directory content
+	when a quota is new. Writes that would go over a quota fail with "disk quota exceeded".
+
+`
+}
+
+func (c *commandFsQuota) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsQuota) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	fsQuotaCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	locationPrefix := fsQuotaCommand.String("locationPrefix", "", "only show this quota location prefix")
+	recount := fsQuotaCommand.Bool("recount", false, "discard the usage counter, so the filer counts the directory content again")
+	apply := fsQuotaCommand.Bool("apply", false, "apply the recount")
+	if err = fsQuotaCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	fc, err := filer.ReadFilerConf(commandEnv.option.FilerAddress, commandEnv.option.GrpcDialOption, commandEnv.MasterClient)
+	if err != nil {
+		return err
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		for _, rule := range fc.ToProto().Locations {
+			if rule.QuotaBytes == 0 && rule.QuotaInodes == 0 {
+				continue
+			}
+			if *locationPrefix != "" && rule.LocationPrefix != *locationPrefix {
+				continue
+			}
+
+			if *recount {
+				if !*apply {
+					fmt.Fprintf(writer, "%s: would recount usage\n", rule.LocationPrefix)
+					continue
+				}
+				resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{Key: filer.QuotaUsageKey(rule.LocationPrefix)})
+				if err != nil {
+					return fmt.Errorf("reset usage of %s: %w", rule.LocationPrefix, err)
+				}
+				if resp.Error != "" {
+					return fmt.Errorf("reset usage of %s: %s", rule.LocationPrefix, resp.Error)
+				}
+				fmt.Fprintf(writer, "%s: usage will be recounted on the next write\n", rule.LocationPrefix)
+				continue
+			}
+
+			resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: filer.QuotaUsageKey(rule.LocationPrefix)})
+			if err != nil {
+				return fmt.Errorf("read usage of %s: %w", rule.LocationPrefix, err)
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("read usage of %s: %s", rule.LocationPrefix, resp.Error)
+			}
+			usage, parseErr := filer.QuotaUsageFromBytes(resp.Value)
+			if parseErr != nil {
+				fmt.Fprintf(writer, "%s\tbytes:-/%s\tinodes:-/%s\t(not counted yet)\n", rule.LocationPrefix,
+					formatQuotaLimit(rule.QuotaBytes), formatQuotaLimit(rule.QuotaInodes))
+				continue
+			}
+			fmt.Fprintf(writer, "%s\tbytes:%d/%s\tinodes:%d/%s\n", rule.LocationPrefix,
+				usage.Bytes, formatQuotaLimit(rule.QuotaBytes), usage.Inodes, formatQuotaLimit(rule.QuotaInodes))
+		}
+		return nil
+	})
+}
+
+func formatQuotaLimit(limit uint64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
@@ -0,0 +1,105 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsRestore{})
+}
+
+type commandFsRestore struct {
+}
+
+func (c *commandFsRestore) Name() string {
+	return "fs.restore"
+}
+
+func (c *commandFsRestore) Help() string {
+	return `restore a previous version of a file, see fs.versions
+
+	fs.restore /home/alice/report.doc                                   # restore the newest version
+	fs.restore -version=1760790000000000000 /home/alice/report.doc      # restore a specific version
+	fs.restore -version=1760790000000000000 -to=/home/alice/report.old.doc /home/alice/report.doc
+
+	The restored content replaces the current file, which is kept as a new version in turn,
+	so a restore can also be undone.
+
+`
+}
+
+func (c *commandFsRestore) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsRestore) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	fsRestoreCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	versionId := fsRestoreCommand.String("version", "", "the version to restore, the newest one if empty")
+	to := fsRestoreCommand.String("to", "", "restore to this path instead of the original one")
+	if err = fsRestoreCommand.Parse(args); err != nil {
+		return nil
+	}
+	if fsRestoreCommand.NArg() == 0 {
+		return fmt.Errorf("need a file path")
+	}
+	path, err := commandEnv.parseUrl(fsRestoreCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+	target := path
+	if *to != "" {
+		if target, err = commandEnv.parseUrl(*to); err != nil {
+			return err
+		}
+	}
+
+	versions, err := listFileVersions(commandEnv, util.FullPath(path))
+	if err != nil {
+		return err
+	}
+	var version *filer_pb.Entry
+	for _, v := range versions {
+		if *versionId == "" || v.Name == *versionId {
+			version = v
+		}
+	}
+	if version == nil {
+		if *versionId == "" {
+			return fmt.Errorf("no versions of %s", path)
+		}
+		return fmt.Errorf("version %s of %s not found", *versionId, path)
+	}
+
+	dir, name := util.FullPath(target).DirAndName()
+	entry := proto.Clone(version).(*filer_pb.Entry)
+	entry.Name = name
+	delete(entry.Extended, filer.VersionExtendedDeleted)
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	entry.Attributes.Mtime = time.Now().Unix()
+	entry.Attributes.Inode = 0
+
+	err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: dir,
+			Entry:     entry,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("restore %s: %w", target, err)
+	}
+	fmt.Fprintf(writer, "restored version %s of %s to %s\n", version.Name, path, target)
+	return nil
+}
@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsRewrap{})
+}
+
+type commandFsRewrap struct {
+}
+
+func (c *commandFsRewrap) Name() string {
+	return "fs.rewrap"
+}
+
+func (c *commandFsRewrap) Help() string {
+	return `re-encrypt the chunk keys of the files in a folder with the current kms key of their location
+
+	fs.rewrap <folder>
+
+	# after changing the kms key of a location, or rotating the key in the kms
+	fs.configure -locationPrefix=/home/tenant1/ -kmsKeyId=tenant1-2025 -apply
+	fs.rewrap /home/tenant1
+
+	Only the metadata is rewritten, the encrypted chunks stay as they are.
+	The previous kms key must still be able to decrypt until the rewrap is done.
+
+`
+}
+
+func (c *commandFsRewrap) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsRewrap) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 1 {
+		return fmt.Errorf("need to have 1 argument")
+	}
+
+	dir, err := commandEnv.parseUrl(args[0])
+	if err != nil {
+		return err
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.RewrapChunkKeys(context.Background(), &filer_pb.RewrapChunkKeysRequest{
+			Directory: dir,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "rewrap: %s, %d files\n", dir, resp.FileCount)
+		return nil
+	})
+
+}
@@ -0,0 +1,267 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotCreate{})
+}
+
+type commandFsSnapshotCreate struct {
+}
+
+func (c *commandFsSnapshotCreate) Name() string {
+	return "fs.snapshot.create"
+}
+
+func (c *commandFsSnapshotCreate) Help() string {
+	return `create a point-in-time metadata snapshot of a directory tree
+
+	fs.snapshot.create /data              # snapshot /data
+	fs.snapshot.create -keep 48 /data     # snapshot /data, and only keep the latest 48 snapshots of /data
+	fs.snapshot.create -name before-upgrade /data
+
+	The snapshot is stored inside the filer under ` + filer.SnapshotsDir + `, in the fs.meta.save format.
+	The filer does not delete any chunk referenced by a retained snapshot, so the data of deleted
+	or overwritten files stays recoverable with fs.snapshot.restore until the snapshot is deleted.
+
+	To take snapshots periodically, add this command to the master.toml maintenance scripts, e.g.
+		fs.snapshot.create -keep 24 /data
+
+`
+}
+
+func (c *commandFsSnapshotCreate) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotCreate) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	snapshotCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := snapshotCommand.String("name", "", "snapshot name, defaults to the path and the current time")
+	keep := snapshotCommand.Int("keep", 0, "delete older snapshots of the same path beyond this count, 0 keeps all")
+	if err = snapshotCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	path, parseErr := commandEnv.parseUrl(findInputDirectory(snapshotCommand.Args()))
+	if parseErr != nil {
+		return parseErr
+	}
+	if err = commandEnv.checkDirectory(path); err != nil {
+		return err
+	}
+
+	snapshot, err := createMetadataSnapshot(commandEnv, writer, path, *name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(writer, "snapshot %s of %s at %s is saved\n", snapshot.Name, snapshot.Path, time.Unix(0, snapshot.TsNs).UTC().Format(time.RFC3339))
+
+	if *keep > 0 {
+		return pruneMetadataSnapshots(commandEnv, writer, path, *keep)
+	}
+	return nil
+}
+
+func defaultSnapshotName(path string, t time.Time) string {
+	prefix := strings.ReplaceAll(strings.Trim(path, "/"), "/", "_")
+	if prefix == "" {
+		prefix = "root"
+	}
+	return fmt.Sprintf("%s-%s", prefix, t.UTC().Format("20060102-150405"))
+}
+
+// createMetadataSnapshot saves the metadata under path into a snapshot file inside the filer.
+// The snapshot time is taken before the traversal starts, so replaying the metadata log from
+// that time onward also covers the changes made while the traversal was running.
+func createMetadataSnapshot(commandEnv *CommandEnv, writer io.Writer, path, name string) (*filer.SnapshotInfo, error) {
+	startTime := time.Now()
+	if name == "" {
+		name = defaultSnapshotName(path, startTime)
+	}
+	if strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid snapshot name %s", name)
+	}
+
+	tempFile, err := os.CreateTemp("", "snapshot*.meta")
+	if err != nil {
+		return nil, fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+
+	err = doTraverseBfsAndSaving(commandEnv, writer, path, false, func(entry *filer_pb.FullEntry, outputChan chan interface{}) error {
+		if strings.HasPrefix(entry.Dir, filer.SnapshotsDir) {
+			return nil
+		}
+		bytes, err := proto.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		outputChan <- bytes
+		return nil
+	}, func(outputChan chan interface{}) {
+		sizeBuf := make([]byte, 4)
+		for item := range outputChan {
+			b := item.([]byte)
+			util.Uint32toBytes(sizeBuf, uint32(len(b)))
+			tempFile.Write(sizeBuf)
+			tempFile.Write(b)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("traverse %s: %w", path, err)
+	}
+
+	if err = saveMetadataSnapshotFile(commandEnv, tempFile, name, path, startTime); err != nil {
+		return nil, fmt.Errorf("save snapshot %s: %w", name, err)
+	}
+
+	return &filer.SnapshotInfo{
+		Name: name,
+		Path: path,
+		TsNs: startTime.UnixNano(),
+	}, nil
+}
+
+func saveMetadataSnapshotFile(commandEnv *CommandEnv, sourceFile *os.File, name, path string, startTime time.Time) error {
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return fmt.Errorf("new uploader: %w", err)
+	}
+
+	fileInfo, err := sourceFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat source file: %w", err)
+	}
+
+	now := time.Now()
+	entry := &filer_pb.Entry{
+		Name: name,
+		Attributes: &filer_pb.FuseAttributes{
+			Crtime:   now.Unix(),
+			Mtime:    now.Unix(),
+			FileMode: uint32(os.FileMode(0600)),
+			FileSize: uint64(fileInfo.Size()),
+		},
+		Extended: map[string][]byte{
+			filer.SnapshotExtendedPath: []byte(path),
+			filer.SnapshotExtendedTsNs: []byte(strconv.FormatInt(startTime.UnixNano(), 10)),
+		},
+	}
+
+	chunkSize := int64(4 * 1024 * 1024)
+	for offset := int64(0); offset < fileInfo.Size(); offset += chunkSize {
+		fileId, uploadResult, err, _ := uploader.UploadWithRetry(
+			commandEnv,
+			&filer_pb.AssignVolumeRequest{
+				Count: 1,
+				Path:  util.Join(filer.SnapshotsDir, name),
+			},
+			&operation.UploadOption{
+				Filename: name,
+			},
+			func(host, fileId string) string {
+				return fmt.Sprintf("http://%s/%s", host, fileId)
+			},
+			io.NewSectionReader(sourceFile, offset, chunkSize),
+		)
+		if err != nil {
+			return fmt.Errorf("upload chunk at %d: %v", offset, err)
+		}
+		if uploadResult.Error != "" {
+			return fmt.Errorf("upload result: %v", uploadResult.Error)
+		}
+		entry.Chunks = append(entry.Chunks, uploadResult.ToPbFileChunk(fileId, offset, now.UnixNano()))
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: filer.SnapshotsDir,
+			Entry:     entry,
+			OExcl:     true,
+		})
+	})
+}
+
+// listMetadataSnapshots returns all snapshots, oldest first
+func listMetadataSnapshots(commandEnv *CommandEnv) (snapshots []*filer.SnapshotInfo, err error) {
+	err = filer_pb.ReadDirAllEntries(context.Background(), commandEnv, filer.SnapshotsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		snapshot, parseErr := filer.NewSnapshotInfo(entry)
+		if parseErr != nil {
+			return nil
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		err = nil
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].TsNs < snapshots[j].TsNs
+	})
+	return
+}
+
+func pruneMetadataSnapshots(commandEnv *CommandEnv, writer io.Writer, path string, keep int) error {
+	snapshots, err := listMetadataSnapshots(commandEnv)
+	if err != nil {
+		return err
+	}
+	var samePath []*filer.SnapshotInfo
+	for _, snapshot := range snapshots {
+		if snapshot.Path == path {
+			samePath = append(samePath, snapshot)
+		}
+	}
+	for len(samePath) > keep {
+		if err := deleteMetadataSnapshot(commandEnv, samePath[0].Name); err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "deleted old snapshot %s\n", samePath[0].Name)
+		samePath = samePath[1:]
+	}
+	return nil
+}
+
+func deleteMetadataSnapshot(commandEnv *CommandEnv, name string) error {
+	return filer_pb.Remove(context.Background(), commandEnv, filer.SnapshotsDir, name, true, false, false, false, nil)
+}
+
+// readMetadataSnapshot streams the entries of a snapshot
+func readMetadataSnapshot(commandEnv *CommandEnv, name string, eachEntryFn func(fullEntry *filer_pb.FullEntry) error) error {
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: filer.SnapshotsDir,
+			Name:      name,
+		})
+		if err != nil {
+			return fmt.Errorf("lookup snapshot %s: %w", name, err)
+		}
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(filer.StreamContent(commandEnv.MasterClient, writer, resp.Entry.GetChunks(), 0, int64(filer.FileSize(resp.Entry))))
+		}()
+		defer reader.Close()
+		return filer.ReadSnapshotEntries(reader, eachEntryFn)
+	})
+}
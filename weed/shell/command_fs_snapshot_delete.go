@@ -0,0 +1,52 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotDelete{})
+}
+
+type commandFsSnapshotDelete struct {
+}
+
+func (c *commandFsSnapshotDelete) Name() string {
+	return "fs.snapshot.delete"
+}
+
+func (c *commandFsSnapshotDelete) Help() string {
+	return `delete a metadata snapshot
+
+	fs.snapshot.delete -name <snapshot_name>
+
+	Chunks that were only kept alive by this snapshot are no longer protected.
+	Chunks of files deleted while the snapshot existed are kept in a list by the filer,
+	and are deleted once no remaining snapshot references them.
+
+`
+}
+
+func (c *commandFsSnapshotDelete) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotDelete) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	snapshotCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := snapshotCommand.String("name", "", "snapshot name")
+	if err = snapshotCommand.Parse(args); err != nil {
+		return nil
+	}
+	if *name == "" {
+		return fmt.Errorf("missing snapshot name")
+	}
+
+	if err = deleteMetadataSnapshot(commandEnv, *name); err != nil {
+		return fmt.Errorf("delete snapshot %s: %w", *name, err)
+	}
+	fmt.Fprintf(writer, "snapshot %s is deleted\n", *name)
+	return nil
+}
@@ -0,0 +1,69 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotList{})
+}
+
+type commandFsSnapshotList struct {
+}
+
+func (c *commandFsSnapshotList) Name() string {
+	return "fs.snapshot.list"
+}
+
+func (c *commandFsSnapshotList) Help() string {
+	return `list metadata snapshots
+
+	fs.snapshot.list         # list all snapshots
+	fs.snapshot.list /data   # list snapshots covering /data
+
+`
+}
+
+func (c *commandFsSnapshotList) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotList) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	snapshotCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	if err = snapshotCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	var path string
+	if snapshotCommand.NArg() > 0 {
+		if path, err = commandEnv.parseUrl(snapshotCommand.Arg(0)); err != nil {
+			return err
+		}
+	}
+
+	snapshots, err := listMetadataSnapshots(commandEnv)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		if path != "" && !isPathCoveredBy(path, snapshot.Path) {
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d bytes\n", snapshot.Name, time.Unix(0, snapshot.TsNs).UTC().Format(time.RFC3339), snapshot.Path, snapshot.Size)
+	}
+	return nil
+}
+
+// isPathCoveredBy tells whether path is dir or inside dir
+func isPathCoveredBy(path, dir string) bool {
+	if dir == "/" || path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
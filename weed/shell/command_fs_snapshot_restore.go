@@ -0,0 +1,289 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotRestore{})
+}
+
+type commandFsSnapshotRestore struct {
+}
+
+func (c *commandFsSnapshotRestore) Name() string {
+	return "fs.snapshot.restore"
+}
+
+func (c *commandFsSnapshotRestore) Help() string {
+	return `restore a directory tree to how it looked at a point in time
+
+	fs.snapshot.restore -time 2025-06-01T10:00:00Z /data/project        # show what would change
+	fs.snapshot.restore -time 2025-06-01T10:00:00Z -apply /data/project # roll /data/project back in place
+	fs.snapshot.restore -timeAgo 30m -to /data/project.restored -apply /data/project
+	fs.snapshot.restore -name data-20250601-100000 -apply /data         # restore exactly the snapshot
+
+	The restore starts from the latest snapshot covering the path that was taken before the target time,
+	and replays the filer metadata log from the snapshot time up to the target time.
+
+	Without -to, the path is restored in place: entries that did not exist at the target time are removed,
+	and a snapshot of the current state is taken first, so the restore itself can be undone.
+	Entries are only metadata; file content is available as long as its chunks were protected by
+	a snapshot or not yet deleted.
+
+`
+}
+
+func (c *commandFsSnapshotRestore) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotRestore) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	restoreCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := restoreCommand.String("name", "", "the snapshot to start from, defaults to the latest one before the target time")
+	targetTime := restoreCommand.String("time", "", "the target time in RFC3339 format, defaults to the snapshot time when -name is set")
+	timeAgo := restoreCommand.Duration("timeAgo", 0, "the target time relative to now, e.g. 30m")
+	to := restoreCommand.String("to", "", "restore into this directory instead of in place")
+	apply := restoreCommand.Bool("apply", false, "apply the changes, otherwise only print them")
+	verbose := restoreCommand.Bool("v", false, "print each changed entry")
+	if err = restoreCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	path, parseErr := commandEnv.parseUrl(findInputDirectory(restoreCommand.Args()))
+	if parseErr != nil {
+		return parseErr
+	}
+	targetDir := path
+	if *to != "" {
+		if targetDir, err = commandEnv.parseUrl(*to); err != nil {
+			return err
+		}
+	}
+	inPlace := targetDir == path
+
+	var targetTsNs int64
+	switch {
+	case *targetTime != "":
+		t, parseErr := time.Parse(time.RFC3339, *targetTime)
+		if parseErr != nil {
+			return fmt.Errorf("parse time %s: %v", *targetTime, parseErr)
+		}
+		targetTsNs = t.UnixNano()
+	case *timeAgo > 0:
+		targetTsNs = time.Now().Add(-*timeAgo).UnixNano()
+	case *name == "":
+		return fmt.Errorf("missing -time, -timeAgo or -name")
+	}
+
+	snapshot, err := findRestoreSnapshot(commandEnv, *name, path, targetTsNs)
+	if err != nil {
+		return err
+	}
+	if targetTsNs == 0 {
+		targetTsNs = snapshot.TsNs
+	}
+	fmt.Fprintf(writer, "restore %s to %s from snapshot %s taken at %s\n", path,
+		time.Unix(0, targetTsNs).UTC().Format(time.RFC3339), snapshot.Name, time.Unix(0, snapshot.TsNs).UTC().Format(time.RFC3339))
+
+	// rebuild the tree as of the target time
+	tree := filer.NewSnapshotTree(util.FullPath(path))
+	if err = readMetadataSnapshot(commandEnv, snapshot.Name, func(fullEntry *filer_pb.FullEntry) error {
+		tree.Add(fullEntry)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("read snapshot %s: %w", snapshot.Name, err)
+	}
+	if targetTsNs > snapshot.TsNs {
+		var eventCount int
+		if err = pb.FollowMetadata(commandEnv.option.FilerAddress, commandEnv.option.GrpcDialOption, &pb.MetadataFollowOption{
+			ClientName:     "shell_snapshot_restore",
+			ClientId:       util.RandomInt32(),
+			PathPrefix:     path,
+			StartTsNs:      snapshot.TsNs,
+			StopTsNs:       targetTsNs,
+			EventErrorType: pb.FatalOnError,
+		}, func(resp *filer_pb.SubscribeMetadataResponse) error {
+			if resp.TsNs > targetTsNs {
+				return nil
+			}
+			tree.Replay(resp)
+			eventCount++
+			return nil
+		}); err != nil {
+			return fmt.Errorf("replay metadata log: %w", err)
+		}
+		fmt.Fprintf(writer, "replayed %d metadata events\n", eventCount)
+	}
+
+	if inPlace && *apply {
+		preRestore, err := createMetadataSnapshot(commandEnv, nil, path, defaultSnapshotName(path, time.Now())+"-pre-restore")
+		if err != nil {
+			return fmt.Errorf("snapshot current state: %w", err)
+		}
+		fmt.Fprintf(writer, "current state is saved as snapshot %s\n", preRestore.Name)
+	}
+
+	return restoreSnapshotTree(commandEnv, writer, tree, path, targetDir, inPlace, *apply, *verbose)
+}
+
+// findRestoreSnapshot picks the named snapshot, or the latest snapshot covering path taken at or before targetTsNs
+func findRestoreSnapshot(commandEnv *CommandEnv, name, path string, targetTsNs int64) (*filer.SnapshotInfo, error) {
+	snapshots, err := listMetadataSnapshots(commandEnv)
+	if err != nil {
+		return nil, err
+	}
+	var found *filer.SnapshotInfo
+	for _, snapshot := range snapshots {
+		if name != "" {
+			if snapshot.Name == name {
+				found = snapshot
+			}
+			continue
+		}
+		if isPathCoveredBy(path, snapshot.Path) && snapshot.TsNs <= targetTsNs {
+			found = snapshot
+		}
+	}
+	if found == nil {
+		if name != "" {
+			return nil, fmt.Errorf("snapshot %s not found", name)
+		}
+		return nil, fmt.Errorf("no snapshot of %s before %s", path, time.Unix(0, targetTsNs).UTC().Format(time.RFC3339))
+	}
+	if !isPathCoveredBy(path, found.Path) {
+		return nil, fmt.Errorf("snapshot %s of %s does not cover %s", found.Name, found.Path, path)
+	}
+	if targetTsNs != 0 && found.TsNs > targetTsNs {
+		return nil, fmt.Errorf("snapshot %s is taken after the target time", found.Name)
+	}
+	return found, nil
+}
+
+func restoreSnapshotTree(commandEnv *CommandEnv, writer io.Writer, tree *filer.SnapshotTree, sourceDir, targetDir string, inPlace, apply, verbose bool) error {
+	toTargetPath := func(path util.FullPath) util.FullPath {
+		return util.JoinPath(targetDir, strings.TrimPrefix(string(path), sourceDir))
+	}
+
+	var createCount, updateCount, deleteCount int
+	err := commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+
+		for _, path := range tree.Paths() {
+			if path == util.FullPath(sourceDir) {
+				continue
+			}
+			entry, _ := tree.Get(path)
+			dir, name := toTargetPath(path).DirAndName()
+
+			existing, lookupErr := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+				Directory: dir,
+				Name:      name,
+			})
+			if lookupErr != nil && lookupErr != filer_pb.ErrNotFound {
+				return fmt.Errorf("lookup %s/%s: %w", dir, name, lookupErr)
+			}
+			if lookupErr == nil && proto.Equal(existing.Entry, entry) {
+				continue
+			}
+
+			if lookupErr == nil {
+				updateCount++
+			} else {
+				createCount++
+			}
+			if verbose {
+				fmt.Fprintf(writer, "restore %s\n", util.NewFullPath(dir, name))
+			}
+			if !apply {
+				continue
+			}
+
+			// replace a changed file without letting the filer free the chunks of the current version
+			if lookupErr == nil && !existing.Entry.IsDirectory {
+				if err := filer_pb.DoRemove(context.Background(), client, dir, name, false, false, false, false, nil); err != nil {
+					return fmt.Errorf("replace %s/%s: %w", dir, name, err)
+				}
+			}
+			if err := filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+				Directory: dir,
+				Entry:     entry,
+			}); err != nil {
+				return fmt.Errorf("restore %s/%s: %w", dir, name, err)
+			}
+		}
+
+		if !inPlace {
+			return nil
+		}
+
+		// remove entries that did not exist at the target time
+		var extraPaths []util.FullPath
+		var extraPathsLock sync.Mutex
+		if err := filer_pb.TraverseBfs(commandEnv, util.FullPath(sourceDir), func(parentPath util.FullPath, entry *filer_pb.Entry) {
+			path := parentPath.Child(entry.Name)
+			if strings.HasPrefix(string(path), filer.SnapshotsDir) || strings.HasPrefix(string(path), filer.SystemLogDir) {
+				return
+			}
+			if _, found := tree.Get(path); !found {
+				extraPathsLock.Lock()
+				extraPaths = append(extraPaths, path)
+				extraPathsLock.Unlock()
+			}
+		}); err != nil {
+			return fmt.Errorf("traverse %s: %w", sourceDir, err)
+		}
+		sort.Slice(extraPaths, func(i, j int) bool {
+			return extraPaths[i] < extraPaths[j]
+		})
+		removed := make(map[util.FullPath]struct{})
+		for _, path := range extraPaths {
+			if hasRemovedParent(removed, path) {
+				continue
+			}
+			removed[path] = struct{}{}
+			deleteCount++
+			if verbose {
+				fmt.Fprintf(writer, "remove %s\n", path)
+			}
+			if !apply {
+				continue
+			}
+			dir, name := path.DirAndName()
+			if err := filer_pb.DoRemove(context.Background(), client, dir, name, false, true, true, false, nil); err != nil {
+				return fmt.Errorf("remove %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+
+	if apply {
+		fmt.Fprintf(writer, "created %d, updated %d, removed %d entries\n", createCount, updateCount, deleteCount)
+	} else {
+		fmt.Fprintf(writer, "would create %d, update %d, remove %d entries. Use -apply to restore.\n", createCount, updateCount, deleteCount)
+	}
+	return err
+}
+
+func hasRemovedParent(removed map[util.FullPath]struct{}, path util.FullPath) bool {
+	for dir, _ := path.DirAndName(); dir != "/" && dir != ""; dir, _ = util.FullPath(dir).DirAndName() {
+		if _, found := removed[util.FullPath(dir)]; found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsVersions{})
+}
+
+type commandFsVersions struct {
+}
+
+func (c *commandFsVersions) Name() string {
+	return "fs.versions"
+}
+
+func (c *commandFsVersions) Help() string {
+	return `list the previous versions kept by the filer
+
+	fs.versions /home/alice/report.doc   # list the versions of a file, the newest last
+	fs.versions /home/alice/             # list the files in a directory with versions, including deleted files
+
+	Versions are kept for the files under a location configured with "fs.configure -versioning",
+	when the files are overwritten or deleted through any access path, e.g. mount, WebDAV, SFTP, or HTTP.
+	Use fs.restore to get a version back.
+
+`
+}
+
+func (c *commandFsVersions) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsVersions) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	fsVersionsCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	if err = fsVersionsCommand.Parse(args); err != nil {
+		return nil
+	}
+	if fsVersionsCommand.NArg() == 0 {
+		return fmt.Errorf("need a file or directory path")
+	}
+	path, err := commandEnv.parseUrl(fsVersionsCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+	fullPath := util.FullPath(path)
+	if path != "/" {
+		fullPath = util.FullPath(strings.TrimSuffix(path, "/"))
+	}
+
+	if strings.HasSuffix(path, "/") || commandEnv.isDirectory(path) {
+		return listVersionedFiles(commandEnv, fullPath, writer)
+	}
+
+	versions, err := listFileVersions(commandEnv, fullPath)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Fprintf(writer, "no versions of %s\n", fullPath)
+		return nil
+	}
+	for _, version := range versions {
+		printVersion(writer, version)
+	}
+	if current, lookupErr := filer_pb.GetEntry(context.Background(), commandEnv, fullPath); lookupErr == nil && current != nil {
+		fmt.Fprintf(writer, "%-19s\t%s\t%d bytes\n", "current", time.Unix(current.Attributes.GetMtime(), 0).UTC().Format(time.RFC3339), filer.FileSize(current))
+	}
+	return nil
+}
+
+// listFileVersions lists the versions of the file, the oldest first
+func listFileVersions(filerClient filer_pb.FilerClient, p util.FullPath) (versions []*filer_pb.Entry, err error) {
+	err = filer_pb.ReadDirAllEntries(context.Background(), filerClient, filer.VersionHolderPath(p), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if !entry.IsDirectory {
+			versions = append(versions, entry)
+		}
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+func printVersion(writer io.Writer, version *filer_pb.Entry) {
+	replacedAt := "unknown"
+	if t, err := filer.VersionTime(version.Name); err == nil {
+		replacedAt = t.UTC().Format(time.RFC3339)
+	}
+	event := "overwritten"
+	if _, found := version.Extended[filer.VersionExtendedDeleted]; found {
+		event = "deleted"
+	}
+	fmt.Fprintf(writer, "%s\t%s\t%d bytes\t%s at %s\n", version.Name, time.Unix(version.Attributes.GetMtime(), 0).UTC().Format(time.RFC3339), filer.FileSize(version), event, replacedAt)
+}
+
+func listVersionedFiles(commandEnv *CommandEnv, dir util.FullPath, writer io.Writer) error {
+	versionsDir := util.FullPath(filer.VersionsDir + string(dir))
+	if dir == "/" {
+		versionsDir = filer.VersionsDir
+	}
+	err := filer_pb.ReadDirAllEntries(context.Background(), commandEnv, versionsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		p, found := filer.VersionedPath(versionsDir.Child(entry.Name))
+		if !entry.IsDirectory || !found {
+			return nil
+		}
+		versions, err := listFileVersions(commandEnv, p)
+		if err != nil || len(versions) == 0 {
+			return err
+		}
+		state := ""
+		if current, lookupErr := filer_pb.GetEntry(context.Background(), commandEnv, p); lookupErr == filer_pb.ErrNotFound || (lookupErr == nil && current == nil) {
+			state = "\tdeleted"
+		}
+		fmt.Fprintf(writer, "%s\t%d versions\tlast replaced at %s%s\n", p.Name(), len(versions), versionTimeText(versions[len(versions)-1].Name), state)
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func versionTimeText(versionId string) string {
+	t, err := filer.VersionTime(versionId)
+	if err != nil {
+		return "unknown"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,89 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/mount_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/resolver/passthrough"
+)
+
+func init() {
+	Commands = append(Commands, &commandMountPin{})
+}
+
+type commandMountPin struct {
+}
+
+func (c *commandMountPin) Name() string {
+	return "mount.pin"
+}
+
+func (c *commandMountPin) Help() string {
+	return `pin a filer path on the mount on current server, to keep a full local copy readable when the filer is offline
+
+	mount.pin -dir=<mount_directory> -path=/projects/docs
+	mount.pin -dir=<mount_directory> -path=/projects/docs -unpin
+	mount.pin -dir=<mount_directory>     # list the pinned paths
+
+	The metadata and chunks under the pinned path are fetched in the background, and refreshed every few minutes.
+	This command connects with local mount via unix socket, so it can only run locally.
+	The "mount_directory" value needs to be exactly the same as how mount was started in "weed mount -dir=<mount_directory>"
+
+`
+}
+
+func (c *commandMountPin) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandMountPin) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	mountPinCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	mountDir := mountPinCommand.String("dir", "", "the mount directory same as how \"weed mount -dir=<mount_directory>\" was started")
+	pinPath := mountPinCommand.String("path", "", "the filer path to pin, under the mounted filer path")
+	unpin := mountPinCommand.Bool("unpin", false, "unpin the path and remove its local copy")
+	if err = mountPinCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	mountDirHash := util.HashToInt32([]byte(*mountDir))
+	if mountDirHash < 0 {
+		mountDirHash = -mountDirHash
+	}
+	localSocket := fmt.Sprintf("/tmp/seaweedfs-mount-%d.sock", mountDirHash)
+
+	clientConn, err := grpc.Dial("passthrough:///unix://"+localSocket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return
+	}
+	defer clientConn.Close()
+
+	client := mount_pb.NewSeaweedMountClient(clientConn)
+	resp, err := client.Pin(context.Background(), &mount_pb.PinRequest{
+		Path:  *pinPath,
+		Unpin: *unpin,
+	})
+	if err != nil {
+		return
+	}
+
+	for _, pin := range resp.Pins {
+		syncedAt := "not synced yet"
+		if pin.SyncedAtNs > 0 {
+			syncedAt = "synced at " + time.Unix(0, pin.SyncedAtNs).Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%s\t%d files\t%d bytes\t%s\n", pin.Path, pin.FileCount, pin.ByteCount, syncedAt)
+		if pin.Error != "" {
+			fmt.Fprintf(writer, "\tlast sync failed: %s\n", pin.Error)
+		}
+	}
+
+	return
+}
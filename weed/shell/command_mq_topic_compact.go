@@ -2,6 +2,7 @@ package shell
 
 import (
 	"flag"
+	"fmt"
 	"github.com/seaweedfs/seaweedfs/weed/filer_client"
 	"github.com/seaweedfs/seaweedfs/weed/mq/logstore"
 	"github.com/seaweedfs/seaweedfs/weed/mq/schema"
@@ -30,6 +31,8 @@ func (c *commandMqTopicCompact) Help() string {
 	Example:
 		mq.topic.compact -namespace <namespace> -topic <topic_name> -timeAgo <time_ago>
 
+	Topics with the compact cleanup policy are not converted, since key compaction only works on log files.
+
 `
 }
 
@@ -76,6 +79,9 @@ func (c *commandMqTopicCompact) Do(args []string, commandEnv *CommandEnv, writer
 	if err != nil {
 		return err
 	}
+	if logstore.IsKeyCompacted(topicConf.Retention) {
+		return fmt.Errorf("topic %s is compacted by key, which does not work on parquet files", t)
+	}
 
 	// get record type
 	recordType := topicConf.GetRecordType()
@@ -9,6 +9,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
 	"io"
+	"time"
 )
 
 func init() {
@@ -27,6 +28,18 @@ func (c *commandMqTopicConfigure) Help() string {
 
 	Example:
 		mq.topic.configure -namespace <namespace> -topic <topic_name> -partition_count <partition_count>
+
+		# keep 7 days or at most 1GB per partition
+		mq.topic.configure -namespace <namespace> -topic <topic_name> -retention 168h -retentionBytes 1073741824
+
+		# a changelog topic that keeps only the latest value per key, and forgets deleted keys after one day
+		mq.topic.configure -namespace <namespace> -topic <topic_name> -cleanupPolicy compact -tombstoneRetention 24h
+
+	Cleanup policies:
+		delete:          remove segments older than -retention, or the oldest ones beyond -retentionBytes
+		compact:         keep only the latest message per key; a keyed message with an empty value is a tombstone,
+		                 so an empty value deletes its key. Parquet files from mq.topic.compact are not compacted.
+		compact,delete:  compact by key, then apply the delete rules
 `
 }
 
@@ -41,10 +54,29 @@ func (c *commandMqTopicConfigure) Do(args []string, commandEnv *CommandEnv, writ
 	namespace := mqCommand.String("namespace", "", "namespace name")
 	topicName := mqCommand.String("topic", "", "topic name")
 	partitionCount := mqCommand.Int("partitionCount", 6, "partition count")
+	retention := mqCommand.Duration("retention", 0, "delete data older than this duration, e.g. 168h")
+	retentionBytes := mqCommand.Int64("retentionBytes", 0, "max bytes kept per partition")
+	cleanupPolicy := mqCommand.String("cleanupPolicy", "", "delete | compact | compact,delete")
+	tombstoneRetention := mqCommand.Duration("tombstoneRetention", 24*time.Hour, "how long compaction keeps tombstones")
 	if err := mqCommand.Parse(args); err != nil {
 		return err
 	}
 
+	var topicRetention *mq_pb.TopicRetention
+	if *retention > 0 || *retentionBytes > 0 || *cleanupPolicy != "" {
+		policy, err := parseTopicCleanupPolicy(*cleanupPolicy)
+		if err != nil {
+			return err
+		}
+		topicRetention = &mq_pb.TopicRetention{
+			Enabled:                   true,
+			RetentionSeconds:          int64(retention.Seconds()),
+			RetentionBytes:            *retentionBytes,
+			CleanupPolicy:             policy,
+			TombstoneRetentionSeconds: int64(tombstoneRetention.Seconds()),
+		}
+	}
+
 	// find the broker balancer
 	brokerBalancer, err := findBrokerBalancer(commandEnv)
 	if err != nil {
@@ -60,6 +92,7 @@ func (c *commandMqTopicConfigure) Do(args []string, commandEnv *CommandEnv, writ
 				Name:      *topicName,
 			},
 			PartitionCount: int32(*partitionCount),
+			Retention:      topicRetention,
 		})
 		if err != nil {
 			return err
@@ -70,3 +103,15 @@ func (c *commandMqTopicConfigure) Do(args []string, commandEnv *CommandEnv, writ
 	})
 
 }
+
+func parseTopicCleanupPolicy(policy string) (mq_pb.TopicCleanupPolicy, error) {
+	switch policy {
+	case "", "delete":
+		return mq_pb.TopicCleanupPolicy_CLEANUP_DELETE, nil
+	case "compact":
+		return mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT, nil
+	case "compact,delete", "delete,compact":
+		return mq_pb.TopicCleanupPolicy_CLEANUP_COMPACT_DELETE, nil
+	}
+	return mq_pb.TopicCleanupPolicy_CLEANUP_DELETE, fmt.Errorf("unknown cleanup policy %q", policy)
+}
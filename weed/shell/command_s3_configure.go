@@ -12,6 +12,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3bucket"
 )
 
 func init() {
@@ -30,6 +31,9 @@ func (c *commandS3Configure) Help() string {
 
 	# see the current configuration file content
 	s3.configure
+
+	# put a user into a tenant, see s3.tenant
+	s3.configure -user=alice -tenant=team1 -apply
 	`
 }
 
@@ -45,11 +49,17 @@ func (c *commandS3Configure) Do(args []string, commandEnv *CommandEnv, writer io
 	buckets := s3ConfigureCommand.String("buckets", "", "bucket name")
 	accessKey := s3ConfigureCommand.String("access_key", "", "specify the access key")
 	secretKey := s3ConfigureCommand.String("secret_key", "", "specify the secret key")
+	tenant := s3ConfigureCommand.String("tenant", "", "put the user into this tenant")
 	isDelete := s3ConfigureCommand.Bool("delete", false, "delete users, actions or access keys")
 	apply := s3ConfigureCommand.Bool("apply", false, "update and apply s3 configuration")
 	if err = s3ConfigureCommand.Parse(args); err != nil {
 		return nil
 	}
+	if *tenant != "" {
+		if err = s3bucket.VerifyTenantName(*tenant); err != nil {
+			return err
+		}
+	}
 
 	var buf bytes.Buffer
 	if err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
@@ -138,6 +148,9 @@ func (c *commandS3Configure) Do(args []string, commandEnv *CommandEnv, writer io
 					}
 				}
 			}
+			if *tenant != "" {
+				s3cfg.Identities[idx].Tenant = *tenant
+			}
 			if *accessKey != "" && *user != "anonymous" {
 				found := false
 				for _, credential := range s3cfg.Identities[idx].Credentials {
@@ -161,6 +174,7 @@ func (c *commandS3Configure) Do(args []string, commandEnv *CommandEnv, writer io
 			Name:        *user,
 			Actions:     cmdActions,
 			Credentials: []*iam_pb.Credential{},
+			Tenant:      *tenant,
 		}
 		if *user != "anonymous" {
 			identity.Credentials = append(identity.Credentials,
@@ -0,0 +1,182 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3bucket"
+)
+
+func init() {
+	Commands = append(Commands, &commandS3Tenant{})
+}
+
+type commandS3Tenant struct {
+}
+
+func (c *commandS3Tenant) Name() string {
+	return "s3.tenant"
+}
+
+func (c *commandS3Tenant) Help() string {
+	return `configure s3 tenants and their limits
+
+	s3.tenant                                                    # list the tenants
+	s3.tenant -name=team1 -maxBuckets=10 -quotaMB=102400 -apply  # create or update a tenant
+	s3.tenant -name=team1 -maxRequestsPerSecond=500 -apply       # limit the request rate of the tenant
+	s3.tenant -name=team1 -delete -apply                         # remove the tenant and its limits
+
+	The buckets of a tenant are stored as <tenant>_<bucket> under the buckets folder,
+	so every tenant has its own bucket namespace. Put users into a tenant with s3.configure -tenant,
+	and give a tenant user the Admin action to let it manage the users of the tenant with the IAM API.
+
+	The storage and object quotas are directory quotas on the tenant's buckets, see fs.quota for the usage.
+	A limit of 0 means unlimited, and updating a tenant replaces all of its limits.
+
+`
+}
+
+func (c *commandS3Tenant) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandS3Tenant) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	s3TenantCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := s3TenantCommand.String("name", "", "tenant name")
+	maxBuckets := s3TenantCommand.Int64("maxBuckets", 0, "maximum number of buckets, 0 for unlimited")
+	quotaMB := s3TenantCommand.Uint64("quotaMB", 0, "storage quota in MiB of all buckets, 0 for unlimited")
+	quotaObjects := s3TenantCommand.Uint64("quotaObjects", 0, "maximum number of objects and folders in all buckets, 0 for unlimited")
+	maxRequestsPerSecond := s3TenantCommand.Int64("maxRequestsPerSecond", 0, "maximum s3 requests per second, 0 for unlimited")
+	isDelete := s3TenantCommand.Bool("delete", false, "delete the tenant")
+	apply := s3TenantCommand.Bool("apply", false, "update and apply the tenant configuration")
+	if err = s3TenantCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.ReadEntry(commandEnv.MasterClient, client, filer.IamConfigDirectory, filer.IamIdentityFile, &buf)
+	}); err != nil && err != filer_pb.ErrNotFound {
+		return err
+	}
+	s3cfg := &iam_pb.S3ApiConfiguration{}
+	if buf.Len() > 0 {
+		if err = filer.ParseS3ConfigurationFromBytes(buf.Bytes(), s3cfg); err != nil {
+			return err
+		}
+	}
+
+	if *name == "" {
+		for _, tenant := range s3cfg.Tenants {
+			printTenant(writer, s3cfg, tenant)
+		}
+		return nil
+	}
+	if err = s3bucket.VerifyTenantName(*name); err != nil {
+		return err
+	}
+
+	var filerBucketsPath string
+	if err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+		if err != nil {
+			return fmt.Errorf("get filer configuration: %w", err)
+		}
+		filerBucketsPath = resp.DirBuckets
+		return nil
+	}); err != nil {
+		return err
+	}
+	fc, err := filer.ReadFilerConf(commandEnv.option.FilerAddress, commandEnv.option.GrpcDialOption, commandEnv.MasterClient)
+	if err != nil {
+		return err
+	}
+	locationPrefix := filerBucketsPath + "/" + s3bucket.TenantBucketPrefix(*name)
+
+	infoAboutSimulationMode(writer, *apply, "-apply")
+
+	var tenant *iam_pb.Tenant
+	for i, t := range s3cfg.Tenants {
+		if t.Name != *name {
+			continue
+		}
+		if *isDelete {
+			s3cfg.Tenants = append(s3cfg.Tenants[:i], s3cfg.Tenants[i+1:]...)
+		} else {
+			tenant = t
+		}
+		break
+	}
+	if *isDelete {
+		for _, identity := range s3cfg.Identities {
+			if identity.Tenant == *name {
+				fmt.Fprintf(writer, "user %s stays in tenant %s without limits\n", identity.Name, *name)
+			}
+		}
+	} else {
+		if tenant == nil {
+			tenant = &iam_pb.Tenant{Name: *name, CreateDate: time.Now().Unix()}
+			s3cfg.Tenants = append(s3cfg.Tenants, tenant)
+		}
+		tenant.MaxBuckets = *maxBuckets
+		tenant.QuotaBytes = *quotaMB * 1024 * 1024
+		tenant.QuotaObjects = *quotaObjects
+		tenant.MaxRequestsPerSecond = *maxRequestsPerSecond
+		printTenant(writer, s3cfg, tenant)
+	}
+
+	// the storage quotas are kept by the filer as a directory quota on the tenant's buckets
+	locConf := &filer_pb.FilerConf_PathConf{LocationPrefix: locationPrefix}
+	if existing, found := fc.GetLocationConf(locationPrefix); found {
+		locConf = proto.Clone(existing).(*filer_pb.FilerConf_PathConf)
+	}
+	locConf.QuotaBytes, locConf.QuotaInodes = 0, 0
+	if tenant != nil {
+		locConf.QuotaBytes, locConf.QuotaInodes = tenant.QuotaBytes, tenant.QuotaObjects
+	}
+	fc.DeleteLocationConf(locationPrefix)
+	if !proto.Equal(locConf, &filer_pb.FilerConf_PathConf{LocationPrefix: locationPrefix}) {
+		if err = fc.SetLocationConf(locConf); err != nil {
+			return err
+		}
+	}
+
+	if !*apply {
+		return nil
+	}
+
+	buf.Reset()
+	filer.ProtoToText(&buf, s3cfg)
+	var confBuf bytes.Buffer
+	if err = fc.ToText(&confBuf); err != nil {
+		return err
+	}
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		if err := filer.SaveInsideFiler(client, filer.IamConfigDirectory, filer.IamIdentityFile, buf.Bytes()); err != nil {
+			return err
+		}
+		return filer.SaveInsideFiler(client, filer.DirectoryEtcSeaweedFS, filer.FilerConfName, confBuf.Bytes())
+	})
+}
+
+func printTenant(writer io.Writer, s3cfg *iam_pb.S3ApiConfiguration, tenant *iam_pb.Tenant) {
+	var users []string
+	for _, identity := range s3cfg.Identities {
+		if identity.Tenant == tenant.Name {
+			users = append(users, identity.Name)
+		}
+	}
+	fmt.Fprintf(writer, "%s\tbuckets:%s\tbytes:%s\tobjects:%s\trequests/s:%s\tusers:%v\n", tenant.Name,
+		formatQuotaLimit(uint64(max(tenant.MaxBuckets, 0))), formatQuotaLimit(tenant.QuotaBytes),
+		formatQuotaLimit(tenant.QuotaObjects), formatQuotaLimit(uint64(max(tenant.MaxRequestsPerSecond, 0))), users)
+}
@@ -235,7 +235,7 @@ func (c *commandVolumeFsck) collectFilerFileIdAndPaths(dataNodeVolumeIdToVInfo m
 		}
 	}()
 
-	return doTraverseBfsAndSaving(c.env, c.writer, c.getCollectFilerFilePath(), false,
+	err := doTraverseBfsAndSaving(c.env, c.writer, c.getCollectFilerFilePath(), false,
 		func(entry *filer_pb.FullEntry, outputChan chan interface{}) (err error) {
 			if *c.verbose && entry.Entry.IsDirectory {
 				fmt.Fprintf(c.writer, "checking directory %s\n", util.NewFullPath(entry.Dir, entry.Entry.Name))
@@ -280,6 +280,47 @@ func (c *commandVolumeFsck) collectFilerFileIdAndPaths(dataNodeVolumeIdToVInfo m
 				}
 			}
 		})
+	if err != nil {
+		return err
+	}
+
+	// chunks referenced by metadata snapshots are still in use, even after the live entries are gone
+	return c.collectSnapshotFileIds(files)
+}
+
+func (c *commandVolumeFsck) collectSnapshotFileIds(files map[uint32]*os.File) error {
+	snapshots, err := listMetadataSnapshots(c.env)
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+	buffer := make([]byte, readbufferSize)
+	for _, snapshot := range snapshots {
+		if *c.verbose {
+			fmt.Fprintf(c.writer, "checking snapshot %s\n", snapshot.Name)
+		}
+		if err := readMetadataSnapshot(c.env, snapshot.Name, func(fullEntry *filer_pb.FullEntry) error {
+			dataChunks, manifestChunks, resolveErr := filer.ResolveChunkManifest(context.Background(), filer.LookupFn(c.env), fullEntry.Entry.GetChunks(), 0, math.MaxInt64)
+			if resolveErr != nil {
+				return fmt.Errorf("failed to ResolveChunkManifest: %+v", resolveErr)
+			}
+			path := util.NewFullPath(fullEntry.Dir, fullEntry.Entry.Name)
+			for _, chunk := range append(dataChunks, manifestChunks...) {
+				f, ok := files[chunk.Fid.VolumeId]
+				if !ok {
+					continue
+				}
+				util.Uint64toBytes(buffer, chunk.Fid.FileKey)
+				util.Uint32toBytes(buffer[8:], chunk.Fid.Cookie)
+				util.Uint32toBytes(buffer[12:], uint32(len(path)))
+				f.Write(buffer)
+				f.Write([]byte(path))
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("read snapshot %s: %w", snapshot.Name, err)
+		}
+	}
+	return nil
 }
 
 func (c *commandVolumeFsck) findFilerChunksMissingInVolumeServers(volumeIdToVInfo map[uint32]VInfo, dataNodeId string, applyPurging bool) error {
@@ -115,6 +115,10 @@ func diskInfosToString(diskInfos map[string]*master_pb.DiskInfo) string {
 func diskInfoToString(diskInfo *master_pb.DiskInfo) string {
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, "volume:%d/%d active:%d free:%d remote:%d", diskInfo.VolumeCount, diskInfo.MaxVolumeCount, diskInfo.ActiveVolumeCount, diskInfo.FreeVolumeCount, diskInfo.RemoteVolumeCount)
+	if load := diskInfo.Load; load != nil && load.TotalBytes > 0 {
+		fmt.Fprintf(&buf, " used:%.1f%% io:%.1f%% write_p50:%dus write_p99:%dus", float64(load.UsedBytes)*100/float64(load.TotalBytes),
+			load.IoUtilization*100, load.WriteLatencyP50Us, load.WriteLatencyP99Us)
+	}
 	return buf.String()
 }
 
@@ -0,0 +1,81 @@
+package shell
+
+import (
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/audit"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// auditedCommands change the cluster or its data, and are recorded in the audit log
+var auditedCommands = map[string]bool{
+	"cluster.raft.add":             true,
+	"cluster.raft.remove":          true,
+	"collection.delete":            true,
+	"ec.balance":                   true,
+	"ec.decode":                    true,
+	"ec.encode":                    true,
+	"ec.rebuild":                   true,
+	"fs.configure":                 true,
+	"fs.jwt":                       true,
+	"fs.log.purge":                 true,
+	"fs.meta.changeVolumeId":       true,
+	"fs.meta.load":                 true,
+	"fs.mergeVolumes":              true,
+	"fs.mv":                        true,
+	"fs.quota":                     true,
+	"fs.restore":                   true,
+	"fs.rewrap":                    true,
+	"fs.rm":                        true,
+	"fs.snapshot.delete":           true,
+	"fs.snapshot.restore":          true,
+	"mount.configure":              true,
+	"mq.topic.configure":           true,
+	"remote.configure":             true,
+	"remote.mount":                 true,
+	"remote.mount.buckets":         true,
+	"remote.unmount":               true,
+	"s3.bucket.create":             true,
+	"s3.bucket.delete":             true,
+	"s3.bucket.quota":              true,
+	"s3.circuitBreaker":            true,
+	"s3.clean.uploads":             true,
+	"s3.configure":                 true,
+	"volume.balance":               true,
+	"volume.configure.replication": true,
+	"volume.copy":                  true,
+	"volume.delete":                true,
+	"volume.deleteEmpty":           true,
+	"volume.fix.replication":       true,
+	"volume.mark":                  true,
+	"volume.mount":                 true,
+	"volume.move":                  true,
+	"volume.tier.move":             true,
+	"volume.tier.upload":           true,
+	"volume.unmount":               true,
+	"volume.vacuum":                true,
+	"volume.vacuum.disable":        true,
+	"volumeServer.evacuate":        true,
+	"volumeServer.leave":           true,
+}
+
+// auditCommand records an audited command with its arguments in the audit log of the filer
+func auditCommand(commandEnv *CommandEnv, name string, args []string, err error) {
+	if !auditedCommands[name] {
+		return
+	}
+	record := audit.NewRecord(audit.ComponentShell, shellUser(), "", name, strings.Join(args, " "), err)
+	audit.Log(func(fn func(filer_pb.SeaweedFilerClient) error) error {
+		return commandEnv.WithFilerClient(false, fn)
+	}, record)
+}
+
+// shellUser is the operating system user running the shell
+func shellUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -116,9 +116,11 @@ func processEachCmd(reg *regexp.Regexp, cmd string, commandEnv *CommandEnv) bool
 			foundCommand := false
 			for _, c := range Commands {
 				if c.Name() == cmd || c.Name() == "fs."+cmd {
-					if err := c.Do(args, commandEnv, os.Stdout); err != nil {
+					err := c.Do(args, commandEnv, os.Stdout)
+					if err != nil {
 						fmt.Fprintf(os.Stderr, "error: %v\n", err)
 					}
+					auditCommand(commandEnv, c.Name(), args, err)
 					foundCommand = true
 				}
 			}
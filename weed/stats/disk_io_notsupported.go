@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package stats
+
+func DiskIoTicks(path string) (ioTicks uint64, found bool) {
+	return 0, false
+}
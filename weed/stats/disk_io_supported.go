@@ -0,0 +1,39 @@
+//go:build linux
+// +build linux
+
+package stats
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// DiskIoTicks returns the milliseconds the block device holding the path has spent doing IO,
+// from the io_ticks field of /proc/diskstats
+func DiskIoTicks(path string) (ioTicks uint64, found bool) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, false
+	}
+	major, minor := strconv.FormatUint(uint64(unix.Major(st.Dev)), 10), strconv.FormatUint(uint64(unix.Minor(st.Dev)), 10)
+
+	f, err := os.Open("/proc/diskstats")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 13 || fields[0] != major || fields[1] != minor {
+			continue
+		}
+		ioTicks, err = strconv.ParseUint(fields[12], 10, 64)
+		return ioTicks, err == nil
+	}
+	return 0, false
+}
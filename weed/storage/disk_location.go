@@ -36,6 +36,8 @@ type DiskLocation struct {
 
 	isDiskSpaceLow bool
 	closeCh        chan struct{}
+
+	load diskLocationLoad
 }
 
 func GenerateDirUuid(dir string) (dirUuidString string, err error) {
@@ -454,6 +456,7 @@ func (l *DiskLocation) CheckDiskSpace() {
 		stats.VolumeServerResourceGauge.WithLabelValues(l.Directory, "all").Set(float64(s.All))
 		stats.VolumeServerResourceGauge.WithLabelValues(l.Directory, "used").Set(float64(s.Used))
 		stats.VolumeServerResourceGauge.WithLabelValues(l.Directory, "free").Set(float64(s.Free))
+		l.load.setDiskUsage(s.All, s.Used)
 
 		isLow, desc := l.MinFreeSpace.IsLow(s.Free, s.PercentFree)
 		if isLow != l.isDiskSpaceLow {
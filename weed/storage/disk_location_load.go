@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+)
+
+const (
+	writeLatencySampleCount  = 1024
+	writeLatencySampleWindow = time.Minute
+)
+
+// diskLocationLoad tracks how busy a disk location is, reported to the master in the heartbeat
+type diskLocationLoad struct {
+	sync.Mutex
+	totalBytes uint64
+	usedBytes  uint64
+
+	lastIoTicks    uint64
+	lastIoSampleAt time.Time
+	ioUtilization  float32
+
+	// recent write latencies, in a ring
+	latencies  [writeLatencySampleCount]time.Duration
+	recordedAt [writeLatencySampleCount]time.Time
+	next       int
+}
+
+func (l *diskLocationLoad) setDiskUsage(total, used uint64) {
+	l.Lock()
+	defer l.Unlock()
+	l.totalBytes, l.usedBytes = total, used
+}
+
+func (l *diskLocationLoad) addWriteLatency(d time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	l.latencies[l.next] = d
+	l.recordedAt[l.next] = time.Now()
+	l.next = (l.next + 1) % writeLatencySampleCount
+}
+
+// writeLatencyPercentiles returns the write latency percentiles of the last minute
+func (l *diskLocationLoad) writeLatencyPercentiles(now time.Time, percentiles ...float64) (ret []time.Duration) {
+	var recent []time.Duration
+	for i, t := range l.recordedAt {
+		if !t.IsZero() && now.Sub(t) < writeLatencySampleWindow {
+			recent = append(recent, l.latencies[i])
+		}
+	}
+	ret = make([]time.Duration, len(percentiles))
+	if len(recent) == 0 {
+		return
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i] < recent[j] })
+	for i, p := range percentiles {
+		index := int(p * float64(len(recent)))
+		if index >= len(recent) {
+			index = len(recent) - 1
+		}
+		ret[i] = recent[index]
+	}
+	return
+}
+
+// sampleIoUtilization measures the fraction of time the disk was busy since the last sample
+func (l *diskLocationLoad) sampleIoUtilization(dir string, now time.Time) {
+	ioTicks, found := stats.DiskIoTicks(dir)
+	if !found {
+		return
+	}
+	if !l.lastIoSampleAt.IsZero() && ioTicks >= l.lastIoTicks {
+		if elapsed := now.Sub(l.lastIoSampleAt).Milliseconds(); elapsed > 0 {
+			l.ioUtilization = float32(ioTicks-l.lastIoTicks) / float32(elapsed)
+			if l.ioUtilization > 1 {
+				l.ioUtilization = 1
+			}
+		}
+	}
+	l.lastIoTicks, l.lastIoSampleAt = ioTicks, now
+}
+
+func (l *diskLocationLoad) toDiskLoad(dir string) *master_pb.DiskLoad {
+	l.Lock()
+	defer l.Unlock()
+	now := time.Now()
+	l.sampleIoUtilization(dir, now)
+	latencies := l.writeLatencyPercentiles(now, 0.5, 0.99)
+	return &master_pb.DiskLoad{
+		TotalBytes:        l.totalBytes,
+		UsedBytes:         l.usedBytes,
+		IoUtilization:     l.ioUtilization,
+		WriteLatencyP50Us: uint32(latencies[0].Microseconds()),
+		WriteLatencyP99Us: uint32(latencies[1].Microseconds()),
+	}
+}
+
+// mergeDiskLoad adds the load of another disk location with the same disk type
+func mergeDiskLoad(to, from *master_pb.DiskLoad) {
+	to.TotalBytes += from.TotalBytes
+	to.UsedBytes += from.UsedBytes
+	if to.IoUtilization < from.IoUtilization {
+		to.IoUtilization = from.IoUtilization
+	}
+	if to.WriteLatencyP50Us < from.WriteLatencyP50Us {
+		to.WriteLatencyP50Us = from.WriteLatencyP50Us
+	}
+	if to.WriteLatencyP99Us < from.WriteLatencyP99Us {
+		to.WriteLatencyP99Us = from.WriteLatencyP99Us
+	}
+}
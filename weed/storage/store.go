@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
@@ -282,6 +283,7 @@ func (s *Store) GetRack() string {
 func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 	var volumeMessages []*master_pb.VolumeInformationMessage
 	maxVolumeCounts := make(map[string]uint32)
+	diskLoads := make(map[string]*master_pb.DiskLoad)
 	var maxFileKey NeedleId
 	collectionVolumeSize := make(map[string]int64)
 	collectionVolumeDeletedBytes := make(map[string]int64)
@@ -289,6 +291,11 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 	for _, location := range s.Locations {
 		var deleteVids []needle.VolumeId
 		maxVolumeCounts[string(location.DiskType)] += uint32(location.MaxVolumeCount)
+		if diskLoad, found := diskLoads[string(location.DiskType)]; found {
+			mergeDiskLoad(diskLoad, location.load.toDiskLoad(location.Directory))
+		} else {
+			diskLoads[string(location.DiskType)] = location.load.toDiskLoad(location.Directory)
+		}
 		location.volumesLock.RLock()
 		for _, v := range location.volumes {
 			curMaxFileKey, volumeMessage := v.ToVolumeInformationMessage()
@@ -409,6 +416,7 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 		HasNoVolumes:    len(volumeMessages) == 0,
 		HasNoEcShards:   len(ecVolumeMessages) == 0,
 		LocationUuids:   uuidList,
+		DiskLoads:       diskLoads,
 	}
 
 }
@@ -471,7 +479,11 @@ func (s *Store) WriteVolumeNeedle(i needle.VolumeId, n *needle.Needle, checkCook
 			err = fmt.Errorf("volume %d is read only", i)
 			return
 		}
+		start := time.Now()
 		_, _, isUnchanged, err = v.writeNeedle2(n, checkCookie, fsync && !s.isStopping)
+		if err == nil && !isUnchanged {
+			v.location.load.addWriteLatency(time.Since(start))
+		}
 		return
 	}
 	glog.V(0).Infoln("volume", i, "not found!")
@@ -155,6 +155,25 @@ func (dn *DataNode) AdjustMaxVolumeCounts(maxVolumeCounts map[string]uint32) {
 	}
 }
 
+// UpdateDiskLoads keeps the disk loads reported in the heartbeat, keyed by disk type
+func (dn *DataNode) UpdateDiskLoads(diskLoads map[string]*master_pb.DiskLoad) {
+	dn.Lock()
+	defer dn.Unlock()
+	for diskType, diskLoad := range diskLoads {
+		dn.getOrCreateDisk(types.ToDiskType(diskType).String()).SetLoad(diskLoad)
+	}
+}
+
+// GetDiskLoad returns the last reported load of the disk type, or nil if unknown
+func (dn *DataNode) GetDiskLoad(diskType types.DiskType) *master_pb.DiskLoad {
+	dn.RLock()
+	defer dn.RUnlock()
+	if c, found := dn.children[NodeId(diskType.String())]; found {
+		return c.(*Disk).GetLoad()
+	}
+	return nil
+}
+
 func (dn *DataNode) GetVolumes() (ret []storage.VolumeInfo) {
 	dn.RLock()
 	for _, c := range dn.children {
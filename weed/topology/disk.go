@@ -20,6 +20,7 @@ type Disk struct {
 	volumes      map[needle.VolumeId]storage.VolumeInfo
 	ecShards     map[needle.VolumeId]*erasure_coding.EcVolumeInfo
 	ecShardsLock sync.RWMutex
+	load         atomic.Pointer[master_pb.DiskLoad]
 }
 
 func NewDisk(diskType string) *Disk {
@@ -244,6 +245,16 @@ func (d *Disk) FreeSpace() int64 {
 	return t.FreeSpace()
 }
 
+// SetLoad keeps the disk usage and load last reported by the volume server
+func (d *Disk) SetLoad(load *master_pb.DiskLoad) {
+	d.load.Store(load)
+}
+
+// GetLoad returns the last reported disk load, or nil if the volume server does not report it
+func (d *Disk) GetLoad() *master_pb.DiskLoad {
+	return d.load.Load()
+}
+
 func (d *Disk) ToDiskInfo() *master_pb.DiskInfo {
 	diskUsage := d.diskUsages.getOrCreateDisk(types.ToDiskType(string(d.Id())))
 
@@ -265,6 +276,7 @@ func (d *Disk) ToDiskInfo() *master_pb.DiskInfo {
 		ActiveVolumeCount: diskUsage.activeVolumeCount,
 		RemoteVolumeCount: diskUsage.remoteVolumeCount,
 		DiskId:            diskId,
+		Load:              d.GetLoad(),
 	}
 	for _, v := range volumes {
 		m.VolumeInfos = append(m.VolumeInfos, v.ToVolumeInformationMessage())
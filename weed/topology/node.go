@@ -173,19 +173,15 @@ func (n *NodeImpl) GetDiskUsages() *DiskUsages {
 
 // the first node must satisfy filterFirstNodeFn(), the rest nodes must have one free slot
 func (n *NodeImpl) PickNodesByWeight(numberOfNodes int, option *VolumeGrowOption, filterFirstNodeFn func(dn Node) error) (firstNode Node, restNodes []Node, err error) {
-	var totalWeights int64
 	var errs []string
 	n.RLock()
 	candidates := make([]Node, 0, len(n.children))
-	candidatesWeights := make([]int64, 0, len(n.children))
-	//pick nodes which has enough free volumes as candidates, and use free volumes number as node weight.
+	//pick nodes which has enough free volumes as candidates
 	for _, node := range n.children {
 		if node.AvailableSpaceFor(option) <= 0 {
 			continue
 		}
-		totalWeights += node.AvailableSpaceFor(option)
 		candidates = append(candidates, node)
-		candidatesWeights = append(candidatesWeights, node.AvailableSpaceFor(option))
 	}
 	n.RUnlock()
 	if len(candidates) < numberOfNodes {
@@ -193,21 +189,8 @@ func (n *NodeImpl) PickNodesByWeight(numberOfNodes int, option *VolumeGrowOption
 		return nil, nil, errors.New("Not enough data nodes found!")
 	}
 
-	//pick nodes randomly by weights, the node picked earlier has higher final weights
-	sortedCandidates := make([]Node, 0, len(candidates))
-	for i := 0; i < len(candidates); i++ {
-		weightsInterval := rand.Int64N(totalWeights)
-		lastWeights := int64(0)
-		for k, weights := range candidatesWeights {
-			if (weightsInterval >= lastWeights) && (weightsInterval < lastWeights+weights) {
-				sortedCandidates = append(sortedCandidates, candidates[k])
-				candidatesWeights[k] = 0
-				totalWeights -= weights
-				break
-			}
-			lastWeights += weights
-		}
-	}
+	//order the nodes by the placement strategy of the collection, the node picked earlier is preferred
+	sortedCandidates := GetPlacementStrategy(option.Collection).OrderNodes(candidates, option)
 
 	restNodes = make([]Node, 0, numberOfNodes-1)
 	ret := false
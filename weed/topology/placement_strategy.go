@@ -0,0 +1,259 @@
+package topology
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+// PlacementStrategy decides where new volumes are created, and which writable volume takes a write
+type PlacementStrategy interface {
+	Name() string
+	// OrderNodes orders the candidate nodes for a new volume, the preferred first.
+	// All candidates have free volume slots for the option.
+	OrderNodes(candidates []Node, option *VolumeGrowOption) []Node
+	// PickVolume picks one of the writable volumes, which are not empty
+	PickVolume(writables []needle.VolumeId, locations func(needle.VolumeId) *VolumeLocationList, option *VolumeGrowOption) needle.VolumeId
+}
+
+const (
+	SlotsPlacement          = "slots"
+	WeightedRandomPlacement = "weightedRandom"
+	LeastLoadedPlacement    = "leastLoaded"
+)
+
+var (
+	PlacementStrategies = map[string]PlacementStrategy{
+		SlotsPlacement:          &slotsPlacementStrategy{},
+		WeightedRandomPlacement: &weightedRandomPlacementStrategy{},
+		LeastLoadedPlacement:    &leastLoadedPlacementStrategy{},
+	}
+
+	placementLock                 sync.RWMutex
+	defaultPlacementStrategy      PlacementStrategy = PlacementStrategies[SlotsPlacement]
+	collectionPlacementStrategies                   = make(map[string]PlacementStrategy)
+)
+
+// SetPlacementStrategy sets the placement strategy of a collection, or the default one if the collection is empty
+func SetPlacementStrategy(collection string, name string) error {
+	strategy, found := PlacementStrategies[name]
+	if !found {
+		return fmt.Errorf("unknown placement strategy %q", name)
+	}
+	placementLock.Lock()
+	defer placementLock.Unlock()
+	if collection == "" {
+		defaultPlacementStrategy = strategy
+	} else {
+		collectionPlacementStrategies[collection] = strategy
+	}
+	return nil
+}
+
+func GetPlacementStrategy(collection string) PlacementStrategy {
+	placementLock.RLock()
+	defer placementLock.RUnlock()
+	if strategy, found := collectionPlacementStrategies[collection]; found {
+		return strategy
+	}
+	return defaultPlacementStrategy
+}
+
+// slotsPlacementStrategy picks nodes randomly, weighted by their free volume slots,
+// and picks writable volumes at random
+type slotsPlacementStrategy struct{}
+
+func (s *slotsPlacementStrategy) Name() string {
+	return SlotsPlacement
+}
+
+func (s *slotsPlacementStrategy) OrderNodes(candidates []Node, option *VolumeGrowOption) []Node {
+	weights := make([]int64, len(candidates))
+	for i, node := range candidates {
+		weights[i] = node.AvailableSpaceFor(option)
+	}
+	return orderByWeights(candidates, weights)
+}
+
+func (s *slotsPlacementStrategy) PickVolume(writables []needle.VolumeId, locations func(needle.VolumeId) *VolumeLocationList, option *VolumeGrowOption) needle.VolumeId {
+	return writables[rand.IntN(len(writables))]
+}
+
+// weightedRandomPlacementStrategy picks nodes randomly, weighted by their free volume slots and how idle they are,
+// and picks writable volumes randomly, weighted by how idle their servers are
+type weightedRandomPlacementStrategy struct{}
+
+func (s *weightedRandomPlacementStrategy) Name() string {
+	return WeightedRandomPlacement
+}
+
+func (s *weightedRandomPlacementStrategy) OrderNodes(candidates []Node, option *VolumeGrowOption) []Node {
+	weights := make([]int64, len(candidates))
+	for i, node := range candidates {
+		weights[i] = int64(float64(node.AvailableSpaceFor(option)) * idleWeight(nodeLoadScore(node, option.DiskType)))
+	}
+	return orderByWeights(candidates, weights)
+}
+
+func (s *weightedRandomPlacementStrategy) PickVolume(writables []needle.VolumeId, locations func(needle.VolumeId) *VolumeLocationList, option *VolumeGrowOption) needle.VolumeId {
+	weights := make([]int64, len(writables))
+	var totalWeights int64
+	for i, vid := range writables {
+		weights[i] = int64(idleWeight(volumeLoadScore(locations(vid), option.DiskType)))
+		totalWeights += weights[i]
+	}
+	r := rand.Int64N(totalWeights)
+	for i, weight := range weights {
+		if r < weight {
+			return writables[i]
+		}
+		r -= weight
+	}
+	return writables[len(writables)-1]
+}
+
+// leastLoadedPlacementStrategy picks the least loaded nodes,
+// and the less loaded of two random writable volumes, so the writes do not all go to the same volume
+// until the next heartbeats
+type leastLoadedPlacementStrategy struct{}
+
+func (s *leastLoadedPlacementStrategy) Name() string {
+	return LeastLoadedPlacement
+}
+
+func (s *leastLoadedPlacementStrategy) OrderNodes(candidates []Node, option *VolumeGrowOption) []Node {
+	scores := make(map[NodeId]float64, len(candidates))
+	slots := make(map[NodeId]int64, len(candidates))
+	for _, node := range candidates {
+		scores[node.Id()] = nodeLoadScore(node, option.DiskType)
+		slots[node.Id()] = node.AvailableSpaceFor(option)
+	}
+	ordered := make([]Node, len(candidates))
+	copy(ordered, candidates)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i].Id(), ordered[j].Id()
+		if scores[a] != scores[b] {
+			return scores[a] < scores[b]
+		}
+		return slots[a] > slots[b]
+	})
+	return ordered
+}
+
+func (s *leastLoadedPlacementStrategy) PickVolume(writables []needle.VolumeId, locations func(needle.VolumeId) *VolumeLocationList, option *VolumeGrowOption) needle.VolumeId {
+	a, b := writables[rand.IntN(len(writables))], writables[rand.IntN(len(writables))]
+	if volumeLoadScore(locations(b), option.DiskType) < volumeLoadScore(locations(a), option.DiskType) {
+		return b
+	}
+	return a
+}
+
+// orderByWeights orders the nodes randomly, where a node with a higher weight is more likely to come first.
+// The nodes without weight come last.
+func orderByWeights(candidates []Node, weights []int64) []Node {
+	var totalWeights int64
+	var unweighted []Node
+	remaining := make([]int64, len(weights))
+	for i, weight := range weights {
+		if weight <= 0 {
+			unweighted = append(unweighted, candidates[i])
+			continue
+		}
+		remaining[i] = weight
+		totalWeights += weight
+	}
+	ordered := make([]Node, 0, len(candidates))
+	for totalWeights > 0 {
+		r := rand.Int64N(totalWeights)
+		for k, weight := range remaining {
+			if r < weight {
+				ordered = append(ordered, candidates[k])
+				remaining[k] = 0
+				totalWeights -= weight
+				break
+			}
+			r -= weight
+		}
+	}
+	rand.Shuffle(len(unweighted), func(i, j int) {
+		unweighted[i], unweighted[j] = unweighted[j], unweighted[i]
+	})
+	return append(ordered, unweighted...)
+}
+
+// idleWeight turns a load score into a weight, 1000 for an idle node and 1 for a fully loaded one
+func idleWeight(score float64) float64 {
+	return 1 + 999*(1-score)
+}
+
+const (
+	diskUsageLoadWeight     = 0.4
+	ioUtilizationLoadWeight = 0.3
+	writeLatencyLoadWeight  = 0.3
+	// the p99 write latency that counts for half of the latency load
+	writeLatencyReferenceUs = 20000
+)
+
+// dataNodeLoadScore rates how loaded the disks of a server are, from 0 for idle and empty to 1 for full or saturated.
+// known is false if the server does not report its disk load.
+func dataNodeLoadScore(dn *DataNode, diskType types.DiskType) (score float64, known bool) {
+	load := dn.GetDiskLoad(diskType)
+	if load == nil {
+		return 0, false
+	}
+	if load.TotalBytes > 0 {
+		score += diskUsageLoadWeight * float64(load.UsedBytes) / float64(load.TotalBytes)
+	}
+	io := float64(load.IoUtilization)
+	if io > 1 {
+		io = 1
+	}
+	score += ioUtilizationLoadWeight * io
+	latency := float64(load.WriteLatencyP99Us)
+	score += writeLatencyLoadWeight * latency / (latency + writeLatencyReferenceUs)
+	return score, true
+}
+
+// nodeLoadScore rates a server, or the average of the servers in a rack or a data center
+func nodeLoadScore(node Node, diskType types.DiskType) float64 {
+	var total float64
+	var count int
+	var visit func(n Node)
+	visit = func(n Node) {
+		if n.IsDataNode() {
+			if score, known := dataNodeLoadScore(n.(*DataNode), diskType); known {
+				total += score
+				count++
+			}
+			return
+		}
+		for _, child := range n.Children() {
+			visit(child)
+		}
+	}
+	visit(node)
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// volumeLoadScore rates a volume by its most loaded replica
+func volumeLoadScore(locationList *VolumeLocationList, diskType types.DiskType) (score float64) {
+	if locationList == nil {
+		return 0
+	}
+	for _, dn := range locationList.list {
+		if s, known := dataNodeLoadScore(dn, diskType); known && s > score {
+			score = s
+		}
+	}
+	return
+}
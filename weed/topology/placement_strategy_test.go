@@ -0,0 +1,127 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+func newLoadedDataNode(id string, maxVolumeCount uint32, load *master_pb.DiskLoad) *DataNode {
+	dn := NewDataNode(id)
+	dn.AdjustMaxVolumeCounts(map[string]uint32{"": maxVolumeCount})
+	if load != nil {
+		dn.UpdateDiskLoads(map[string]*master_pb.DiskLoad{"": load})
+	}
+	return dn
+}
+
+func TestDataNodeLoadScore(t *testing.T) {
+	idle := newLoadedDataNode("idle", 10, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 0})
+	busy := newLoadedDataNode("busy", 10, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 90, IoUtilization: 0.9, WriteLatencyP99Us: 200000})
+	unknown := newLoadedDataNode("unknown", 10, nil)
+
+	idleScore, known := dataNodeLoadScore(idle, types.HardDriveType)
+	if !known || idleScore != 0 {
+		t.Errorf("idle score %v %v", idleScore, known)
+	}
+	busyScore, _ := dataNodeLoadScore(busy, types.HardDriveType)
+	if busyScore <= 0.5 || busyScore > 1 {
+		t.Errorf("busy score %v", busyScore)
+	}
+	if _, known = dataNodeLoadScore(unknown, types.HardDriveType); known {
+		t.Errorf("unknown load is known")
+	}
+	if _, known = dataNodeLoadScore(busy, types.SsdType); known {
+		t.Errorf("load of another disk type is known")
+	}
+}
+
+func TestLeastLoadedOrderNodes(t *testing.T) {
+	option := &VolumeGrowOption{}
+	busy := newLoadedDataNode("busy", 100, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 80, IoUtilization: 0.5})
+	idle := newLoadedDataNode("idle", 1, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 10})
+	half := newLoadedDataNode("half", 10, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 50})
+
+	strategy := PlacementStrategies[LeastLoadedPlacement]
+	for i := 0; i < 10; i++ {
+		ordered := strategy.OrderNodes([]Node{busy, idle, half}, option)
+		if ordered[0] != Node(idle) || ordered[1] != Node(half) || ordered[2] != Node(busy) {
+			t.Fatalf("unexpected order %v %v %v", ordered[0].Id(), ordered[1].Id(), ordered[2].Id())
+		}
+	}
+}
+
+func TestWeightedRandomOrderNodes(t *testing.T) {
+	option := &VolumeGrowOption{}
+	busy := newLoadedDataNode("busy", 10, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 100, IoUtilization: 1, WriteLatencyP99Us: 1000000})
+	idle := newLoadedDataNode("idle", 10, &master_pb.DiskLoad{TotalBytes: 100})
+
+	strategy := PlacementStrategies[WeightedRandomPlacement]
+	idleFirst := 0
+	for i := 0; i < 1000; i++ {
+		ordered := strategy.OrderNodes([]Node{busy, idle}, option)
+		if len(ordered) != 2 {
+			t.Fatalf("ordered %d nodes", len(ordered))
+		}
+		if ordered[0] == Node(idle) {
+			idleFirst++
+		}
+	}
+	if idleFirst < 900 {
+		t.Errorf("idle node first %d times out of 1000", idleFirst)
+	}
+}
+
+func TestPlacementPickVolume(t *testing.T) {
+	option := &VolumeGrowOption{}
+	busy := newLoadedDataNode("busy", 10, &master_pb.DiskLoad{TotalBytes: 100, UsedBytes: 100, IoUtilization: 1, WriteLatencyP99Us: 1000000000})
+	idle := newLoadedDataNode("idle", 10, &master_pb.DiskLoad{TotalBytes: 100})
+	locations := map[needle.VolumeId]*VolumeLocationList{
+		1: {list: []*DataNode{busy}},
+		2: {list: []*DataNode{idle}},
+		3: {list: []*DataNode{idle, busy}},
+	}
+	lookup := func(vid needle.VolumeId) *VolumeLocationList {
+		return locations[vid]
+	}
+
+	picked := make(map[needle.VolumeId]int)
+	for i := 0; i < 1000; i++ {
+		picked[PlacementStrategies[LeastLoadedPlacement].PickVolume([]needle.VolumeId{1, 2, 3}, lookup, option)]++
+	}
+	// a volume on the busy server is only picked when it is drawn twice
+	if picked[2] < picked[1] || picked[2] < picked[3] {
+		t.Errorf("least loaded picked %v", picked)
+	}
+
+	picked = make(map[needle.VolumeId]int)
+	for i := 0; i < 1000; i++ {
+		picked[PlacementStrategies[WeightedRandomPlacement].PickVolume([]needle.VolumeId{1, 2}, lookup, option)]++
+	}
+	if picked[2] < 800 {
+		t.Errorf("weighted random picked %v", picked)
+	}
+}
+
+func TestSetPlacementStrategy(t *testing.T) {
+	defer SetPlacementStrategy("", SlotsPlacement)
+	if err := SetPlacementStrategy("", "unknown"); err == nil {
+		t.Errorf("set an unknown strategy")
+	}
+	if err := SetPlacementStrategy("logs", LeastLoadedPlacement); err != nil {
+		t.Fatalf("set strategy: %v", err)
+	}
+	defer func() {
+		placementLock.Lock()
+		delete(collectionPlacementStrategies, "logs")
+		placementLock.Unlock()
+	}()
+	if name := GetPlacementStrategy("logs").Name(); name != LeastLoadedPlacement {
+		t.Errorf("strategy of logs: %s", name)
+	}
+	if name := GetPlacementStrategy("other").Name(); name != SlotsPlacement {
+		t.Errorf("strategy of other: %s", name)
+	}
+}
@@ -2,8 +2,8 @@ package topology
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math/rand/v2"
 	"reflect"
 	"sync"
 	"time"
@@ -189,7 +189,6 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 
 	// Select appropriate functions based on useReservations flag
 	var availableSpaceFunc func(Node, *VolumeGrowOption) int64
-	var reserveOneVolumeFunc func(Node, int64, *VolumeGrowOption) (*DataNode, error)
 
 	if useReservations {
 		// Initialize tentative reservation tracking
@@ -203,16 +202,10 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 		availableSpaceFunc = func(node Node, option *VolumeGrowOption) int64 {
 			return node.AvailableSpaceForReservation(option)
 		}
-		reserveOneVolumeFunc = func(node Node, r int64, option *VolumeGrowOption) (*DataNode, error) {
-			return node.ReserveOneVolumeForReservation(r, option)
-		}
 	} else {
 		availableSpaceFunc = func(node Node, option *VolumeGrowOption) int64 {
 			return node.AvailableSpaceFor(option)
 		}
-		reserveOneVolumeFunc = func(node Node, r int64, option *VolumeGrowOption) (*DataNode, error) {
-			return node.ReserveOneVolume(r, option)
-		}
 	}
 
 	// Ensure cleanup of partial reservations on error
@@ -312,8 +305,7 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 		servers = append(servers, server.(*DataNode))
 	}
 	for _, rack := range otherRacks {
-		r := rand.Int64N(availableSpaceFunc(rack, option))
-		if server, e := reserveOneVolumeFunc(rack, r, option); e == nil {
+		if server, e := pickOneDataNode(rack, option, availableSpaceFunc); e == nil {
 			servers = append(servers, server)
 
 			// If using reservations, also make a reservation on the selected server
@@ -330,8 +322,7 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 		}
 	}
 	for _, datacenter := range otherDataCenters {
-		r := rand.Int64N(availableSpaceFunc(datacenter, option))
-		if server, e := reserveOneVolumeFunc(datacenter, r, option); e == nil {
+		if server, e := pickOneDataNode(datacenter, option, availableSpaceFunc); e == nil {
 			servers = append(servers, server)
 
 			// If using reservations, also make a reservation on the selected server
@@ -357,6 +348,28 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 	return servers, reservation, nil
 }
 
+// pickOneDataNode picks a data node with a free slot under the node, in the order of the placement strategy
+func pickOneDataNode(node Node, option *VolumeGrowOption, availableSpaceFunc func(Node, *VolumeGrowOption) int64) (*DataNode, error) {
+	var candidates []Node
+	for _, child := range node.Children() {
+		if availableSpaceFunc(child, option) > 0 {
+			candidates = append(candidates, child)
+		}
+	}
+	for _, child := range GetPlacementStrategy(option.Collection).OrderNodes(candidates, option) {
+		if child.IsDataNode() {
+			if dn := child.(*DataNode); !dn.IsTerminating {
+				return dn, nil
+			}
+			continue
+		}
+		if dn, err := pickOneDataNode(child, option, availableSpaceFunc); err == nil {
+			return dn, nil
+		}
+	}
+	return nil, errors.New("No free volume slot found!")
+}
+
 // grow creates volumes on the provided servers, optionally managing capacity reservations
 func (vg *VolumeGrowth) grow(grpcDialOption grpc.DialOption, topo *Topology, vid needle.VolumeId, option *VolumeGrowOption, reservation *VolumeGrowReservation, servers ...*DataNode) (growErr error) {
 	var createdVolumes []storage.VolumeInfo
@@ -10,6 +10,8 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types"
 	"google.golang.org/grpc"
 )
@@ -104,8 +106,14 @@ func (c *GrpcAdminClient) attemptConnection() error {
 	c.conn = conn
 	c.client = worker_pb.NewWorkerServiceClient(conn)
 
-	// Create bidirectional stream
+	// Create bidirectional stream, authenticated with the operator role if the admin server requires it
 	c.streamCtx, c.streamCancel = context.WithCancel(context.Background())
+	v := util.GetViper()
+	v.SetDefault("jwt.admin_signing.expires_after_seconds", 10)
+	if token := security.GenJwtForAdminServer(security.SigningKey(v.GetString("jwt.admin_signing.key")),
+		v.GetInt("jwt.admin_signing.expires_after_seconds"), c.workerID, "operator"); token != "" {
+		c.streamCtx = security.WithGrpcJwt(c.streamCtx, token)
+	}
 	stream, err := c.client.WorkerStream(c.streamCtx)
 	if err != nil {
 		c.conn.Close()